@@ -1,6 +1,7 @@
 package app
 
 import (
+	"io/ioutil"
 	"log"
 
 	"github.com/gin-gonic/gin"
@@ -10,19 +11,36 @@ import (
 	_ "github.com/lib/pq" // Postgres driver
 	"github.com/yashap/crius/internal/controller"
 	"github.com/yashap/crius/internal/db"
+	"github.com/yashap/crius/internal/domain/client"
+	"github.com/yashap/crius/internal/domain/history"
+	"github.com/yashap/crius/internal/domain/policy"
 	"github.com/yashap/crius/internal/domain/service"
+	"github.com/yashap/crius/internal/domain/topic"
+	"github.com/yashap/crius/internal/dto"
 	"go.uber.org/zap"
 )
 
 // Crius is the Crius application
 type Crius interface {
-	// MigrateDB runs the DB migrations
+	// MigrateDB runs the DB migrations, and then backfills the history of Services, Topics and Clients that were last
+	// saved before their history was recorded
 	MigrateDB(migrationDir string) Crius
+	// LoadPolicies loads the policy rules in a YAML policy file, replacing any that were loaded before. With no file,
+	// any rules that were loaded before are removed
+	LoadPolicies(policyFile string) Crius
 	// ListenAndServe starts the HTTP server
 	ListenAndServe() Crius
 
 	// ServiceRepository returns the app's service.Repository
 	ServiceRepository() *service.Repository
+	// TopicRepository returns the app's topic.Repository
+	TopicRepository() *topic.Repository
+	// ClientRepository returns the app's client.Repository
+	ClientRepository() *client.Repository
+	// PolicyRepository returns the app's policy.Repository
+	PolicyRepository() *policy.Repository
+	// HistoryRepository returns the app's history.Repository
+	HistoryRepository() *history.Repository
 	// Router returns the app's Router
 	Router() *gin.Engine
 }
@@ -32,6 +50,10 @@ type crius struct {
 	dbURL             *dburl.URL
 	logger            *zap.SugaredLogger
 	serviceRepository *service.Repository
+	topicRepository   *topic.Repository
+	clientRepository  *client.Repository
+	policyRepository  *policy.Repository
+	historyRepository *history.Repository
 	router            *gin.Engine
 }
 
@@ -40,24 +62,130 @@ func NewCrius(dbURL *dburl.URL) Crius {
 	logger := zap.NewExample().Sugar()
 	defer logger.Sync()
 
+	// MySQL only decodes DATE and DATETIME columns, like sunset dates and history timestamps, with parseTime=true
+	if dbURL.Driver == "mysql" && dbURL.Query().Get("parseTime") != "true" {
+		log.Fatal("For MySQL, your DB URL must set the query param parseTime=true")
+	}
 	database, err := sqlx.Connect(dbURL.Driver, dbURL.DSN)
 	if err != nil {
 		log.Fatalf("Failed to connect to database. URL: %s ; Error: %s", dbURL, err.Error())
 	}
-	serviceRepository := service.NewRepository(dbURL, database, logger)
-	router := controller.SetupRouter(serviceRepository, logger)
+	historyRepository := history.NewRepository(dbURL, database, logger)
+	serviceRepository := service.NewRepository(dbURL, database, logger, historyRepository)
+	topicRepository := topic.NewRepository(dbURL, database, logger, historyRepository)
+	clientRepository := client.NewRepository(dbURL, database, logger, historyRepository)
+	policyRepository := policy.NewRepository(dbURL, database, logger)
+	router := controller.SetupRouter(
+		serviceRepository,
+		topicRepository,
+		clientRepository,
+		policyRepository,
+		historyRepository,
+		db.NewTransactor(database, logger),
+		logger,
+	)
 
 	return &crius{
 		db:                database,
 		dbURL:             dbURL,
 		logger:            logger,
 		serviceRepository: &serviceRepository,
+		topicRepository:   &topicRepository,
+		clientRepository:  &clientRepository,
+		policyRepository:  &policyRepository,
+		historyRepository: &historyRepository,
 		router:            router,
 	}
 }
 
 func (c *crius) MigrateDB(migrationDir string) Crius {
 	db.Migrate(c.db, c.dbURL, migrationDir)
+	c.backfillHistory()
+	return c
+}
+
+// backfillHistory records the Services that have no history yet. Environments came after history, so every Service
+// that was last saved before history was recorded is in the service.DefaultEnvironment
+func (c *crius) backfillHistory() {
+	services, err := (*c.serviceRepository).FindAll()
+	if err != nil {
+		log.Fatalf("Failed to find services to backfill history for: %s", err.Error())
+	}
+	changes, err := (*c.historyRepository).Backfill(services)
+	if err != nil {
+		log.Fatalf("Failed to backfill service history: %s", err.Error())
+	}
+	if len(changes) > 0 {
+		c.logger.Infow("Backfilled service history", "services", len(changes))
+	}
+	c.backfillTopicHistory()
+	c.backfillClientHistory()
+}
+
+// backfillTopicHistory records the Topics that have no history yet. Topic history came after environments, so the
+// Topics of every Environment are backfilled
+func (c *crius) backfillTopicHistory() {
+	envs, err := (*c.topicRepository).FindEnvironments()
+	if err != nil {
+		log.Fatalf("Failed to find environments to backfill topic history for: %s", err.Error())
+	}
+	for _, env := range envs {
+		topics, err := (*c.topicRepository).InEnvironment(env).FindAll()
+		if err != nil {
+			log.Fatalf("Failed to find topics to backfill history for: %s", err.Error())
+		}
+		changes, err := (*c.historyRepository).InEnvironment(env).BackfillTopics(topics)
+		if err != nil {
+			log.Fatalf("Failed to backfill topic history: %s", err.Error())
+		}
+		if len(changes) > 0 {
+			c.logger.Infow("Backfilled topic history", "environment", env, "topics", len(changes))
+		}
+	}
+}
+
+// backfillClientHistory records the Clients that have no history yet. Client history came after environments, so the
+// Clients of every Environment are backfilled
+func (c *crius) backfillClientHistory() {
+	envs, err := (*c.clientRepository).FindEnvironments()
+	if err != nil {
+		log.Fatalf("Failed to find environments to backfill client history for: %s", err.Error())
+	}
+	for _, env := range envs {
+		clients, err := (*c.clientRepository).InEnvironment(env).FindAll()
+		if err != nil {
+			log.Fatalf("Failed to find clients to backfill history for: %s", err.Error())
+		}
+		changes, err := (*c.historyRepository).InEnvironment(env).BackfillClients(clients)
+		if err != nil {
+			log.Fatalf("Failed to backfill client history: %s", err.Error())
+		}
+		if len(changes) > 0 {
+			c.logger.Infow("Backfilled client history", "environment", env, "clients", len(changes))
+		}
+	}
+}
+
+func (c *crius) LoadPolicies(policyFile string) Crius {
+	rules := make([]policy.Rule, 0)
+	if policyFile != "" {
+		data, err := ioutil.ReadFile(policyFile)
+		if err != nil {
+			log.Fatalf("Failed to read policy file %s: %s", policyFile, err.Error())
+		}
+		rulesDTO, err := dto.MakePolicyRulesFromYAML(data)
+		if err != nil {
+			log.Fatalf("Failed to parse policy file %s: %s", policyFile, err.Error())
+		}
+		rules, err = rulesDTO.ToEntities()
+		if err != nil {
+			log.Fatalf("Failed to parse policy file %s: %s", policyFile, err.Error())
+		}
+	}
+	err := (*c.policyRepository).ReplaceSource(policy.FileSource, rules)
+	if err != nil {
+		log.Fatalf("Failed to load policy file %s: %s", policyFile, err.Error())
+	}
 	return c
 }
 
@@ -73,6 +201,22 @@ func (c *crius) ServiceRepository() *service.Repository {
 	return c.serviceRepository
 }
 
+func (c *crius) TopicRepository() *topic.Repository {
+	return c.topicRepository
+}
+
+func (c *crius) ClientRepository() *client.Repository {
+	return c.clientRepository
+}
+
+func (c *crius) PolicyRepository() *policy.Repository {
+	return c.policyRepository
+}
+
+func (c *crius) HistoryRepository() *history.Repository {
+	return c.historyRepository
+}
+
 func (c *crius) Router() *gin.Engine {
 	return c.router
 }
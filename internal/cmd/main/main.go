@@ -15,5 +15,6 @@ func main() {
 		log.Fatalf("Failed to parse DB URL: %s", rawDBURL)
 	}
 	migrationDir := os.Getenv("CRIUS_MIGRATIONS_DIR")
-	app.NewCrius(dbURL).MigrateDB(migrationDir).ListenAndServe()
+	policyFile := os.Getenv("CRIUS_POLICY_FILE")
+	app.NewCrius(dbURL).MigrateDB(migrationDir).LoadPolicies(policyFile).ListenAndServe()
 }
@@ -0,0 +1,140 @@
+package controller
+
+import (
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/yashap/crius/internal/domain/client"
+	"github.com/yashap/crius/internal/domain/graph"
+	"github.com/yashap/crius/internal/domain/history"
+	"github.com/yashap/crius/internal/domain/service"
+	"github.com/yashap/crius/internal/domain/topic"
+	"github.com/yashap/crius/internal/errors"
+)
+
+// makeAsOf parses the asOf query param, an RFC3339 timestamp like 2020-11-03T09:30:00Z. It is nil if the param isn't
+// set, meaning that the request is about the dependency graph as it is now
+func makeAsOf(c *gin.Context) (*time.Time, error) {
+	rawAsOf, ok := c.GetQuery("asOf")
+	if !ok {
+		return nil, nil
+	}
+	asOf, err := time.Parse(time.RFC3339, rawAsOf)
+	if err != nil {
+		return nil, errors.InvalidInput("query param 'asOf' must be an RFC3339 timestamp, like 2020-11-03T09:30:00Z", &err)
+	}
+	return &asOf, nil
+}
+
+// findAllServices finds every service.Service, sorted by Code. If the request's asOf query param is set, the Services
+// are reconstructed from their history as they were at that moment, and otherwise they are found as they are now
+func findAllServices(
+	c *gin.Context,
+	serviceRepository service.Repository,
+	historyRepository history.Repository,
+) ([]service.Service, error) {
+	asOf, err := makeAsOf(c)
+	if err != nil {
+		return nil, err
+	}
+	return findServicesAsOf(serviceRepository, historyRepository, asOf)
+}
+
+// findServicesAsOf finds every service.Service, sorted by Code, as they were at a moment, or as they are now if asOf
+// is nil
+func findServicesAsOf(
+	serviceRepository service.Repository,
+	historyRepository history.Repository,
+	asOf *time.Time,
+) ([]service.Service, error) {
+	if asOf == nil {
+		return serviceRepository.FindAll()
+	}
+	latest, err := historyRepository.FindLatestAsOf(*asOf)
+	if err != nil {
+		return nil, err
+	}
+	return history.Services(latest), nil
+}
+
+// findGraph builds the dependency graph out of every service.Service, along with the edges through every topic.Topic.
+// If the request's asOf query param is set, the Services and Topics are as they were at that moment
+func findGraph(
+	c *gin.Context,
+	serviceRepository service.Repository,
+	topicRepository topic.Repository,
+	historyRepository history.Repository,
+) (graph.Graph, error) {
+	asOf, err := makeAsOf(c)
+	if err != nil {
+		return graph.Graph{}, err
+	}
+	return findGraphAsOf(serviceRepository, topicRepository, historyRepository, asOf)
+}
+
+// findGraphAsOf builds the dependency graph, with the service.Services and topic.Topics as they were at a moment, or
+// as they are now if asOf is nil
+func findGraphAsOf(
+	serviceRepository service.Repository,
+	topicRepository topic.Repository,
+	historyRepository history.Repository,
+	asOf *time.Time,
+) (graph.Graph, error) {
+	services, err := findServicesAsOf(serviceRepository, historyRepository, asOf)
+	if err != nil {
+		return graph.Graph{}, err
+	}
+	topics, err := findTopicsAsOf(topicRepository, historyRepository, asOf)
+	if err != nil {
+		return graph.Graph{}, err
+	}
+	return graph.New(services, topics), nil
+}
+
+// findTopicsAsOf finds every topic.Topic, sorted by Code, as they were at a moment, or as they are now if asOf is nil
+func findTopicsAsOf(
+	topicRepository topic.Repository,
+	historyRepository history.Repository,
+	asOf *time.Time,
+) ([]topic.Topic, error) {
+	if asOf == nil {
+		return topicRepository.FindAll()
+	}
+	latest, err := historyRepository.FindLatestTopicsAsOf(*asOf)
+	if err != nil {
+		return nil, err
+	}
+	return history.Topics(latest), nil
+}
+
+// findClientsByDependencies finds every client.Client that depends on at least one of the Endpoints, sorted by Code.
+// If the request's asOf query param is set, the Clients are reconstructed from their history as they were at that
+// moment, and otherwise they are found as they are now
+func findClientsByDependencies(
+	c *gin.Context,
+	clientRepository client.Repository,
+	historyRepository history.Repository,
+	refs []service.EndpointRef,
+) ([]client.Client, error) {
+	asOf, err := makeAsOf(c)
+	if err != nil {
+		return nil, err
+	}
+	if asOf == nil {
+		return clientRepository.FindByDependencies(refs)
+	}
+	latest, err := historyRepository.FindLatestClientsAsOf(*asOf)
+	if err != nil {
+		return nil, err
+	}
+	clients := make([]client.Client, 0)
+	for _, cl := range history.Clients(latest) {
+		for _, ref := range refs {
+			if cl.DependsOn(ref) {
+				clients = append(clients, cl)
+				break
+			}
+		}
+	}
+	return clients, nil
+}
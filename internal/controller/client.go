@@ -0,0 +1,82 @@
+package controller
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/yashap/crius/internal/domain/client"
+	"github.com/yashap/crius/internal/dto"
+	"github.com/yashap/crius/internal/errors"
+)
+
+// Client is a controller for /clients endpoints
+type Client struct {
+	clientRepository client.Repository
+}
+
+// NewClient instantiates a Client controller
+func NewClient(clientRepository client.Repository) Client {
+	return Client{clientRepository}
+}
+
+// clients is the client.Repository of the request's Environment, which records its changes as made by the request's
+// actor
+func (cc *Client) clients(c *gin.Context) client.Repository {
+	return cc.clientRepository.InEnvironment(environment(c)).AsActor(actor(c))
+}
+
+// Create creates a new client.Client, or fully replaces an existing one, including its dependencies
+// POST /clients { ... client DTO ... } { "id": ... }
+func (cc *Client) Create(c *gin.Context) {
+	clientDTO, err := dto.MakeClientFromRequest(c)
+	if err != nil {
+		errors.SetResponse(err, c)
+		return
+	}
+	cl := clientDTO.ToEntity()
+	err = cc.clients(c).Save(&cl)
+	if err != nil {
+		errors.SetResponse(err, c)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"id": cl.ID})
+}
+
+// List lists every client.Client
+// GET /clients { "clients": [ ... client DTOs ... ] }
+func (cc *Client) List(c *gin.Context) {
+	clients, err := cc.clients(c).FindAll()
+	if err != nil {
+		errors.SetResponse(err, c)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"clients": dto.MakeClientsFromEntities(clients)})
+}
+
+// GetByCode gets a client.Client by the client's code
+// GET /clients/:code { ... client DTO ... }
+func (cc *Client) GetByCode(c *gin.Context) {
+	code := c.Param("code")
+	cl, err := cc.clients(c).FindByCode(code)
+	if err != nil {
+		errors.SetResponse(err, c)
+		return
+	}
+	if cl == nil {
+		errors.SetResponse(errors.ClientNotFound(fmt.Sprintf("Client with code %s not found", code), nil), c)
+		return
+	}
+	c.JSON(http.StatusOK, dto.MakeClientFromEntity(*cl))
+}
+
+// Delete deletes a client.Client by the client's code, along with its dependencies
+// DELETE /clients/:code {}
+func (cc *Client) Delete(c *gin.Context) {
+	err := cc.clients(c).Delete(c.Param("code"))
+	if err != nil {
+		errors.SetResponse(err, c)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{})
+}
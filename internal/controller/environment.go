@@ -0,0 +1,266 @@
+package controller
+
+import (
+	"database/sql"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/yashap/crius/internal/db"
+	"github.com/yashap/crius/internal/domain/client"
+	"github.com/yashap/crius/internal/domain/service"
+	"github.com/yashap/crius/internal/domain/topic"
+	"github.com/yashap/crius/internal/dto"
+	"github.com/yashap/crius/internal/errors"
+)
+
+// environment is the service.Environment that a request is scoped to. Routes under /envs/:env are scoped to the env
+// path param, and all other routes to the service.DefaultEnvironment
+func environment(c *gin.Context) service.Environment {
+	if env := c.Param("env"); env != "" {
+		return env
+	}
+	return service.DefaultEnvironment
+}
+
+// validateEnvironment is middleware that rejects requests scoped to an invalid service.Environment
+func validateEnvironment(c *gin.Context) {
+	err := service.ValidateEnvironment(environment(c))
+	if err != nil {
+		errors.SetResponse(err, c)
+		c.Abort()
+		return
+	}
+	c.Next()
+}
+
+// Environment is a controller for /envs/:env endpoints that act on a whole environment
+type Environment struct {
+	serviceRepository service.Repository
+	topicRepository   topic.Repository
+	clientRepository  client.Repository
+	transactor        db.Transactor
+}
+
+// NewEnvironment instantiates an Environment controller
+func NewEnvironment(
+	serviceRepository service.Repository,
+	topicRepository topic.Repository,
+	clientRepository client.Repository,
+	transactor db.Transactor,
+) Environment {
+	return Environment{serviceRepository, topicRepository, clientRepository, transactor}
+}
+
+// Promote copies the declared graph of an environment (its services, along with their endpoints and dependencies, its
+// topics and its clients) into another environment, fully replacing the services, topics and clients with the same
+// codes there. With prune=true, services, topics and clients that aren't in the source environment are deleted from
+// the target, so that it ends up with the same graph. Placeholder services aren't copied, but are created again where
+// something depends on them. Cycles and policy rules aren't checked again, as the graph already passed those checks
+// in the source environment. The promotion is all or nothing: if any part of it fails, the target is left as it was
+// POST /envs/:env/promote?to=production&prune=true
+// { "services": { "saved": [ ... ], "deleted": [ ... ] }, "topics": { ... }, "clients": { ... } }
+func (ec *Environment) Promote(c *gin.Context) {
+	to, ok := c.GetQuery("to")
+	if !ok {
+		errors.SetResponse(errors.InvalidInput("query param 'to' is required", nil), c)
+		return
+	}
+	err := service.ValidateEnvironment(to)
+	if err != nil {
+		errors.SetResponse(err, c)
+		return
+	}
+	if to == environment(c) {
+		errors.SetResponse(
+			errors.InvalidInput(fmt.Sprintf("environment %s can't be promoted into itself", to), nil),
+			c,
+		)
+		return
+	}
+	prune, err := strconv.ParseBool(c.DefaultQuery("prune", "false"))
+	if err != nil {
+		errors.SetResponse(errors.InvalidInput("query param 'prune' must be true or false", &err), c)
+		return
+	}
+	promotion := dto.Promotion{
+		Topics:  dto.PromotedCodes{Deleted: make([]string, 0)},
+		Clients: dto.PromotedCodes{Deleted: make([]string, 0)},
+	}
+	// Topics and clients depend on services, so they are pruned first, and saved last. Everything is done in one
+	// transaction, so a promotion that fails partway leaves the target Environment as it was
+	err = ec.transactor.InTransaction(func(tx *sql.Tx) error {
+		if prune {
+			promotion.Topics.Deleted, err = ec.pruneTopics(c, tx, to)
+			if err != nil {
+				return err
+			}
+			promotion.Clients.Deleted, err = ec.pruneClients(c, tx, to)
+			if err != nil {
+				return err
+			}
+		}
+		promotion.Services, err = ec.promoteServices(c, tx, to, prune)
+		if err != nil {
+			return err
+		}
+		promotion.Topics.Saved, err = ec.promoteTopics(c, tx, to)
+		if err != nil {
+			return err
+		}
+		promotion.Clients.Saved, err = ec.promoteClients(c, tx, to)
+		return err
+	})
+	if err != nil {
+		errors.SetResponse(err, c)
+		return
+	}
+	c.JSON(http.StatusOK, promotion)
+}
+
+// promoteServices copies the confirmed service.Services of the request's Environment into the Environment to, in the
+// transaction tx. They are saved together, so they can stop depending on each other's Endpoints in any order
+func (ec *Environment) promoteServices(
+	c *gin.Context,
+	tx *sql.Tx,
+	to service.Environment,
+	prune bool,
+) (dto.PromotedCodes, error) {
+	promoted := dto.PromotedCodes{Saved: make([]string, 0), Deleted: make([]string, 0)}
+	sourceServices, err := ec.serviceRepository.InEnvironment(environment(c)).FindAll()
+	if err != nil {
+		return promoted, err
+	}
+	target := ec.serviceRepository.InEnvironment(to).AsActor(actor(c)).InTransaction(tx)
+	if prune {
+		targetServices, err := target.FindAll()
+		if err != nil {
+			return promoted, err
+		}
+		inSource := make(map[service.Code]bool)
+		for _, svc := range sourceServices {
+			inSource[svc.Code] = true
+		}
+		for _, svc := range targetServices {
+			if inSource[svc.Code] {
+				continue
+			}
+			_, err = target.Delete(svc.Code, true)
+			if err != nil {
+				return promoted, err
+			}
+			promoted.Deleted = append(promoted.Deleted, svc.Code)
+		}
+	}
+	copies := make([]service.Service, 0, len(sourceServices))
+	for _, svc := range sourceServices {
+		if svc.Confirmed {
+			copies = append(copies, svc.Promoted())
+			promoted.Saved = append(promoted.Saved, svc.Code)
+		}
+	}
+	err = target.SaveAll(copies, true)
+	if err != nil {
+		return promoted, err
+	}
+	return promoted, nil
+}
+
+// promoteTopics copies the topic.Topics of the request's Environment into the Environment to, in the transaction tx
+func (ec *Environment) promoteTopics(c *gin.Context, tx *sql.Tx, to service.Environment) ([]string, error) {
+	topics, err := ec.topicRepository.InEnvironment(environment(c)).FindAll()
+	if err != nil {
+		return nil, err
+	}
+	target := ec.topicRepository.InEnvironment(to).AsActor(actor(c)).InTransaction(tx)
+	saved := make([]string, len(topics))
+	for idx := range topics {
+		topics[idx].ID = nil
+		err = target.Save(&topics[idx])
+		if err != nil {
+			return nil, err
+		}
+		saved[idx] = topics[idx].Code
+	}
+	return saved, nil
+}
+
+// pruneTopics deletes the topic.Topics of the Environment to that aren't in the request's Environment, in the
+// transaction tx
+func (ec *Environment) pruneTopics(c *gin.Context, tx *sql.Tx, to service.Environment) ([]string, error) {
+	sourceTopics, err := ec.topicRepository.InEnvironment(environment(c)).FindAll()
+	if err != nil {
+		return nil, err
+	}
+	target := ec.topicRepository.InEnvironment(to).AsActor(actor(c)).InTransaction(tx)
+	targetTopics, err := target.FindAll()
+	if err != nil {
+		return nil, err
+	}
+	inSource := make(map[topic.Code]bool)
+	for _, t := range sourceTopics {
+		inSource[t.Code] = true
+	}
+	deleted := make([]string, 0)
+	for _, t := range targetTopics {
+		if inSource[t.Code] {
+			continue
+		}
+		err = target.Delete(t.Code)
+		if err != nil {
+			return nil, err
+		}
+		deleted = append(deleted, t.Code)
+	}
+	return deleted, nil
+}
+
+// promoteClients copies the client.Clients of the request's Environment into the Environment to, in the transaction tx
+func (ec *Environment) promoteClients(c *gin.Context, tx *sql.Tx, to service.Environment) ([]string, error) {
+	clients, err := ec.clientRepository.InEnvironment(environment(c)).FindAll()
+	if err != nil {
+		return nil, err
+	}
+	target := ec.clientRepository.InEnvironment(to).AsActor(actor(c)).InTransaction(tx)
+	saved := make([]string, len(clients))
+	for idx := range clients {
+		clients[idx].ID = nil
+		err = target.Save(&clients[idx])
+		if err != nil {
+			return nil, err
+		}
+		saved[idx] = clients[idx].Code
+	}
+	return saved, nil
+}
+
+// pruneClients deletes the client.Clients of the Environment to that aren't in the request's Environment, in the
+// transaction tx
+func (ec *Environment) pruneClients(c *gin.Context, tx *sql.Tx, to service.Environment) ([]string, error) {
+	sourceClients, err := ec.clientRepository.InEnvironment(environment(c)).FindAll()
+	if err != nil {
+		return nil, err
+	}
+	target := ec.clientRepository.InEnvironment(to).AsActor(actor(c)).InTransaction(tx)
+	targetClients, err := target.FindAll()
+	if err != nil {
+		return nil, err
+	}
+	inSource := make(map[client.Code]bool)
+	for _, cl := range sourceClients {
+		inSource[cl.Code] = true
+	}
+	deleted := make([]string, 0)
+	for _, cl := range targetClients {
+		if inSource[cl.Code] {
+			continue
+		}
+		err = target.Delete(cl.Code)
+		if err != nil {
+			return nil, err
+		}
+		deleted = append(deleted, cl.Code)
+	}
+	return deleted, nil
+}
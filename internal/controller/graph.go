@@ -0,0 +1,359 @@
+package controller
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/yashap/crius/internal/domain/client"
+	"github.com/yashap/crius/internal/domain/graph"
+	"github.com/yashap/crius/internal/domain/history"
+	"github.com/yashap/crius/internal/domain/service"
+	"github.com/yashap/crius/internal/domain/topic"
+	"github.com/yashap/crius/internal/dto"
+	"github.com/yashap/crius/internal/errors"
+	"github.com/yashap/crius/internal/export"
+)
+
+const (
+	mermaidContentType  = "text/vnd.mermaid"
+	plantUMLContentType = "text/x-plantuml"
+)
+
+// Graph is a controller for endpoints that render or analyze the dependency graph, as a whole or in part. They all take
+// an asOf query param, to render or analyze the graph as it was at that moment
+type Graph struct {
+	serviceRepository service.Repository
+	topicRepository   topic.Repository
+	clientRepository  client.Repository
+	historyRepository history.Repository
+}
+
+// NewGraph instantiates a Graph controller
+func NewGraph(
+	serviceRepository service.Repository,
+	topicRepository topic.Repository,
+	clientRepository client.Repository,
+	historyRepository history.Repository,
+) Graph {
+	return Graph{serviceRepository, topicRepository, clientRepository, historyRepository}
+}
+
+// services is the service.Repository of the request's Environment
+func (gc *Graph) services(c *gin.Context) service.Repository {
+	return gc.serviceRepository.InEnvironment(environment(c))
+}
+
+// topics is the topic.Repository of the request's Environment
+func (gc *Graph) topics(c *gin.Context) topic.Repository {
+	return gc.topicRepository.InEnvironment(environment(c))
+}
+
+// clients is the client.Repository of the request's Environment
+func (gc *Graph) clients(c *gin.Context) client.Repository {
+	return gc.clientRepository.InEnvironment(environment(c))
+}
+
+// history is the history.Repository of the request's Environment
+func (gc *Graph) history(c *gin.Context) history.Repository {
+	return gc.historyRepository.InEnvironment(environment(c))
+}
+
+// GetDOT renders the dependency graph in the Graphviz DOT language. The graph can be narrowed down to a root service,
+// or to whatever matches a label selector, and whatever is reachable from there, optionally limited to a depth.
+// Services can be collapsed into single nodes
+// GET /graph.dot?root=&selector=&direction=down|up&depth=N&granularity=endpoint|service&asOf= digraph crius { ... }
+func (gc *Graph) GetDOT(c *gin.Context) {
+	var root *service.Code
+	if rawRoot, ok := c.GetQuery("root"); ok {
+		root = &rawRoot
+	}
+	g, err := gc.selectGraph(c, root)
+	if err != nil {
+		errors.SetResponse(err, c)
+		return
+	}
+	granularity, err := makeGranularity(c)
+	if err != nil {
+		errors.SetResponse(err, c)
+		return
+	}
+	c.Data(http.StatusOK, "text/vnd.graphviz; charset=utf-8", []byte(export.DOT(g, granularity)))
+}
+
+// GetServiceDiagram renders the part of the dependency graph reachable from a service as a diagram. The format is
+// taken from the format query param if set, and otherwise negotiated from the Accept header, defaulting to Mermaid
+// GET /services/:code/diagram?format=mermaid|plantuml&selector=&direction=down|up&depth=2&granularity=endpoint|service
+func (gc *Graph) GetServiceDiagram(c *gin.Context) {
+	code := c.Param("code")
+	g, err := gc.selectGraph(c, &code)
+	if err != nil {
+		errors.SetResponse(err, c)
+		return
+	}
+	granularity, err := makeGranularity(c)
+	if err != nil {
+		errors.SetResponse(err, c)
+		return
+	}
+	contentType := c.NegotiateFormat(mermaidContentType, plantUMLContentType)
+	if format, ok := c.GetQuery("format"); ok {
+		switch format {
+		case "mermaid":
+			contentType = mermaidContentType
+		case "plantuml":
+			contentType = plantUMLContentType
+		default:
+			errors.SetResponse(errors.InvalidInput("query param 'format' must be mermaid or plantuml", nil), c)
+			return
+		}
+	}
+	if contentType == plantUMLContentType {
+		c.Data(http.StatusOK, plantUMLContentType+"; charset=utf-8", []byte(export.PlantUML(g, granularity)))
+	} else {
+		c.Data(http.StatusOK, mermaidContentType+"; charset=utf-8", []byte(export.Mermaid(g, granularity)))
+	}
+}
+
+// GetCycles finds the dependency cycles in the graph, between endpoints and between services
+// GET /graph/cycles?asOf= { "endpoint_cycles": [ ... ], "service_cycles": [ ... ] }
+func (gc *Graph) GetCycles(c *gin.Context) {
+	g, err := findGraph(c, gc.services(c), gc.topics(c), gc.history(c))
+	if err != nil {
+		errors.SetResponse(err, c)
+		return
+	}
+	c.JSON(http.StatusOK, dto.MakeCyclesFromEntity(g.Cycles()))
+}
+
+// GetViolations finds every endpoint that depends on a less critical endpoint, i.e. one with a higher tier. Endpoints
+// default to their service's tier. Dependencies that are only reachable through other endpoints are included, unless
+// transitive is false
+// GET /graph/violations?transitive=true|false&asOf= { "violations": [ ... ] }
+func (gc *Graph) GetViolations(c *gin.Context) {
+	transitive, err := strconv.ParseBool(c.DefaultQuery("transitive", "true"))
+	if err != nil {
+		errors.SetResponse(errors.InvalidInput("query param 'transitive' must be true or false", &err), c)
+		return
+	}
+	g, err := findGraph(c, gc.services(c), gc.topics(c), gc.history(c))
+	if err != nil {
+		errors.SetResponse(err, c)
+		return
+	}
+	c.JSON(http.StatusOK, dto.MakeViolationsFromEntities(g.Violations(transitive)))
+}
+
+// GetStalePins finds every dependency that is pinned, with a key like "payments@^2", to versions that the endpoint it
+// depends on no longer has. Endpoints default to their service's version, and those with no version aren't checked
+// GET /graph/stale-pins?asOf= { "stale_pins": [ ... ] }
+func (gc *Graph) GetStalePins(c *gin.Context) {
+	g, err := findGraph(c, gc.services(c), gc.topics(c), gc.history(c))
+	if err != nil {
+		errors.SetResponse(err, c)
+		return
+	}
+	c.JSON(http.StatusOK, dto.MakeStalePinsFromEntities(g.StalePins()))
+}
+
+// GetDeprecations lists every deprecated or retired endpoint that is still called, along with the endpoints and clients
+// that call it, ordered by sunset date. Endpoints default to their service's lifecycle
+// GET /graph/deprecations?asOf= { "deprecations": [ ... ] }
+func (gc *Graph) GetDeprecations(c *gin.Context) {
+	g, err := findGraph(c, gc.services(c), gc.topics(c), gc.history(c))
+	if err != nil {
+		errors.SetResponse(err, c)
+		return
+	}
+	deprecations := g.Deprecations()
+	refs := make([]service.EndpointRef, len(deprecations))
+	for idx, deprecation := range deprecations {
+		refs[idx] = deprecation.Endpoint
+	}
+	clients, err := findClientsByDependencies(c, gc.clients(c), gc.history(c), refs)
+	if err != nil {
+		errors.SetResponse(err, c)
+		return
+	}
+	clientCodes := make(map[service.EndpointRef][]client.Code)
+	called := make([]graph.Deprecation, 0, len(deprecations))
+	for _, deprecation := range deprecations {
+		for _, cl := range clients {
+			if cl.DependsOn(deprecation.Endpoint) {
+				clientCodes[deprecation.Endpoint] = append(clientCodes[deprecation.Endpoint], cl.Code)
+			}
+		}
+		if len(deprecation.Callers) > 0 || len(clientCodes[deprecation.Endpoint]) > 0 {
+			called = append(called, deprecation)
+		}
+	}
+	c.JSON(http.StatusOK, dto.MakeDeprecationsFromEntities(called, clientCodes))
+}
+
+// GetDiff compares two versions of the dependency graph, reporting the services, endpoints and dependencies that were
+// added and removed between them. Each version is an RFC3339 timestamp, or a snapshot id: the id of a change in a
+// service's history, which stands for the whole graph right after that change. Without to, the graph as it is now is
+// compared against. Each version is of the request's environment, unless fromEnv or toEnv name another one, so two
+// environments can be compared too, in which case from can also be left out, to compare their graphs as they are now.
+// The diff can be rendered as a DOT or Mermaid graph instead of JSON, with what was added in green, and what was
+// removed in dashed red
+// GET /graph/diff?from=&to=&fromEnv=&toEnv=&format=json|dot|mermaid&granularity=endpoint|service
+// { "services": { "added": [ ... ], "removed": [ ... ] }, "endpoints": { ... }, "dependencies": { ... } }
+func (gc *Graph) GetDiff(c *gin.Context) {
+	fromEnv, err := makeDiffEnvironment(c, "fromEnv")
+	if err != nil {
+		errors.SetResponse(err, c)
+		return
+	}
+	toEnv, err := makeDiffEnvironment(c, "toEnv")
+	if err != nil {
+		errors.SetResponse(err, c)
+		return
+	}
+	var rawFrom *string
+	if value, ok := c.GetQuery("from"); ok {
+		rawFrom = &value
+	} else if fromEnv == toEnv {
+		errors.SetResponse(
+			errors.InvalidInput("query param 'from' is required, unless fromEnv and toEnv differ", nil),
+			c,
+		)
+		return
+	}
+	from, err := gc.findGraphVersion(c, fromEnv, "from", rawFrom)
+	if err != nil {
+		errors.SetResponse(err, c)
+		return
+	}
+	var rawTo *string
+	if value, ok := c.GetQuery("to"); ok {
+		rawTo = &value
+	}
+	to, err := gc.findGraphVersion(c, toEnv, "to", rawTo)
+	if err != nil {
+		errors.SetResponse(err, c)
+		return
+	}
+	granularity, err := makeGranularity(c)
+	if err != nil {
+		errors.SetResponse(err, c)
+		return
+	}
+	switch c.DefaultQuery("format", "json") {
+	case "json":
+		c.JSON(http.StatusOK, dto.MakeGraphDiffFromEntity(graph.Compare(from, to)))
+	case "dot":
+		c.Data(http.StatusOK, "text/vnd.graphviz; charset=utf-8", []byte(export.DiffDOT(from, to, granularity)))
+	case "mermaid":
+		c.Data(http.StatusOK, mermaidContentType+"; charset=utf-8", []byte(export.DiffMermaid(from, to, granularity)))
+	default:
+		errors.SetResponse(errors.InvalidInput("query param 'format' must be json, dot or mermaid", nil), c)
+	}
+}
+
+// makeDiffEnvironment parses a query param of GetDiff that names the service.Environment of a version of the
+// dependency graph. If it isn't set, the version is of the request's Environment
+func makeDiffEnvironment(c *gin.Context, param string) (service.Environment, error) {
+	env, ok := c.GetQuery(param)
+	if !ok {
+		return environment(c), nil
+	}
+	return env, service.ValidateEnvironment(env)
+}
+
+// findGraphVersion finds the version of the dependency graph of the service.Environment named by the value of a query
+// param, which is an RFC3339 timestamp or a snapshot id. If the value is nil, the graph as it is now is found
+func (gc *Graph) findGraphVersion(
+	c *gin.Context,
+	env service.Environment,
+	param string,
+	version *string,
+) (graph.Graph, error) {
+	historyRepository := gc.historyRepository.InEnvironment(env)
+	var asOf *time.Time
+	if version != nil {
+		timestamp, timestampErr := time.Parse(time.RFC3339, *version)
+		snapshotID, snapshotIDErr := strconv.ParseInt(*version, 10, 64)
+		if timestampErr == nil {
+			asOf = &timestamp
+		} else if snapshotIDErr == nil {
+			change, err := historyRepository.FindByID(snapshotID)
+			if err != nil {
+				return graph.Graph{}, err
+			}
+			if change == nil {
+				return graph.Graph{}, errors.InvalidInput(
+					fmt.Sprintf("query param '%s' is snapshot id %d, but there is no such snapshot", param, snapshotID),
+					nil,
+				)
+			}
+			asOf = &change.ChangedAt
+		} else {
+			return graph.Graph{}, errors.InvalidInput(
+				fmt.Sprintf("query param '%s' must be an RFC3339 timestamp or a snapshot id", param),
+				nil,
+			)
+		}
+	}
+	return findGraphAsOf(
+		gc.serviceRepository.InEnvironment(env),
+		gc.topicRepository.InEnvironment(env),
+		historyRepository,
+		asOf,
+	)
+}
+
+// selectGraph loads the dependency graph, and selects the part of it described by the request's query params
+func (gc *Graph) selectGraph(c *gin.Context, root *service.Code) (graph.Graph, error) {
+	query, err := makeGraphQuery(c, root)
+	if err != nil {
+		return graph.Graph{}, err
+	}
+	g, err := findGraph(c, gc.services(c), gc.topics(c), gc.history(c))
+	if err != nil {
+		return graph.Graph{}, err
+	}
+	return g.Select(query)
+}
+
+func makeGraphQuery(c *gin.Context, root *service.Code) (graph.Query, error) {
+	query := graph.Query{Root: root, Direction: service.Downstream}
+	if rawSelector, ok := c.GetQuery("selector"); ok {
+		selector, err := service.ParseSelector(rawSelector)
+		if err != nil {
+			return query, err
+		}
+		query.Selector = selector
+	}
+	switch c.DefaultQuery("direction", "down") {
+	case "down":
+	case "up":
+		query.Direction = service.Upstream
+	default:
+		return query, errors.InvalidInput("query param 'direction' must be down or up", nil)
+	}
+	if rawDepth, ok := c.GetQuery("depth"); ok {
+		if root == nil && query.Selector == nil {
+			return query, errors.InvalidInput("query param 'depth' can only be used with a root or a selector", nil)
+		}
+		depth, err := strconv.Atoi(rawDepth)
+		if err != nil {
+			return query, errors.InvalidInput("query param 'depth' must be a positive integer", &err)
+		}
+		if depth < 1 {
+			return query, errors.InvalidInput("query param 'depth' must be a positive integer", nil)
+		}
+		query.MaxDepth = depth
+	}
+	return query, nil
+}
+
+func makeGranularity(c *gin.Context) (export.Granularity, error) {
+	granularity := c.DefaultQuery("granularity", export.EndpointGranularity)
+	if granularity != export.EndpointGranularity && granularity != export.ServiceGranularity {
+		return granularity, errors.InvalidInput("query param 'granularity' must be endpoint or service", nil)
+	}
+	return granularity, nil
+}
@@ -5,22 +5,86 @@ import (
 
 	ginzap "github.com/gin-contrib/zap"
 	"github.com/gin-gonic/gin"
+	"github.com/yashap/crius/internal/db"
+	"github.com/yashap/crius/internal/domain/client"
+	"github.com/yashap/crius/internal/domain/history"
+	"github.com/yashap/crius/internal/domain/policy"
 	"github.com/yashap/crius/internal/domain/service"
+	"github.com/yashap/crius/internal/domain/topic"
 	"go.uber.org/zap"
 )
 
 // SetupRouter sets up the Gin router
-func SetupRouter(serviceRepository service.Repository, logger *zap.SugaredLogger) *gin.Engine {
-	serviceController := NewService(serviceRepository)
+func SetupRouter(
+	serviceRepository service.Repository,
+	topicRepository topic.Repository,
+	clientRepository client.Repository,
+	policyRepository policy.Repository,
+	historyRepository history.Repository,
+	transactor db.Transactor,
+	logger *zap.SugaredLogger,
+) *gin.Engine {
+	serviceController := NewService(
+		serviceRepository,
+		topicRepository,
+		clientRepository,
+		policyRepository,
+		historyRepository,
+		transactor,
+	)
+	topicController := NewTopic(topicRepository)
+	clientController := NewClient(clientRepository)
+	graphController := NewGraph(serviceRepository, topicRepository, clientRepository, historyRepository)
+	policyController := NewPolicy(policyRepository)
+	environmentController := NewEnvironment(serviceRepository, topicRepository, clientRepository, transactor)
 
 	// Run the server
 	r := gin.New()
+	// Endpoint codes often contain slashes (e.g. "GET /teams/{id}"), so match routes against the escaped path, letting
+	// clients send them as a single, URL encoded path segment
+	r.UseRawPath = true
+	r.UnescapePathValues = true
 	r.Use(ginzap.Ginzap(logger.Desugar(), time.RFC3339, true))
 	r.Use(ginzap.RecoveryWithZap(logger.Desugar(), true))
-	r.POST("/services", serviceController.Create)
-	r.GET("/services/:code", serviceController.GetByCode)
-	// TODO r.GET
-	// TODO r.DELETE
+	// Everything but policy rules belongs to an environment. Routes under /envs/:env are scoped to that environment, and
+	// the same routes without the prefix are scoped to the default environment
+	for _, routes := range []gin.IRoutes{r, r.Group("/envs/:env", validateEnvironment)} {
+		routes.POST("/services", serviceController.Create)
+		routes.GET("/services", serviceController.List)
+		routes.GET("/services/:code", serviceController.GetByCode)
+		routes.PATCH("/services/:code", serviceController.Update)
+		routes.DELETE("/services/:code", serviceController.Delete)
+		routes.GET("/services/:code/dependencies", serviceController.GetDependencies)
+		routes.GET("/services/:code/dependents", serviceController.GetDependents)
+		routes.GET("/services/:code/diagram", graphController.GetServiceDiagram)
+		routes.GET("/services/:code/history", serviceController.GetHistory)
+		routes.POST("/services/:code/import/openapi", serviceController.ImportOpenAPI)
+		routes.PUT("/services/:code/endpoints/:endpointCode", serviceController.SaveEndpoint)
+		routes.GET("/services/:code/endpoints/:endpointCode", serviceController.GetEndpoint)
+		routes.DELETE("/services/:code/endpoints/:endpointCode", serviceController.DeleteEndpoint)
+		routes.GET("/services/:code/endpoints/:endpointCode/dependencies", serviceController.GetEndpointDependencies)
+		routes.GET("/services/:code/endpoints/:endpointCode/dependents", serviceController.GetEndpointDependents)
+		routes.GET("/teams/:team/services", serviceController.ListByTeam)
+		routes.POST("/topics", topicController.Create)
+		routes.GET("/topics", topicController.List)
+		routes.GET("/topics/:code", topicController.GetByCode)
+		routes.DELETE("/topics/:code", topicController.Delete)
+		routes.POST("/clients", clientController.Create)
+		routes.GET("/clients", clientController.List)
+		routes.GET("/clients/:code", clientController.GetByCode)
+		routes.DELETE("/clients/:code", clientController.Delete)
+		routes.GET("/graph.dot", graphController.GetDOT)
+		routes.GET("/graph/cycles", graphController.GetCycles)
+		routes.GET("/graph/violations", graphController.GetViolations)
+		routes.GET("/graph/deprecations", graphController.GetDeprecations)
+		routes.GET("/graph/stale-pins", graphController.GetStalePins)
+		routes.GET("/graph/diff", graphController.GetDiff)
+	}
+	r.POST("/envs/:env/promote", validateEnvironment, environmentController.Promote)
+	r.POST("/policies", policyController.Create)
+	r.GET("/policies", policyController.List)
+	r.GET("/policies/:code", policyController.GetByCode)
+	r.DELETE("/policies/:code", policyController.Delete)
 
 	return r
 }
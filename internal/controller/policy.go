@@ -0,0 +1,110 @@
+package controller
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/yashap/crius/internal/domain/policy"
+	"github.com/yashap/crius/internal/dto"
+	"github.com/yashap/crius/internal/errors"
+)
+
+// Policy is a controller for /policies endpoints
+type Policy struct {
+	policyRepository policy.Repository
+}
+
+// NewPolicy instantiates a Policy controller
+func NewPolicy(policyRepository policy.Repository) Policy {
+	return Policy{policyRepository}
+}
+
+// Create creates a new policy.Rule, or fully replaces an existing one. Rules loaded from the policy file can only be
+// changed there
+// POST /policies { ... policy rule DTO ... } { "id": ... }
+func (pc *Policy) Create(c *gin.Context) {
+	ruleDTO, err := dto.MakePolicyRuleFromRequest(c)
+	if err != nil {
+		errors.SetResponse(err, c)
+		return
+	}
+	rule, err := ruleDTO.ToEntity()
+	if err != nil {
+		errors.SetResponse(err, c)
+		return
+	}
+	err = pc.checkNotFromFile(rule.Code)
+	if err != nil {
+		errors.SetResponse(err, c)
+		return
+	}
+	err = pc.policyRepository.Save(&rule)
+	if err != nil {
+		errors.SetResponse(err, c)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"id": rule.ID})
+}
+
+// List lists every policy.Rule, whether saved through the API or loaded from the policy file
+// GET /policies { "rules": [ ... policy rule DTOs ... ] }
+func (pc *Policy) List(c *gin.Context) {
+	rules, err := pc.policyRepository.FindAll()
+	if err != nil {
+		errors.SetResponse(err, c)
+		return
+	}
+	c.JSON(http.StatusOK, dto.MakePolicyRulesFromEntities(rules))
+}
+
+// GetByCode gets a policy.Rule by the rule's code
+// GET /policies/:code { ... policy rule DTO ... }
+func (pc *Policy) GetByCode(c *gin.Context) {
+	code := c.Param("code")
+	rule, err := pc.policyRepository.FindByCode(code)
+	if err != nil {
+		errors.SetResponse(err, c)
+		return
+	}
+	if rule == nil {
+		errors.SetResponse(
+			errors.PolicyRuleNotFound(fmt.Sprintf("Policy rule with code %s not found", code), nil),
+			c,
+		)
+		return
+	}
+	c.JSON(http.StatusOK, dto.MakePolicyRuleFromEntity(*rule))
+}
+
+// Delete deletes a policy.Rule by the rule's code. Rules loaded from the policy file can only be removed from there
+// DELETE /policies/:code
+func (pc *Policy) Delete(c *gin.Context) {
+	code := c.Param("code")
+	err := pc.checkNotFromFile(code)
+	if err != nil {
+		errors.SetResponse(err, c)
+		return
+	}
+	err = pc.policyRepository.Delete(code)
+	if err != nil {
+		errors.SetResponse(err, c)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{})
+}
+
+// checkNotFromFile returns an error if the policy.Rule with the code was loaded from the policy file
+func (pc *Policy) checkNotFromFile(code policy.Code) error {
+	existing, err := pc.policyRepository.FindByCode(code)
+	if err != nil {
+		return err
+	}
+	if existing != nil && existing.Source == policy.FileSource {
+		return errors.InvalidInput(
+			fmt.Sprintf("Policy rule with code %s is loaded from the policy file, so it can only be changed there", code),
+			nil,
+		)
+	}
+	return nil
+}
@@ -1,48 +1,236 @@
 package controller
 
 import (
+	"database/sql"
 	"fmt"
 	"net/http"
+	"strconv"
 
 	"github.com/yashap/crius/internal/errors"
 
 	"github.com/gin-gonic/gin"
+	"github.com/yashap/crius/internal/db"
+	"github.com/yashap/crius/internal/domain/client"
+	"github.com/yashap/crius/internal/domain/graph"
+	"github.com/yashap/crius/internal/domain/history"
+	"github.com/yashap/crius/internal/domain/policy"
 	"github.com/yashap/crius/internal/domain/service"
+	"github.com/yashap/crius/internal/domain/topic"
 	"github.com/yashap/crius/internal/dto"
 )
 
+// actorHeader is the request header that says who is making a change, so that it can be recorded in the history
+const actorHeader = "X-Crius-Actor"
+
 // Service is a controller for /service endpoints
 type Service struct {
 	serviceRepository service.Repository
+	topicRepository   topic.Repository
+	clientRepository  client.Repository
+	policyRepository  policy.Repository
+	historyRepository history.Repository
+	transactor        db.Transactor
 }
 
 // NewService instantiates a Service controller
-func NewService(serviceRepository service.Repository) Service {
-	return Service{serviceRepository}
+func NewService(
+	serviceRepository service.Repository,
+	topicRepository topic.Repository,
+	clientRepository client.Repository,
+	policyRepository policy.Repository,
+	historyRepository history.Repository,
+	transactor db.Transactor,
+) Service {
+	return Service{
+		serviceRepository,
+		topicRepository,
+		clientRepository,
+		policyRepository,
+		historyRepository,
+		transactor,
+	}
+}
+
+// services is the service.Repository of the request's Environment, which records its changes as made by the request's
+// actor
+func (sc *Service) services(c *gin.Context) service.Repository {
+	return sc.serviceRepository.InEnvironment(environment(c)).AsActor(actor(c))
 }
 
-// Create creates a new service.Service
-// POST /services { ... service DTO ... }
+// topics is the topic.Repository of the request's Environment
+func (sc *Service) topics(c *gin.Context) topic.Repository {
+	return sc.topicRepository.InEnvironment(environment(c))
+}
+
+// clients is the client.Repository of the request's Environment
+func (sc *Service) clients(c *gin.Context) client.Repository {
+	return sc.clientRepository.InEnvironment(environment(c))
+}
+
+// inTransaction runs fn in a transaction that the service.Repository and topic.Repository of the request's Environment
+// are joined to, so that changes are checked against the same graph that they are saved to
+func (sc *Service) inTransaction(
+	c *gin.Context,
+	fn func(services service.Repository, topics topic.Repository) error,
+) error {
+	return sc.transactor.InTransaction(func(tx *sql.Tx) error {
+		return fn(sc.services(c).InTransaction(tx), sc.topics(c).InTransaction(tx))
+	})
+}
+
+// history is the history.Repository of the request's Environment
+func (sc *Service) history(c *gin.Context) history.Repository {
+	return sc.historyRepository.InEnvironment(environment(c))
+}
+
+// Create creates a new service.Service, or fully replaces an existing one, reporting how its dependencies changed. With
+// rejectCycles=true, the save is rejected if it would introduce a new dependency cycle. With placeholders=true,
+// dependencies on services and endpoints that aren't registered yet create unconfirmed placeholders for them. New
+// dependencies are checked against the policy rules, and the save is rejected if they break any rule that rejects, or
+// warned about if they only break rules that warn
+// POST /services?rejectCycles=true&placeholders=true { ... service DTO ... }
+// { "id": ..., "dependencies": { ... }, "policy_warnings": [ ... ] }
 func (sc *Service) Create(c *gin.Context) {
 	serviceDTO, err := dto.MakeServiceFromRequest(c)
 	if err != nil {
 		errors.SetResponse(err, c)
 		return
 	}
-	svc := serviceDTO.ToEntity()
-	err = sc.serviceRepository.Save(&svc)
+	sc.save(c, serviceDTO.ToEntity())
+}
+
+// ImportOpenAPI creates or fully replaces the endpoints of a service.Service from an OpenAPI 3 document, in JSON or
+// YAML. Each operation becomes an endpoint with a code like "GET /teams/{id}", named after the operation's summary or
+// operationId. Operations can declare their endpoint's dependencies with an x-crius-dependencies extension, shaped like
+// an endpoint DTO's dependencies. Endpoints that the document doesn't have are removed, while those that it still has
+// keep their dependencies, unless their operations declare some. The rest of the service is left alone. It takes the
+// same query params as Create, and malformed operations are reported one by one in the error's details
+// POST /services/:code/import/openapi?rejectCycles=true|false&placeholders=true|false { ... OpenAPI document ... }
+// { "id": 123, "dependencies": { ... dependency diff DTO ... }, "policy_warnings": [ ... ] }
+func (sc *Service) ImportOpenAPI(c *gin.Context) {
+	openAPI, err := dto.MakeOpenAPIFromRequest(c)
+	if err != nil {
+		errors.SetResponse(err, c)
+		return
+	}
+	code := c.Param("code")
+	existing, err := sc.services(c).FindByCode(code)
 	if err != nil {
 		errors.SetResponse(err, c)
 		return
 	}
-	c.JSON(http.StatusOK, gin.H{"id": svc.ID})
+	sc.save(c, openAPI.ToEntity(code, existing))
 }
 
-// GetByCode gets a service.Service by the service's code
-// GET /services/:code { ... service DTO ... }
+// save creates or fully replaces a service.Service, after checking its changes (see checkChanges) in the same
+// transaction, and responds with how its dependencies changed
+func (sc *Service) save(c *gin.Context, svc service.Service) {
+	placeholders, err := strconv.ParseBool(c.DefaultQuery("placeholders", "false"))
+	if err != nil {
+		errors.SetResponse(errors.InvalidInput("query param 'placeholders' must be true or false", &err), c)
+		return
+	}
+	var warnings []policy.Violation
+	var diff service.DependencyDiff
+	err = sc.inTransaction(c, func(services service.Repository, topics topic.Repository) error {
+		warnings, err = sc.checkChanges(c, services, topics, svc)
+		if err != nil {
+			return err
+		}
+		diff, err = services.Save(&svc, placeholders)
+		return err
+	})
+	if err != nil {
+		errors.SetResponse(err, c)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"id":              svc.ID,
+		"dependencies":    dto.MakeDependencyDiffFromEntity(diff),
+		"policy_warnings": dto.MakePolicyViolationsFromEntities(warnings),
+	})
+}
+
+// Update partially updates an existing service.Service. The name and ownership fields are only replaced if set, and
+// endpoints are saved one at a time, leaving the service's other endpoints alone. The updated service's changes are
+// checked like Create's, and it takes the same rejectCycles query param
+// PATCH /services/:code?rejectCycles=true { ... service patch DTO ... }
+// { ... service DTO ..., "policy_warnings": [ ... ] }
+func (sc *Service) Update(c *gin.Context) {
+	patchDTO, err := dto.MakeServicePatchFromRequest(c)
+	if err != nil {
+		errors.SetResponse(err, c)
+		return
+	}
+	code := c.Param("code")
+	patch := patchDTO.ToEntity()
+	var warnings []policy.Violation
+	err = sc.inTransaction(c, func(services service.Repository, topics topic.Repository) error {
+		existing, err := findExistingService(services, code)
+		if err != nil {
+			return err
+		}
+		warnings, err = sc.checkChanges(c, services, topics, patch.Apply(*existing))
+		if err != nil {
+			return err
+		}
+		return services.Update(code, patch)
+	})
+	if err != nil {
+		errors.SetResponse(err, c)
+		return
+	}
+	updated, err := findExistingService(sc.services(c), code)
+	if err != nil {
+		errors.SetResponse(err, c)
+		return
+	}
+	c.JSON(http.StatusOK, dto.UpdatedService{
+		Service:        dto.MakeServiceFromEntity(*updated),
+		PolicyWarnings: dto.MakePolicyViolationsFromEntities(warnings),
+	})
+}
+
+// List lists summaries of service.Services, one page at a time
+// GET /services?codePrefix=&nameContains=&hasEndpoint=&team=&sort=code|-code|name|-name&limit=N&cursor=
+// { "services": [ ... service summary DTOs ... ], "next_cursor": "..." }
+func (sc *Service) List(c *gin.Context) {
+	query, err := dto.MakeListQueryFromRequest(c)
+	if err != nil {
+		errors.SetResponse(err, c)
+		return
+	}
+	sc.list(c, query)
+}
+
+// ListByTeam lists summaries of the service.Services owned by a team, one page at a time. It takes the same query
+// params as List
+// GET /teams/:team/services?... { "services": [ ... service summary DTOs ... ], "next_cursor": "..." }
+func (sc *Service) ListByTeam(c *gin.Context) {
+	query, err := dto.MakeListQueryFromRequest(c)
+	if err != nil {
+		errors.SetResponse(err, c)
+		return
+	}
+	team := c.Param("team")
+	query.Team = &team
+	sc.list(c, query)
+}
+
+func (sc *Service) list(c *gin.Context, query service.ListQuery) {
+	page, err := sc.services(c).List(query)
+	if err != nil {
+		errors.SetResponse(err, c)
+		return
+	}
+	c.JSON(http.StatusOK, dto.MakeServiceSummaryPageFromEntity(page, query))
+}
+
+// GetByCode gets a service.Service by the service's code. With asOf, it gets the service as it was at that moment
+// GET /services/:code?asOf=2020-11-03T09:30:00Z { ... service DTO ... }
 func (sc *Service) GetByCode(c *gin.Context) {
 	code := c.Param("code")
-	svc, err := sc.serviceRepository.FindByCode(code)
+	svc, err := sc.findService(c, code)
 	if err != nil {
 		errors.SetResponse(err, c)
 		return
@@ -56,3 +244,388 @@ func (sc *Service) GetByCode(c *gin.Context) {
 	}
 	c.JSON(http.StatusOK, dto.MakeServiceFromEntity(*svc))
 }
+
+// Delete deletes a service.Service by the service's code. If other services or clients depend on it, the deletion is
+// rejected, unless force=true is set, in which case the dependencies on it are removed too
+// DELETE /services/:code?force=true
+// { "removed_dependencies": [ ... dependency edge DTOs ... ], "removed_client_dependencies": [ ... ] }
+func (sc *Service) Delete(c *gin.Context) {
+	force, err := strconv.ParseBool(c.DefaultQuery("force", "false"))
+	if err != nil {
+		errors.SetResponse(errors.InvalidInput("query param 'force' must be true or false", &err), c)
+		return
+	}
+	code := c.Param("code")
+	removed, err := sc.services(c).Delete(code, force)
+	if err != nil {
+		errors.SetResponse(err, c)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"removed_dependencies":        dto.MakeDependencyEdgesFromEntities(removed.Dependencies),
+		"removed_client_dependencies": dto.MakeClientDependencyEdgesFromEntities(removed.ClientDependencies),
+	})
+}
+
+// GetHistory gets every change that was made to a service.Service, oldest first, each described as the difference from
+// the version before it. The history outlives the service, so deleted services still have one
+// GET /services/:code/history { "changes": [ ... change DTOs ... ] }
+func (sc *Service) GetHistory(c *gin.Context) {
+	code := c.Param("code")
+	changes, err := sc.history(c).FindByServiceCode(code)
+	if err != nil {
+		errors.SetResponse(err, c)
+		return
+	}
+	if len(changes) == 0 {
+		errors.SetResponse(errors.ServiceNotFound(fmt.Sprintf("Service with code %s not found", code), nil), c)
+		return
+	}
+	c.JSON(http.StatusOK, dto.MakeHistoryFromEntities(history.MakeEntries(changes)))
+}
+
+// SaveEndpoint creates or replaces a single service.Endpoint of an existing service.Service. The service's changes are
+// checked like Create's, and it takes the same rejectCycles query param
+// PUT /services/:code/endpoints/:endpointCode?rejectCycles=true { ... endpoint DTO ... }
+// { "id": ..., "policy_warnings": [ ... ] }
+func (sc *Service) SaveEndpoint(c *gin.Context) {
+	endpointDTO, err := dto.MakeEndpointFromRequest(c, c.Param("endpointCode"))
+	if err != nil {
+		errors.SetResponse(err, c)
+		return
+	}
+	endpoint := endpointDTO.ToEntity()
+	code := c.Param("code")
+	var warnings []policy.Violation
+	err = sc.inTransaction(c, func(services service.Repository, topics topic.Repository) error {
+		existing, err := findExistingService(services, code)
+		if err != nil {
+			return err
+		}
+		warnings, err = sc.checkChanges(c, services, topics, existing.WithEndpoint(endpoint))
+		if err != nil {
+			return err
+		}
+		return services.SaveEndpoint(code, &endpoint)
+	})
+	if err != nil {
+		errors.SetResponse(err, c)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"id":              endpoint.ID,
+		"policy_warnings": dto.MakePolicyViolationsFromEntities(warnings),
+	})
+}
+
+// GetEndpoint gets a single service.Endpoint by its code, and the code of its service
+// GET /services/:code/endpoints/:endpointCode { ... endpoint DTO ... }
+func (sc *Service) GetEndpoint(c *gin.Context) {
+	code := c.Param("code")
+	endpointCode := c.Param("endpointCode")
+	endpoint, err := sc.services(c).FindEndpoint(code, endpointCode)
+	if err != nil {
+		errors.SetResponse(err, c)
+		return
+	}
+	if endpoint == nil {
+		errors.SetResponse(
+			errors.EndpointNotFound(
+				fmt.Sprintf("Endpoint with code %s not found on service %s", endpointCode, code),
+				nil,
+			),
+			c,
+		)
+		return
+	}
+	c.JSON(http.StatusOK, dto.MakeEndpointFromEntity(*endpoint))
+}
+
+// DeleteEndpoint deletes a single service.Endpoint. If other endpoints or clients depend on it, the deletion is
+// rejected, unless force=true is set, in which case the dependencies on it are removed too
+// DELETE /services/:code/endpoints/:endpointCode?force=true
+// { "removed_dependencies": [ ... ], "removed_client_dependencies": [ ... ] }
+func (sc *Service) DeleteEndpoint(c *gin.Context) {
+	force, err := strconv.ParseBool(c.DefaultQuery("force", "false"))
+	if err != nil {
+		errors.SetResponse(errors.InvalidInput("query param 'force' must be true or false", &err), c)
+		return
+	}
+	code := c.Param("code")
+	removed, err := sc.services(c).DeleteEndpoint(code, c.Param("endpointCode"), force)
+	if err != nil {
+		errors.SetResponse(err, c)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"removed_dependencies":        dto.MakeDependencyEdgesFromEntities(removed.Dependencies),
+		"removed_client_dependencies": dto.MakeClientDependencyEdgesFromEntities(removed.ClientDependencies),
+	})
+}
+
+// GetDependencies gets the Endpoints that a service.Service depends on
+// GET /services/:code/dependencies?transitive=true&depth=N&asOf= { "dependencies": [ ... dependency DTOs ... ] }
+func (sc *Service) GetDependencies(c *gin.Context) {
+	sc.getDependencies(c, nil)
+}
+
+// GetEndpointDependencies gets the Endpoints that a single service.Endpoint depends on
+// GET /services/:code/endpoints/:endpointCode/dependencies?transitive=true&depth=N&asOf= { "dependencies": [ ... ] }
+func (sc *Service) GetEndpointDependencies(c *gin.Context) {
+	endpointCode := c.Param("endpointCode")
+	sc.getDependencies(c, &endpointCode)
+}
+
+func (sc *Service) getDependencies(c *gin.Context, endpointCode *service.EndpointCode) {
+	query, err := makeDependencyQuery(c, endpointCode, false)
+	if err != nil {
+		errors.SetResponse(err, c)
+		return
+	}
+	dependencies, err := sc.traverse(c, query, service.Downstream)
+	if err != nil {
+		errors.SetResponse(err, c)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"dependencies": dto.MakeDependenciesFromEntities(dependencies)})
+}
+
+// GetDependents gets every Endpoint that depends on a service.Service, directly or transitively, grouped by Service,
+// along with every client.Client that depends on it
+// GET /services/:code/dependents?transitive=false&depth=N&asOf= { "dependents": [ ... ], "summary": { ... } }
+func (sc *Service) GetDependents(c *gin.Context) {
+	sc.getDependents(c, nil)
+}
+
+// GetEndpointDependents gets every Endpoint that depends on a single service.Endpoint, directly or transitively,
+// grouped by Service, along with every client.Client that depends on it
+// GET /services/:code/endpoints/:endpointCode/dependents?transitive=false&depth=N&asOf= { "dependents": [ ... ] }
+func (sc *Service) GetEndpointDependents(c *gin.Context) {
+	endpointCode := c.Param("endpointCode")
+	sc.getDependents(c, &endpointCode)
+}
+
+func (sc *Service) getDependents(c *gin.Context, endpointCode *service.EndpointCode) {
+	query, err := makeDependencyQuery(c, endpointCode, true)
+	if err != nil {
+		errors.SetResponse(err, c)
+		return
+	}
+	dependents, err := sc.traverse(c, query, service.Upstream)
+	if err != nil {
+		errors.SetResponse(err, c)
+		return
+	}
+	clientDependents, err := sc.findClientDependents(c, query, dependents)
+	if err != nil {
+		errors.SetResponse(err, c)
+		return
+	}
+	c.JSON(http.StatusOK, dto.MakeDependentsFromEntities(dependents, clientDependents))
+}
+
+// traverse walks the dependency graph in the given direction, from the starting point described by the query. With
+// asOf, it walks the graph as it was at that moment, through the same edges (dependencies, and producers reaching
+// consumers through topics) as without
+func (sc *Service) traverse(
+	c *gin.Context,
+	query service.DependencyQuery,
+	direction service.Direction,
+) ([]service.Dependency, error) {
+	asOf, err := makeAsOf(c)
+	if err != nil {
+		return nil, err
+	}
+	if asOf == nil && direction == service.Upstream {
+		return sc.services(c).FindDependents(query)
+	} else if asOf == nil {
+		return sc.services(c).FindDependencies(query)
+	}
+	g, err := findGraph(c, sc.services(c), sc.topics(c), sc.history(c))
+	if err != nil {
+		return nil, err
+	}
+	return g.Traverse(query, direction)
+}
+
+// findService finds a service.Service by its code, or nil if there is none. With asOf, it finds the Service as it was
+// at that moment
+func (sc *Service) findService(c *gin.Context, code service.Code) (*service.Service, error) {
+	asOf, err := makeAsOf(c)
+	if err != nil {
+		return nil, err
+	}
+	if asOf == nil {
+		return sc.services(c).FindByCode(code)
+	}
+	services, err := findAllServices(c, sc.services(c), sc.history(c))
+	if err != nil {
+		return nil, err
+	}
+	for idx := range services {
+		if services[idx].Code == code {
+			return &services[idx], nil
+		}
+	}
+	return nil, nil
+}
+
+// findClientDependents finds the Clients that call the starting Endpoints of the query, or any of their dependents.
+// With asOf, it finds the Clients as they were at that moment
+func (sc *Service) findClientDependents(
+	c *gin.Context,
+	query service.DependencyQuery,
+	dependents []service.Dependency,
+) ([]client.Dependent, error) {
+	start := make([]service.EndpointRef, 0)
+	if query.EndpointCode != nil {
+		start = append(start, service.EndpointRef{ServiceCode: query.ServiceCode, EndpointCode: *query.EndpointCode})
+	} else {
+		s, err := sc.findService(c, query.ServiceCode)
+		if err != nil {
+			return nil, err
+		}
+		if s != nil {
+			for _, endpoint := range s.Endpoints {
+				start = append(start, service.EndpointRef{ServiceCode: s.Code, EndpointCode: endpoint.Code})
+			}
+		}
+	}
+	refs := append(make([]service.EndpointRef, 0, len(start)+len(dependents)), start...)
+	for _, dependent := range dependents {
+		refs = append(refs, dependent.Endpoint)
+	}
+	clients, err := findClientsByDependencies(c, sc.clients(c), sc.history(c), refs)
+	if err != nil {
+		return nil, err
+	}
+	return client.MakeDependents(start, dependents, clients), nil
+}
+
+// makeDependencyQuery constructs a service.DependencyQuery from the path and query params of a request. The transitive
+// query param decides whether only direct dependencies are searched, or whether the search goes on without limit (or
+// for depth hops, if depth is set). If it is not set, defaultTransitive is used
+func makeDependencyQuery(
+	c *gin.Context,
+	endpointCode *service.EndpointCode,
+	defaultTransitive bool,
+) (service.DependencyQuery, error) {
+	query := service.DependencyQuery{
+		ServiceCode:  c.Param("code"),
+		EndpointCode: endpointCode,
+		MaxDepth:     1,
+	}
+	transitive, err := strconv.ParseBool(c.DefaultQuery("transitive", strconv.FormatBool(defaultTransitive)))
+	if err != nil {
+		return query, errors.InvalidInput("query param 'transitive' must be true or false", &err)
+	}
+	if !transitive {
+		return query, nil
+	}
+	query.MaxDepth = 0
+	if rawDepth, ok := c.GetQuery("depth"); ok {
+		depth, err := strconv.Atoi(rawDepth)
+		if err != nil {
+			return query, errors.InvalidInput("query param 'depth' must be a positive integer", &err)
+		}
+		if depth < 1 {
+			return query, errors.InvalidInput("query param 'depth' must be a positive integer", nil)
+		}
+		query.MaxDepth = depth
+	}
+	return query, nil
+}
+
+// findExistingService finds a service.Service by its code, returning a ServiceNotFound error if there is none
+func findExistingService(services service.Repository, code service.Code) (*service.Service, error) {
+	svc, err := services.FindByCode(code)
+	if err != nil {
+		return nil, err
+	}
+	if svc == nil {
+		return nil, errors.ServiceNotFound(fmt.Sprintf("Service with code %s not found", code), nil)
+	}
+	return svc, nil
+}
+
+// checkChanges checks the dependencies that saving the service.Service would add, before it is saved, against the graph
+// of the given repositories. With rejectCycles=true, it returns an error if they would introduce a new dependency
+// cycle. They are checked against the policy rules, returning an error if any rule that rejects is broken, and
+// otherwise the violations of rules that only warn
+func (sc *Service) checkChanges(
+	c *gin.Context,
+	services service.Repository,
+	topics topic.Repository,
+	svc service.Service,
+) ([]policy.Violation, error) {
+	rejectCycles, err := strconv.ParseBool(c.DefaultQuery("rejectCycles", "false"))
+	if err != nil {
+		return nil, errors.InvalidInput("query param 'rejectCycles' must be true or false", &err)
+	}
+	if rejectCycles {
+		err = sc.checkForNewCycles(c, services, topics, svc)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return sc.checkPolicies(c, services, topics, svc)
+}
+
+// checkForNewCycles returns an error if saving the service.Service would introduce a new dependency cycle into the
+// graph of the given repositories
+func (sc *Service) checkForNewCycles(
+	c *gin.Context,
+	services service.Repository,
+	topics topic.Repository,
+	svc service.Service,
+) error {
+	before, err := findGraphAsOf(services, topics, sc.history(c), nil)
+	if err != nil {
+		return err
+	}
+	cycles := graph.IntroducedCycles(before, before.Replace(svc))
+	if len(cycles.Services) == 0 && len(cycles.Endpoints) == 0 {
+		return nil
+	}
+	return errors.IntroducesCycle(
+		fmt.Sprintf("Saving service %s would introduce a dependency cycle", svc.Code),
+		dto.MakeCycleDetails(cycles),
+	)
+}
+
+// checkPolicies evaluates the policy rules against the dependencies that saving the service.Service would add. It
+// returns an error if any rule that rejects is broken, and otherwise the violations of rules that only warn
+func (sc *Service) checkPolicies(
+	c *gin.Context,
+	services service.Repository,
+	topics topic.Repository,
+	svc service.Service,
+) ([]policy.Violation, error) {
+	rules, err := sc.policyRepository.FindAll()
+	if err != nil {
+		return nil, err
+	}
+	if len(rules) == 0 {
+		return make([]policy.Violation, 0), nil
+	}
+	before, err := findGraphAsOf(services, topics, sc.history(c), nil)
+	if err != nil {
+		return nil, err
+	}
+	rejected, warned := policy.Partition(policy.Evaluate(rules, before, before.Replace(svc)))
+	if len(rejected) > 0 {
+		return nil, errors.PolicyViolations(
+			fmt.Sprintf("Saving service %s would break policy rules", svc.Code),
+			dto.MakePolicyViolationDetails(rejected),
+		)
+	}
+	return warned, nil
+}
+
+// actor is whoever is making the request's changes, as named by its actorHeader
+func actor(c *gin.Context) service.Actor {
+	if actor := c.GetHeader(actorHeader); actor != "" {
+		return actor
+	}
+	return service.AnonymousActor
+}
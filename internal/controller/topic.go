@@ -0,0 +1,83 @@
+package controller
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/yashap/crius/internal/domain/topic"
+	"github.com/yashap/crius/internal/dto"
+	"github.com/yashap/crius/internal/errors"
+)
+
+// Topic is a controller for /topics endpoints
+type Topic struct {
+	topicRepository topic.Repository
+}
+
+// NewTopic instantiates a Topic controller
+func NewTopic(topicRepository topic.Repository) Topic {
+	return Topic{topicRepository}
+}
+
+// topics is the topic.Repository of the request's Environment, which records its changes as made by the request's
+// actor
+func (tc *Topic) topics(c *gin.Context) topic.Repository {
+	return tc.topicRepository.InEnvironment(environment(c)).AsActor(actor(c))
+}
+
+// Create creates a new topic.Topic, or fully replaces an existing one, including its producers and consumers
+// POST /topics { ... topic DTO ... } { "id": ... }
+func (tc *Topic) Create(c *gin.Context) {
+	topicDTO, err := dto.MakeTopicFromRequest(c)
+	if err != nil {
+		errors.SetResponse(err, c)
+		return
+	}
+	t := topicDTO.ToEntity()
+	err = tc.topics(c).Save(&t)
+	if err != nil {
+		errors.SetResponse(err, c)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"id": t.ID})
+}
+
+// List lists every topic.Topic
+// GET /topics { "topics": [ ... topic DTOs ... ] }
+func (tc *Topic) List(c *gin.Context) {
+	topics, err := tc.topics(c).FindAll()
+	if err != nil {
+		errors.SetResponse(err, c)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"topics": dto.MakeTopicsFromEntities(topics)})
+}
+
+// GetByCode gets a topic.Topic by the topic's code
+// GET /topics/:code { ... topic DTO ... }
+func (tc *Topic) GetByCode(c *gin.Context) {
+	code := c.Param("code")
+	t, err := tc.topics(c).FindByCode(code)
+	if err != nil {
+		errors.SetResponse(err, c)
+		return
+	}
+	if t == nil {
+		errors.SetResponse(errors.TopicNotFound(fmt.Sprintf("Topic with code %s not found", code), nil), c)
+		return
+	}
+	c.JSON(http.StatusOK, dto.MakeTopicFromEntity(*t))
+}
+
+// Delete deletes a topic.Topic by the topic's code. Its producers and consumers are left alone, but no longer reach
+// each other through it
+// DELETE /topics/:code {}
+func (tc *Topic) Delete(c *gin.Context) {
+	err := tc.topics(c).Delete(c.Param("code"))
+	if err != nil {
+		errors.SetResponse(err, c)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{})
+}
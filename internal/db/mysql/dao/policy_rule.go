@@ -0,0 +1,959 @@
+// Code generated by SQLBoiler 4.2.0 (https://github.com/volatiletech/sqlboiler). DO NOT EDIT.
+// This file is meant to be re-generated in place and/or deleted at any time.
+
+package models
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/friendsofgo/errors"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+	"github.com/volatiletech/sqlboiler/v4/queries/qmhelper"
+	"github.com/volatiletech/strmangle"
+)
+
+// PolicyRule is an object representing the database table.
+type PolicyRule struct {
+	ID          int64  `boil:"id" json:"id" toml:"id" yaml:"id"`
+	Code        string `boil:"code" json:"code" toml:"code" yaml:"code"`
+	Description string `boil:"description" json:"description" toml:"description" yaml:"description"`
+	Effect      string `boil:"effect" json:"effect" toml:"effect" yaml:"effect"`
+	Enforcement string `boil:"enforcement" json:"enforcement" toml:"enforcement" yaml:"enforcement"`
+	FromMatcher string `boil:"from_matcher" json:"from_matcher" toml:"from_matcher" yaml:"from_matcher"`
+	ToMatcher   string `boil:"to_matcher" json:"to_matcher" toml:"to_matcher" yaml:"to_matcher"`
+	Source      string `boil:"source" json:"source" toml:"source" yaml:"source"`
+
+	R *policyRuleR `boil:"-" json:"-" toml:"-" yaml:"-"`
+	L policyRuleL  `boil:"-" json:"-" toml:"-" yaml:"-"`
+}
+
+var PolicyRuleColumns = struct {
+	ID          string
+	Code        string
+	Description string
+	Effect      string
+	Enforcement string
+	FromMatcher string
+	ToMatcher   string
+	Source      string
+}{
+	ID:          "id",
+	Code:        "code",
+	Description: "description",
+	Effect:      "effect",
+	Enforcement: "enforcement",
+	FromMatcher: "from_matcher",
+	ToMatcher:   "to_matcher",
+	Source:      "source",
+}
+
+// Generated where
+
+var PolicyRuleWhere = struct {
+	ID          whereHelperint64
+	Code        whereHelperstring
+	Description whereHelperstring
+	Effect      whereHelperstring
+	Enforcement whereHelperstring
+	FromMatcher whereHelperstring
+	ToMatcher   whereHelperstring
+	Source      whereHelperstring
+}{
+	ID:          whereHelperint64{field: "`policy_rule`.`id`"},
+	Code:        whereHelperstring{field: "`policy_rule`.`code`"},
+	Description: whereHelperstring{field: "`policy_rule`.`description`"},
+	Effect:      whereHelperstring{field: "`policy_rule`.`effect`"},
+	Enforcement: whereHelperstring{field: "`policy_rule`.`enforcement`"},
+	FromMatcher: whereHelperstring{field: "`policy_rule`.`from_matcher`"},
+	ToMatcher:   whereHelperstring{field: "`policy_rule`.`to_matcher`"},
+	Source:      whereHelperstring{field: "`policy_rule`.`source`"},
+}
+
+// PolicyRuleRels is where relationship names are stored.
+var PolicyRuleRels = struct {
+}{}
+
+// policyRuleR is where relationships are stored.
+type policyRuleR struct {
+}
+
+// NewStruct creates a new relationship struct
+func (*policyRuleR) NewStruct() *policyRuleR {
+	return &policyRuleR{}
+}
+
+// policyRuleL is where Load methods for each relationship are stored.
+type policyRuleL struct{}
+
+var (
+	policyRuleAllColumns            = []string{"id", "code", "description", "effect", "enforcement", "from_matcher", "to_matcher", "source"}
+	policyRuleColumnsWithoutDefault = []string{"code", "description", "effect", "enforcement", "from_matcher", "to_matcher", "source"}
+	policyRuleColumnsWithDefault    = []string{"id"}
+	policyRulePrimaryKeyColumns     = []string{"id"}
+)
+
+type (
+	// PolicyRuleSlice is an alias for a slice of pointers to PolicyRule.
+	// This should generally be used opposed to []PolicyRule.
+	PolicyRuleSlice []*PolicyRule
+	// PolicyRuleHook is the signature for custom PolicyRule hook methods
+	PolicyRuleHook func(context.Context, boil.ContextExecutor, *PolicyRule) error
+
+	policyRuleQuery struct {
+		*queries.Query
+	}
+)
+
+// Cache for insert, update and upsert
+var (
+	policyRuleType                 = reflect.TypeOf(&PolicyRule{})
+	policyRuleMapping              = queries.MakeStructMapping(policyRuleType)
+	policyRulePrimaryKeyMapping, _ = queries.BindMapping(policyRuleType, policyRuleMapping, policyRulePrimaryKeyColumns)
+	policyRuleInsertCacheMut       sync.RWMutex
+	policyRuleInsertCache          = make(map[string]insertCache)
+	policyRuleUpdateCacheMut       sync.RWMutex
+	policyRuleUpdateCache          = make(map[string]updateCache)
+	policyRuleUpsertCacheMut       sync.RWMutex
+	policyRuleUpsertCache          = make(map[string]insertCache)
+)
+
+var (
+	// Force time package dependency for automated UpdatedAt/CreatedAt.
+	_ = time.Second
+	// Force qmhelper dependency for where clause generation (which doesn't
+	// always happen)
+	_ = qmhelper.Where
+)
+
+var policyRuleBeforeInsertHooks []PolicyRuleHook
+var policyRuleBeforeUpdateHooks []PolicyRuleHook
+var policyRuleBeforeDeleteHooks []PolicyRuleHook
+var policyRuleBeforeUpsertHooks []PolicyRuleHook
+
+var policyRuleAfterInsertHooks []PolicyRuleHook
+var policyRuleAfterSelectHooks []PolicyRuleHook
+var policyRuleAfterUpdateHooks []PolicyRuleHook
+var policyRuleAfterDeleteHooks []PolicyRuleHook
+var policyRuleAfterUpsertHooks []PolicyRuleHook
+
+// doBeforeInsertHooks executes all "before insert" hooks.
+func (o *PolicyRule) doBeforeInsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range policyRuleBeforeInsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeUpdateHooks executes all "before Update" hooks.
+func (o *PolicyRule) doBeforeUpdateHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range policyRuleBeforeUpdateHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeDeleteHooks executes all "before Delete" hooks.
+func (o *PolicyRule) doBeforeDeleteHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range policyRuleBeforeDeleteHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeUpsertHooks executes all "before Upsert" hooks.
+func (o *PolicyRule) doBeforeUpsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range policyRuleBeforeUpsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterInsertHooks executes all "after Insert" hooks.
+func (o *PolicyRule) doAfterInsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range policyRuleAfterInsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterSelectHooks executes all "after Select" hooks.
+func (o *PolicyRule) doAfterSelectHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range policyRuleAfterSelectHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterUpdateHooks executes all "after Update" hooks.
+func (o *PolicyRule) doAfterUpdateHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range policyRuleAfterUpdateHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterDeleteHooks executes all "after Delete" hooks.
+func (o *PolicyRule) doAfterDeleteHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range policyRuleAfterDeleteHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterUpsertHooks executes all "after Upsert" hooks.
+func (o *PolicyRule) doAfterUpsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range policyRuleAfterUpsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// AddPolicyRuleHook registers your hook function for all future operations.
+func AddPolicyRuleHook(hookPoint boil.HookPoint, policyRuleHook PolicyRuleHook) {
+	switch hookPoint {
+	case boil.BeforeInsertHook:
+		policyRuleBeforeInsertHooks = append(policyRuleBeforeInsertHooks, policyRuleHook)
+	case boil.BeforeUpdateHook:
+		policyRuleBeforeUpdateHooks = append(policyRuleBeforeUpdateHooks, policyRuleHook)
+	case boil.BeforeDeleteHook:
+		policyRuleBeforeDeleteHooks = append(policyRuleBeforeDeleteHooks, policyRuleHook)
+	case boil.BeforeUpsertHook:
+		policyRuleBeforeUpsertHooks = append(policyRuleBeforeUpsertHooks, policyRuleHook)
+	case boil.AfterInsertHook:
+		policyRuleAfterInsertHooks = append(policyRuleAfterInsertHooks, policyRuleHook)
+	case boil.AfterSelectHook:
+		policyRuleAfterSelectHooks = append(policyRuleAfterSelectHooks, policyRuleHook)
+	case boil.AfterUpdateHook:
+		policyRuleAfterUpdateHooks = append(policyRuleAfterUpdateHooks, policyRuleHook)
+	case boil.AfterDeleteHook:
+		policyRuleAfterDeleteHooks = append(policyRuleAfterDeleteHooks, policyRuleHook)
+	case boil.AfterUpsertHook:
+		policyRuleAfterUpsertHooks = append(policyRuleAfterUpsertHooks, policyRuleHook)
+	}
+}
+
+// One returns a single policyRule record from the query.
+func (q policyRuleQuery) One(ctx context.Context, exec boil.ContextExecutor) (*PolicyRule, error) {
+	o := &PolicyRule{}
+
+	queries.SetLimit(q.Query, 1)
+
+	err := q.Bind(ctx, exec, o)
+	if err != nil {
+		if errors.Cause(err) == sql.ErrNoRows {
+			return nil, sql.ErrNoRows
+		}
+		return nil, errors.Wrap(err, "models: failed to execute a one query for policy_rule")
+	}
+
+	if err := o.doAfterSelectHooks(ctx, exec); err != nil {
+		return o, err
+	}
+
+	return o, nil
+}
+
+// All returns all PolicyRule records from the query.
+func (q policyRuleQuery) All(ctx context.Context, exec boil.ContextExecutor) (PolicyRuleSlice, error) {
+	var o []*PolicyRule
+
+	err := q.Bind(ctx, exec, &o)
+	if err != nil {
+		return nil, errors.Wrap(err, "models: failed to assign all query results to PolicyRule slice")
+	}
+
+	if len(policyRuleAfterSelectHooks) != 0 {
+		for _, obj := range o {
+			if err := obj.doAfterSelectHooks(ctx, exec); err != nil {
+				return o, err
+			}
+		}
+	}
+
+	return o, nil
+}
+
+// Count returns the count of all PolicyRule records in the query.
+func (q policyRuleQuery) Count(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	var count int64
+
+	queries.SetSelect(q.Query, nil)
+	queries.SetCount(q.Query)
+
+	err := q.Query.QueryRowContext(ctx, exec).Scan(&count)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to count policy_rule rows")
+	}
+
+	return count, nil
+}
+
+// Exists checks if the row exists in the table.
+func (q policyRuleQuery) Exists(ctx context.Context, exec boil.ContextExecutor) (bool, error) {
+	var count int64
+
+	queries.SetSelect(q.Query, nil)
+	queries.SetCount(q.Query)
+	queries.SetLimit(q.Query, 1)
+
+	err := q.Query.QueryRowContext(ctx, exec).Scan(&count)
+	if err != nil {
+		return false, errors.Wrap(err, "models: failed to check if policy_rule exists")
+	}
+
+	return count > 0, nil
+}
+
+// PolicyRules retrieves all the records using an executor.
+func PolicyRules(mods ...qm.QueryMod) policyRuleQuery {
+	mods = append(mods, qm.From("`policy_rule`"))
+	return policyRuleQuery{NewQuery(mods...)}
+}
+
+// FindPolicyRule retrieves a single record by ID with an executor.
+// If selectCols is empty Find will return all columns.
+func FindPolicyRule(ctx context.Context, exec boil.ContextExecutor, iD int64, selectCols ...string) (*PolicyRule, error) {
+	policyRuleObj := &PolicyRule{}
+
+	sel := "*"
+	if len(selectCols) > 0 {
+		sel = strings.Join(strmangle.IdentQuoteSlice(dialect.LQ, dialect.RQ, selectCols), ",")
+	}
+	query := fmt.Sprintf(
+		"select %s from `policy_rule` where `id`=?", sel,
+	)
+
+	q := queries.Raw(query, iD)
+
+	err := q.Bind(ctx, exec, policyRuleObj)
+	if err != nil {
+		if errors.Cause(err) == sql.ErrNoRows {
+			return nil, sql.ErrNoRows
+		}
+		return nil, errors.Wrap(err, "models: unable to select from policy_rule")
+	}
+
+	return policyRuleObj, nil
+}
+
+// Insert a single record using an executor.
+// See boil.Columns.InsertColumnSet documentation to understand column list inference for inserts.
+func (o *PolicyRule) Insert(ctx context.Context, exec boil.ContextExecutor, columns boil.Columns) error {
+	if o == nil {
+		return errors.New("models: no policy_rule provided for insertion")
+	}
+
+	var err error
+
+	if err := o.doBeforeInsertHooks(ctx, exec); err != nil {
+		return err
+	}
+
+	nzDefaults := queries.NonZeroDefaultSet(policyRuleColumnsWithDefault, o)
+
+	key := makeCacheKey(columns, nzDefaults)
+	policyRuleInsertCacheMut.RLock()
+	cache, cached := policyRuleInsertCache[key]
+	policyRuleInsertCacheMut.RUnlock()
+
+	if !cached {
+		wl, returnColumns := columns.InsertColumnSet(
+			policyRuleAllColumns,
+			policyRuleColumnsWithDefault,
+			policyRuleColumnsWithoutDefault,
+			nzDefaults,
+		)
+
+		cache.valueMapping, err = queries.BindMapping(policyRuleType, policyRuleMapping, wl)
+		if err != nil {
+			return err
+		}
+		cache.retMapping, err = queries.BindMapping(policyRuleType, policyRuleMapping, returnColumns)
+		if err != nil {
+			return err
+		}
+		if len(wl) != 0 {
+			cache.query = fmt.Sprintf("INSERT INTO `policy_rule` (`%s`) %%sVALUES (%s)%%s", strings.Join(wl, "`,`"), strmangle.Placeholders(dialect.UseIndexPlaceholders, len(wl), 1, 1))
+		} else {
+			cache.query = "INSERT INTO `policy_rule` () VALUES ()%s%s"
+		}
+
+		var queryOutput, queryReturning string
+
+		if len(cache.retMapping) != 0 {
+			cache.retQuery = fmt.Sprintf("SELECT `%s` FROM `policy_rule` WHERE %s", strings.Join(returnColumns, "`,`"), strmangle.WhereClause("`", "`", 0, policyRulePrimaryKeyColumns))
+		}
+
+		cache.query = fmt.Sprintf(cache.query, queryOutput, queryReturning)
+	}
+
+	value := reflect.Indirect(reflect.ValueOf(o))
+	vals := queries.ValuesFromMapping(value, cache.valueMapping)
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.query)
+		fmt.Fprintln(writer, vals)
+	}
+	result, err := exec.ExecContext(ctx, cache.query, vals...)
+
+	if err != nil {
+		return errors.Wrap(err, "models: unable to insert into policy_rule")
+	}
+
+	var lastID int64
+	var identifierCols []interface{}
+
+	if len(cache.retMapping) == 0 {
+		goto CacheNoHooks
+	}
+
+	lastID, err = result.LastInsertId()
+	if err != nil {
+		return ErrSyncFail
+	}
+
+	o.ID = int64(lastID)
+	if lastID != 0 && len(cache.retMapping) == 1 && cache.retMapping[0] == policyRuleMapping["id"] {
+		goto CacheNoHooks
+	}
+
+	identifierCols = []interface{}{
+		o.ID,
+	}
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.retQuery)
+		fmt.Fprintln(writer, identifierCols...)
+	}
+	err = exec.QueryRowContext(ctx, cache.retQuery, identifierCols...).Scan(queries.PtrsFromMapping(value, cache.retMapping)...)
+	if err != nil {
+		return errors.Wrap(err, "models: unable to populate default values for policy_rule")
+	}
+
+CacheNoHooks:
+	if !cached {
+		policyRuleInsertCacheMut.Lock()
+		policyRuleInsertCache[key] = cache
+		policyRuleInsertCacheMut.Unlock()
+	}
+
+	return o.doAfterInsertHooks(ctx, exec)
+}
+
+// Update uses an executor to update the PolicyRule.
+// See boil.Columns.UpdateColumnSet documentation to understand column list inference for updates.
+// Update does not automatically update the record in case of default values. Use .Reload() to refresh the records.
+func (o *PolicyRule) Update(ctx context.Context, exec boil.ContextExecutor, columns boil.Columns) (int64, error) {
+	var err error
+	if err = o.doBeforeUpdateHooks(ctx, exec); err != nil {
+		return 0, err
+	}
+	key := makeCacheKey(columns, nil)
+	policyRuleUpdateCacheMut.RLock()
+	cache, cached := policyRuleUpdateCache[key]
+	policyRuleUpdateCacheMut.RUnlock()
+
+	if !cached {
+		wl := columns.UpdateColumnSet(
+			policyRuleAllColumns,
+			policyRulePrimaryKeyColumns,
+		)
+
+		if !columns.IsWhitelist() {
+			wl = strmangle.SetComplement(wl, []string{"created_at"})
+		}
+		if len(wl) == 0 {
+			return 0, errors.New("models: unable to update policy_rule, could not build whitelist")
+		}
+
+		cache.query = fmt.Sprintf("UPDATE `policy_rule` SET %s WHERE %s",
+			strmangle.SetParamNames("`", "`", 0, wl),
+			strmangle.WhereClause("`", "`", 0, policyRulePrimaryKeyColumns),
+		)
+		cache.valueMapping, err = queries.BindMapping(policyRuleType, policyRuleMapping, append(wl, policyRulePrimaryKeyColumns...))
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	values := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(o)), cache.valueMapping)
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.query)
+		fmt.Fprintln(writer, values)
+	}
+	var result sql.Result
+	result, err = exec.ExecContext(ctx, cache.query, values...)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to update policy_rule row")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to get rows affected by update for policy_rule")
+	}
+
+	if !cached {
+		policyRuleUpdateCacheMut.Lock()
+		policyRuleUpdateCache[key] = cache
+		policyRuleUpdateCacheMut.Unlock()
+	}
+
+	return rowsAff, o.doAfterUpdateHooks(ctx, exec)
+}
+
+// UpdateAll updates all rows with the specified column values.
+func (q policyRuleQuery) UpdateAll(ctx context.Context, exec boil.ContextExecutor, cols M) (int64, error) {
+	queries.SetUpdate(q.Query, cols)
+
+	result, err := q.Query.ExecContext(ctx, exec)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to update all for policy_rule")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to retrieve rows affected for policy_rule")
+	}
+
+	return rowsAff, nil
+}
+
+// UpdateAll updates all rows with the specified column values, using an executor.
+func (o PolicyRuleSlice) UpdateAll(ctx context.Context, exec boil.ContextExecutor, cols M) (int64, error) {
+	ln := int64(len(o))
+	if ln == 0 {
+		return 0, nil
+	}
+
+	if len(cols) == 0 {
+		return 0, errors.New("models: update all requires at least one column argument")
+	}
+
+	colNames := make([]string, len(cols))
+	args := make([]interface{}, len(cols))
+
+	i := 0
+	for name, value := range cols {
+		colNames[i] = name
+		args[i] = value
+		i++
+	}
+
+	// Append all of the primary key values for each column
+	for _, obj := range o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), policyRulePrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := fmt.Sprintf("UPDATE `policy_rule` SET %s WHERE %s",
+		strmangle.SetParamNames("`", "`", 0, colNames),
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), 0, policyRulePrimaryKeyColumns, len(o)))
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, args...)
+	}
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to update all in policyRule slice")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to retrieve rows affected all in update all policyRule")
+	}
+	return rowsAff, nil
+}
+
+var mySQLPolicyRuleUniqueColumns = []string{
+	"id",
+	"code",
+}
+
+// Upsert attempts an insert using an executor, and does an update or ignore on conflict.
+// See boil.Columns documentation for how to properly use updateColumns and insertColumns.
+func (o *PolicyRule) Upsert(ctx context.Context, exec boil.ContextExecutor, updateColumns, insertColumns boil.Columns) error {
+	if o == nil {
+		return errors.New("models: no policy_rule provided for upsert")
+	}
+
+	if err := o.doBeforeUpsertHooks(ctx, exec); err != nil {
+		return err
+	}
+
+	nzDefaults := queries.NonZeroDefaultSet(policyRuleColumnsWithDefault, o)
+	nzUniques := queries.NonZeroDefaultSet(mySQLPolicyRuleUniqueColumns, o)
+
+	if len(nzUniques) == 0 {
+		return errors.New("cannot upsert with a table that cannot conflict on a unique column")
+	}
+
+	// Build cache key in-line uglily - mysql vs psql problems
+	buf := strmangle.GetBuffer()
+	buf.WriteString(strconv.Itoa(updateColumns.Kind))
+	for _, c := range updateColumns.Cols {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	buf.WriteString(strconv.Itoa(insertColumns.Kind))
+	for _, c := range insertColumns.Cols {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	for _, c := range nzDefaults {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	for _, c := range nzUniques {
+		buf.WriteString(c)
+	}
+	key := buf.String()
+	strmangle.PutBuffer(buf)
+
+	policyRuleUpsertCacheMut.RLock()
+	cache, cached := policyRuleUpsertCache[key]
+	policyRuleUpsertCacheMut.RUnlock()
+
+	var err error
+
+	if !cached {
+		insert, ret := insertColumns.InsertColumnSet(
+			policyRuleAllColumns,
+			policyRuleColumnsWithDefault,
+			policyRuleColumnsWithoutDefault,
+			nzDefaults,
+		)
+		update := updateColumns.UpdateColumnSet(
+			policyRuleAllColumns,
+			policyRulePrimaryKeyColumns,
+		)
+
+		if len(update) == 0 {
+			return errors.New("models: unable to upsert policy_rule, could not build update column list")
+		}
+
+		ret = strmangle.SetComplement(ret, nzUniques)
+		cache.query = buildUpsertQueryMySQL(dialect, "policy_rule", update, insert)
+		cache.retQuery = fmt.Sprintf(
+			"SELECT %s FROM `policy_rule` WHERE %s",
+			strings.Join(strmangle.IdentQuoteSlice(dialect.LQ, dialect.RQ, ret), ","),
+			strmangle.WhereClause("`", "`", 0, nzUniques),
+		)
+
+		cache.valueMapping, err = queries.BindMapping(policyRuleType, policyRuleMapping, insert)
+		if err != nil {
+			return err
+		}
+		if len(ret) != 0 {
+			cache.retMapping, err = queries.BindMapping(policyRuleType, policyRuleMapping, ret)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	value := reflect.Indirect(reflect.ValueOf(o))
+	vals := queries.ValuesFromMapping(value, cache.valueMapping)
+	var returns []interface{}
+	if len(cache.retMapping) != 0 {
+		returns = queries.PtrsFromMapping(value, cache.retMapping)
+	}
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.query)
+		fmt.Fprintln(writer, vals)
+	}
+	result, err := exec.ExecContext(ctx, cache.query, vals...)
+
+	if err != nil {
+		return errors.Wrap(err, "models: unable to upsert for policy_rule")
+	}
+
+	var lastID int64
+	var uniqueMap []uint64
+	var nzUniqueCols []interface{}
+
+	if len(cache.retMapping) == 0 {
+		goto CacheNoHooks
+	}
+
+	lastID, err = result.LastInsertId()
+	if err != nil {
+		return ErrSyncFail
+	}
+
+	o.ID = int64(lastID)
+	if lastID != 0 && len(cache.retMapping) == 1 && cache.retMapping[0] == policyRuleMapping["id"] {
+		goto CacheNoHooks
+	}
+
+	uniqueMap, err = queries.BindMapping(policyRuleType, policyRuleMapping, nzUniques)
+	if err != nil {
+		return errors.Wrap(err, "models: unable to retrieve unique values for policy_rule")
+	}
+	nzUniqueCols = queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(o)), uniqueMap)
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.retQuery)
+		fmt.Fprintln(writer, nzUniqueCols...)
+	}
+	err = exec.QueryRowContext(ctx, cache.retQuery, nzUniqueCols...).Scan(returns...)
+	if err != nil {
+		return errors.Wrap(err, "models: unable to populate default values for policy_rule")
+	}
+
+CacheNoHooks:
+	if !cached {
+		policyRuleUpsertCacheMut.Lock()
+		policyRuleUpsertCache[key] = cache
+		policyRuleUpsertCacheMut.Unlock()
+	}
+
+	return o.doAfterUpsertHooks(ctx, exec)
+}
+
+// Delete deletes a single PolicyRule record with an executor.
+// Delete will match against the primary key column to find the record to delete.
+func (o *PolicyRule) Delete(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if o == nil {
+		return 0, errors.New("models: no PolicyRule provided for delete")
+	}
+
+	if err := o.doBeforeDeleteHooks(ctx, exec); err != nil {
+		return 0, err
+	}
+
+	args := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(o)), policyRulePrimaryKeyMapping)
+	sql := "DELETE FROM `policy_rule` WHERE `id`=?"
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, args...)
+	}
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to delete from policy_rule")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to get rows affected by delete for policy_rule")
+	}
+
+	if err := o.doAfterDeleteHooks(ctx, exec); err != nil {
+		return 0, err
+	}
+
+	return rowsAff, nil
+}
+
+// DeleteAll deletes all matching rows.
+func (q policyRuleQuery) DeleteAll(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if q.Query == nil {
+		return 0, errors.New("models: no policyRuleQuery provided for delete all")
+	}
+
+	queries.SetDelete(q.Query)
+
+	result, err := q.Query.ExecContext(ctx, exec)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to delete all from policy_rule")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to get rows affected by deleteall for policy_rule")
+	}
+
+	return rowsAff, nil
+}
+
+// DeleteAll deletes all rows in the slice, using an executor.
+func (o PolicyRuleSlice) DeleteAll(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if len(o) == 0 {
+		return 0, nil
+	}
+
+	if len(policyRuleBeforeDeleteHooks) != 0 {
+		for _, obj := range o {
+			if err := obj.doBeforeDeleteHooks(ctx, exec); err != nil {
+				return 0, err
+			}
+		}
+	}
+
+	var args []interface{}
+	for _, obj := range o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), policyRulePrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := "DELETE FROM `policy_rule` WHERE " +
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), 0, policyRulePrimaryKeyColumns, len(o))
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, args)
+	}
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to delete all from policyRule slice")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to get rows affected by deleteall for policy_rule")
+	}
+
+	if len(policyRuleAfterDeleteHooks) != 0 {
+		for _, obj := range o {
+			if err := obj.doAfterDeleteHooks(ctx, exec); err != nil {
+				return 0, err
+			}
+		}
+	}
+
+	return rowsAff, nil
+}
+
+// Reload refetches the object from the database
+// using the primary keys with an executor.
+func (o *PolicyRule) Reload(ctx context.Context, exec boil.ContextExecutor) error {
+	ret, err := FindPolicyRule(ctx, exec, o.ID)
+	if err != nil {
+		return err
+	}
+
+	*o = *ret
+	return nil
+}
+
+// ReloadAll refetches every row with matching primary key column values
+// and overwrites the original object slice with the newly updated slice.
+func (o *PolicyRuleSlice) ReloadAll(ctx context.Context, exec boil.ContextExecutor) error {
+	if o == nil || len(*o) == 0 {
+		return nil
+	}
+
+	slice := PolicyRuleSlice{}
+	var args []interface{}
+	for _, obj := range *o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), policyRulePrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := "SELECT `policy_rule`.* FROM `policy_rule` WHERE " +
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), 0, policyRulePrimaryKeyColumns, len(*o))
+
+	q := queries.Raw(sql, args...)
+
+	err := q.Bind(ctx, exec, &slice)
+	if err != nil {
+		return errors.Wrap(err, "models: unable to reload all in PolicyRuleSlice")
+	}
+
+	*o = slice
+
+	return nil
+}
+
+// PolicyRuleExists checks if the PolicyRule row exists.
+func PolicyRuleExists(ctx context.Context, exec boil.ContextExecutor, iD int64) (bool, error) {
+	var exists bool
+	sql := "select exists(select 1 from `policy_rule` where `id`=? limit 1)"
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, iD)
+	}
+	row := exec.QueryRowContext(ctx, sql, iD)
+
+	err := row.Scan(&exists)
+	if err != nil {
+		return false, errors.Wrap(err, "models: unable to check if policy_rule exists")
+	}
+
+	return exists, nil
+}
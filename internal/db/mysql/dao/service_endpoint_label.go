@@ -0,0 +1,1107 @@
+// Code generated by SQLBoiler 4.2.0 (https://github.com/volatiletech/sqlboiler). DO NOT EDIT.
+// This file is meant to be re-generated in place and/or deleted at any time.
+
+package models
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/friendsofgo/errors"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+	"github.com/volatiletech/sqlboiler/v4/queries/qmhelper"
+	"github.com/volatiletech/strmangle"
+)
+
+// ServiceEndpointLabel is an object representing the database table.
+type ServiceEndpointLabel struct {
+	ID                int64  `boil:"id" json:"id" toml:"id" yaml:"id"`
+	ServiceEndpointID int64  `boil:"service_endpoint_id" json:"service_endpoint_id" toml:"service_endpoint_id" yaml:"service_endpoint_id"`
+	LabelKey          string `boil:"label_key" json:"label_key" toml:"label_key" yaml:"label_key"`
+	LabelValue        string `boil:"label_value" json:"label_value" toml:"label_value" yaml:"label_value"`
+
+	R *serviceEndpointLabelR `boil:"-" json:"-" toml:"-" yaml:"-"`
+	L serviceEndpointLabelL  `boil:"-" json:"-" toml:"-" yaml:"-"`
+}
+
+var ServiceEndpointLabelColumns = struct {
+	ID                string
+	ServiceEndpointID string
+	LabelKey          string
+	LabelValue        string
+}{
+	ID:                "id",
+	ServiceEndpointID: "service_endpoint_id",
+	LabelKey:          "label_key",
+	LabelValue:        "label_value",
+}
+
+// Generated where
+
+var ServiceEndpointLabelWhere = struct {
+	ID                whereHelperint64
+	ServiceEndpointID whereHelperint64
+	LabelKey          whereHelperstring
+	LabelValue        whereHelperstring
+}{
+	ID:                whereHelperint64{field: "`service_endpoint_label`.`id`"},
+	ServiceEndpointID: whereHelperint64{field: "`service_endpoint_label`.`service_endpoint_id`"},
+	LabelKey:          whereHelperstring{field: "`service_endpoint_label`.`label_key`"},
+	LabelValue:        whereHelperstring{field: "`service_endpoint_label`.`label_value`"},
+}
+
+// ServiceEndpointLabelRels is where relationship names are stored.
+var ServiceEndpointLabelRels = struct {
+	ServiceEndpoint string
+}{
+	ServiceEndpoint: "ServiceEndpoint",
+}
+
+// serviceEndpointLabelR is where relationships are stored.
+type serviceEndpointLabelR struct {
+	ServiceEndpoint *ServiceEndpoint `boil:"ServiceEndpoint" json:"ServiceEndpoint" toml:"ServiceEndpoint" yaml:"ServiceEndpoint"`
+}
+
+// NewStruct creates a new relationship struct
+func (*serviceEndpointLabelR) NewStruct() *serviceEndpointLabelR {
+	return &serviceEndpointLabelR{}
+}
+
+// serviceEndpointLabelL is where Load methods for each relationship are stored.
+type serviceEndpointLabelL struct{}
+
+var (
+	serviceEndpointLabelAllColumns            = []string{"id", "service_endpoint_id", "label_key", "label_value"}
+	serviceEndpointLabelColumnsWithoutDefault = []string{"service_endpoint_id", "label_key", "label_value"}
+	serviceEndpointLabelColumnsWithDefault    = []string{"id"}
+	serviceEndpointLabelPrimaryKeyColumns     = []string{"id"}
+)
+
+type (
+	// ServiceEndpointLabelSlice is an alias for a slice of pointers to ServiceEndpointLabel.
+	// This should generally be used opposed to []ServiceEndpointLabel.
+	ServiceEndpointLabelSlice []*ServiceEndpointLabel
+	// ServiceEndpointLabelHook is the signature for custom ServiceEndpointLabel hook methods
+	ServiceEndpointLabelHook func(context.Context, boil.ContextExecutor, *ServiceEndpointLabel) error
+
+	serviceEndpointLabelQuery struct {
+		*queries.Query
+	}
+)
+
+// Cache for insert, update and upsert
+var (
+	serviceEndpointLabelType                 = reflect.TypeOf(&ServiceEndpointLabel{})
+	serviceEndpointLabelMapping              = queries.MakeStructMapping(serviceEndpointLabelType)
+	serviceEndpointLabelPrimaryKeyMapping, _ = queries.BindMapping(serviceEndpointLabelType, serviceEndpointLabelMapping, serviceEndpointLabelPrimaryKeyColumns)
+	serviceEndpointLabelInsertCacheMut       sync.RWMutex
+	serviceEndpointLabelInsertCache          = make(map[string]insertCache)
+	serviceEndpointLabelUpdateCacheMut       sync.RWMutex
+	serviceEndpointLabelUpdateCache          = make(map[string]updateCache)
+	serviceEndpointLabelUpsertCacheMut       sync.RWMutex
+	serviceEndpointLabelUpsertCache          = make(map[string]insertCache)
+)
+
+var (
+	// Force time package dependency for automated UpdatedAt/CreatedAt.
+	_ = time.Second
+	// Force qmhelper dependency for where clause generation (which doesn't
+	// always happen)
+	_ = qmhelper.Where
+)
+
+var serviceEndpointLabelBeforeInsertHooks []ServiceEndpointLabelHook
+var serviceEndpointLabelBeforeUpdateHooks []ServiceEndpointLabelHook
+var serviceEndpointLabelBeforeDeleteHooks []ServiceEndpointLabelHook
+var serviceEndpointLabelBeforeUpsertHooks []ServiceEndpointLabelHook
+
+var serviceEndpointLabelAfterInsertHooks []ServiceEndpointLabelHook
+var serviceEndpointLabelAfterSelectHooks []ServiceEndpointLabelHook
+var serviceEndpointLabelAfterUpdateHooks []ServiceEndpointLabelHook
+var serviceEndpointLabelAfterDeleteHooks []ServiceEndpointLabelHook
+var serviceEndpointLabelAfterUpsertHooks []ServiceEndpointLabelHook
+
+// doBeforeInsertHooks executes all "before insert" hooks.
+func (o *ServiceEndpointLabel) doBeforeInsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range serviceEndpointLabelBeforeInsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeUpdateHooks executes all "before Update" hooks.
+func (o *ServiceEndpointLabel) doBeforeUpdateHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range serviceEndpointLabelBeforeUpdateHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeDeleteHooks executes all "before Delete" hooks.
+func (o *ServiceEndpointLabel) doBeforeDeleteHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range serviceEndpointLabelBeforeDeleteHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeUpsertHooks executes all "before Upsert" hooks.
+func (o *ServiceEndpointLabel) doBeforeUpsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range serviceEndpointLabelBeforeUpsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterInsertHooks executes all "after Insert" hooks.
+func (o *ServiceEndpointLabel) doAfterInsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range serviceEndpointLabelAfterInsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterSelectHooks executes all "after Select" hooks.
+func (o *ServiceEndpointLabel) doAfterSelectHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range serviceEndpointLabelAfterSelectHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterUpdateHooks executes all "after Update" hooks.
+func (o *ServiceEndpointLabel) doAfterUpdateHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range serviceEndpointLabelAfterUpdateHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterDeleteHooks executes all "after Delete" hooks.
+func (o *ServiceEndpointLabel) doAfterDeleteHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range serviceEndpointLabelAfterDeleteHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterUpsertHooks executes all "after Upsert" hooks.
+func (o *ServiceEndpointLabel) doAfterUpsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range serviceEndpointLabelAfterUpsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// AddServiceEndpointLabelHook registers your hook function for all future operations.
+func AddServiceEndpointLabelHook(hookPoint boil.HookPoint, serviceEndpointLabelHook ServiceEndpointLabelHook) {
+	switch hookPoint {
+	case boil.BeforeInsertHook:
+		serviceEndpointLabelBeforeInsertHooks = append(serviceEndpointLabelBeforeInsertHooks, serviceEndpointLabelHook)
+	case boil.BeforeUpdateHook:
+		serviceEndpointLabelBeforeUpdateHooks = append(serviceEndpointLabelBeforeUpdateHooks, serviceEndpointLabelHook)
+	case boil.BeforeDeleteHook:
+		serviceEndpointLabelBeforeDeleteHooks = append(serviceEndpointLabelBeforeDeleteHooks, serviceEndpointLabelHook)
+	case boil.BeforeUpsertHook:
+		serviceEndpointLabelBeforeUpsertHooks = append(serviceEndpointLabelBeforeUpsertHooks, serviceEndpointLabelHook)
+	case boil.AfterInsertHook:
+		serviceEndpointLabelAfterInsertHooks = append(serviceEndpointLabelAfterInsertHooks, serviceEndpointLabelHook)
+	case boil.AfterSelectHook:
+		serviceEndpointLabelAfterSelectHooks = append(serviceEndpointLabelAfterSelectHooks, serviceEndpointLabelHook)
+	case boil.AfterUpdateHook:
+		serviceEndpointLabelAfterUpdateHooks = append(serviceEndpointLabelAfterUpdateHooks, serviceEndpointLabelHook)
+	case boil.AfterDeleteHook:
+		serviceEndpointLabelAfterDeleteHooks = append(serviceEndpointLabelAfterDeleteHooks, serviceEndpointLabelHook)
+	case boil.AfterUpsertHook:
+		serviceEndpointLabelAfterUpsertHooks = append(serviceEndpointLabelAfterUpsertHooks, serviceEndpointLabelHook)
+	}
+}
+
+// One returns a single serviceEndpointLabel record from the query.
+func (q serviceEndpointLabelQuery) One(ctx context.Context, exec boil.ContextExecutor) (*ServiceEndpointLabel, error) {
+	o := &ServiceEndpointLabel{}
+
+	queries.SetLimit(q.Query, 1)
+
+	err := q.Bind(ctx, exec, o)
+	if err != nil {
+		if errors.Cause(err) == sql.ErrNoRows {
+			return nil, sql.ErrNoRows
+		}
+		return nil, errors.Wrap(err, "models: failed to execute a one query for service_endpoint_label")
+	}
+
+	if err := o.doAfterSelectHooks(ctx, exec); err != nil {
+		return o, err
+	}
+
+	return o, nil
+}
+
+// All returns all ServiceEndpointLabel records from the query.
+func (q serviceEndpointLabelQuery) All(ctx context.Context, exec boil.ContextExecutor) (ServiceEndpointLabelSlice, error) {
+	var o []*ServiceEndpointLabel
+
+	err := q.Bind(ctx, exec, &o)
+	if err != nil {
+		return nil, errors.Wrap(err, "models: failed to assign all query results to ServiceEndpointLabel slice")
+	}
+
+	if len(serviceEndpointLabelAfterSelectHooks) != 0 {
+		for _, obj := range o {
+			if err := obj.doAfterSelectHooks(ctx, exec); err != nil {
+				return o, err
+			}
+		}
+	}
+
+	return o, nil
+}
+
+// Count returns the count of all ServiceEndpointLabel records in the query.
+func (q serviceEndpointLabelQuery) Count(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	var count int64
+
+	queries.SetSelect(q.Query, nil)
+	queries.SetCount(q.Query)
+
+	err := q.Query.QueryRowContext(ctx, exec).Scan(&count)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to count service_endpoint_label rows")
+	}
+
+	return count, nil
+}
+
+// Exists checks if the row exists in the table.
+func (q serviceEndpointLabelQuery) Exists(ctx context.Context, exec boil.ContextExecutor) (bool, error) {
+	var count int64
+
+	queries.SetSelect(q.Query, nil)
+	queries.SetCount(q.Query)
+	queries.SetLimit(q.Query, 1)
+
+	err := q.Query.QueryRowContext(ctx, exec).Scan(&count)
+	if err != nil {
+		return false, errors.Wrap(err, "models: failed to check if service_endpoint_label exists")
+	}
+
+	return count > 0, nil
+}
+
+// ServiceEndpoint pointed to by the foreign key.
+func (o *ServiceEndpointLabel) ServiceEndpoint(mods ...qm.QueryMod) serviceEndpointQuery {
+	queryMods := []qm.QueryMod{
+		qm.Where("`id` = ?", o.ServiceEndpointID),
+	}
+
+	queryMods = append(queryMods, mods...)
+
+	query := ServiceEndpoints(queryMods...)
+	queries.SetFrom(query.Query, "`service_endpoint`")
+
+	return query
+}
+
+// LoadServiceEndpoint allows an eager lookup of values, cached into the
+// loaded structs of the objects. This is for an N-1 relationship.
+func (serviceEndpointLabelL) LoadServiceEndpoint(ctx context.Context, e boil.ContextExecutor, singular bool, maybeServiceEndpointLabel interface{}, mods queries.Applicator) error {
+	var slice []*ServiceEndpointLabel
+	var object *ServiceEndpointLabel
+
+	if singular {
+		object = maybeServiceEndpointLabel.(*ServiceEndpointLabel)
+	} else {
+		slice = *maybeServiceEndpointLabel.(*[]*ServiceEndpointLabel)
+	}
+
+	args := make([]interface{}, 0, 1)
+	if singular {
+		if object.R == nil {
+			object.R = &serviceEndpointLabelR{}
+		}
+		args = append(args, object.ServiceEndpointID)
+
+	} else {
+	Outer:
+		for _, obj := range slice {
+			if obj.R == nil {
+				obj.R = &serviceEndpointLabelR{}
+			}
+
+			for _, a := range args {
+				if a == obj.ServiceEndpointID {
+					continue Outer
+				}
+			}
+
+			args = append(args, obj.ServiceEndpointID)
+
+		}
+	}
+
+	if len(args) == 0 {
+		return nil
+	}
+
+	query := NewQuery(
+		qm.From(`service_endpoint`),
+		qm.WhereIn(`service_endpoint.id in ?`, args...),
+	)
+	if mods != nil {
+		mods.Apply(query)
+	}
+
+	results, err := query.QueryContext(ctx, e)
+	if err != nil {
+		return errors.Wrap(err, "failed to eager load ServiceEndpoint")
+	}
+
+	var resultSlice []*ServiceEndpoint
+	if err = queries.Bind(results, &resultSlice); err != nil {
+		return errors.Wrap(err, "failed to bind eager loaded slice ServiceEndpoint")
+	}
+
+	if err = results.Close(); err != nil {
+		return errors.Wrap(err, "failed to close results of eager load for service_endpoint")
+	}
+	if err = results.Err(); err != nil {
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for service_endpoint")
+	}
+
+	if len(serviceEndpointLabelAfterSelectHooks) != 0 {
+		for _, obj := range resultSlice {
+			if err := obj.doAfterSelectHooks(ctx, e); err != nil {
+				return err
+			}
+		}
+	}
+
+	if len(resultSlice) == 0 {
+		return nil
+	}
+
+	if singular {
+		foreign := resultSlice[0]
+		object.R.ServiceEndpoint = foreign
+		if foreign.R == nil {
+			foreign.R = &serviceEndpointR{}
+		}
+		foreign.R.ServiceEndpointLabels = append(foreign.R.ServiceEndpointLabels, object)
+		return nil
+	}
+
+	for _, local := range slice {
+		for _, foreign := range resultSlice {
+			if local.ServiceEndpointID == foreign.ID {
+				local.R.ServiceEndpoint = foreign
+				if foreign.R == nil {
+					foreign.R = &serviceEndpointR{}
+				}
+				foreign.R.ServiceEndpointLabels = append(foreign.R.ServiceEndpointLabels, local)
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
+// SetServiceEndpoint of the serviceEndpointLabel to the related item.
+// Sets o.R.ServiceEndpoint to related.
+// Adds o to related.R.ServiceEndpointLabels.
+func (o *ServiceEndpointLabel) SetServiceEndpoint(ctx context.Context, exec boil.ContextExecutor, insert bool, related *ServiceEndpoint) error {
+	var err error
+	if insert {
+		if err = related.Insert(ctx, exec, boil.Infer()); err != nil {
+			return errors.Wrap(err, "failed to insert into foreign table")
+		}
+	}
+
+	updateQuery := fmt.Sprintf(
+		"UPDATE `service_endpoint_label` SET %s WHERE %s",
+		strmangle.SetParamNames("`", "`", 0, []string{"service_endpoint_id"}),
+		strmangle.WhereClause("`", "`", 0, serviceEndpointLabelPrimaryKeyColumns),
+	)
+	values := []interface{}{related.ID, o.ID}
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, updateQuery)
+		fmt.Fprintln(writer, values)
+	}
+	if _, err = exec.ExecContext(ctx, updateQuery, values...); err != nil {
+		return errors.Wrap(err, "failed to update local table")
+	}
+
+	o.ServiceEndpointID = related.ID
+	if o.R == nil {
+		o.R = &serviceEndpointLabelR{
+			ServiceEndpoint: related,
+		}
+	} else {
+		o.R.ServiceEndpoint = related
+	}
+
+	if related.R == nil {
+		related.R = &serviceEndpointR{
+			ServiceEndpointLabels: ServiceEndpointLabelSlice{o},
+		}
+	} else {
+		related.R.ServiceEndpointLabels = append(related.R.ServiceEndpointLabels, o)
+	}
+
+	return nil
+}
+
+// ServiceEndpointLabels retrieves all the records using an executor.
+func ServiceEndpointLabels(mods ...qm.QueryMod) serviceEndpointLabelQuery {
+	mods = append(mods, qm.From("`service_endpoint_label`"))
+	return serviceEndpointLabelQuery{NewQuery(mods...)}
+}
+
+// FindServiceEndpointLabel retrieves a single record by ID with an executor.
+// If selectCols is empty Find will return all columns.
+func FindServiceEndpointLabel(ctx context.Context, exec boil.ContextExecutor, iD int64, selectCols ...string) (*ServiceEndpointLabel, error) {
+	serviceEndpointLabelObj := &ServiceEndpointLabel{}
+
+	sel := "*"
+	if len(selectCols) > 0 {
+		sel = strings.Join(strmangle.IdentQuoteSlice(dialect.LQ, dialect.RQ, selectCols), ",")
+	}
+	query := fmt.Sprintf(
+		"select %s from `service_endpoint_label` where `id`=?", sel,
+	)
+
+	q := queries.Raw(query, iD)
+
+	err := q.Bind(ctx, exec, serviceEndpointLabelObj)
+	if err != nil {
+		if errors.Cause(err) == sql.ErrNoRows {
+			return nil, sql.ErrNoRows
+		}
+		return nil, errors.Wrap(err, "models: unable to select from service_endpoint_label")
+	}
+
+	return serviceEndpointLabelObj, nil
+}
+
+// Insert a single record using an executor.
+// See boil.Columns.InsertColumnSet documentation to understand column list inference for inserts.
+func (o *ServiceEndpointLabel) Insert(ctx context.Context, exec boil.ContextExecutor, columns boil.Columns) error {
+	if o == nil {
+		return errors.New("models: no service_endpoint_label provided for insertion")
+	}
+
+	var err error
+
+	if err := o.doBeforeInsertHooks(ctx, exec); err != nil {
+		return err
+	}
+
+	nzDefaults := queries.NonZeroDefaultSet(serviceEndpointLabelColumnsWithDefault, o)
+
+	key := makeCacheKey(columns, nzDefaults)
+	serviceEndpointLabelInsertCacheMut.RLock()
+	cache, cached := serviceEndpointLabelInsertCache[key]
+	serviceEndpointLabelInsertCacheMut.RUnlock()
+
+	if !cached {
+		wl, returnColumns := columns.InsertColumnSet(
+			serviceEndpointLabelAllColumns,
+			serviceEndpointLabelColumnsWithDefault,
+			serviceEndpointLabelColumnsWithoutDefault,
+			nzDefaults,
+		)
+
+		cache.valueMapping, err = queries.BindMapping(serviceEndpointLabelType, serviceEndpointLabelMapping, wl)
+		if err != nil {
+			return err
+		}
+		cache.retMapping, err = queries.BindMapping(serviceEndpointLabelType, serviceEndpointLabelMapping, returnColumns)
+		if err != nil {
+			return err
+		}
+		if len(wl) != 0 {
+			cache.query = fmt.Sprintf("INSERT INTO `service_endpoint_label` (`%s`) %%sVALUES (%s)%%s", strings.Join(wl, "`,`"), strmangle.Placeholders(dialect.UseIndexPlaceholders, len(wl), 1, 1))
+		} else {
+			cache.query = "INSERT INTO `service_endpoint_label` () VALUES ()%s%s"
+		}
+
+		var queryOutput, queryReturning string
+
+		if len(cache.retMapping) != 0 {
+			cache.retQuery = fmt.Sprintf("SELECT `%s` FROM `service_endpoint_label` WHERE %s", strings.Join(returnColumns, "`,`"), strmangle.WhereClause("`", "`", 0, serviceEndpointLabelPrimaryKeyColumns))
+		}
+
+		cache.query = fmt.Sprintf(cache.query, queryOutput, queryReturning)
+	}
+
+	value := reflect.Indirect(reflect.ValueOf(o))
+	vals := queries.ValuesFromMapping(value, cache.valueMapping)
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.query)
+		fmt.Fprintln(writer, vals)
+	}
+	result, err := exec.ExecContext(ctx, cache.query, vals...)
+
+	if err != nil {
+		return errors.Wrap(err, "models: unable to insert into service_endpoint_label")
+	}
+
+	var lastID int64
+	var identifierCols []interface{}
+
+	if len(cache.retMapping) == 0 {
+		goto CacheNoHooks
+	}
+
+	lastID, err = result.LastInsertId()
+	if err != nil {
+		return ErrSyncFail
+	}
+
+	o.ID = int64(lastID)
+	if lastID != 0 && len(cache.retMapping) == 1 && cache.retMapping[0] == serviceEndpointLabelMapping["id"] {
+		goto CacheNoHooks
+	}
+
+	identifierCols = []interface{}{
+		o.ID,
+	}
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.retQuery)
+		fmt.Fprintln(writer, identifierCols...)
+	}
+	err = exec.QueryRowContext(ctx, cache.retQuery, identifierCols...).Scan(queries.PtrsFromMapping(value, cache.retMapping)...)
+	if err != nil {
+		return errors.Wrap(err, "models: unable to populate default values for service_endpoint_label")
+	}
+
+CacheNoHooks:
+	if !cached {
+		serviceEndpointLabelInsertCacheMut.Lock()
+		serviceEndpointLabelInsertCache[key] = cache
+		serviceEndpointLabelInsertCacheMut.Unlock()
+	}
+
+	return o.doAfterInsertHooks(ctx, exec)
+}
+
+// Update uses an executor to update the ServiceEndpointLabel.
+// See boil.Columns.UpdateColumnSet documentation to understand column list inference for updates.
+// Update does not automatically update the record in case of default values. Use .Reload() to refresh the records.
+func (o *ServiceEndpointLabel) Update(ctx context.Context, exec boil.ContextExecutor, columns boil.Columns) (int64, error) {
+	var err error
+	if err = o.doBeforeUpdateHooks(ctx, exec); err != nil {
+		return 0, err
+	}
+	key := makeCacheKey(columns, nil)
+	serviceEndpointLabelUpdateCacheMut.RLock()
+	cache, cached := serviceEndpointLabelUpdateCache[key]
+	serviceEndpointLabelUpdateCacheMut.RUnlock()
+
+	if !cached {
+		wl := columns.UpdateColumnSet(
+			serviceEndpointLabelAllColumns,
+			serviceEndpointLabelPrimaryKeyColumns,
+		)
+
+		if !columns.IsWhitelist() {
+			wl = strmangle.SetComplement(wl, []string{"created_at"})
+		}
+		if len(wl) == 0 {
+			return 0, errors.New("models: unable to update service_endpoint_label, could not build whitelist")
+		}
+
+		cache.query = fmt.Sprintf("UPDATE `service_endpoint_label` SET %s WHERE %s",
+			strmangle.SetParamNames("`", "`", 0, wl),
+			strmangle.WhereClause("`", "`", 0, serviceEndpointLabelPrimaryKeyColumns),
+		)
+		cache.valueMapping, err = queries.BindMapping(serviceEndpointLabelType, serviceEndpointLabelMapping, append(wl, serviceEndpointLabelPrimaryKeyColumns...))
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	values := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(o)), cache.valueMapping)
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.query)
+		fmt.Fprintln(writer, values)
+	}
+	var result sql.Result
+	result, err = exec.ExecContext(ctx, cache.query, values...)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to update service_endpoint_label row")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to get rows affected by update for service_endpoint_label")
+	}
+
+	if !cached {
+		serviceEndpointLabelUpdateCacheMut.Lock()
+		serviceEndpointLabelUpdateCache[key] = cache
+		serviceEndpointLabelUpdateCacheMut.Unlock()
+	}
+
+	return rowsAff, o.doAfterUpdateHooks(ctx, exec)
+}
+
+// UpdateAll updates all rows with the specified column values.
+func (q serviceEndpointLabelQuery) UpdateAll(ctx context.Context, exec boil.ContextExecutor, cols M) (int64, error) {
+	queries.SetUpdate(q.Query, cols)
+
+	result, err := q.Query.ExecContext(ctx, exec)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to update all for service_endpoint_label")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to retrieve rows affected for service_endpoint_label")
+	}
+
+	return rowsAff, nil
+}
+
+// UpdateAll updates all rows with the specified column values, using an executor.
+func (o ServiceEndpointLabelSlice) UpdateAll(ctx context.Context, exec boil.ContextExecutor, cols M) (int64, error) {
+	ln := int64(len(o))
+	if ln == 0 {
+		return 0, nil
+	}
+
+	if len(cols) == 0 {
+		return 0, errors.New("models: update all requires at least one column argument")
+	}
+
+	colNames := make([]string, len(cols))
+	args := make([]interface{}, len(cols))
+
+	i := 0
+	for name, value := range cols {
+		colNames[i] = name
+		args[i] = value
+		i++
+	}
+
+	// Append all of the primary key values for each column
+	for _, obj := range o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), serviceEndpointLabelPrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := fmt.Sprintf("UPDATE `service_endpoint_label` SET %s WHERE %s",
+		strmangle.SetParamNames("`", "`", 0, colNames),
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), 0, serviceEndpointLabelPrimaryKeyColumns, len(o)))
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, args...)
+	}
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to update all in serviceEndpointLabel slice")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to retrieve rows affected all in update all serviceEndpointLabel")
+	}
+	return rowsAff, nil
+}
+
+var mySQLServiceEndpointLabelUniqueColumns = []string{
+	"id",
+}
+
+// Upsert attempts an insert using an executor, and does an update or ignore on conflict.
+// See boil.Columns documentation for how to properly use updateColumns and insertColumns.
+func (o *ServiceEndpointLabel) Upsert(ctx context.Context, exec boil.ContextExecutor, updateColumns, insertColumns boil.Columns) error {
+	if o == nil {
+		return errors.New("models: no service_endpoint_label provided for upsert")
+	}
+
+	if err := o.doBeforeUpsertHooks(ctx, exec); err != nil {
+		return err
+	}
+
+	nzDefaults := queries.NonZeroDefaultSet(serviceEndpointLabelColumnsWithDefault, o)
+	nzUniques := queries.NonZeroDefaultSet(mySQLServiceEndpointLabelUniqueColumns, o)
+
+	if len(nzUniques) == 0 {
+		return errors.New("cannot upsert with a table that cannot conflict on a unique column")
+	}
+
+	// Build cache key in-line uglily - mysql vs psql problems
+	buf := strmangle.GetBuffer()
+	buf.WriteString(strconv.Itoa(updateColumns.Kind))
+	for _, c := range updateColumns.Cols {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	buf.WriteString(strconv.Itoa(insertColumns.Kind))
+	for _, c := range insertColumns.Cols {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	for _, c := range nzDefaults {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	for _, c := range nzUniques {
+		buf.WriteString(c)
+	}
+	key := buf.String()
+	strmangle.PutBuffer(buf)
+
+	serviceEndpointLabelUpsertCacheMut.RLock()
+	cache, cached := serviceEndpointLabelUpsertCache[key]
+	serviceEndpointLabelUpsertCacheMut.RUnlock()
+
+	var err error
+
+	if !cached {
+		insert, ret := insertColumns.InsertColumnSet(
+			serviceEndpointLabelAllColumns,
+			serviceEndpointLabelColumnsWithDefault,
+			serviceEndpointLabelColumnsWithoutDefault,
+			nzDefaults,
+		)
+		update := updateColumns.UpdateColumnSet(
+			serviceEndpointLabelAllColumns,
+			serviceEndpointLabelPrimaryKeyColumns,
+		)
+
+		if len(update) == 0 {
+			return errors.New("models: unable to upsert service_endpoint_label, could not build update column list")
+		}
+
+		ret = strmangle.SetComplement(ret, nzUniques)
+		cache.query = buildUpsertQueryMySQL(dialect, "service_endpoint_label", update, insert)
+		cache.retQuery = fmt.Sprintf(
+			"SELECT %s FROM `service_endpoint_label` WHERE %s",
+			strings.Join(strmangle.IdentQuoteSlice(dialect.LQ, dialect.RQ, ret), ","),
+			strmangle.WhereClause("`", "`", 0, nzUniques),
+		)
+
+		cache.valueMapping, err = queries.BindMapping(serviceEndpointLabelType, serviceEndpointLabelMapping, insert)
+		if err != nil {
+			return err
+		}
+		if len(ret) != 0 {
+			cache.retMapping, err = queries.BindMapping(serviceEndpointLabelType, serviceEndpointLabelMapping, ret)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	value := reflect.Indirect(reflect.ValueOf(o))
+	vals := queries.ValuesFromMapping(value, cache.valueMapping)
+	var returns []interface{}
+	if len(cache.retMapping) != 0 {
+		returns = queries.PtrsFromMapping(value, cache.retMapping)
+	}
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.query)
+		fmt.Fprintln(writer, vals)
+	}
+	result, err := exec.ExecContext(ctx, cache.query, vals...)
+
+	if err != nil {
+		return errors.Wrap(err, "models: unable to upsert for service_endpoint_label")
+	}
+
+	var lastID int64
+	var uniqueMap []uint64
+	var nzUniqueCols []interface{}
+
+	if len(cache.retMapping) == 0 {
+		goto CacheNoHooks
+	}
+
+	lastID, err = result.LastInsertId()
+	if err != nil {
+		return ErrSyncFail
+	}
+
+	o.ID = int64(lastID)
+	if lastID != 0 && len(cache.retMapping) == 1 && cache.retMapping[0] == serviceEndpointLabelMapping["id"] {
+		goto CacheNoHooks
+	}
+
+	uniqueMap, err = queries.BindMapping(serviceEndpointLabelType, serviceEndpointLabelMapping, nzUniques)
+	if err != nil {
+		return errors.Wrap(err, "models: unable to retrieve unique values for service_endpoint_label")
+	}
+	nzUniqueCols = queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(o)), uniqueMap)
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.retQuery)
+		fmt.Fprintln(writer, nzUniqueCols...)
+	}
+	err = exec.QueryRowContext(ctx, cache.retQuery, nzUniqueCols...).Scan(returns...)
+	if err != nil {
+		return errors.Wrap(err, "models: unable to populate default values for service_endpoint_label")
+	}
+
+CacheNoHooks:
+	if !cached {
+		serviceEndpointLabelUpsertCacheMut.Lock()
+		serviceEndpointLabelUpsertCache[key] = cache
+		serviceEndpointLabelUpsertCacheMut.Unlock()
+	}
+
+	return o.doAfterUpsertHooks(ctx, exec)
+}
+
+// Delete deletes a single ServiceEndpointLabel record with an executor.
+// Delete will match against the primary key column to find the record to delete.
+func (o *ServiceEndpointLabel) Delete(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if o == nil {
+		return 0, errors.New("models: no ServiceEndpointLabel provided for delete")
+	}
+
+	if err := o.doBeforeDeleteHooks(ctx, exec); err != nil {
+		return 0, err
+	}
+
+	args := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(o)), serviceEndpointLabelPrimaryKeyMapping)
+	sql := "DELETE FROM `service_endpoint_label` WHERE `id`=?"
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, args...)
+	}
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to delete from service_endpoint_label")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to get rows affected by delete for service_endpoint_label")
+	}
+
+	if err := o.doAfterDeleteHooks(ctx, exec); err != nil {
+		return 0, err
+	}
+
+	return rowsAff, nil
+}
+
+// DeleteAll deletes all matching rows.
+func (q serviceEndpointLabelQuery) DeleteAll(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if q.Query == nil {
+		return 0, errors.New("models: no serviceEndpointLabelQuery provided for delete all")
+	}
+
+	queries.SetDelete(q.Query)
+
+	result, err := q.Query.ExecContext(ctx, exec)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to delete all from service_endpoint_label")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to get rows affected by deleteall for service_endpoint_label")
+	}
+
+	return rowsAff, nil
+}
+
+// DeleteAll deletes all rows in the slice, using an executor.
+func (o ServiceEndpointLabelSlice) DeleteAll(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if len(o) == 0 {
+		return 0, nil
+	}
+
+	if len(serviceEndpointLabelBeforeDeleteHooks) != 0 {
+		for _, obj := range o {
+			if err := obj.doBeforeDeleteHooks(ctx, exec); err != nil {
+				return 0, err
+			}
+		}
+	}
+
+	var args []interface{}
+	for _, obj := range o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), serviceEndpointLabelPrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := "DELETE FROM `service_endpoint_label` WHERE " +
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), 0, serviceEndpointLabelPrimaryKeyColumns, len(o))
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, args)
+	}
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to delete all from serviceEndpointLabel slice")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to get rows affected by deleteall for service_endpoint_label")
+	}
+
+	if len(serviceEndpointLabelAfterDeleteHooks) != 0 {
+		for _, obj := range o {
+			if err := obj.doAfterDeleteHooks(ctx, exec); err != nil {
+				return 0, err
+			}
+		}
+	}
+
+	return rowsAff, nil
+}
+
+// Reload refetches the object from the database
+// using the primary keys with an executor.
+func (o *ServiceEndpointLabel) Reload(ctx context.Context, exec boil.ContextExecutor) error {
+	ret, err := FindServiceEndpointLabel(ctx, exec, o.ID)
+	if err != nil {
+		return err
+	}
+
+	*o = *ret
+	return nil
+}
+
+// ReloadAll refetches every row with matching primary key column values
+// and overwrites the original object slice with the newly updated slice.
+func (o *ServiceEndpointLabelSlice) ReloadAll(ctx context.Context, exec boil.ContextExecutor) error {
+	if o == nil || len(*o) == 0 {
+		return nil
+	}
+
+	slice := ServiceEndpointLabelSlice{}
+	var args []interface{}
+	for _, obj := range *o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), serviceEndpointLabelPrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := "SELECT `service_endpoint_label`.* FROM `service_endpoint_label` WHERE " +
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), 0, serviceEndpointLabelPrimaryKeyColumns, len(*o))
+
+	q := queries.Raw(sql, args...)
+
+	err := q.Bind(ctx, exec, &slice)
+	if err != nil {
+		return errors.Wrap(err, "models: unable to reload all in ServiceEndpointLabelSlice")
+	}
+
+	*o = slice
+
+	return nil
+}
+
+// ServiceEndpointLabelExists checks if the ServiceEndpointLabel row exists.
+func ServiceEndpointLabelExists(ctx context.Context, exec boil.ContextExecutor, iD int64) (bool, error) {
+	var exists bool
+	sql := "select exists(select 1 from `service_endpoint_label` where `id`=? limit 1)"
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, iD)
+	}
+	row := exec.QueryRowContext(ctx, sql, iD)
+
+	err := row.Scan(&exists)
+	if err != nil {
+		return false, errors.Wrap(err, "models: unable to check if service_endpoint_label exists")
+	}
+
+	return exists, nil
+}
@@ -0,0 +1,1107 @@
+// Code generated by SQLBoiler 4.2.0 (https://github.com/volatiletech/sqlboiler). DO NOT EDIT.
+// This file is meant to be re-generated in place and/or deleted at any time.
+
+package models
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/friendsofgo/errors"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+	"github.com/volatiletech/sqlboiler/v4/queries/qmhelper"
+	"github.com/volatiletech/strmangle"
+)
+
+// ServiceLabel is an object representing the database table.
+type ServiceLabel struct {
+	ID         int64  `boil:"id" json:"id" toml:"id" yaml:"id"`
+	ServiceID  int64  `boil:"service_id" json:"service_id" toml:"service_id" yaml:"service_id"`
+	LabelKey   string `boil:"label_key" json:"label_key" toml:"label_key" yaml:"label_key"`
+	LabelValue string `boil:"label_value" json:"label_value" toml:"label_value" yaml:"label_value"`
+
+	R *serviceLabelR `boil:"-" json:"-" toml:"-" yaml:"-"`
+	L serviceLabelL  `boil:"-" json:"-" toml:"-" yaml:"-"`
+}
+
+var ServiceLabelColumns = struct {
+	ID         string
+	ServiceID  string
+	LabelKey   string
+	LabelValue string
+}{
+	ID:         "id",
+	ServiceID:  "service_id",
+	LabelKey:   "label_key",
+	LabelValue: "label_value",
+}
+
+// Generated where
+
+var ServiceLabelWhere = struct {
+	ID         whereHelperint64
+	ServiceID  whereHelperint64
+	LabelKey   whereHelperstring
+	LabelValue whereHelperstring
+}{
+	ID:         whereHelperint64{field: "`service_label`.`id`"},
+	ServiceID:  whereHelperint64{field: "`service_label`.`service_id`"},
+	LabelKey:   whereHelperstring{field: "`service_label`.`label_key`"},
+	LabelValue: whereHelperstring{field: "`service_label`.`label_value`"},
+}
+
+// ServiceLabelRels is where relationship names are stored.
+var ServiceLabelRels = struct {
+	Service string
+}{
+	Service: "Service",
+}
+
+// serviceLabelR is where relationships are stored.
+type serviceLabelR struct {
+	Service *Service `boil:"Service" json:"Service" toml:"Service" yaml:"Service"`
+}
+
+// NewStruct creates a new relationship struct
+func (*serviceLabelR) NewStruct() *serviceLabelR {
+	return &serviceLabelR{}
+}
+
+// serviceLabelL is where Load methods for each relationship are stored.
+type serviceLabelL struct{}
+
+var (
+	serviceLabelAllColumns            = []string{"id", "service_id", "label_key", "label_value"}
+	serviceLabelColumnsWithoutDefault = []string{"service_id", "label_key", "label_value"}
+	serviceLabelColumnsWithDefault    = []string{"id"}
+	serviceLabelPrimaryKeyColumns     = []string{"id"}
+)
+
+type (
+	// ServiceLabelSlice is an alias for a slice of pointers to ServiceLabel.
+	// This should generally be used opposed to []ServiceLabel.
+	ServiceLabelSlice []*ServiceLabel
+	// ServiceLabelHook is the signature for custom ServiceLabel hook methods
+	ServiceLabelHook func(context.Context, boil.ContextExecutor, *ServiceLabel) error
+
+	serviceLabelQuery struct {
+		*queries.Query
+	}
+)
+
+// Cache for insert, update and upsert
+var (
+	serviceLabelType                 = reflect.TypeOf(&ServiceLabel{})
+	serviceLabelMapping              = queries.MakeStructMapping(serviceLabelType)
+	serviceLabelPrimaryKeyMapping, _ = queries.BindMapping(serviceLabelType, serviceLabelMapping, serviceLabelPrimaryKeyColumns)
+	serviceLabelInsertCacheMut       sync.RWMutex
+	serviceLabelInsertCache          = make(map[string]insertCache)
+	serviceLabelUpdateCacheMut       sync.RWMutex
+	serviceLabelUpdateCache          = make(map[string]updateCache)
+	serviceLabelUpsertCacheMut       sync.RWMutex
+	serviceLabelUpsertCache          = make(map[string]insertCache)
+)
+
+var (
+	// Force time package dependency for automated UpdatedAt/CreatedAt.
+	_ = time.Second
+	// Force qmhelper dependency for where clause generation (which doesn't
+	// always happen)
+	_ = qmhelper.Where
+)
+
+var serviceLabelBeforeInsertHooks []ServiceLabelHook
+var serviceLabelBeforeUpdateHooks []ServiceLabelHook
+var serviceLabelBeforeDeleteHooks []ServiceLabelHook
+var serviceLabelBeforeUpsertHooks []ServiceLabelHook
+
+var serviceLabelAfterInsertHooks []ServiceLabelHook
+var serviceLabelAfterSelectHooks []ServiceLabelHook
+var serviceLabelAfterUpdateHooks []ServiceLabelHook
+var serviceLabelAfterDeleteHooks []ServiceLabelHook
+var serviceLabelAfterUpsertHooks []ServiceLabelHook
+
+// doBeforeInsertHooks executes all "before insert" hooks.
+func (o *ServiceLabel) doBeforeInsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range serviceLabelBeforeInsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeUpdateHooks executes all "before Update" hooks.
+func (o *ServiceLabel) doBeforeUpdateHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range serviceLabelBeforeUpdateHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeDeleteHooks executes all "before Delete" hooks.
+func (o *ServiceLabel) doBeforeDeleteHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range serviceLabelBeforeDeleteHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeUpsertHooks executes all "before Upsert" hooks.
+func (o *ServiceLabel) doBeforeUpsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range serviceLabelBeforeUpsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterInsertHooks executes all "after Insert" hooks.
+func (o *ServiceLabel) doAfterInsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range serviceLabelAfterInsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterSelectHooks executes all "after Select" hooks.
+func (o *ServiceLabel) doAfterSelectHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range serviceLabelAfterSelectHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterUpdateHooks executes all "after Update" hooks.
+func (o *ServiceLabel) doAfterUpdateHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range serviceLabelAfterUpdateHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterDeleteHooks executes all "after Delete" hooks.
+func (o *ServiceLabel) doAfterDeleteHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range serviceLabelAfterDeleteHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterUpsertHooks executes all "after Upsert" hooks.
+func (o *ServiceLabel) doAfterUpsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range serviceLabelAfterUpsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// AddServiceLabelHook registers your hook function for all future operations.
+func AddServiceLabelHook(hookPoint boil.HookPoint, serviceLabelHook ServiceLabelHook) {
+	switch hookPoint {
+	case boil.BeforeInsertHook:
+		serviceLabelBeforeInsertHooks = append(serviceLabelBeforeInsertHooks, serviceLabelHook)
+	case boil.BeforeUpdateHook:
+		serviceLabelBeforeUpdateHooks = append(serviceLabelBeforeUpdateHooks, serviceLabelHook)
+	case boil.BeforeDeleteHook:
+		serviceLabelBeforeDeleteHooks = append(serviceLabelBeforeDeleteHooks, serviceLabelHook)
+	case boil.BeforeUpsertHook:
+		serviceLabelBeforeUpsertHooks = append(serviceLabelBeforeUpsertHooks, serviceLabelHook)
+	case boil.AfterInsertHook:
+		serviceLabelAfterInsertHooks = append(serviceLabelAfterInsertHooks, serviceLabelHook)
+	case boil.AfterSelectHook:
+		serviceLabelAfterSelectHooks = append(serviceLabelAfterSelectHooks, serviceLabelHook)
+	case boil.AfterUpdateHook:
+		serviceLabelAfterUpdateHooks = append(serviceLabelAfterUpdateHooks, serviceLabelHook)
+	case boil.AfterDeleteHook:
+		serviceLabelAfterDeleteHooks = append(serviceLabelAfterDeleteHooks, serviceLabelHook)
+	case boil.AfterUpsertHook:
+		serviceLabelAfterUpsertHooks = append(serviceLabelAfterUpsertHooks, serviceLabelHook)
+	}
+}
+
+// One returns a single serviceLabel record from the query.
+func (q serviceLabelQuery) One(ctx context.Context, exec boil.ContextExecutor) (*ServiceLabel, error) {
+	o := &ServiceLabel{}
+
+	queries.SetLimit(q.Query, 1)
+
+	err := q.Bind(ctx, exec, o)
+	if err != nil {
+		if errors.Cause(err) == sql.ErrNoRows {
+			return nil, sql.ErrNoRows
+		}
+		return nil, errors.Wrap(err, "models: failed to execute a one query for service_label")
+	}
+
+	if err := o.doAfterSelectHooks(ctx, exec); err != nil {
+		return o, err
+	}
+
+	return o, nil
+}
+
+// All returns all ServiceLabel records from the query.
+func (q serviceLabelQuery) All(ctx context.Context, exec boil.ContextExecutor) (ServiceLabelSlice, error) {
+	var o []*ServiceLabel
+
+	err := q.Bind(ctx, exec, &o)
+	if err != nil {
+		return nil, errors.Wrap(err, "models: failed to assign all query results to ServiceLabel slice")
+	}
+
+	if len(serviceLabelAfterSelectHooks) != 0 {
+		for _, obj := range o {
+			if err := obj.doAfterSelectHooks(ctx, exec); err != nil {
+				return o, err
+			}
+		}
+	}
+
+	return o, nil
+}
+
+// Count returns the count of all ServiceLabel records in the query.
+func (q serviceLabelQuery) Count(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	var count int64
+
+	queries.SetSelect(q.Query, nil)
+	queries.SetCount(q.Query)
+
+	err := q.Query.QueryRowContext(ctx, exec).Scan(&count)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to count service_label rows")
+	}
+
+	return count, nil
+}
+
+// Exists checks if the row exists in the table.
+func (q serviceLabelQuery) Exists(ctx context.Context, exec boil.ContextExecutor) (bool, error) {
+	var count int64
+
+	queries.SetSelect(q.Query, nil)
+	queries.SetCount(q.Query)
+	queries.SetLimit(q.Query, 1)
+
+	err := q.Query.QueryRowContext(ctx, exec).Scan(&count)
+	if err != nil {
+		return false, errors.Wrap(err, "models: failed to check if service_label exists")
+	}
+
+	return count > 0, nil
+}
+
+// Service pointed to by the foreign key.
+func (o *ServiceLabel) Service(mods ...qm.QueryMod) serviceQuery {
+	queryMods := []qm.QueryMod{
+		qm.Where("`id` = ?", o.ServiceID),
+	}
+
+	queryMods = append(queryMods, mods...)
+
+	query := Services(queryMods...)
+	queries.SetFrom(query.Query, "`service`")
+
+	return query
+}
+
+// LoadService allows an eager lookup of values, cached into the
+// loaded structs of the objects. This is for an N-1 relationship.
+func (serviceLabelL) LoadService(ctx context.Context, e boil.ContextExecutor, singular bool, maybeServiceLabel interface{}, mods queries.Applicator) error {
+	var slice []*ServiceLabel
+	var object *ServiceLabel
+
+	if singular {
+		object = maybeServiceLabel.(*ServiceLabel)
+	} else {
+		slice = *maybeServiceLabel.(*[]*ServiceLabel)
+	}
+
+	args := make([]interface{}, 0, 1)
+	if singular {
+		if object.R == nil {
+			object.R = &serviceLabelR{}
+		}
+		args = append(args, object.ServiceID)
+
+	} else {
+	Outer:
+		for _, obj := range slice {
+			if obj.R == nil {
+				obj.R = &serviceLabelR{}
+			}
+
+			for _, a := range args {
+				if a == obj.ServiceID {
+					continue Outer
+				}
+			}
+
+			args = append(args, obj.ServiceID)
+
+		}
+	}
+
+	if len(args) == 0 {
+		return nil
+	}
+
+	query := NewQuery(
+		qm.From(`service`),
+		qm.WhereIn(`service.id in ?`, args...),
+	)
+	if mods != nil {
+		mods.Apply(query)
+	}
+
+	results, err := query.QueryContext(ctx, e)
+	if err != nil {
+		return errors.Wrap(err, "failed to eager load Service")
+	}
+
+	var resultSlice []*Service
+	if err = queries.Bind(results, &resultSlice); err != nil {
+		return errors.Wrap(err, "failed to bind eager loaded slice Service")
+	}
+
+	if err = results.Close(); err != nil {
+		return errors.Wrap(err, "failed to close results of eager load for service")
+	}
+	if err = results.Err(); err != nil {
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for service")
+	}
+
+	if len(serviceLabelAfterSelectHooks) != 0 {
+		for _, obj := range resultSlice {
+			if err := obj.doAfterSelectHooks(ctx, e); err != nil {
+				return err
+			}
+		}
+	}
+
+	if len(resultSlice) == 0 {
+		return nil
+	}
+
+	if singular {
+		foreign := resultSlice[0]
+		object.R.Service = foreign
+		if foreign.R == nil {
+			foreign.R = &serviceR{}
+		}
+		foreign.R.ServiceLabels = append(foreign.R.ServiceLabels, object)
+		return nil
+	}
+
+	for _, local := range slice {
+		for _, foreign := range resultSlice {
+			if local.ServiceID == foreign.ID {
+				local.R.Service = foreign
+				if foreign.R == nil {
+					foreign.R = &serviceR{}
+				}
+				foreign.R.ServiceLabels = append(foreign.R.ServiceLabels, local)
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
+// SetService of the serviceLabel to the related item.
+// Sets o.R.Service to related.
+// Adds o to related.R.ServiceLabels.
+func (o *ServiceLabel) SetService(ctx context.Context, exec boil.ContextExecutor, insert bool, related *Service) error {
+	var err error
+	if insert {
+		if err = related.Insert(ctx, exec, boil.Infer()); err != nil {
+			return errors.Wrap(err, "failed to insert into foreign table")
+		}
+	}
+
+	updateQuery := fmt.Sprintf(
+		"UPDATE `service_label` SET %s WHERE %s",
+		strmangle.SetParamNames("`", "`", 0, []string{"service_id"}),
+		strmangle.WhereClause("`", "`", 0, serviceLabelPrimaryKeyColumns),
+	)
+	values := []interface{}{related.ID, o.ID}
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, updateQuery)
+		fmt.Fprintln(writer, values)
+	}
+	if _, err = exec.ExecContext(ctx, updateQuery, values...); err != nil {
+		return errors.Wrap(err, "failed to update local table")
+	}
+
+	o.ServiceID = related.ID
+	if o.R == nil {
+		o.R = &serviceLabelR{
+			Service: related,
+		}
+	} else {
+		o.R.Service = related
+	}
+
+	if related.R == nil {
+		related.R = &serviceR{
+			ServiceLabels: ServiceLabelSlice{o},
+		}
+	} else {
+		related.R.ServiceLabels = append(related.R.ServiceLabels, o)
+	}
+
+	return nil
+}
+
+// ServiceLabels retrieves all the records using an executor.
+func ServiceLabels(mods ...qm.QueryMod) serviceLabelQuery {
+	mods = append(mods, qm.From("`service_label`"))
+	return serviceLabelQuery{NewQuery(mods...)}
+}
+
+// FindServiceLabel retrieves a single record by ID with an executor.
+// If selectCols is empty Find will return all columns.
+func FindServiceLabel(ctx context.Context, exec boil.ContextExecutor, iD int64, selectCols ...string) (*ServiceLabel, error) {
+	serviceLabelObj := &ServiceLabel{}
+
+	sel := "*"
+	if len(selectCols) > 0 {
+		sel = strings.Join(strmangle.IdentQuoteSlice(dialect.LQ, dialect.RQ, selectCols), ",")
+	}
+	query := fmt.Sprintf(
+		"select %s from `service_label` where `id`=?", sel,
+	)
+
+	q := queries.Raw(query, iD)
+
+	err := q.Bind(ctx, exec, serviceLabelObj)
+	if err != nil {
+		if errors.Cause(err) == sql.ErrNoRows {
+			return nil, sql.ErrNoRows
+		}
+		return nil, errors.Wrap(err, "models: unable to select from service_label")
+	}
+
+	return serviceLabelObj, nil
+}
+
+// Insert a single record using an executor.
+// See boil.Columns.InsertColumnSet documentation to understand column list inference for inserts.
+func (o *ServiceLabel) Insert(ctx context.Context, exec boil.ContextExecutor, columns boil.Columns) error {
+	if o == nil {
+		return errors.New("models: no service_label provided for insertion")
+	}
+
+	var err error
+
+	if err := o.doBeforeInsertHooks(ctx, exec); err != nil {
+		return err
+	}
+
+	nzDefaults := queries.NonZeroDefaultSet(serviceLabelColumnsWithDefault, o)
+
+	key := makeCacheKey(columns, nzDefaults)
+	serviceLabelInsertCacheMut.RLock()
+	cache, cached := serviceLabelInsertCache[key]
+	serviceLabelInsertCacheMut.RUnlock()
+
+	if !cached {
+		wl, returnColumns := columns.InsertColumnSet(
+			serviceLabelAllColumns,
+			serviceLabelColumnsWithDefault,
+			serviceLabelColumnsWithoutDefault,
+			nzDefaults,
+		)
+
+		cache.valueMapping, err = queries.BindMapping(serviceLabelType, serviceLabelMapping, wl)
+		if err != nil {
+			return err
+		}
+		cache.retMapping, err = queries.BindMapping(serviceLabelType, serviceLabelMapping, returnColumns)
+		if err != nil {
+			return err
+		}
+		if len(wl) != 0 {
+			cache.query = fmt.Sprintf("INSERT INTO `service_label` (`%s`) %%sVALUES (%s)%%s", strings.Join(wl, "`,`"), strmangle.Placeholders(dialect.UseIndexPlaceholders, len(wl), 1, 1))
+		} else {
+			cache.query = "INSERT INTO `service_label` () VALUES ()%s%s"
+		}
+
+		var queryOutput, queryReturning string
+
+		if len(cache.retMapping) != 0 {
+			cache.retQuery = fmt.Sprintf("SELECT `%s` FROM `service_label` WHERE %s", strings.Join(returnColumns, "`,`"), strmangle.WhereClause("`", "`", 0, serviceLabelPrimaryKeyColumns))
+		}
+
+		cache.query = fmt.Sprintf(cache.query, queryOutput, queryReturning)
+	}
+
+	value := reflect.Indirect(reflect.ValueOf(o))
+	vals := queries.ValuesFromMapping(value, cache.valueMapping)
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.query)
+		fmt.Fprintln(writer, vals)
+	}
+	result, err := exec.ExecContext(ctx, cache.query, vals...)
+
+	if err != nil {
+		return errors.Wrap(err, "models: unable to insert into service_label")
+	}
+
+	var lastID int64
+	var identifierCols []interface{}
+
+	if len(cache.retMapping) == 0 {
+		goto CacheNoHooks
+	}
+
+	lastID, err = result.LastInsertId()
+	if err != nil {
+		return ErrSyncFail
+	}
+
+	o.ID = int64(lastID)
+	if lastID != 0 && len(cache.retMapping) == 1 && cache.retMapping[0] == serviceLabelMapping["id"] {
+		goto CacheNoHooks
+	}
+
+	identifierCols = []interface{}{
+		o.ID,
+	}
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.retQuery)
+		fmt.Fprintln(writer, identifierCols...)
+	}
+	err = exec.QueryRowContext(ctx, cache.retQuery, identifierCols...).Scan(queries.PtrsFromMapping(value, cache.retMapping)...)
+	if err != nil {
+		return errors.Wrap(err, "models: unable to populate default values for service_label")
+	}
+
+CacheNoHooks:
+	if !cached {
+		serviceLabelInsertCacheMut.Lock()
+		serviceLabelInsertCache[key] = cache
+		serviceLabelInsertCacheMut.Unlock()
+	}
+
+	return o.doAfterInsertHooks(ctx, exec)
+}
+
+// Update uses an executor to update the ServiceLabel.
+// See boil.Columns.UpdateColumnSet documentation to understand column list inference for updates.
+// Update does not automatically update the record in case of default values. Use .Reload() to refresh the records.
+func (o *ServiceLabel) Update(ctx context.Context, exec boil.ContextExecutor, columns boil.Columns) (int64, error) {
+	var err error
+	if err = o.doBeforeUpdateHooks(ctx, exec); err != nil {
+		return 0, err
+	}
+	key := makeCacheKey(columns, nil)
+	serviceLabelUpdateCacheMut.RLock()
+	cache, cached := serviceLabelUpdateCache[key]
+	serviceLabelUpdateCacheMut.RUnlock()
+
+	if !cached {
+		wl := columns.UpdateColumnSet(
+			serviceLabelAllColumns,
+			serviceLabelPrimaryKeyColumns,
+		)
+
+		if !columns.IsWhitelist() {
+			wl = strmangle.SetComplement(wl, []string{"created_at"})
+		}
+		if len(wl) == 0 {
+			return 0, errors.New("models: unable to update service_label, could not build whitelist")
+		}
+
+		cache.query = fmt.Sprintf("UPDATE `service_label` SET %s WHERE %s",
+			strmangle.SetParamNames("`", "`", 0, wl),
+			strmangle.WhereClause("`", "`", 0, serviceLabelPrimaryKeyColumns),
+		)
+		cache.valueMapping, err = queries.BindMapping(serviceLabelType, serviceLabelMapping, append(wl, serviceLabelPrimaryKeyColumns...))
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	values := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(o)), cache.valueMapping)
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.query)
+		fmt.Fprintln(writer, values)
+	}
+	var result sql.Result
+	result, err = exec.ExecContext(ctx, cache.query, values...)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to update service_label row")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to get rows affected by update for service_label")
+	}
+
+	if !cached {
+		serviceLabelUpdateCacheMut.Lock()
+		serviceLabelUpdateCache[key] = cache
+		serviceLabelUpdateCacheMut.Unlock()
+	}
+
+	return rowsAff, o.doAfterUpdateHooks(ctx, exec)
+}
+
+// UpdateAll updates all rows with the specified column values.
+func (q serviceLabelQuery) UpdateAll(ctx context.Context, exec boil.ContextExecutor, cols M) (int64, error) {
+	queries.SetUpdate(q.Query, cols)
+
+	result, err := q.Query.ExecContext(ctx, exec)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to update all for service_label")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to retrieve rows affected for service_label")
+	}
+
+	return rowsAff, nil
+}
+
+// UpdateAll updates all rows with the specified column values, using an executor.
+func (o ServiceLabelSlice) UpdateAll(ctx context.Context, exec boil.ContextExecutor, cols M) (int64, error) {
+	ln := int64(len(o))
+	if ln == 0 {
+		return 0, nil
+	}
+
+	if len(cols) == 0 {
+		return 0, errors.New("models: update all requires at least one column argument")
+	}
+
+	colNames := make([]string, len(cols))
+	args := make([]interface{}, len(cols))
+
+	i := 0
+	for name, value := range cols {
+		colNames[i] = name
+		args[i] = value
+		i++
+	}
+
+	// Append all of the primary key values for each column
+	for _, obj := range o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), serviceLabelPrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := fmt.Sprintf("UPDATE `service_label` SET %s WHERE %s",
+		strmangle.SetParamNames("`", "`", 0, colNames),
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), 0, serviceLabelPrimaryKeyColumns, len(o)))
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, args...)
+	}
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to update all in serviceLabel slice")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to retrieve rows affected all in update all serviceLabel")
+	}
+	return rowsAff, nil
+}
+
+var mySQLServiceLabelUniqueColumns = []string{
+	"id",
+}
+
+// Upsert attempts an insert using an executor, and does an update or ignore on conflict.
+// See boil.Columns documentation for how to properly use updateColumns and insertColumns.
+func (o *ServiceLabel) Upsert(ctx context.Context, exec boil.ContextExecutor, updateColumns, insertColumns boil.Columns) error {
+	if o == nil {
+		return errors.New("models: no service_label provided for upsert")
+	}
+
+	if err := o.doBeforeUpsertHooks(ctx, exec); err != nil {
+		return err
+	}
+
+	nzDefaults := queries.NonZeroDefaultSet(serviceLabelColumnsWithDefault, o)
+	nzUniques := queries.NonZeroDefaultSet(mySQLServiceLabelUniqueColumns, o)
+
+	if len(nzUniques) == 0 {
+		return errors.New("cannot upsert with a table that cannot conflict on a unique column")
+	}
+
+	// Build cache key in-line uglily - mysql vs psql problems
+	buf := strmangle.GetBuffer()
+	buf.WriteString(strconv.Itoa(updateColumns.Kind))
+	for _, c := range updateColumns.Cols {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	buf.WriteString(strconv.Itoa(insertColumns.Kind))
+	for _, c := range insertColumns.Cols {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	for _, c := range nzDefaults {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	for _, c := range nzUniques {
+		buf.WriteString(c)
+	}
+	key := buf.String()
+	strmangle.PutBuffer(buf)
+
+	serviceLabelUpsertCacheMut.RLock()
+	cache, cached := serviceLabelUpsertCache[key]
+	serviceLabelUpsertCacheMut.RUnlock()
+
+	var err error
+
+	if !cached {
+		insert, ret := insertColumns.InsertColumnSet(
+			serviceLabelAllColumns,
+			serviceLabelColumnsWithDefault,
+			serviceLabelColumnsWithoutDefault,
+			nzDefaults,
+		)
+		update := updateColumns.UpdateColumnSet(
+			serviceLabelAllColumns,
+			serviceLabelPrimaryKeyColumns,
+		)
+
+		if len(update) == 0 {
+			return errors.New("models: unable to upsert service_label, could not build update column list")
+		}
+
+		ret = strmangle.SetComplement(ret, nzUniques)
+		cache.query = buildUpsertQueryMySQL(dialect, "service_label", update, insert)
+		cache.retQuery = fmt.Sprintf(
+			"SELECT %s FROM `service_label` WHERE %s",
+			strings.Join(strmangle.IdentQuoteSlice(dialect.LQ, dialect.RQ, ret), ","),
+			strmangle.WhereClause("`", "`", 0, nzUniques),
+		)
+
+		cache.valueMapping, err = queries.BindMapping(serviceLabelType, serviceLabelMapping, insert)
+		if err != nil {
+			return err
+		}
+		if len(ret) != 0 {
+			cache.retMapping, err = queries.BindMapping(serviceLabelType, serviceLabelMapping, ret)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	value := reflect.Indirect(reflect.ValueOf(o))
+	vals := queries.ValuesFromMapping(value, cache.valueMapping)
+	var returns []interface{}
+	if len(cache.retMapping) != 0 {
+		returns = queries.PtrsFromMapping(value, cache.retMapping)
+	}
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.query)
+		fmt.Fprintln(writer, vals)
+	}
+	result, err := exec.ExecContext(ctx, cache.query, vals...)
+
+	if err != nil {
+		return errors.Wrap(err, "models: unable to upsert for service_label")
+	}
+
+	var lastID int64
+	var uniqueMap []uint64
+	var nzUniqueCols []interface{}
+
+	if len(cache.retMapping) == 0 {
+		goto CacheNoHooks
+	}
+
+	lastID, err = result.LastInsertId()
+	if err != nil {
+		return ErrSyncFail
+	}
+
+	o.ID = int64(lastID)
+	if lastID != 0 && len(cache.retMapping) == 1 && cache.retMapping[0] == serviceLabelMapping["id"] {
+		goto CacheNoHooks
+	}
+
+	uniqueMap, err = queries.BindMapping(serviceLabelType, serviceLabelMapping, nzUniques)
+	if err != nil {
+		return errors.Wrap(err, "models: unable to retrieve unique values for service_label")
+	}
+	nzUniqueCols = queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(o)), uniqueMap)
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.retQuery)
+		fmt.Fprintln(writer, nzUniqueCols...)
+	}
+	err = exec.QueryRowContext(ctx, cache.retQuery, nzUniqueCols...).Scan(returns...)
+	if err != nil {
+		return errors.Wrap(err, "models: unable to populate default values for service_label")
+	}
+
+CacheNoHooks:
+	if !cached {
+		serviceLabelUpsertCacheMut.Lock()
+		serviceLabelUpsertCache[key] = cache
+		serviceLabelUpsertCacheMut.Unlock()
+	}
+
+	return o.doAfterUpsertHooks(ctx, exec)
+}
+
+// Delete deletes a single ServiceLabel record with an executor.
+// Delete will match against the primary key column to find the record to delete.
+func (o *ServiceLabel) Delete(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if o == nil {
+		return 0, errors.New("models: no ServiceLabel provided for delete")
+	}
+
+	if err := o.doBeforeDeleteHooks(ctx, exec); err != nil {
+		return 0, err
+	}
+
+	args := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(o)), serviceLabelPrimaryKeyMapping)
+	sql := "DELETE FROM `service_label` WHERE `id`=?"
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, args...)
+	}
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to delete from service_label")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to get rows affected by delete for service_label")
+	}
+
+	if err := o.doAfterDeleteHooks(ctx, exec); err != nil {
+		return 0, err
+	}
+
+	return rowsAff, nil
+}
+
+// DeleteAll deletes all matching rows.
+func (q serviceLabelQuery) DeleteAll(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if q.Query == nil {
+		return 0, errors.New("models: no serviceLabelQuery provided for delete all")
+	}
+
+	queries.SetDelete(q.Query)
+
+	result, err := q.Query.ExecContext(ctx, exec)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to delete all from service_label")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to get rows affected by deleteall for service_label")
+	}
+
+	return rowsAff, nil
+}
+
+// DeleteAll deletes all rows in the slice, using an executor.
+func (o ServiceLabelSlice) DeleteAll(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if len(o) == 0 {
+		return 0, nil
+	}
+
+	if len(serviceLabelBeforeDeleteHooks) != 0 {
+		for _, obj := range o {
+			if err := obj.doBeforeDeleteHooks(ctx, exec); err != nil {
+				return 0, err
+			}
+		}
+	}
+
+	var args []interface{}
+	for _, obj := range o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), serviceLabelPrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := "DELETE FROM `service_label` WHERE " +
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), 0, serviceLabelPrimaryKeyColumns, len(o))
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, args)
+	}
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to delete all from serviceLabel slice")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to get rows affected by deleteall for service_label")
+	}
+
+	if len(serviceLabelAfterDeleteHooks) != 0 {
+		for _, obj := range o {
+			if err := obj.doAfterDeleteHooks(ctx, exec); err != nil {
+				return 0, err
+			}
+		}
+	}
+
+	return rowsAff, nil
+}
+
+// Reload refetches the object from the database
+// using the primary keys with an executor.
+func (o *ServiceLabel) Reload(ctx context.Context, exec boil.ContextExecutor) error {
+	ret, err := FindServiceLabel(ctx, exec, o.ID)
+	if err != nil {
+		return err
+	}
+
+	*o = *ret
+	return nil
+}
+
+// ReloadAll refetches every row with matching primary key column values
+// and overwrites the original object slice with the newly updated slice.
+func (o *ServiceLabelSlice) ReloadAll(ctx context.Context, exec boil.ContextExecutor) error {
+	if o == nil || len(*o) == 0 {
+		return nil
+	}
+
+	slice := ServiceLabelSlice{}
+	var args []interface{}
+	for _, obj := range *o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), serviceLabelPrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := "SELECT `service_label`.* FROM `service_label` WHERE " +
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), 0, serviceLabelPrimaryKeyColumns, len(*o))
+
+	q := queries.Raw(sql, args...)
+
+	err := q.Bind(ctx, exec, &slice)
+	if err != nil {
+		return errors.Wrap(err, "models: unable to reload all in ServiceLabelSlice")
+	}
+
+	*o = slice
+
+	return nil
+}
+
+// ServiceLabelExists checks if the ServiceLabel row exists.
+func ServiceLabelExists(ctx context.Context, exec boil.ContextExecutor, iD int64) (bool, error) {
+	var exists bool
+	sql := "select exists(select 1 from `service_label` where `id`=? limit 1)"
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, iD)
+	}
+	row := exec.QueryRowContext(ctx, sql, iD)
+
+	err := row.Scan(&exists)
+	if err != nil {
+		return false, errors.Wrap(err, "models: unable to check if service_label exists")
+	}
+
+	return exists, nil
+}
@@ -0,0 +1,959 @@
+// Code generated by SQLBoiler 4.2.0 (https://github.com/volatiletech/sqlboiler). DO NOT EDIT.
+// This file is meant to be re-generated in place and/or deleted at any time.
+
+package models
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/friendsofgo/errors"
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+	"github.com/volatiletech/sqlboiler/v4/queries/qmhelper"
+	"github.com/volatiletech/strmangle"
+)
+
+// TopicHistory is an object representing the database table.
+type TopicHistory struct {
+	ID          int64       `boil:"id" json:"id" toml:"id" yaml:"id"`
+	Environment string      `boil:"environment" json:"environment" toml:"environment" yaml:"environment"`
+	TopicCode   string      `boil:"topic_code" json:"topic_code" toml:"topic_code" yaml:"topic_code"`
+	Version     int         `boil:"version" json:"version" toml:"version" yaml:"version"`
+	ChangeType  string      `boil:"change_type" json:"change_type" toml:"change_type" yaml:"change_type"`
+	Actor       string      `boil:"actor" json:"actor" toml:"actor" yaml:"actor"`
+	ChangedAt   time.Time   `boil:"changed_at" json:"changed_at" toml:"changed_at" yaml:"changed_at"`
+	Snapshot    null.String `boil:"snapshot" json:"snapshot,omitempty" toml:"snapshot" yaml:"snapshot,omitempty"`
+
+	R *topicHistoryR `boil:"-" json:"-" toml:"-" yaml:"-"`
+	L topicHistoryL  `boil:"-" json:"-" toml:"-" yaml:"-"`
+}
+
+var TopicHistoryColumns = struct {
+	ID          string
+	Environment string
+	TopicCode   string
+	Version     string
+	ChangeType  string
+	Actor       string
+	ChangedAt   string
+	Snapshot    string
+}{
+	ID:          "id",
+	Environment: "environment",
+	TopicCode:   "topic_code",
+	Version:     "version",
+	ChangeType:  "change_type",
+	Actor:       "actor",
+	ChangedAt:   "changed_at",
+	Snapshot:    "snapshot",
+}
+
+// Generated where
+
+var TopicHistoryWhere = struct {
+	ID          whereHelperint64
+	Environment whereHelperstring
+	TopicCode   whereHelperstring
+	Version     whereHelperint
+	ChangeType  whereHelperstring
+	Actor       whereHelperstring
+	ChangedAt   whereHelpertime_Time
+	Snapshot    whereHelpernull_String
+}{
+	ID:          whereHelperint64{field: "`topic_history`.`id`"},
+	Environment: whereHelperstring{field: "`topic_history`.`environment`"},
+	TopicCode:   whereHelperstring{field: "`topic_history`.`topic_code`"},
+	Version:     whereHelperint{field: "`topic_history`.`version`"},
+	ChangeType:  whereHelperstring{field: "`topic_history`.`change_type`"},
+	Actor:       whereHelperstring{field: "`topic_history`.`actor`"},
+	ChangedAt:   whereHelpertime_Time{field: "`topic_history`.`changed_at`"},
+	Snapshot:    whereHelpernull_String{field: "`topic_history`.`snapshot`"},
+}
+
+// TopicHistoryRels is where relationship names are stored.
+var TopicHistoryRels = struct {
+}{}
+
+// topicHistoryR is where relationships are stored.
+type topicHistoryR struct {
+}
+
+// NewStruct creates a new relationship struct
+func (*topicHistoryR) NewStruct() *topicHistoryR {
+	return &topicHistoryR{}
+}
+
+// topicHistoryL is where Load methods for each relationship are stored.
+type topicHistoryL struct{}
+
+var (
+	topicHistoryAllColumns            = []string{"id", "environment", "topic_code", "version", "change_type", "actor", "changed_at", "snapshot"}
+	topicHistoryColumnsWithoutDefault = []string{"topic_code", "version", "change_type", "actor", "changed_at", "snapshot"}
+	topicHistoryColumnsWithDefault    = []string{"id", "environment"}
+	topicHistoryPrimaryKeyColumns     = []string{"id"}
+)
+
+type (
+	// TopicHistorySlice is an alias for a slice of pointers to TopicHistory.
+	// This should generally be used opposed to []TopicHistory.
+	TopicHistorySlice []*TopicHistory
+	// TopicHistoryHook is the signature for custom TopicHistory hook methods
+	TopicHistoryHook func(context.Context, boil.ContextExecutor, *TopicHistory) error
+
+	topicHistoryQuery struct {
+		*queries.Query
+	}
+)
+
+// Cache for insert, update and upsert
+var (
+	topicHistoryType                 = reflect.TypeOf(&TopicHistory{})
+	topicHistoryMapping              = queries.MakeStructMapping(topicHistoryType)
+	topicHistoryPrimaryKeyMapping, _ = queries.BindMapping(topicHistoryType, topicHistoryMapping, topicHistoryPrimaryKeyColumns)
+	topicHistoryInsertCacheMut       sync.RWMutex
+	topicHistoryInsertCache          = make(map[string]insertCache)
+	topicHistoryUpdateCacheMut       sync.RWMutex
+	topicHistoryUpdateCache          = make(map[string]updateCache)
+	topicHistoryUpsertCacheMut       sync.RWMutex
+	topicHistoryUpsertCache          = make(map[string]insertCache)
+)
+
+var (
+	// Force time package dependency for automated UpdatedAt/CreatedAt.
+	_ = time.Second
+	// Force qmhelper dependency for where clause generation (which doesn't
+	// always happen)
+	_ = qmhelper.Where
+)
+
+var topicHistoryBeforeInsertHooks []TopicHistoryHook
+var topicHistoryBeforeUpdateHooks []TopicHistoryHook
+var topicHistoryBeforeDeleteHooks []TopicHistoryHook
+var topicHistoryBeforeUpsertHooks []TopicHistoryHook
+
+var topicHistoryAfterInsertHooks []TopicHistoryHook
+var topicHistoryAfterSelectHooks []TopicHistoryHook
+var topicHistoryAfterUpdateHooks []TopicHistoryHook
+var topicHistoryAfterDeleteHooks []TopicHistoryHook
+var topicHistoryAfterUpsertHooks []TopicHistoryHook
+
+// doBeforeInsertHooks executes all "before insert" hooks.
+func (o *TopicHistory) doBeforeInsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range topicHistoryBeforeInsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeUpdateHooks executes all "before Update" hooks.
+func (o *TopicHistory) doBeforeUpdateHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range topicHistoryBeforeUpdateHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeDeleteHooks executes all "before Delete" hooks.
+func (o *TopicHistory) doBeforeDeleteHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range topicHistoryBeforeDeleteHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeUpsertHooks executes all "before Upsert" hooks.
+func (o *TopicHistory) doBeforeUpsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range topicHistoryBeforeUpsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterInsertHooks executes all "after Insert" hooks.
+func (o *TopicHistory) doAfterInsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range topicHistoryAfterInsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterSelectHooks executes all "after Select" hooks.
+func (o *TopicHistory) doAfterSelectHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range topicHistoryAfterSelectHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterUpdateHooks executes all "after Update" hooks.
+func (o *TopicHistory) doAfterUpdateHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range topicHistoryAfterUpdateHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterDeleteHooks executes all "after Delete" hooks.
+func (o *TopicHistory) doAfterDeleteHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range topicHistoryAfterDeleteHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterUpsertHooks executes all "after Upsert" hooks.
+func (o *TopicHistory) doAfterUpsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range topicHistoryAfterUpsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// AddTopicHistoryHook registers your hook function for all future operations.
+func AddTopicHistoryHook(hookPoint boil.HookPoint, topicHistoryHook TopicHistoryHook) {
+	switch hookPoint {
+	case boil.BeforeInsertHook:
+		topicHistoryBeforeInsertHooks = append(topicHistoryBeforeInsertHooks, topicHistoryHook)
+	case boil.BeforeUpdateHook:
+		topicHistoryBeforeUpdateHooks = append(topicHistoryBeforeUpdateHooks, topicHistoryHook)
+	case boil.BeforeDeleteHook:
+		topicHistoryBeforeDeleteHooks = append(topicHistoryBeforeDeleteHooks, topicHistoryHook)
+	case boil.BeforeUpsertHook:
+		topicHistoryBeforeUpsertHooks = append(topicHistoryBeforeUpsertHooks, topicHistoryHook)
+	case boil.AfterInsertHook:
+		topicHistoryAfterInsertHooks = append(topicHistoryAfterInsertHooks, topicHistoryHook)
+	case boil.AfterSelectHook:
+		topicHistoryAfterSelectHooks = append(topicHistoryAfterSelectHooks, topicHistoryHook)
+	case boil.AfterUpdateHook:
+		topicHistoryAfterUpdateHooks = append(topicHistoryAfterUpdateHooks, topicHistoryHook)
+	case boil.AfterDeleteHook:
+		topicHistoryAfterDeleteHooks = append(topicHistoryAfterDeleteHooks, topicHistoryHook)
+	case boil.AfterUpsertHook:
+		topicHistoryAfterUpsertHooks = append(topicHistoryAfterUpsertHooks, topicHistoryHook)
+	}
+}
+
+// One returns a single topicHistory record from the query.
+func (q topicHistoryQuery) One(ctx context.Context, exec boil.ContextExecutor) (*TopicHistory, error) {
+	o := &TopicHistory{}
+
+	queries.SetLimit(q.Query, 1)
+
+	err := q.Bind(ctx, exec, o)
+	if err != nil {
+		if errors.Cause(err) == sql.ErrNoRows {
+			return nil, sql.ErrNoRows
+		}
+		return nil, errors.Wrap(err, "models: failed to execute a one query for topic_history")
+	}
+
+	if err := o.doAfterSelectHooks(ctx, exec); err != nil {
+		return o, err
+	}
+
+	return o, nil
+}
+
+// All returns all TopicHistory records from the query.
+func (q topicHistoryQuery) All(ctx context.Context, exec boil.ContextExecutor) (TopicHistorySlice, error) {
+	var o []*TopicHistory
+
+	err := q.Bind(ctx, exec, &o)
+	if err != nil {
+		return nil, errors.Wrap(err, "models: failed to assign all query results to TopicHistory slice")
+	}
+
+	if len(topicHistoryAfterSelectHooks) != 0 {
+		for _, obj := range o {
+			if err := obj.doAfterSelectHooks(ctx, exec); err != nil {
+				return o, err
+			}
+		}
+	}
+
+	return o, nil
+}
+
+// Count returns the count of all TopicHistory records in the query.
+func (q topicHistoryQuery) Count(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	var count int64
+
+	queries.SetSelect(q.Query, nil)
+	queries.SetCount(q.Query)
+
+	err := q.Query.QueryRowContext(ctx, exec).Scan(&count)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to count topic_history rows")
+	}
+
+	return count, nil
+}
+
+// Exists checks if the row exists in the table.
+func (q topicHistoryQuery) Exists(ctx context.Context, exec boil.ContextExecutor) (bool, error) {
+	var count int64
+
+	queries.SetSelect(q.Query, nil)
+	queries.SetCount(q.Query)
+	queries.SetLimit(q.Query, 1)
+
+	err := q.Query.QueryRowContext(ctx, exec).Scan(&count)
+	if err != nil {
+		return false, errors.Wrap(err, "models: failed to check if topic_history exists")
+	}
+
+	return count > 0, nil
+}
+
+// TopicHistories retrieves all the records using an executor.
+func TopicHistories(mods ...qm.QueryMod) topicHistoryQuery {
+	mods = append(mods, qm.From("`topic_history`"))
+	return topicHistoryQuery{NewQuery(mods...)}
+}
+
+// FindTopicHistory retrieves a single record by ID with an executor.
+// If selectCols is empty Find will return all columns.
+func FindTopicHistory(ctx context.Context, exec boil.ContextExecutor, iD int64, selectCols ...string) (*TopicHistory, error) {
+	topicHistoryObj := &TopicHistory{}
+
+	sel := "*"
+	if len(selectCols) > 0 {
+		sel = strings.Join(strmangle.IdentQuoteSlice(dialect.LQ, dialect.RQ, selectCols), ",")
+	}
+	query := fmt.Sprintf(
+		"select %s from `topic_history` where `id`=?", sel,
+	)
+
+	q := queries.Raw(query, iD)
+
+	err := q.Bind(ctx, exec, topicHistoryObj)
+	if err != nil {
+		if errors.Cause(err) == sql.ErrNoRows {
+			return nil, sql.ErrNoRows
+		}
+		return nil, errors.Wrap(err, "models: unable to select from topic_history")
+	}
+
+	return topicHistoryObj, nil
+}
+
+// Insert a single record using an executor.
+// See boil.Columns.InsertColumnSet documentation to understand column list inference for inserts.
+func (o *TopicHistory) Insert(ctx context.Context, exec boil.ContextExecutor, columns boil.Columns) error {
+	if o == nil {
+		return errors.New("models: no topic_history provided for insertion")
+	}
+
+	var err error
+
+	if err := o.doBeforeInsertHooks(ctx, exec); err != nil {
+		return err
+	}
+
+	nzDefaults := queries.NonZeroDefaultSet(topicHistoryColumnsWithDefault, o)
+
+	key := makeCacheKey(columns, nzDefaults)
+	topicHistoryInsertCacheMut.RLock()
+	cache, cached := topicHistoryInsertCache[key]
+	topicHistoryInsertCacheMut.RUnlock()
+
+	if !cached {
+		wl, returnColumns := columns.InsertColumnSet(
+			topicHistoryAllColumns,
+			topicHistoryColumnsWithDefault,
+			topicHistoryColumnsWithoutDefault,
+			nzDefaults,
+		)
+
+		cache.valueMapping, err = queries.BindMapping(topicHistoryType, topicHistoryMapping, wl)
+		if err != nil {
+			return err
+		}
+		cache.retMapping, err = queries.BindMapping(topicHistoryType, topicHistoryMapping, returnColumns)
+		if err != nil {
+			return err
+		}
+		if len(wl) != 0 {
+			cache.query = fmt.Sprintf("INSERT INTO `topic_history` (`%s`) %%sVALUES (%s)%%s", strings.Join(wl, "`,`"), strmangle.Placeholders(dialect.UseIndexPlaceholders, len(wl), 1, 1))
+		} else {
+			cache.query = "INSERT INTO `topic_history` () VALUES ()%s%s"
+		}
+
+		var queryOutput, queryReturning string
+
+		if len(cache.retMapping) != 0 {
+			cache.retQuery = fmt.Sprintf("SELECT `%s` FROM `topic_history` WHERE %s", strings.Join(returnColumns, "`,`"), strmangle.WhereClause("`", "`", 0, topicHistoryPrimaryKeyColumns))
+		}
+
+		cache.query = fmt.Sprintf(cache.query, queryOutput, queryReturning)
+	}
+
+	value := reflect.Indirect(reflect.ValueOf(o))
+	vals := queries.ValuesFromMapping(value, cache.valueMapping)
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.query)
+		fmt.Fprintln(writer, vals)
+	}
+	result, err := exec.ExecContext(ctx, cache.query, vals...)
+
+	if err != nil {
+		return errors.Wrap(err, "models: unable to insert into topic_history")
+	}
+
+	var lastID int64
+	var identifierCols []interface{}
+
+	if len(cache.retMapping) == 0 {
+		goto CacheNoHooks
+	}
+
+	lastID, err = result.LastInsertId()
+	if err != nil {
+		return ErrSyncFail
+	}
+
+	o.ID = int64(lastID)
+	if lastID != 0 && len(cache.retMapping) == 1 && cache.retMapping[0] == topicHistoryMapping["id"] {
+		goto CacheNoHooks
+	}
+
+	identifierCols = []interface{}{
+		o.ID,
+	}
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.retQuery)
+		fmt.Fprintln(writer, identifierCols...)
+	}
+	err = exec.QueryRowContext(ctx, cache.retQuery, identifierCols...).Scan(queries.PtrsFromMapping(value, cache.retMapping)...)
+	if err != nil {
+		return errors.Wrap(err, "models: unable to populate default values for topic_history")
+	}
+
+CacheNoHooks:
+	if !cached {
+		topicHistoryInsertCacheMut.Lock()
+		topicHistoryInsertCache[key] = cache
+		topicHistoryInsertCacheMut.Unlock()
+	}
+
+	return o.doAfterInsertHooks(ctx, exec)
+}
+
+// Update uses an executor to update the TopicHistory.
+// See boil.Columns.UpdateColumnSet documentation to understand column list inference for updates.
+// Update does not automatically update the record in case of default values. Use .Reload() to refresh the records.
+func (o *TopicHistory) Update(ctx context.Context, exec boil.ContextExecutor, columns boil.Columns) (int64, error) {
+	var err error
+	if err = o.doBeforeUpdateHooks(ctx, exec); err != nil {
+		return 0, err
+	}
+	key := makeCacheKey(columns, nil)
+	topicHistoryUpdateCacheMut.RLock()
+	cache, cached := topicHistoryUpdateCache[key]
+	topicHistoryUpdateCacheMut.RUnlock()
+
+	if !cached {
+		wl := columns.UpdateColumnSet(
+			topicHistoryAllColumns,
+			topicHistoryPrimaryKeyColumns,
+		)
+
+		if !columns.IsWhitelist() {
+			wl = strmangle.SetComplement(wl, []string{"created_at"})
+		}
+		if len(wl) == 0 {
+			return 0, errors.New("models: unable to update topic_history, could not build whitelist")
+		}
+
+		cache.query = fmt.Sprintf("UPDATE `topic_history` SET %s WHERE %s",
+			strmangle.SetParamNames("`", "`", 0, wl),
+			strmangle.WhereClause("`", "`", 0, topicHistoryPrimaryKeyColumns),
+		)
+		cache.valueMapping, err = queries.BindMapping(topicHistoryType, topicHistoryMapping, append(wl, topicHistoryPrimaryKeyColumns...))
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	values := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(o)), cache.valueMapping)
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.query)
+		fmt.Fprintln(writer, values)
+	}
+	var result sql.Result
+	result, err = exec.ExecContext(ctx, cache.query, values...)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to update topic_history row")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to get rows affected by update for topic_history")
+	}
+
+	if !cached {
+		topicHistoryUpdateCacheMut.Lock()
+		topicHistoryUpdateCache[key] = cache
+		topicHistoryUpdateCacheMut.Unlock()
+	}
+
+	return rowsAff, o.doAfterUpdateHooks(ctx, exec)
+}
+
+// UpdateAll updates all rows with the specified column values.
+func (q topicHistoryQuery) UpdateAll(ctx context.Context, exec boil.ContextExecutor, cols M) (int64, error) {
+	queries.SetUpdate(q.Query, cols)
+
+	result, err := q.Query.ExecContext(ctx, exec)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to update all for topic_history")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to retrieve rows affected for topic_history")
+	}
+
+	return rowsAff, nil
+}
+
+// UpdateAll updates all rows with the specified column values, using an executor.
+func (o TopicHistorySlice) UpdateAll(ctx context.Context, exec boil.ContextExecutor, cols M) (int64, error) {
+	ln := int64(len(o))
+	if ln == 0 {
+		return 0, nil
+	}
+
+	if len(cols) == 0 {
+		return 0, errors.New("models: update all requires at least one column argument")
+	}
+
+	colNames := make([]string, len(cols))
+	args := make([]interface{}, len(cols))
+
+	i := 0
+	for name, value := range cols {
+		colNames[i] = name
+		args[i] = value
+		i++
+	}
+
+	// Append all of the primary key values for each column
+	for _, obj := range o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), topicHistoryPrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := fmt.Sprintf("UPDATE `topic_history` SET %s WHERE %s",
+		strmangle.SetParamNames("`", "`", 0, colNames),
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), 0, topicHistoryPrimaryKeyColumns, len(o)))
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, args...)
+	}
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to update all in topicHistory slice")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to retrieve rows affected all in update all topicHistory")
+	}
+	return rowsAff, nil
+}
+
+var mySQLTopicHistoryUniqueColumns = []string{
+	"id",
+}
+
+// Upsert attempts an insert using an executor, and does an update or ignore on conflict.
+// See boil.Columns documentation for how to properly use updateColumns and insertColumns.
+func (o *TopicHistory) Upsert(ctx context.Context, exec boil.ContextExecutor, updateColumns, insertColumns boil.Columns) error {
+	if o == nil {
+		return errors.New("models: no topic_history provided for upsert")
+	}
+
+	if err := o.doBeforeUpsertHooks(ctx, exec); err != nil {
+		return err
+	}
+
+	nzDefaults := queries.NonZeroDefaultSet(topicHistoryColumnsWithDefault, o)
+	nzUniques := queries.NonZeroDefaultSet(mySQLTopicHistoryUniqueColumns, o)
+
+	if len(nzUniques) == 0 {
+		return errors.New("cannot upsert with a table that cannot conflict on a unique column")
+	}
+
+	// Build cache key in-line uglily - mysql vs psql problems
+	buf := strmangle.GetBuffer()
+	buf.WriteString(strconv.Itoa(updateColumns.Kind))
+	for _, c := range updateColumns.Cols {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	buf.WriteString(strconv.Itoa(insertColumns.Kind))
+	for _, c := range insertColumns.Cols {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	for _, c := range nzDefaults {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	for _, c := range nzUniques {
+		buf.WriteString(c)
+	}
+	key := buf.String()
+	strmangle.PutBuffer(buf)
+
+	topicHistoryUpsertCacheMut.RLock()
+	cache, cached := topicHistoryUpsertCache[key]
+	topicHistoryUpsertCacheMut.RUnlock()
+
+	var err error
+
+	if !cached {
+		insert, ret := insertColumns.InsertColumnSet(
+			topicHistoryAllColumns,
+			topicHistoryColumnsWithDefault,
+			topicHistoryColumnsWithoutDefault,
+			nzDefaults,
+		)
+		update := updateColumns.UpdateColumnSet(
+			topicHistoryAllColumns,
+			topicHistoryPrimaryKeyColumns,
+		)
+
+		if len(update) == 0 {
+			return errors.New("models: unable to upsert topic_history, could not build update column list")
+		}
+
+		ret = strmangle.SetComplement(ret, nzUniques)
+		cache.query = buildUpsertQueryMySQL(dialect, "topic_history", update, insert)
+		cache.retQuery = fmt.Sprintf(
+			"SELECT %s FROM `topic_history` WHERE %s",
+			strings.Join(strmangle.IdentQuoteSlice(dialect.LQ, dialect.RQ, ret), ","),
+			strmangle.WhereClause("`", "`", 0, nzUniques),
+		)
+
+		cache.valueMapping, err = queries.BindMapping(topicHistoryType, topicHistoryMapping, insert)
+		if err != nil {
+			return err
+		}
+		if len(ret) != 0 {
+			cache.retMapping, err = queries.BindMapping(topicHistoryType, topicHistoryMapping, ret)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	value := reflect.Indirect(reflect.ValueOf(o))
+	vals := queries.ValuesFromMapping(value, cache.valueMapping)
+	var returns []interface{}
+	if len(cache.retMapping) != 0 {
+		returns = queries.PtrsFromMapping(value, cache.retMapping)
+	}
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.query)
+		fmt.Fprintln(writer, vals)
+	}
+	result, err := exec.ExecContext(ctx, cache.query, vals...)
+
+	if err != nil {
+		return errors.Wrap(err, "models: unable to upsert for topic_history")
+	}
+
+	var lastID int64
+	var uniqueMap []uint64
+	var nzUniqueCols []interface{}
+
+	if len(cache.retMapping) == 0 {
+		goto CacheNoHooks
+	}
+
+	lastID, err = result.LastInsertId()
+	if err != nil {
+		return ErrSyncFail
+	}
+
+	o.ID = int64(lastID)
+	if lastID != 0 && len(cache.retMapping) == 1 && cache.retMapping[0] == topicHistoryMapping["id"] {
+		goto CacheNoHooks
+	}
+
+	uniqueMap, err = queries.BindMapping(topicHistoryType, topicHistoryMapping, nzUniques)
+	if err != nil {
+		return errors.Wrap(err, "models: unable to retrieve unique values for topic_history")
+	}
+	nzUniqueCols = queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(o)), uniqueMap)
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.retQuery)
+		fmt.Fprintln(writer, nzUniqueCols...)
+	}
+	err = exec.QueryRowContext(ctx, cache.retQuery, nzUniqueCols...).Scan(returns...)
+	if err != nil {
+		return errors.Wrap(err, "models: unable to populate default values for topic_history")
+	}
+
+CacheNoHooks:
+	if !cached {
+		topicHistoryUpsertCacheMut.Lock()
+		topicHistoryUpsertCache[key] = cache
+		topicHistoryUpsertCacheMut.Unlock()
+	}
+
+	return o.doAfterUpsertHooks(ctx, exec)
+}
+
+// Delete deletes a single TopicHistory record with an executor.
+// Delete will match against the primary key column to find the record to delete.
+func (o *TopicHistory) Delete(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if o == nil {
+		return 0, errors.New("models: no TopicHistory provided for delete")
+	}
+
+	if err := o.doBeforeDeleteHooks(ctx, exec); err != nil {
+		return 0, err
+	}
+
+	args := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(o)), topicHistoryPrimaryKeyMapping)
+	sql := "DELETE FROM `topic_history` WHERE `id`=?"
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, args...)
+	}
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to delete from topic_history")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to get rows affected by delete for topic_history")
+	}
+
+	if err := o.doAfterDeleteHooks(ctx, exec); err != nil {
+		return 0, err
+	}
+
+	return rowsAff, nil
+}
+
+// DeleteAll deletes all matching rows.
+func (q topicHistoryQuery) DeleteAll(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if q.Query == nil {
+		return 0, errors.New("models: no topicHistoryQuery provided for delete all")
+	}
+
+	queries.SetDelete(q.Query)
+
+	result, err := q.Query.ExecContext(ctx, exec)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to delete all from topic_history")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to get rows affected by deleteall for topic_history")
+	}
+
+	return rowsAff, nil
+}
+
+// DeleteAll deletes all rows in the slice, using an executor.
+func (o TopicHistorySlice) DeleteAll(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if len(o) == 0 {
+		return 0, nil
+	}
+
+	if len(topicHistoryBeforeDeleteHooks) != 0 {
+		for _, obj := range o {
+			if err := obj.doBeforeDeleteHooks(ctx, exec); err != nil {
+				return 0, err
+			}
+		}
+	}
+
+	var args []interface{}
+	for _, obj := range o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), topicHistoryPrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := "DELETE FROM `topic_history` WHERE " +
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), 0, topicHistoryPrimaryKeyColumns, len(o))
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, args)
+	}
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to delete all from topicHistory slice")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to get rows affected by deleteall for topic_history")
+	}
+
+	if len(topicHistoryAfterDeleteHooks) != 0 {
+		for _, obj := range o {
+			if err := obj.doAfterDeleteHooks(ctx, exec); err != nil {
+				return 0, err
+			}
+		}
+	}
+
+	return rowsAff, nil
+}
+
+// Reload refetches the object from the database
+// using the primary keys with an executor.
+func (o *TopicHistory) Reload(ctx context.Context, exec boil.ContextExecutor) error {
+	ret, err := FindTopicHistory(ctx, exec, o.ID)
+	if err != nil {
+		return err
+	}
+
+	*o = *ret
+	return nil
+}
+
+// ReloadAll refetches every row with matching primary key column values
+// and overwrites the original object slice with the newly updated slice.
+func (o *TopicHistorySlice) ReloadAll(ctx context.Context, exec boil.ContextExecutor) error {
+	if o == nil || len(*o) == 0 {
+		return nil
+	}
+
+	slice := TopicHistorySlice{}
+	var args []interface{}
+	for _, obj := range *o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), topicHistoryPrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := "SELECT `topic_history`.* FROM `topic_history` WHERE " +
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), 0, topicHistoryPrimaryKeyColumns, len(*o))
+
+	q := queries.Raw(sql, args...)
+
+	err := q.Bind(ctx, exec, &slice)
+	if err != nil {
+		return errors.Wrap(err, "models: unable to reload all in TopicHistorySlice")
+	}
+
+	*o = slice
+
+	return nil
+}
+
+// TopicHistoryExists checks if the TopicHistory row exists.
+func TopicHistoryExists(ctx context.Context, exec boil.ContextExecutor, iD int64) (bool, error) {
+	var exists bool
+	sql := "select exists(select 1 from `topic_history` where `id`=? limit 1)"
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, iD)
+	}
+	row := exec.QueryRowContext(ctx, sql, iD)
+
+	err := row.Scan(&exists)
+	if err != nil {
+		return false, errors.Wrap(err, "models: unable to check if topic_history exists")
+	}
+
+	return exists, nil
+}
@@ -0,0 +1,1270 @@
+// Code generated by SQLBoiler 4.2.0 (https://github.com/volatiletech/sqlboiler). DO NOT EDIT.
+// This file is meant to be re-generated in place and/or deleted at any time.
+
+package models
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/friendsofgo/errors"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+	"github.com/volatiletech/sqlboiler/v4/queries/qmhelper"
+	"github.com/volatiletech/strmangle"
+)
+
+// TopicProducer is an object representing the database table.
+type TopicProducer struct {
+	ID                int64 `boil:"id" json:"id" toml:"id" yaml:"id"`
+	TopicID           int64 `boil:"topic_id" json:"topic_id" toml:"topic_id" yaml:"topic_id"`
+	ServiceEndpointID int64 `boil:"service_endpoint_id" json:"service_endpoint_id" toml:"service_endpoint_id" yaml:"service_endpoint_id"`
+
+	R *topicProducerR `boil:"-" json:"-" toml:"-" yaml:"-"`
+	L topicProducerL  `boil:"-" json:"-" toml:"-" yaml:"-"`
+}
+
+var TopicProducerColumns = struct {
+	ID                string
+	TopicID           string
+	ServiceEndpointID string
+}{
+	ID:                "id",
+	TopicID:           "topic_id",
+	ServiceEndpointID: "service_endpoint_id",
+}
+
+// Generated where
+
+var TopicProducerWhere = struct {
+	ID                whereHelperint64
+	TopicID           whereHelperint64
+	ServiceEndpointID whereHelperint64
+}{
+	ID:                whereHelperint64{field: "`topic_producer`.`id`"},
+	TopicID:           whereHelperint64{field: "`topic_producer`.`topic_id`"},
+	ServiceEndpointID: whereHelperint64{field: "`topic_producer`.`service_endpoint_id`"},
+}
+
+// TopicProducerRels is where relationship names are stored.
+var TopicProducerRels = struct {
+	ServiceEndpoint string
+	Topic           string
+}{
+	ServiceEndpoint: "ServiceEndpoint",
+	Topic:           "Topic",
+}
+
+// topicProducerR is where relationships are stored.
+type topicProducerR struct {
+	ServiceEndpoint *ServiceEndpoint `boil:"ServiceEndpoint" json:"ServiceEndpoint" toml:"ServiceEndpoint" yaml:"ServiceEndpoint"`
+	Topic           *Topic           `boil:"Topic" json:"Topic" toml:"Topic" yaml:"Topic"`
+}
+
+// NewStruct creates a new relationship struct
+func (*topicProducerR) NewStruct() *topicProducerR {
+	return &topicProducerR{}
+}
+
+// topicProducerL is where Load methods for each relationship are stored.
+type topicProducerL struct{}
+
+var (
+	topicProducerAllColumns            = []string{"id", "topic_id", "service_endpoint_id"}
+	topicProducerColumnsWithoutDefault = []string{"topic_id", "service_endpoint_id"}
+	topicProducerColumnsWithDefault    = []string{"id"}
+	topicProducerPrimaryKeyColumns     = []string{"id"}
+)
+
+type (
+	// TopicProducerSlice is an alias for a slice of pointers to TopicProducer.
+	// This should generally be used opposed to []TopicProducer.
+	TopicProducerSlice []*TopicProducer
+	// TopicProducerHook is the signature for custom TopicProducer hook methods
+	TopicProducerHook func(context.Context, boil.ContextExecutor, *TopicProducer) error
+
+	topicProducerQuery struct {
+		*queries.Query
+	}
+)
+
+// Cache for insert, update and upsert
+var (
+	topicProducerType                 = reflect.TypeOf(&TopicProducer{})
+	topicProducerMapping              = queries.MakeStructMapping(topicProducerType)
+	topicProducerPrimaryKeyMapping, _ = queries.BindMapping(topicProducerType, topicProducerMapping, topicProducerPrimaryKeyColumns)
+	topicProducerInsertCacheMut       sync.RWMutex
+	topicProducerInsertCache          = make(map[string]insertCache)
+	topicProducerUpdateCacheMut       sync.RWMutex
+	topicProducerUpdateCache          = make(map[string]updateCache)
+	topicProducerUpsertCacheMut       sync.RWMutex
+	topicProducerUpsertCache          = make(map[string]insertCache)
+)
+
+var (
+	// Force time package dependency for automated UpdatedAt/CreatedAt.
+	_ = time.Second
+	// Force qmhelper dependency for where clause generation (which doesn't
+	// always happen)
+	_ = qmhelper.Where
+)
+
+var topicProducerBeforeInsertHooks []TopicProducerHook
+var topicProducerBeforeUpdateHooks []TopicProducerHook
+var topicProducerBeforeDeleteHooks []TopicProducerHook
+var topicProducerBeforeUpsertHooks []TopicProducerHook
+
+var topicProducerAfterInsertHooks []TopicProducerHook
+var topicProducerAfterSelectHooks []TopicProducerHook
+var topicProducerAfterUpdateHooks []TopicProducerHook
+var topicProducerAfterDeleteHooks []TopicProducerHook
+var topicProducerAfterUpsertHooks []TopicProducerHook
+
+// doBeforeInsertHooks executes all "before insert" hooks.
+func (o *TopicProducer) doBeforeInsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range topicProducerBeforeInsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeUpdateHooks executes all "before Update" hooks.
+func (o *TopicProducer) doBeforeUpdateHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range topicProducerBeforeUpdateHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeDeleteHooks executes all "before Delete" hooks.
+func (o *TopicProducer) doBeforeDeleteHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range topicProducerBeforeDeleteHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeUpsertHooks executes all "before Upsert" hooks.
+func (o *TopicProducer) doBeforeUpsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range topicProducerBeforeUpsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterInsertHooks executes all "after Insert" hooks.
+func (o *TopicProducer) doAfterInsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range topicProducerAfterInsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterSelectHooks executes all "after Select" hooks.
+func (o *TopicProducer) doAfterSelectHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range topicProducerAfterSelectHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterUpdateHooks executes all "after Update" hooks.
+func (o *TopicProducer) doAfterUpdateHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range topicProducerAfterUpdateHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterDeleteHooks executes all "after Delete" hooks.
+func (o *TopicProducer) doAfterDeleteHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range topicProducerAfterDeleteHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterUpsertHooks executes all "after Upsert" hooks.
+func (o *TopicProducer) doAfterUpsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range topicProducerAfterUpsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// AddTopicProducerHook registers your hook function for all future operations.
+func AddTopicProducerHook(hookPoint boil.HookPoint, topicProducerHook TopicProducerHook) {
+	switch hookPoint {
+	case boil.BeforeInsertHook:
+		topicProducerBeforeInsertHooks = append(topicProducerBeforeInsertHooks, topicProducerHook)
+	case boil.BeforeUpdateHook:
+		topicProducerBeforeUpdateHooks = append(topicProducerBeforeUpdateHooks, topicProducerHook)
+	case boil.BeforeDeleteHook:
+		topicProducerBeforeDeleteHooks = append(topicProducerBeforeDeleteHooks, topicProducerHook)
+	case boil.BeforeUpsertHook:
+		topicProducerBeforeUpsertHooks = append(topicProducerBeforeUpsertHooks, topicProducerHook)
+	case boil.AfterInsertHook:
+		topicProducerAfterInsertHooks = append(topicProducerAfterInsertHooks, topicProducerHook)
+	case boil.AfterSelectHook:
+		topicProducerAfterSelectHooks = append(topicProducerAfterSelectHooks, topicProducerHook)
+	case boil.AfterUpdateHook:
+		topicProducerAfterUpdateHooks = append(topicProducerAfterUpdateHooks, topicProducerHook)
+	case boil.AfterDeleteHook:
+		topicProducerAfterDeleteHooks = append(topicProducerAfterDeleteHooks, topicProducerHook)
+	case boil.AfterUpsertHook:
+		topicProducerAfterUpsertHooks = append(topicProducerAfterUpsertHooks, topicProducerHook)
+	}
+}
+
+// One returns a single topicProducer record from the query.
+func (q topicProducerQuery) One(ctx context.Context, exec boil.ContextExecutor) (*TopicProducer, error) {
+	o := &TopicProducer{}
+
+	queries.SetLimit(q.Query, 1)
+
+	err := q.Bind(ctx, exec, o)
+	if err != nil {
+		if errors.Cause(err) == sql.ErrNoRows {
+			return nil, sql.ErrNoRows
+		}
+		return nil, errors.Wrap(err, "models: failed to execute a one query for topic_producer")
+	}
+
+	if err := o.doAfterSelectHooks(ctx, exec); err != nil {
+		return o, err
+	}
+
+	return o, nil
+}
+
+// All returns all TopicProducer records from the query.
+func (q topicProducerQuery) All(ctx context.Context, exec boil.ContextExecutor) (TopicProducerSlice, error) {
+	var o []*TopicProducer
+
+	err := q.Bind(ctx, exec, &o)
+	if err != nil {
+		return nil, errors.Wrap(err, "models: failed to assign all query results to TopicProducer slice")
+	}
+
+	if len(topicProducerAfterSelectHooks) != 0 {
+		for _, obj := range o {
+			if err := obj.doAfterSelectHooks(ctx, exec); err != nil {
+				return o, err
+			}
+		}
+	}
+
+	return o, nil
+}
+
+// Count returns the count of all TopicProducer records in the query.
+func (q topicProducerQuery) Count(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	var count int64
+
+	queries.SetSelect(q.Query, nil)
+	queries.SetCount(q.Query)
+
+	err := q.Query.QueryRowContext(ctx, exec).Scan(&count)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to count topic_producer rows")
+	}
+
+	return count, nil
+}
+
+// Exists checks if the row exists in the table.
+func (q topicProducerQuery) Exists(ctx context.Context, exec boil.ContextExecutor) (bool, error) {
+	var count int64
+
+	queries.SetSelect(q.Query, nil)
+	queries.SetCount(q.Query)
+	queries.SetLimit(q.Query, 1)
+
+	err := q.Query.QueryRowContext(ctx, exec).Scan(&count)
+	if err != nil {
+		return false, errors.Wrap(err, "models: failed to check if topic_producer exists")
+	}
+
+	return count > 0, nil
+}
+
+// ServiceEndpoint pointed to by the foreign key.
+func (o *TopicProducer) ServiceEndpoint(mods ...qm.QueryMod) serviceEndpointQuery {
+	queryMods := []qm.QueryMod{
+		qm.Where("`id` = ?", o.ServiceEndpointID),
+	}
+
+	queryMods = append(queryMods, mods...)
+
+	query := ServiceEndpoints(queryMods...)
+	queries.SetFrom(query.Query, "`service_endpoint`")
+
+	return query
+}
+
+// Topic pointed to by the foreign key.
+func (o *TopicProducer) Topic(mods ...qm.QueryMod) topicQuery {
+	queryMods := []qm.QueryMod{
+		qm.Where("`id` = ?", o.TopicID),
+	}
+
+	queryMods = append(queryMods, mods...)
+
+	query := Topics(queryMods...)
+	queries.SetFrom(query.Query, "`topic`")
+
+	return query
+}
+
+// LoadServiceEndpoint allows an eager lookup of values, cached into the
+// loaded structs of the objects. This is for an N-1 relationship.
+func (topicProducerL) LoadServiceEndpoint(ctx context.Context, e boil.ContextExecutor, singular bool, maybeTopicProducer interface{}, mods queries.Applicator) error {
+	var slice []*TopicProducer
+	var object *TopicProducer
+
+	if singular {
+		object = maybeTopicProducer.(*TopicProducer)
+	} else {
+		slice = *maybeTopicProducer.(*[]*TopicProducer)
+	}
+
+	args := make([]interface{}, 0, 1)
+	if singular {
+		if object.R == nil {
+			object.R = &topicProducerR{}
+		}
+		args = append(args, object.ServiceEndpointID)
+
+	} else {
+	Outer:
+		for _, obj := range slice {
+			if obj.R == nil {
+				obj.R = &topicProducerR{}
+			}
+
+			for _, a := range args {
+				if a == obj.ServiceEndpointID {
+					continue Outer
+				}
+			}
+
+			args = append(args, obj.ServiceEndpointID)
+
+		}
+	}
+
+	if len(args) == 0 {
+		return nil
+	}
+
+	query := NewQuery(
+		qm.From(`service_endpoint`),
+		qm.WhereIn(`service_endpoint.id in ?`, args...),
+	)
+	if mods != nil {
+		mods.Apply(query)
+	}
+
+	results, err := query.QueryContext(ctx, e)
+	if err != nil {
+		return errors.Wrap(err, "failed to eager load ServiceEndpoint")
+	}
+
+	var resultSlice []*ServiceEndpoint
+	if err = queries.Bind(results, &resultSlice); err != nil {
+		return errors.Wrap(err, "failed to bind eager loaded slice ServiceEndpoint")
+	}
+
+	if err = results.Close(); err != nil {
+		return errors.Wrap(err, "failed to close results of eager load for service_endpoint")
+	}
+	if err = results.Err(); err != nil {
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for service_endpoint")
+	}
+
+	if len(topicProducerAfterSelectHooks) != 0 {
+		for _, obj := range resultSlice {
+			if err := obj.doAfterSelectHooks(ctx, e); err != nil {
+				return err
+			}
+		}
+	}
+
+	if len(resultSlice) == 0 {
+		return nil
+	}
+
+	if singular {
+		foreign := resultSlice[0]
+		object.R.ServiceEndpoint = foreign
+		if foreign.R == nil {
+			foreign.R = &serviceEndpointR{}
+		}
+		foreign.R.TopicProducers = append(foreign.R.TopicProducers, object)
+		return nil
+	}
+
+	for _, local := range slice {
+		for _, foreign := range resultSlice {
+			if local.ServiceEndpointID == foreign.ID {
+				local.R.ServiceEndpoint = foreign
+				if foreign.R == nil {
+					foreign.R = &serviceEndpointR{}
+				}
+				foreign.R.TopicProducers = append(foreign.R.TopicProducers, local)
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
+// LoadTopic allows an eager lookup of values, cached into the
+// loaded structs of the objects. This is for an N-1 relationship.
+func (topicProducerL) LoadTopic(ctx context.Context, e boil.ContextExecutor, singular bool, maybeTopicProducer interface{}, mods queries.Applicator) error {
+	var slice []*TopicProducer
+	var object *TopicProducer
+
+	if singular {
+		object = maybeTopicProducer.(*TopicProducer)
+	} else {
+		slice = *maybeTopicProducer.(*[]*TopicProducer)
+	}
+
+	args := make([]interface{}, 0, 1)
+	if singular {
+		if object.R == nil {
+			object.R = &topicProducerR{}
+		}
+		args = append(args, object.TopicID)
+
+	} else {
+	Outer:
+		for _, obj := range slice {
+			if obj.R == nil {
+				obj.R = &topicProducerR{}
+			}
+
+			for _, a := range args {
+				if a == obj.TopicID {
+					continue Outer
+				}
+			}
+
+			args = append(args, obj.TopicID)
+
+		}
+	}
+
+	if len(args) == 0 {
+		return nil
+	}
+
+	query := NewQuery(
+		qm.From(`topic`),
+		qm.WhereIn(`topic.id in ?`, args...),
+	)
+	if mods != nil {
+		mods.Apply(query)
+	}
+
+	results, err := query.QueryContext(ctx, e)
+	if err != nil {
+		return errors.Wrap(err, "failed to eager load Topic")
+	}
+
+	var resultSlice []*Topic
+	if err = queries.Bind(results, &resultSlice); err != nil {
+		return errors.Wrap(err, "failed to bind eager loaded slice Topic")
+	}
+
+	if err = results.Close(); err != nil {
+		return errors.Wrap(err, "failed to close results of eager load for topic")
+	}
+	if err = results.Err(); err != nil {
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for topic")
+	}
+
+	if len(topicProducerAfterSelectHooks) != 0 {
+		for _, obj := range resultSlice {
+			if err := obj.doAfterSelectHooks(ctx, e); err != nil {
+				return err
+			}
+		}
+	}
+
+	if len(resultSlice) == 0 {
+		return nil
+	}
+
+	if singular {
+		foreign := resultSlice[0]
+		object.R.Topic = foreign
+		if foreign.R == nil {
+			foreign.R = &topicR{}
+		}
+		foreign.R.TopicProducers = append(foreign.R.TopicProducers, object)
+		return nil
+	}
+
+	for _, local := range slice {
+		for _, foreign := range resultSlice {
+			if local.TopicID == foreign.ID {
+				local.R.Topic = foreign
+				if foreign.R == nil {
+					foreign.R = &topicR{}
+				}
+				foreign.R.TopicProducers = append(foreign.R.TopicProducers, local)
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
+// SetServiceEndpoint of the topicProducer to the related item.
+// Sets o.R.ServiceEndpoint to related.
+// Adds o to related.R.TopicProducers.
+func (o *TopicProducer) SetServiceEndpoint(ctx context.Context, exec boil.ContextExecutor, insert bool, related *ServiceEndpoint) error {
+	var err error
+	if insert {
+		if err = related.Insert(ctx, exec, boil.Infer()); err != nil {
+			return errors.Wrap(err, "failed to insert into foreign table")
+		}
+	}
+
+	updateQuery := fmt.Sprintf(
+		"UPDATE `topic_producer` SET %s WHERE %s",
+		strmangle.SetParamNames("`", "`", 0, []string{"service_endpoint_id"}),
+		strmangle.WhereClause("`", "`", 0, topicProducerPrimaryKeyColumns),
+	)
+	values := []interface{}{related.ID, o.ID}
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, updateQuery)
+		fmt.Fprintln(writer, values)
+	}
+	if _, err = exec.ExecContext(ctx, updateQuery, values...); err != nil {
+		return errors.Wrap(err, "failed to update local table")
+	}
+
+	o.ServiceEndpointID = related.ID
+	if o.R == nil {
+		o.R = &topicProducerR{
+			ServiceEndpoint: related,
+		}
+	} else {
+		o.R.ServiceEndpoint = related
+	}
+
+	if related.R == nil {
+		related.R = &serviceEndpointR{
+			TopicProducers: TopicProducerSlice{o},
+		}
+	} else {
+		related.R.TopicProducers = append(related.R.TopicProducers, o)
+	}
+
+	return nil
+}
+
+// SetTopic of the topicProducer to the related item.
+// Sets o.R.Topic to related.
+// Adds o to related.R.TopicProducers.
+func (o *TopicProducer) SetTopic(ctx context.Context, exec boil.ContextExecutor, insert bool, related *Topic) error {
+	var err error
+	if insert {
+		if err = related.Insert(ctx, exec, boil.Infer()); err != nil {
+			return errors.Wrap(err, "failed to insert into foreign table")
+		}
+	}
+
+	updateQuery := fmt.Sprintf(
+		"UPDATE `topic_producer` SET %s WHERE %s",
+		strmangle.SetParamNames("`", "`", 0, []string{"topic_id"}),
+		strmangle.WhereClause("`", "`", 0, topicProducerPrimaryKeyColumns),
+	)
+	values := []interface{}{related.ID, o.ID}
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, updateQuery)
+		fmt.Fprintln(writer, values)
+	}
+	if _, err = exec.ExecContext(ctx, updateQuery, values...); err != nil {
+		return errors.Wrap(err, "failed to update local table")
+	}
+
+	o.TopicID = related.ID
+	if o.R == nil {
+		o.R = &topicProducerR{
+			Topic: related,
+		}
+	} else {
+		o.R.Topic = related
+	}
+
+	if related.R == nil {
+		related.R = &topicR{
+			TopicProducers: TopicProducerSlice{o},
+		}
+	} else {
+		related.R.TopicProducers = append(related.R.TopicProducers, o)
+	}
+
+	return nil
+}
+
+// TopicProducers retrieves all the records using an executor.
+func TopicProducers(mods ...qm.QueryMod) topicProducerQuery {
+	mods = append(mods, qm.From("`topic_producer`"))
+	return topicProducerQuery{NewQuery(mods...)}
+}
+
+// FindTopicProducer retrieves a single record by ID with an executor.
+// If selectCols is empty Find will return all columns.
+func FindTopicProducer(ctx context.Context, exec boil.ContextExecutor, iD int64, selectCols ...string) (*TopicProducer, error) {
+	topicProducerObj := &TopicProducer{}
+
+	sel := "*"
+	if len(selectCols) > 0 {
+		sel = strings.Join(strmangle.IdentQuoteSlice(dialect.LQ, dialect.RQ, selectCols), ",")
+	}
+	query := fmt.Sprintf(
+		"select %s from `topic_producer` where `id`=?", sel,
+	)
+
+	q := queries.Raw(query, iD)
+
+	err := q.Bind(ctx, exec, topicProducerObj)
+	if err != nil {
+		if errors.Cause(err) == sql.ErrNoRows {
+			return nil, sql.ErrNoRows
+		}
+		return nil, errors.Wrap(err, "models: unable to select from topic_producer")
+	}
+
+	return topicProducerObj, nil
+}
+
+// Insert a single record using an executor.
+// See boil.Columns.InsertColumnSet documentation to understand column list inference for inserts.
+func (o *TopicProducer) Insert(ctx context.Context, exec boil.ContextExecutor, columns boil.Columns) error {
+	if o == nil {
+		return errors.New("models: no topic_producer provided for insertion")
+	}
+
+	var err error
+
+	if err := o.doBeforeInsertHooks(ctx, exec); err != nil {
+		return err
+	}
+
+	nzDefaults := queries.NonZeroDefaultSet(topicProducerColumnsWithDefault, o)
+
+	key := makeCacheKey(columns, nzDefaults)
+	topicProducerInsertCacheMut.RLock()
+	cache, cached := topicProducerInsertCache[key]
+	topicProducerInsertCacheMut.RUnlock()
+
+	if !cached {
+		wl, returnColumns := columns.InsertColumnSet(
+			topicProducerAllColumns,
+			topicProducerColumnsWithDefault,
+			topicProducerColumnsWithoutDefault,
+			nzDefaults,
+		)
+
+		cache.valueMapping, err = queries.BindMapping(topicProducerType, topicProducerMapping, wl)
+		if err != nil {
+			return err
+		}
+		cache.retMapping, err = queries.BindMapping(topicProducerType, topicProducerMapping, returnColumns)
+		if err != nil {
+			return err
+		}
+		if len(wl) != 0 {
+			cache.query = fmt.Sprintf("INSERT INTO `topic_producer` (`%s`) %%sVALUES (%s)%%s", strings.Join(wl, "`,`"), strmangle.Placeholders(dialect.UseIndexPlaceholders, len(wl), 1, 1))
+		} else {
+			cache.query = "INSERT INTO `topic_producer` () VALUES ()%s%s"
+		}
+
+		var queryOutput, queryReturning string
+
+		if len(cache.retMapping) != 0 {
+			cache.retQuery = fmt.Sprintf("SELECT `%s` FROM `topic_producer` WHERE %s", strings.Join(returnColumns, "`,`"), strmangle.WhereClause("`", "`", 0, topicProducerPrimaryKeyColumns))
+		}
+
+		cache.query = fmt.Sprintf(cache.query, queryOutput, queryReturning)
+	}
+
+	value := reflect.Indirect(reflect.ValueOf(o))
+	vals := queries.ValuesFromMapping(value, cache.valueMapping)
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.query)
+		fmt.Fprintln(writer, vals)
+	}
+	result, err := exec.ExecContext(ctx, cache.query, vals...)
+
+	if err != nil {
+		return errors.Wrap(err, "models: unable to insert into topic_producer")
+	}
+
+	var lastID int64
+	var identifierCols []interface{}
+
+	if len(cache.retMapping) == 0 {
+		goto CacheNoHooks
+	}
+
+	lastID, err = result.LastInsertId()
+	if err != nil {
+		return ErrSyncFail
+	}
+
+	o.ID = int64(lastID)
+	if lastID != 0 && len(cache.retMapping) == 1 && cache.retMapping[0] == topicProducerMapping["id"] {
+		goto CacheNoHooks
+	}
+
+	identifierCols = []interface{}{
+		o.ID,
+	}
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.retQuery)
+		fmt.Fprintln(writer, identifierCols...)
+	}
+	err = exec.QueryRowContext(ctx, cache.retQuery, identifierCols...).Scan(queries.PtrsFromMapping(value, cache.retMapping)...)
+	if err != nil {
+		return errors.Wrap(err, "models: unable to populate default values for topic_producer")
+	}
+
+CacheNoHooks:
+	if !cached {
+		topicProducerInsertCacheMut.Lock()
+		topicProducerInsertCache[key] = cache
+		topicProducerInsertCacheMut.Unlock()
+	}
+
+	return o.doAfterInsertHooks(ctx, exec)
+}
+
+// Update uses an executor to update the TopicProducer.
+// See boil.Columns.UpdateColumnSet documentation to understand column list inference for updates.
+// Update does not automatically update the record in case of default values. Use .Reload() to refresh the records.
+func (o *TopicProducer) Update(ctx context.Context, exec boil.ContextExecutor, columns boil.Columns) (int64, error) {
+	var err error
+	if err = o.doBeforeUpdateHooks(ctx, exec); err != nil {
+		return 0, err
+	}
+	key := makeCacheKey(columns, nil)
+	topicProducerUpdateCacheMut.RLock()
+	cache, cached := topicProducerUpdateCache[key]
+	topicProducerUpdateCacheMut.RUnlock()
+
+	if !cached {
+		wl := columns.UpdateColumnSet(
+			topicProducerAllColumns,
+			topicProducerPrimaryKeyColumns,
+		)
+
+		if !columns.IsWhitelist() {
+			wl = strmangle.SetComplement(wl, []string{"created_at"})
+		}
+		if len(wl) == 0 {
+			return 0, errors.New("models: unable to update topic_producer, could not build whitelist")
+		}
+
+		cache.query = fmt.Sprintf("UPDATE `topic_producer` SET %s WHERE %s",
+			strmangle.SetParamNames("`", "`", 0, wl),
+			strmangle.WhereClause("`", "`", 0, topicProducerPrimaryKeyColumns),
+		)
+		cache.valueMapping, err = queries.BindMapping(topicProducerType, topicProducerMapping, append(wl, topicProducerPrimaryKeyColumns...))
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	values := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(o)), cache.valueMapping)
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.query)
+		fmt.Fprintln(writer, values)
+	}
+	var result sql.Result
+	result, err = exec.ExecContext(ctx, cache.query, values...)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to update topic_producer row")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to get rows affected by update for topic_producer")
+	}
+
+	if !cached {
+		topicProducerUpdateCacheMut.Lock()
+		topicProducerUpdateCache[key] = cache
+		topicProducerUpdateCacheMut.Unlock()
+	}
+
+	return rowsAff, o.doAfterUpdateHooks(ctx, exec)
+}
+
+// UpdateAll updates all rows with the specified column values.
+func (q topicProducerQuery) UpdateAll(ctx context.Context, exec boil.ContextExecutor, cols M) (int64, error) {
+	queries.SetUpdate(q.Query, cols)
+
+	result, err := q.Query.ExecContext(ctx, exec)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to update all for topic_producer")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to retrieve rows affected for topic_producer")
+	}
+
+	return rowsAff, nil
+}
+
+// UpdateAll updates all rows with the specified column values, using an executor.
+func (o TopicProducerSlice) UpdateAll(ctx context.Context, exec boil.ContextExecutor, cols M) (int64, error) {
+	ln := int64(len(o))
+	if ln == 0 {
+		return 0, nil
+	}
+
+	if len(cols) == 0 {
+		return 0, errors.New("models: update all requires at least one column argument")
+	}
+
+	colNames := make([]string, len(cols))
+	args := make([]interface{}, len(cols))
+
+	i := 0
+	for name, value := range cols {
+		colNames[i] = name
+		args[i] = value
+		i++
+	}
+
+	// Append all of the primary key values for each column
+	for _, obj := range o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), topicProducerPrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := fmt.Sprintf("UPDATE `topic_producer` SET %s WHERE %s",
+		strmangle.SetParamNames("`", "`", 0, colNames),
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), 0, topicProducerPrimaryKeyColumns, len(o)))
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, args...)
+	}
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to update all in topicProducer slice")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to retrieve rows affected all in update all topicProducer")
+	}
+	return rowsAff, nil
+}
+
+var mySQLTopicProducerUniqueColumns = []string{
+	"id",
+}
+
+// Upsert attempts an insert using an executor, and does an update or ignore on conflict.
+// See boil.Columns documentation for how to properly use updateColumns and insertColumns.
+func (o *TopicProducer) Upsert(ctx context.Context, exec boil.ContextExecutor, updateColumns, insertColumns boil.Columns) error {
+	if o == nil {
+		return errors.New("models: no topic_producer provided for upsert")
+	}
+
+	if err := o.doBeforeUpsertHooks(ctx, exec); err != nil {
+		return err
+	}
+
+	nzDefaults := queries.NonZeroDefaultSet(topicProducerColumnsWithDefault, o)
+	nzUniques := queries.NonZeroDefaultSet(mySQLTopicProducerUniqueColumns, o)
+
+	if len(nzUniques) == 0 {
+		return errors.New("cannot upsert with a table that cannot conflict on a unique column")
+	}
+
+	// Build cache key in-line uglily - mysql vs psql problems
+	buf := strmangle.GetBuffer()
+	buf.WriteString(strconv.Itoa(updateColumns.Kind))
+	for _, c := range updateColumns.Cols {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	buf.WriteString(strconv.Itoa(insertColumns.Kind))
+	for _, c := range insertColumns.Cols {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	for _, c := range nzDefaults {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	for _, c := range nzUniques {
+		buf.WriteString(c)
+	}
+	key := buf.String()
+	strmangle.PutBuffer(buf)
+
+	topicProducerUpsertCacheMut.RLock()
+	cache, cached := topicProducerUpsertCache[key]
+	topicProducerUpsertCacheMut.RUnlock()
+
+	var err error
+
+	if !cached {
+		insert, ret := insertColumns.InsertColumnSet(
+			topicProducerAllColumns,
+			topicProducerColumnsWithDefault,
+			topicProducerColumnsWithoutDefault,
+			nzDefaults,
+		)
+		update := updateColumns.UpdateColumnSet(
+			topicProducerAllColumns,
+			topicProducerPrimaryKeyColumns,
+		)
+
+		if len(update) == 0 {
+			return errors.New("models: unable to upsert topic_producer, could not build update column list")
+		}
+
+		ret = strmangle.SetComplement(ret, nzUniques)
+		cache.query = buildUpsertQueryMySQL(dialect, "topic_producer", update, insert)
+		cache.retQuery = fmt.Sprintf(
+			"SELECT %s FROM `topic_producer` WHERE %s",
+			strings.Join(strmangle.IdentQuoteSlice(dialect.LQ, dialect.RQ, ret), ","),
+			strmangle.WhereClause("`", "`", 0, nzUniques),
+		)
+
+		cache.valueMapping, err = queries.BindMapping(topicProducerType, topicProducerMapping, insert)
+		if err != nil {
+			return err
+		}
+		if len(ret) != 0 {
+			cache.retMapping, err = queries.BindMapping(topicProducerType, topicProducerMapping, ret)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	value := reflect.Indirect(reflect.ValueOf(o))
+	vals := queries.ValuesFromMapping(value, cache.valueMapping)
+	var returns []interface{}
+	if len(cache.retMapping) != 0 {
+		returns = queries.PtrsFromMapping(value, cache.retMapping)
+	}
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.query)
+		fmt.Fprintln(writer, vals)
+	}
+	result, err := exec.ExecContext(ctx, cache.query, vals...)
+
+	if err != nil {
+		return errors.Wrap(err, "models: unable to upsert for topic_producer")
+	}
+
+	var lastID int64
+	var uniqueMap []uint64
+	var nzUniqueCols []interface{}
+
+	if len(cache.retMapping) == 0 {
+		goto CacheNoHooks
+	}
+
+	lastID, err = result.LastInsertId()
+	if err != nil {
+		return ErrSyncFail
+	}
+
+	o.ID = int64(lastID)
+	if lastID != 0 && len(cache.retMapping) == 1 && cache.retMapping[0] == topicProducerMapping["id"] {
+		goto CacheNoHooks
+	}
+
+	uniqueMap, err = queries.BindMapping(topicProducerType, topicProducerMapping, nzUniques)
+	if err != nil {
+		return errors.Wrap(err, "models: unable to retrieve unique values for topic_producer")
+	}
+	nzUniqueCols = queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(o)), uniqueMap)
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.retQuery)
+		fmt.Fprintln(writer, nzUniqueCols...)
+	}
+	err = exec.QueryRowContext(ctx, cache.retQuery, nzUniqueCols...).Scan(returns...)
+	if err != nil {
+		return errors.Wrap(err, "models: unable to populate default values for topic_producer")
+	}
+
+CacheNoHooks:
+	if !cached {
+		topicProducerUpsertCacheMut.Lock()
+		topicProducerUpsertCache[key] = cache
+		topicProducerUpsertCacheMut.Unlock()
+	}
+
+	return o.doAfterUpsertHooks(ctx, exec)
+}
+
+// Delete deletes a single TopicProducer record with an executor.
+// Delete will match against the primary key column to find the record to delete.
+func (o *TopicProducer) Delete(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if o == nil {
+		return 0, errors.New("models: no TopicProducer provided for delete")
+	}
+
+	if err := o.doBeforeDeleteHooks(ctx, exec); err != nil {
+		return 0, err
+	}
+
+	args := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(o)), topicProducerPrimaryKeyMapping)
+	sql := "DELETE FROM `topic_producer` WHERE `id`=?"
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, args...)
+	}
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to delete from topic_producer")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to get rows affected by delete for topic_producer")
+	}
+
+	if err := o.doAfterDeleteHooks(ctx, exec); err != nil {
+		return 0, err
+	}
+
+	return rowsAff, nil
+}
+
+// DeleteAll deletes all matching rows.
+func (q topicProducerQuery) DeleteAll(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if q.Query == nil {
+		return 0, errors.New("models: no topicProducerQuery provided for delete all")
+	}
+
+	queries.SetDelete(q.Query)
+
+	result, err := q.Query.ExecContext(ctx, exec)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to delete all from topic_producer")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to get rows affected by deleteall for topic_producer")
+	}
+
+	return rowsAff, nil
+}
+
+// DeleteAll deletes all rows in the slice, using an executor.
+func (o TopicProducerSlice) DeleteAll(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if len(o) == 0 {
+		return 0, nil
+	}
+
+	if len(topicProducerBeforeDeleteHooks) != 0 {
+		for _, obj := range o {
+			if err := obj.doBeforeDeleteHooks(ctx, exec); err != nil {
+				return 0, err
+			}
+		}
+	}
+
+	var args []interface{}
+	for _, obj := range o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), topicProducerPrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := "DELETE FROM `topic_producer` WHERE " +
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), 0, topicProducerPrimaryKeyColumns, len(o))
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, args)
+	}
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to delete all from topicProducer slice")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to get rows affected by deleteall for topic_producer")
+	}
+
+	if len(topicProducerAfterDeleteHooks) != 0 {
+		for _, obj := range o {
+			if err := obj.doAfterDeleteHooks(ctx, exec); err != nil {
+				return 0, err
+			}
+		}
+	}
+
+	return rowsAff, nil
+}
+
+// Reload refetches the object from the database
+// using the primary keys with an executor.
+func (o *TopicProducer) Reload(ctx context.Context, exec boil.ContextExecutor) error {
+	ret, err := FindTopicProducer(ctx, exec, o.ID)
+	if err != nil {
+		return err
+	}
+
+	*o = *ret
+	return nil
+}
+
+// ReloadAll refetches every row with matching primary key column values
+// and overwrites the original object slice with the newly updated slice.
+func (o *TopicProducerSlice) ReloadAll(ctx context.Context, exec boil.ContextExecutor) error {
+	if o == nil || len(*o) == 0 {
+		return nil
+	}
+
+	slice := TopicProducerSlice{}
+	var args []interface{}
+	for _, obj := range *o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), topicProducerPrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := "SELECT `topic_producer`.* FROM `topic_producer` WHERE " +
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), 0, topicProducerPrimaryKeyColumns, len(*o))
+
+	q := queries.Raw(sql, args...)
+
+	err := q.Bind(ctx, exec, &slice)
+	if err != nil {
+		return errors.Wrap(err, "models: unable to reload all in TopicProducerSlice")
+	}
+
+	*o = slice
+
+	return nil
+}
+
+// TopicProducerExists checks if the TopicProducer row exists.
+func TopicProducerExists(ctx context.Context, exec boil.ContextExecutor, iD int64) (bool, error) {
+	var exists bool
+	sql := "select exists(select 1 from `topic_producer` where `id`=? limit 1)"
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, iD)
+	}
+	row := exec.QueryRowContext(ctx, sql, iD)
+
+	err := row.Scan(&exists)
+	if err != nil {
+		return false, errors.Wrap(err, "models: unable to check if topic_producer exists")
+	}
+
+	return exists, nil
+}
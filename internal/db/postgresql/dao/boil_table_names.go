@@ -4,11 +4,33 @@
 package models
 
 var TableNames = struct {
+	Client                    string
+	ClientDependency          string
+	ClientHistory             string
+	PolicyRule                string
 	Service                   string
 	ServiceEndpoint           string
 	ServiceEndpointDependency string
+	ServiceEndpointLabel      string
+	ServiceHistory            string
+	ServiceLabel              string
+	Topic                     string
+	TopicConsumer             string
+	TopicHistory              string
+	TopicProducer             string
 }{
+	Client:                    "client",
+	ClientDependency:          "client_dependency",
+	ClientHistory:             "client_history",
+	PolicyRule:                "policy_rule",
 	Service:                   "service",
 	ServiceEndpoint:           "service_endpoint",
 	ServiceEndpointDependency: "service_endpoint_dependency",
+	ServiceEndpointLabel:      "service_endpoint_label",
+	ServiceHistory:            "service_history",
+	ServiceLabel:              "service_label",
+	Topic:                     "topic",
+	TopicConsumer:             "topic_consumer",
+	TopicHistory:              "topic_history",
+	TopicProducer:             "topic_producer",
 }
@@ -0,0 +1,1210 @@
+// Code generated by SQLBoiler 4.2.0 (https://github.com/volatiletech/sqlboiler). DO NOT EDIT.
+// This file is meant to be re-generated in place and/or deleted at any time.
+
+package models
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/friendsofgo/errors"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+	"github.com/volatiletech/sqlboiler/v4/queries/qmhelper"
+	"github.com/volatiletech/strmangle"
+)
+
+// ClientDependency is an object representing the database table.
+type ClientDependency struct {
+	ID                          int64 `boil:"id" json:"id" toml:"id" yaml:"id"`
+	ClientID                    int64 `boil:"client_id" json:"client_id" toml:"client_id" yaml:"client_id"`
+	DependencyServiceEndpointID int64 `boil:"dependency_service_endpoint_id" json:"dependency_service_endpoint_id" toml:"dependency_service_endpoint_id" yaml:"dependency_service_endpoint_id"`
+
+	R *clientDependencyR `boil:"-" json:"-" toml:"-" yaml:"-"`
+	L clientDependencyL  `boil:"-" json:"-" toml:"-" yaml:"-"`
+}
+
+var ClientDependencyColumns = struct {
+	ID                          string
+	ClientID                    string
+	DependencyServiceEndpointID string
+}{
+	ID:                          "id",
+	ClientID:                    "client_id",
+	DependencyServiceEndpointID: "dependency_service_endpoint_id",
+}
+
+// Generated where
+
+var ClientDependencyWhere = struct {
+	ID                          whereHelperint64
+	ClientID                    whereHelperint64
+	DependencyServiceEndpointID whereHelperint64
+}{
+	ID:                          whereHelperint64{field: "\"client_dependency\".\"id\""},
+	ClientID:                    whereHelperint64{field: "\"client_dependency\".\"client_id\""},
+	DependencyServiceEndpointID: whereHelperint64{field: "\"client_dependency\".\"dependency_service_endpoint_id\""},
+}
+
+// ClientDependencyRels is where relationship names are stored.
+var ClientDependencyRels = struct {
+	Client                    string
+	DependencyServiceEndpoint string
+}{
+	Client:                    "Client",
+	DependencyServiceEndpoint: "DependencyServiceEndpoint",
+}
+
+// clientDependencyR is where relationships are stored.
+type clientDependencyR struct {
+	Client                    *Client          `boil:"Client" json:"Client" toml:"Client" yaml:"Client"`
+	DependencyServiceEndpoint *ServiceEndpoint `boil:"DependencyServiceEndpoint" json:"DependencyServiceEndpoint" toml:"DependencyServiceEndpoint" yaml:"DependencyServiceEndpoint"`
+}
+
+// NewStruct creates a new relationship struct
+func (*clientDependencyR) NewStruct() *clientDependencyR {
+	return &clientDependencyR{}
+}
+
+// clientDependencyL is where Load methods for each relationship are stored.
+type clientDependencyL struct{}
+
+var (
+	clientDependencyAllColumns            = []string{"id", "client_id", "dependency_service_endpoint_id"}
+	clientDependencyColumnsWithoutDefault = []string{"client_id", "dependency_service_endpoint_id"}
+	clientDependencyColumnsWithDefault    = []string{"id"}
+	clientDependencyPrimaryKeyColumns     = []string{"id"}
+)
+
+type (
+	// ClientDependencySlice is an alias for a slice of pointers to ClientDependency.
+	// This should generally be used opposed to []ClientDependency.
+	ClientDependencySlice []*ClientDependency
+	// ClientDependencyHook is the signature for custom ClientDependency hook methods
+	ClientDependencyHook func(context.Context, boil.ContextExecutor, *ClientDependency) error
+
+	clientDependencyQuery struct {
+		*queries.Query
+	}
+)
+
+// Cache for insert, update and upsert
+var (
+	clientDependencyType                 = reflect.TypeOf(&ClientDependency{})
+	clientDependencyMapping              = queries.MakeStructMapping(clientDependencyType)
+	clientDependencyPrimaryKeyMapping, _ = queries.BindMapping(clientDependencyType, clientDependencyMapping, clientDependencyPrimaryKeyColumns)
+	clientDependencyInsertCacheMut       sync.RWMutex
+	clientDependencyInsertCache          = make(map[string]insertCache)
+	clientDependencyUpdateCacheMut       sync.RWMutex
+	clientDependencyUpdateCache          = make(map[string]updateCache)
+	clientDependencyUpsertCacheMut       sync.RWMutex
+	clientDependencyUpsertCache          = make(map[string]insertCache)
+)
+
+var (
+	// Force time package dependency for automated UpdatedAt/CreatedAt.
+	_ = time.Second
+	// Force qmhelper dependency for where clause generation (which doesn't
+	// always happen)
+	_ = qmhelper.Where
+)
+
+var clientDependencyBeforeInsertHooks []ClientDependencyHook
+var clientDependencyBeforeUpdateHooks []ClientDependencyHook
+var clientDependencyBeforeDeleteHooks []ClientDependencyHook
+var clientDependencyBeforeUpsertHooks []ClientDependencyHook
+
+var clientDependencyAfterInsertHooks []ClientDependencyHook
+var clientDependencyAfterSelectHooks []ClientDependencyHook
+var clientDependencyAfterUpdateHooks []ClientDependencyHook
+var clientDependencyAfterDeleteHooks []ClientDependencyHook
+var clientDependencyAfterUpsertHooks []ClientDependencyHook
+
+// doBeforeInsertHooks executes all "before insert" hooks.
+func (o *ClientDependency) doBeforeInsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range clientDependencyBeforeInsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeUpdateHooks executes all "before Update" hooks.
+func (o *ClientDependency) doBeforeUpdateHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range clientDependencyBeforeUpdateHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeDeleteHooks executes all "before Delete" hooks.
+func (o *ClientDependency) doBeforeDeleteHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range clientDependencyBeforeDeleteHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeUpsertHooks executes all "before Upsert" hooks.
+func (o *ClientDependency) doBeforeUpsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range clientDependencyBeforeUpsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterInsertHooks executes all "after Insert" hooks.
+func (o *ClientDependency) doAfterInsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range clientDependencyAfterInsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterSelectHooks executes all "after Select" hooks.
+func (o *ClientDependency) doAfterSelectHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range clientDependencyAfterSelectHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterUpdateHooks executes all "after Update" hooks.
+func (o *ClientDependency) doAfterUpdateHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range clientDependencyAfterUpdateHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterDeleteHooks executes all "after Delete" hooks.
+func (o *ClientDependency) doAfterDeleteHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range clientDependencyAfterDeleteHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterUpsertHooks executes all "after Upsert" hooks.
+func (o *ClientDependency) doAfterUpsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range clientDependencyAfterUpsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// AddClientDependencyHook registers your hook function for all future operations.
+func AddClientDependencyHook(hookPoint boil.HookPoint, clientDependencyHook ClientDependencyHook) {
+	switch hookPoint {
+	case boil.BeforeInsertHook:
+		clientDependencyBeforeInsertHooks = append(clientDependencyBeforeInsertHooks, clientDependencyHook)
+	case boil.BeforeUpdateHook:
+		clientDependencyBeforeUpdateHooks = append(clientDependencyBeforeUpdateHooks, clientDependencyHook)
+	case boil.BeforeDeleteHook:
+		clientDependencyBeforeDeleteHooks = append(clientDependencyBeforeDeleteHooks, clientDependencyHook)
+	case boil.BeforeUpsertHook:
+		clientDependencyBeforeUpsertHooks = append(clientDependencyBeforeUpsertHooks, clientDependencyHook)
+	case boil.AfterInsertHook:
+		clientDependencyAfterInsertHooks = append(clientDependencyAfterInsertHooks, clientDependencyHook)
+	case boil.AfterSelectHook:
+		clientDependencyAfterSelectHooks = append(clientDependencyAfterSelectHooks, clientDependencyHook)
+	case boil.AfterUpdateHook:
+		clientDependencyAfterUpdateHooks = append(clientDependencyAfterUpdateHooks, clientDependencyHook)
+	case boil.AfterDeleteHook:
+		clientDependencyAfterDeleteHooks = append(clientDependencyAfterDeleteHooks, clientDependencyHook)
+	case boil.AfterUpsertHook:
+		clientDependencyAfterUpsertHooks = append(clientDependencyAfterUpsertHooks, clientDependencyHook)
+	}
+}
+
+// One returns a single clientDependency record from the query.
+func (q clientDependencyQuery) One(ctx context.Context, exec boil.ContextExecutor) (*ClientDependency, error) {
+	o := &ClientDependency{}
+
+	queries.SetLimit(q.Query, 1)
+
+	err := q.Bind(ctx, exec, o)
+	if err != nil {
+		if errors.Cause(err) == sql.ErrNoRows {
+			return nil, sql.ErrNoRows
+		}
+		return nil, errors.Wrap(err, "models: failed to execute a one query for client_dependency")
+	}
+
+	if err := o.doAfterSelectHooks(ctx, exec); err != nil {
+		return o, err
+	}
+
+	return o, nil
+}
+
+// All returns all ClientDependency records from the query.
+func (q clientDependencyQuery) All(ctx context.Context, exec boil.ContextExecutor) (ClientDependencySlice, error) {
+	var o []*ClientDependency
+
+	err := q.Bind(ctx, exec, &o)
+	if err != nil {
+		return nil, errors.Wrap(err, "models: failed to assign all query results to ClientDependency slice")
+	}
+
+	if len(clientDependencyAfterSelectHooks) != 0 {
+		for _, obj := range o {
+			if err := obj.doAfterSelectHooks(ctx, exec); err != nil {
+				return o, err
+			}
+		}
+	}
+
+	return o, nil
+}
+
+// Count returns the count of all ClientDependency records in the query.
+func (q clientDependencyQuery) Count(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	var count int64
+
+	queries.SetSelect(q.Query, nil)
+	queries.SetCount(q.Query)
+
+	err := q.Query.QueryRowContext(ctx, exec).Scan(&count)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to count client_dependency rows")
+	}
+
+	return count, nil
+}
+
+// Exists checks if the row exists in the table.
+func (q clientDependencyQuery) Exists(ctx context.Context, exec boil.ContextExecutor) (bool, error) {
+	var count int64
+
+	queries.SetSelect(q.Query, nil)
+	queries.SetCount(q.Query)
+	queries.SetLimit(q.Query, 1)
+
+	err := q.Query.QueryRowContext(ctx, exec).Scan(&count)
+	if err != nil {
+		return false, errors.Wrap(err, "models: failed to check if client_dependency exists")
+	}
+
+	return count > 0, nil
+}
+
+// Client pointed to by the foreign key.
+func (o *ClientDependency) Client(mods ...qm.QueryMod) clientQuery {
+	queryMods := []qm.QueryMod{
+		qm.Where("\"id\" = ?", o.ClientID),
+	}
+
+	queryMods = append(queryMods, mods...)
+
+	query := Clients(queryMods...)
+	queries.SetFrom(query.Query, "\"client\"")
+
+	return query
+}
+
+// DependencyServiceEndpoint pointed to by the foreign key.
+func (o *ClientDependency) DependencyServiceEndpoint(mods ...qm.QueryMod) serviceEndpointQuery {
+	queryMods := []qm.QueryMod{
+		qm.Where("\"id\" = ?", o.DependencyServiceEndpointID),
+	}
+
+	queryMods = append(queryMods, mods...)
+
+	query := ServiceEndpoints(queryMods...)
+	queries.SetFrom(query.Query, "\"service_endpoint\"")
+
+	return query
+}
+
+// LoadClient allows an eager lookup of values, cached into the
+// loaded structs of the objects. This is for an N-1 relationship.
+func (clientDependencyL) LoadClient(ctx context.Context, e boil.ContextExecutor, singular bool, maybeClientDependency interface{}, mods queries.Applicator) error {
+	var slice []*ClientDependency
+	var object *ClientDependency
+
+	if singular {
+		object = maybeClientDependency.(*ClientDependency)
+	} else {
+		slice = *maybeClientDependency.(*[]*ClientDependency)
+	}
+
+	args := make([]interface{}, 0, 1)
+	if singular {
+		if object.R == nil {
+			object.R = &clientDependencyR{}
+		}
+		args = append(args, object.ClientID)
+
+	} else {
+	Outer:
+		for _, obj := range slice {
+			if obj.R == nil {
+				obj.R = &clientDependencyR{}
+			}
+
+			for _, a := range args {
+				if a == obj.ClientID {
+					continue Outer
+				}
+			}
+
+			args = append(args, obj.ClientID)
+
+		}
+	}
+
+	if len(args) == 0 {
+		return nil
+	}
+
+	query := NewQuery(
+		qm.From(`client`),
+		qm.WhereIn(`client.id in ?`, args...),
+	)
+	if mods != nil {
+		mods.Apply(query)
+	}
+
+	results, err := query.QueryContext(ctx, e)
+	if err != nil {
+		return errors.Wrap(err, "failed to eager load Client")
+	}
+
+	var resultSlice []*Client
+	if err = queries.Bind(results, &resultSlice); err != nil {
+		return errors.Wrap(err, "failed to bind eager loaded slice Client")
+	}
+
+	if err = results.Close(); err != nil {
+		return errors.Wrap(err, "failed to close results of eager load for client")
+	}
+	if err = results.Err(); err != nil {
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for client")
+	}
+
+	if len(clientDependencyAfterSelectHooks) != 0 {
+		for _, obj := range resultSlice {
+			if err := obj.doAfterSelectHooks(ctx, e); err != nil {
+				return err
+			}
+		}
+	}
+
+	if len(resultSlice) == 0 {
+		return nil
+	}
+
+	if singular {
+		foreign := resultSlice[0]
+		object.R.Client = foreign
+		if foreign.R == nil {
+			foreign.R = &clientR{}
+		}
+		foreign.R.ClientDependencies = append(foreign.R.ClientDependencies, object)
+		return nil
+	}
+
+	for _, local := range slice {
+		for _, foreign := range resultSlice {
+			if local.ClientID == foreign.ID {
+				local.R.Client = foreign
+				if foreign.R == nil {
+					foreign.R = &clientR{}
+				}
+				foreign.R.ClientDependencies = append(foreign.R.ClientDependencies, local)
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
+// LoadDependencyServiceEndpoint allows an eager lookup of values, cached into the
+// loaded structs of the objects. This is for an N-1 relationship.
+func (clientDependencyL) LoadDependencyServiceEndpoint(ctx context.Context, e boil.ContextExecutor, singular bool, maybeClientDependency interface{}, mods queries.Applicator) error {
+	var slice []*ClientDependency
+	var object *ClientDependency
+
+	if singular {
+		object = maybeClientDependency.(*ClientDependency)
+	} else {
+		slice = *maybeClientDependency.(*[]*ClientDependency)
+	}
+
+	args := make([]interface{}, 0, 1)
+	if singular {
+		if object.R == nil {
+			object.R = &clientDependencyR{}
+		}
+		args = append(args, object.DependencyServiceEndpointID)
+
+	} else {
+	Outer:
+		for _, obj := range slice {
+			if obj.R == nil {
+				obj.R = &clientDependencyR{}
+			}
+
+			for _, a := range args {
+				if a == obj.DependencyServiceEndpointID {
+					continue Outer
+				}
+			}
+
+			args = append(args, obj.DependencyServiceEndpointID)
+
+		}
+	}
+
+	if len(args) == 0 {
+		return nil
+	}
+
+	query := NewQuery(
+		qm.From(`service_endpoint`),
+		qm.WhereIn(`service_endpoint.id in ?`, args...),
+	)
+	if mods != nil {
+		mods.Apply(query)
+	}
+
+	results, err := query.QueryContext(ctx, e)
+	if err != nil {
+		return errors.Wrap(err, "failed to eager load ServiceEndpoint")
+	}
+
+	var resultSlice []*ServiceEndpoint
+	if err = queries.Bind(results, &resultSlice); err != nil {
+		return errors.Wrap(err, "failed to bind eager loaded slice ServiceEndpoint")
+	}
+
+	if err = results.Close(); err != nil {
+		return errors.Wrap(err, "failed to close results of eager load for service_endpoint")
+	}
+	if err = results.Err(); err != nil {
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for service_endpoint")
+	}
+
+	if len(clientDependencyAfterSelectHooks) != 0 {
+		for _, obj := range resultSlice {
+			if err := obj.doAfterSelectHooks(ctx, e); err != nil {
+				return err
+			}
+		}
+	}
+
+	if len(resultSlice) == 0 {
+		return nil
+	}
+
+	if singular {
+		foreign := resultSlice[0]
+		object.R.DependencyServiceEndpoint = foreign
+		if foreign.R == nil {
+			foreign.R = &serviceEndpointR{}
+		}
+		foreign.R.DependencyServiceEndpointClientDependencies = append(foreign.R.DependencyServiceEndpointClientDependencies, object)
+		return nil
+	}
+
+	for _, local := range slice {
+		for _, foreign := range resultSlice {
+			if local.DependencyServiceEndpointID == foreign.ID {
+				local.R.DependencyServiceEndpoint = foreign
+				if foreign.R == nil {
+					foreign.R = &serviceEndpointR{}
+				}
+				foreign.R.DependencyServiceEndpointClientDependencies = append(foreign.R.DependencyServiceEndpointClientDependencies, local)
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
+// SetClient of the clientDependency to the related item.
+// Sets o.R.Client to related.
+// Adds o to related.R.ClientDependencies.
+func (o *ClientDependency) SetClient(ctx context.Context, exec boil.ContextExecutor, insert bool, related *Client) error {
+	var err error
+	if insert {
+		if err = related.Insert(ctx, exec, boil.Infer()); err != nil {
+			return errors.Wrap(err, "failed to insert into foreign table")
+		}
+	}
+
+	updateQuery := fmt.Sprintf(
+		"UPDATE \"client_dependency\" SET %s WHERE %s",
+		strmangle.SetParamNames("\"", "\"", 1, []string{"client_id"}),
+		strmangle.WhereClause("\"", "\"", 2, clientDependencyPrimaryKeyColumns),
+	)
+	values := []interface{}{related.ID, o.ID}
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, updateQuery)
+		fmt.Fprintln(writer, values)
+	}
+	if _, err = exec.ExecContext(ctx, updateQuery, values...); err != nil {
+		return errors.Wrap(err, "failed to update local table")
+	}
+
+	o.ClientID = related.ID
+	if o.R == nil {
+		o.R = &clientDependencyR{
+			Client: related,
+		}
+	} else {
+		o.R.Client = related
+	}
+
+	if related.R == nil {
+		related.R = &clientR{
+			ClientDependencies: ClientDependencySlice{o},
+		}
+	} else {
+		related.R.ClientDependencies = append(related.R.ClientDependencies, o)
+	}
+
+	return nil
+}
+
+// SetDependencyServiceEndpoint of the clientDependency to the related item.
+// Sets o.R.DependencyServiceEndpoint to related.
+// Adds o to related.R.DependencyServiceEndpointClientDependencies.
+func (o *ClientDependency) SetDependencyServiceEndpoint(ctx context.Context, exec boil.ContextExecutor, insert bool, related *ServiceEndpoint) error {
+	var err error
+	if insert {
+		if err = related.Insert(ctx, exec, boil.Infer()); err != nil {
+			return errors.Wrap(err, "failed to insert into foreign table")
+		}
+	}
+
+	updateQuery := fmt.Sprintf(
+		"UPDATE \"client_dependency\" SET %s WHERE %s",
+		strmangle.SetParamNames("\"", "\"", 1, []string{"dependency_service_endpoint_id"}),
+		strmangle.WhereClause("\"", "\"", 2, clientDependencyPrimaryKeyColumns),
+	)
+	values := []interface{}{related.ID, o.ID}
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, updateQuery)
+		fmt.Fprintln(writer, values)
+	}
+	if _, err = exec.ExecContext(ctx, updateQuery, values...); err != nil {
+		return errors.Wrap(err, "failed to update local table")
+	}
+
+	o.DependencyServiceEndpointID = related.ID
+	if o.R == nil {
+		o.R = &clientDependencyR{
+			DependencyServiceEndpoint: related,
+		}
+	} else {
+		o.R.DependencyServiceEndpoint = related
+	}
+
+	if related.R == nil {
+		related.R = &serviceEndpointR{
+			DependencyServiceEndpointClientDependencies: ClientDependencySlice{o},
+		}
+	} else {
+		related.R.DependencyServiceEndpointClientDependencies = append(related.R.DependencyServiceEndpointClientDependencies, o)
+	}
+
+	return nil
+}
+
+// ClientDependencies retrieves all the records using an executor.
+func ClientDependencies(mods ...qm.QueryMod) clientDependencyQuery {
+	mods = append(mods, qm.From("\"client_dependency\""))
+	return clientDependencyQuery{NewQuery(mods...)}
+}
+
+// FindClientDependency retrieves a single record by ID with an executor.
+// If selectCols is empty Find will return all columns.
+func FindClientDependency(ctx context.Context, exec boil.ContextExecutor, iD int64, selectCols ...string) (*ClientDependency, error) {
+	clientDependencyObj := &ClientDependency{}
+
+	sel := "*"
+	if len(selectCols) > 0 {
+		sel = strings.Join(strmangle.IdentQuoteSlice(dialect.LQ, dialect.RQ, selectCols), ",")
+	}
+	query := fmt.Sprintf(
+		"select %s from \"client_dependency\" where \"id\"=$1", sel,
+	)
+
+	q := queries.Raw(query, iD)
+
+	err := q.Bind(ctx, exec, clientDependencyObj)
+	if err != nil {
+		if errors.Cause(err) == sql.ErrNoRows {
+			return nil, sql.ErrNoRows
+		}
+		return nil, errors.Wrap(err, "models: unable to select from client_dependency")
+	}
+
+	return clientDependencyObj, nil
+}
+
+// Insert a single record using an executor.
+// See boil.Columns.InsertColumnSet documentation to understand column list inference for inserts.
+func (o *ClientDependency) Insert(ctx context.Context, exec boil.ContextExecutor, columns boil.Columns) error {
+	if o == nil {
+		return errors.New("models: no client_dependency provided for insertion")
+	}
+
+	var err error
+
+	if err := o.doBeforeInsertHooks(ctx, exec); err != nil {
+		return err
+	}
+
+	nzDefaults := queries.NonZeroDefaultSet(clientDependencyColumnsWithDefault, o)
+
+	key := makeCacheKey(columns, nzDefaults)
+	clientDependencyInsertCacheMut.RLock()
+	cache, cached := clientDependencyInsertCache[key]
+	clientDependencyInsertCacheMut.RUnlock()
+
+	if !cached {
+		wl, returnColumns := columns.InsertColumnSet(
+			clientDependencyAllColumns,
+			clientDependencyColumnsWithDefault,
+			clientDependencyColumnsWithoutDefault,
+			nzDefaults,
+		)
+
+		cache.valueMapping, err = queries.BindMapping(clientDependencyType, clientDependencyMapping, wl)
+		if err != nil {
+			return err
+		}
+		cache.retMapping, err = queries.BindMapping(clientDependencyType, clientDependencyMapping, returnColumns)
+		if err != nil {
+			return err
+		}
+		if len(wl) != 0 {
+			cache.query = fmt.Sprintf("INSERT INTO \"client_dependency\" (\"%s\") %%sVALUES (%s)%%s", strings.Join(wl, "\",\""), strmangle.Placeholders(dialect.UseIndexPlaceholders, len(wl), 1, 1))
+		} else {
+			cache.query = "INSERT INTO \"client_dependency\" %sDEFAULT VALUES%s"
+		}
+
+		var queryOutput, queryReturning string
+
+		if len(cache.retMapping) != 0 {
+			queryReturning = fmt.Sprintf(" RETURNING \"%s\"", strings.Join(returnColumns, "\",\""))
+		}
+
+		cache.query = fmt.Sprintf(cache.query, queryOutput, queryReturning)
+	}
+
+	value := reflect.Indirect(reflect.ValueOf(o))
+	vals := queries.ValuesFromMapping(value, cache.valueMapping)
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.query)
+		fmt.Fprintln(writer, vals)
+	}
+
+	if len(cache.retMapping) != 0 {
+		err = exec.QueryRowContext(ctx, cache.query, vals...).Scan(queries.PtrsFromMapping(value, cache.retMapping)...)
+	} else {
+		_, err = exec.ExecContext(ctx, cache.query, vals...)
+	}
+
+	if err != nil {
+		return errors.Wrap(err, "models: unable to insert into client_dependency")
+	}
+
+	if !cached {
+		clientDependencyInsertCacheMut.Lock()
+		clientDependencyInsertCache[key] = cache
+		clientDependencyInsertCacheMut.Unlock()
+	}
+
+	return o.doAfterInsertHooks(ctx, exec)
+}
+
+// Update uses an executor to update the ClientDependency.
+// See boil.Columns.UpdateColumnSet documentation to understand column list inference for updates.
+// Update does not automatically update the record in case of default values. Use .Reload() to refresh the records.
+func (o *ClientDependency) Update(ctx context.Context, exec boil.ContextExecutor, columns boil.Columns) (int64, error) {
+	var err error
+	if err = o.doBeforeUpdateHooks(ctx, exec); err != nil {
+		return 0, err
+	}
+	key := makeCacheKey(columns, nil)
+	clientDependencyUpdateCacheMut.RLock()
+	cache, cached := clientDependencyUpdateCache[key]
+	clientDependencyUpdateCacheMut.RUnlock()
+
+	if !cached {
+		wl := columns.UpdateColumnSet(
+			clientDependencyAllColumns,
+			clientDependencyPrimaryKeyColumns,
+		)
+
+		if !columns.IsWhitelist() {
+			wl = strmangle.SetComplement(wl, []string{"created_at"})
+		}
+		if len(wl) == 0 {
+			return 0, errors.New("models: unable to update client_dependency, could not build whitelist")
+		}
+
+		cache.query = fmt.Sprintf("UPDATE \"client_dependency\" SET %s WHERE %s",
+			strmangle.SetParamNames("\"", "\"", 1, wl),
+			strmangle.WhereClause("\"", "\"", len(wl)+1, clientDependencyPrimaryKeyColumns),
+		)
+		cache.valueMapping, err = queries.BindMapping(clientDependencyType, clientDependencyMapping, append(wl, clientDependencyPrimaryKeyColumns...))
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	values := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(o)), cache.valueMapping)
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.query)
+		fmt.Fprintln(writer, values)
+	}
+	var result sql.Result
+	result, err = exec.ExecContext(ctx, cache.query, values...)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to update client_dependency row")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to get rows affected by update for client_dependency")
+	}
+
+	if !cached {
+		clientDependencyUpdateCacheMut.Lock()
+		clientDependencyUpdateCache[key] = cache
+		clientDependencyUpdateCacheMut.Unlock()
+	}
+
+	return rowsAff, o.doAfterUpdateHooks(ctx, exec)
+}
+
+// UpdateAll updates all rows with the specified column values.
+func (q clientDependencyQuery) UpdateAll(ctx context.Context, exec boil.ContextExecutor, cols M) (int64, error) {
+	queries.SetUpdate(q.Query, cols)
+
+	result, err := q.Query.ExecContext(ctx, exec)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to update all for client_dependency")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to retrieve rows affected for client_dependency")
+	}
+
+	return rowsAff, nil
+}
+
+// UpdateAll updates all rows with the specified column values, using an executor.
+func (o ClientDependencySlice) UpdateAll(ctx context.Context, exec boil.ContextExecutor, cols M) (int64, error) {
+	ln := int64(len(o))
+	if ln == 0 {
+		return 0, nil
+	}
+
+	if len(cols) == 0 {
+		return 0, errors.New("models: update all requires at least one column argument")
+	}
+
+	colNames := make([]string, len(cols))
+	args := make([]interface{}, len(cols))
+
+	i := 0
+	for name, value := range cols {
+		colNames[i] = name
+		args[i] = value
+		i++
+	}
+
+	// Append all of the primary key values for each column
+	for _, obj := range o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), clientDependencyPrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := fmt.Sprintf("UPDATE \"client_dependency\" SET %s WHERE %s",
+		strmangle.SetParamNames("\"", "\"", 1, colNames),
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), len(colNames)+1, clientDependencyPrimaryKeyColumns, len(o)))
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, args...)
+	}
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to update all in clientDependency slice")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to retrieve rows affected all in update all clientDependency")
+	}
+	return rowsAff, nil
+}
+
+// Upsert attempts an insert using an executor, and does an update or ignore on conflict.
+// See boil.Columns documentation for how to properly use updateColumns and insertColumns.
+func (o *ClientDependency) Upsert(ctx context.Context, exec boil.ContextExecutor, updateOnConflict bool, conflictColumns []string, updateColumns, insertColumns boil.Columns) error {
+	if o == nil {
+		return errors.New("models: no client_dependency provided for upsert")
+	}
+
+	if err := o.doBeforeUpsertHooks(ctx, exec); err != nil {
+		return err
+	}
+
+	nzDefaults := queries.NonZeroDefaultSet(clientDependencyColumnsWithDefault, o)
+
+	// Build cache key in-line uglily - mysql vs psql problems
+	buf := strmangle.GetBuffer()
+	if updateOnConflict {
+		buf.WriteByte('t')
+	} else {
+		buf.WriteByte('f')
+	}
+	buf.WriteByte('.')
+	for _, c := range conflictColumns {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	buf.WriteString(strconv.Itoa(updateColumns.Kind))
+	for _, c := range updateColumns.Cols {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	buf.WriteString(strconv.Itoa(insertColumns.Kind))
+	for _, c := range insertColumns.Cols {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	for _, c := range nzDefaults {
+		buf.WriteString(c)
+	}
+	key := buf.String()
+	strmangle.PutBuffer(buf)
+
+	clientDependencyUpsertCacheMut.RLock()
+	cache, cached := clientDependencyUpsertCache[key]
+	clientDependencyUpsertCacheMut.RUnlock()
+
+	var err error
+
+	if !cached {
+		insert, ret := insertColumns.InsertColumnSet(
+			clientDependencyAllColumns,
+			clientDependencyColumnsWithDefault,
+			clientDependencyColumnsWithoutDefault,
+			nzDefaults,
+		)
+		update := updateColumns.UpdateColumnSet(
+			clientDependencyAllColumns,
+			clientDependencyPrimaryKeyColumns,
+		)
+
+		if updateOnConflict && len(update) == 0 {
+			return errors.New("models: unable to upsert client_dependency, could not build update column list")
+		}
+
+		conflict := conflictColumns
+		if len(conflict) == 0 {
+			conflict = make([]string, len(clientDependencyPrimaryKeyColumns))
+			copy(conflict, clientDependencyPrimaryKeyColumns)
+		}
+		cache.query = buildUpsertQueryPostgres(dialect, "\"client_dependency\"", updateOnConflict, ret, update, conflict, insert)
+
+		cache.valueMapping, err = queries.BindMapping(clientDependencyType, clientDependencyMapping, insert)
+		if err != nil {
+			return err
+		}
+		if len(ret) != 0 {
+			cache.retMapping, err = queries.BindMapping(clientDependencyType, clientDependencyMapping, ret)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	value := reflect.Indirect(reflect.ValueOf(o))
+	vals := queries.ValuesFromMapping(value, cache.valueMapping)
+	var returns []interface{}
+	if len(cache.retMapping) != 0 {
+		returns = queries.PtrsFromMapping(value, cache.retMapping)
+	}
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.query)
+		fmt.Fprintln(writer, vals)
+	}
+	if len(cache.retMapping) != 0 {
+		err = exec.QueryRowContext(ctx, cache.query, vals...).Scan(returns...)
+		if err == sql.ErrNoRows {
+			err = nil // Postgres doesn't return anything when there's no update
+		}
+	} else {
+		_, err = exec.ExecContext(ctx, cache.query, vals...)
+	}
+	if err != nil {
+		return errors.Wrap(err, "models: unable to upsert client_dependency")
+	}
+
+	if !cached {
+		clientDependencyUpsertCacheMut.Lock()
+		clientDependencyUpsertCache[key] = cache
+		clientDependencyUpsertCacheMut.Unlock()
+	}
+
+	return o.doAfterUpsertHooks(ctx, exec)
+}
+
+// Delete deletes a single ClientDependency record with an executor.
+// Delete will match against the primary key column to find the record to delete.
+func (o *ClientDependency) Delete(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if o == nil {
+		return 0, errors.New("models: no ClientDependency provided for delete")
+	}
+
+	if err := o.doBeforeDeleteHooks(ctx, exec); err != nil {
+		return 0, err
+	}
+
+	args := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(o)), clientDependencyPrimaryKeyMapping)
+	sql := "DELETE FROM \"client_dependency\" WHERE \"id\"=$1"
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, args...)
+	}
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to delete from client_dependency")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to get rows affected by delete for client_dependency")
+	}
+
+	if err := o.doAfterDeleteHooks(ctx, exec); err != nil {
+		return 0, err
+	}
+
+	return rowsAff, nil
+}
+
+// DeleteAll deletes all matching rows.
+func (q clientDependencyQuery) DeleteAll(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if q.Query == nil {
+		return 0, errors.New("models: no clientDependencyQuery provided for delete all")
+	}
+
+	queries.SetDelete(q.Query)
+
+	result, err := q.Query.ExecContext(ctx, exec)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to delete all from client_dependency")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to get rows affected by deleteall for client_dependency")
+	}
+
+	return rowsAff, nil
+}
+
+// DeleteAll deletes all rows in the slice, using an executor.
+func (o ClientDependencySlice) DeleteAll(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if len(o) == 0 {
+		return 0, nil
+	}
+
+	if len(clientDependencyBeforeDeleteHooks) != 0 {
+		for _, obj := range o {
+			if err := obj.doBeforeDeleteHooks(ctx, exec); err != nil {
+				return 0, err
+			}
+		}
+	}
+
+	var args []interface{}
+	for _, obj := range o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), clientDependencyPrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := "DELETE FROM \"client_dependency\" WHERE " +
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), 1, clientDependencyPrimaryKeyColumns, len(o))
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, args)
+	}
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to delete all from clientDependency slice")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to get rows affected by deleteall for client_dependency")
+	}
+
+	if len(clientDependencyAfterDeleteHooks) != 0 {
+		for _, obj := range o {
+			if err := obj.doAfterDeleteHooks(ctx, exec); err != nil {
+				return 0, err
+			}
+		}
+	}
+
+	return rowsAff, nil
+}
+
+// Reload refetches the object from the database
+// using the primary keys with an executor.
+func (o *ClientDependency) Reload(ctx context.Context, exec boil.ContextExecutor) error {
+	ret, err := FindClientDependency(ctx, exec, o.ID)
+	if err != nil {
+		return err
+	}
+
+	*o = *ret
+	return nil
+}
+
+// ReloadAll refetches every row with matching primary key column values
+// and overwrites the original object slice with the newly updated slice.
+func (o *ClientDependencySlice) ReloadAll(ctx context.Context, exec boil.ContextExecutor) error {
+	if o == nil || len(*o) == 0 {
+		return nil
+	}
+
+	slice := ClientDependencySlice{}
+	var args []interface{}
+	for _, obj := range *o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), clientDependencyPrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := "SELECT \"client_dependency\".* FROM \"client_dependency\" WHERE " +
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), 1, clientDependencyPrimaryKeyColumns, len(*o))
+
+	q := queries.Raw(sql, args...)
+
+	err := q.Bind(ctx, exec, &slice)
+	if err != nil {
+		return errors.Wrap(err, "models: unable to reload all in ClientDependencySlice")
+	}
+
+	*o = slice
+
+	return nil
+}
+
+// ClientDependencyExists checks if the ClientDependency row exists.
+func ClientDependencyExists(ctx context.Context, exec boil.ContextExecutor, iD int64) (bool, error) {
+	var exists bool
+	sql := "select exists(select 1 from \"client_dependency\" where \"id\"=$1 limit 1)"
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, iD)
+	}
+	row := exec.QueryRowContext(ctx, sql, iD)
+
+	err := row.Scan(&exists)
+	if err != nil {
+		return false, errors.Wrap(err, "models: unable to check if client_dependency exists")
+	}
+
+	return exists, nil
+}
@@ -14,6 +14,7 @@ import (
 	"time"
 
 	"github.com/friendsofgo/errors"
+	"github.com/volatiletech/null/v8"
 	"github.com/volatiletech/sqlboiler/v4/boil"
 	"github.com/volatiletech/sqlboiler/v4/queries"
 	"github.com/volatiletech/sqlboiler/v4/queries/qm"
@@ -23,92 +24,159 @@ import (
 
 // Service is an object representing the database table.
 type Service struct {
-	ID   int64  `boil:"id" json:"id" toml:"id" yaml:"id"`
-	Code string `boil:"code" json:"code" toml:"code" yaml:"code"`
-	Name string `boil:"name" json:"name" toml:"name" yaml:"name"`
+	ID            int64       `boil:"id" json:"id" toml:"id" yaml:"id"`
+	Code          string      `boil:"code" json:"code" toml:"code" yaml:"code"`
+	Name          string      `boil:"name" json:"name" toml:"name" yaml:"name"`
+	Confirmed     bool        `boil:"confirmed" json:"confirmed" toml:"confirmed" yaml:"confirmed"`
+	Team          null.String `boil:"team" json:"team,omitempty" toml:"team" yaml:"team,omitempty"`
+	OnCall        null.String `boil:"on_call" json:"on_call,omitempty" toml:"on_call" yaml:"on_call,omitempty"`
+	SlackChannel  null.String `boil:"slack_channel" json:"slack_channel,omitempty" toml:"slack_channel" yaml:"slack_channel,omitempty"`
+	Email         null.String `boil:"email" json:"email,omitempty" toml:"email" yaml:"email,omitempty"`
+	RepositoryURL null.String `boil:"repository_url" json:"repository_url,omitempty" toml:"repository_url" yaml:"repository_url,omitempty"`
+	Tier          null.Int    `boil:"tier" json:"tier,omitempty" toml:"tier" yaml:"tier,omitempty"`
+	Lifecycle     string      `boil:"lifecycle" json:"lifecycle" toml:"lifecycle" yaml:"lifecycle"`
+	SunsetDate    null.Time   `boil:"sunset_date" json:"sunset_date,omitempty" toml:"sunset_date" yaml:"sunset_date,omitempty"`
+	Environment   string      `boil:"environment" json:"environment" toml:"environment" yaml:"environment"`
+	Version       null.String `boil:"version" json:"version,omitempty" toml:"version" yaml:"version,omitempty"`
 
 	R *serviceR `boil:"-" json:"-" toml:"-" yaml:"-"`
 	L serviceL  `boil:"-" json:"-" toml:"-" yaml:"-"`
 }
 
 var ServiceColumns = struct {
-	ID   string
-	Code string
-	Name string
+	ID            string
+	Code          string
+	Name          string
+	Confirmed     string
+	Team          string
+	OnCall        string
+	SlackChannel  string
+	Email         string
+	RepositoryURL string
+	Tier          string
+	Lifecycle     string
+	SunsetDate    string
+	Environment   string
+	Version       string
 }{
-	ID:   "id",
-	Code: "code",
-	Name: "name",
+	ID:            "id",
+	Code:          "code",
+	Name:          "name",
+	Confirmed:     "confirmed",
+	Team:          "team",
+	OnCall:        "on_call",
+	SlackChannel:  "slack_channel",
+	Email:         "email",
+	RepositoryURL: "repository_url",
+	Tier:          "tier",
+	Lifecycle:     "lifecycle",
+	SunsetDate:    "sunset_date",
+	Environment:   "environment",
+	Version:       "version",
 }
 
 // Generated where
 
-type whereHelperint64 struct{ field string }
+type whereHelperbool struct{ field string }
 
-func (w whereHelperint64) EQ(x int64) qm.QueryMod  { return qmhelper.Where(w.field, qmhelper.EQ, x) }
-func (w whereHelperint64) NEQ(x int64) qm.QueryMod { return qmhelper.Where(w.field, qmhelper.NEQ, x) }
-func (w whereHelperint64) LT(x int64) qm.QueryMod  { return qmhelper.Where(w.field, qmhelper.LT, x) }
-func (w whereHelperint64) LTE(x int64) qm.QueryMod { return qmhelper.Where(w.field, qmhelper.LTE, x) }
-func (w whereHelperint64) GT(x int64) qm.QueryMod  { return qmhelper.Where(w.field, qmhelper.GT, x) }
-func (w whereHelperint64) GTE(x int64) qm.QueryMod { return qmhelper.Where(w.field, qmhelper.GTE, x) }
-func (w whereHelperint64) IN(slice []int64) qm.QueryMod {
-	values := make([]interface{}, 0, len(slice))
-	for _, value := range slice {
-		values = append(values, value)
-	}
-	return qm.WhereIn(fmt.Sprintf("%s IN ?", w.field), values...)
+func (w whereHelperbool) EQ(x bool) qm.QueryMod  { return qmhelper.Where(w.field, qmhelper.EQ, x) }
+func (w whereHelperbool) NEQ(x bool) qm.QueryMod { return qmhelper.Where(w.field, qmhelper.NEQ, x) }
+func (w whereHelperbool) LT(x bool) qm.QueryMod  { return qmhelper.Where(w.field, qmhelper.LT, x) }
+func (w whereHelperbool) LTE(x bool) qm.QueryMod { return qmhelper.Where(w.field, qmhelper.LTE, x) }
+func (w whereHelperbool) GT(x bool) qm.QueryMod  { return qmhelper.Where(w.field, qmhelper.GT, x) }
+func (w whereHelperbool) GTE(x bool) qm.QueryMod { return qmhelper.Where(w.field, qmhelper.GTE, x) }
+
+type whereHelpernull_Int struct{ field string }
+
+func (w whereHelpernull_Int) EQ(x null.Int) qm.QueryMod {
+	return qmhelper.WhereNullEQ(w.field, false, x)
 }
-func (w whereHelperint64) NIN(slice []int64) qm.QueryMod {
-	values := make([]interface{}, 0, len(slice))
-	for _, value := range slice {
-		values = append(values, value)
-	}
-	return qm.WhereNotIn(fmt.Sprintf("%s NOT IN ?", w.field), values...)
+func (w whereHelpernull_Int) NEQ(x null.Int) qm.QueryMod {
+	return qmhelper.WhereNullEQ(w.field, true, x)
+}
+func (w whereHelpernull_Int) IsNull() qm.QueryMod    { return qmhelper.WhereIsNull(w.field) }
+func (w whereHelpernull_Int) IsNotNull() qm.QueryMod { return qmhelper.WhereIsNotNull(w.field) }
+func (w whereHelpernull_Int) LT(x null.Int) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.LT, x)
+}
+func (w whereHelpernull_Int) LTE(x null.Int) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.LTE, x)
+}
+func (w whereHelpernull_Int) GT(x null.Int) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.GT, x)
+}
+func (w whereHelpernull_Int) GTE(x null.Int) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.GTE, x)
 }
 
-type whereHelperstring struct{ field string }
+type whereHelpernull_Time struct{ field string }
 
-func (w whereHelperstring) EQ(x string) qm.QueryMod  { return qmhelper.Where(w.field, qmhelper.EQ, x) }
-func (w whereHelperstring) NEQ(x string) qm.QueryMod { return qmhelper.Where(w.field, qmhelper.NEQ, x) }
-func (w whereHelperstring) LT(x string) qm.QueryMod  { return qmhelper.Where(w.field, qmhelper.LT, x) }
-func (w whereHelperstring) LTE(x string) qm.QueryMod { return qmhelper.Where(w.field, qmhelper.LTE, x) }
-func (w whereHelperstring) GT(x string) qm.QueryMod  { return qmhelper.Where(w.field, qmhelper.GT, x) }
-func (w whereHelperstring) GTE(x string) qm.QueryMod { return qmhelper.Where(w.field, qmhelper.GTE, x) }
-func (w whereHelperstring) IN(slice []string) qm.QueryMod {
-	values := make([]interface{}, 0, len(slice))
-	for _, value := range slice {
-		values = append(values, value)
-	}
-	return qm.WhereIn(fmt.Sprintf("%s IN ?", w.field), values...)
+func (w whereHelpernull_Time) EQ(x null.Time) qm.QueryMod {
+	return qmhelper.WhereNullEQ(w.field, false, x)
 }
-func (w whereHelperstring) NIN(slice []string) qm.QueryMod {
-	values := make([]interface{}, 0, len(slice))
-	for _, value := range slice {
-		values = append(values, value)
-	}
-	return qm.WhereNotIn(fmt.Sprintf("%s NOT IN ?", w.field), values...)
+func (w whereHelpernull_Time) NEQ(x null.Time) qm.QueryMod {
+	return qmhelper.WhereNullEQ(w.field, true, x)
+}
+func (w whereHelpernull_Time) IsNull() qm.QueryMod    { return qmhelper.WhereIsNull(w.field) }
+func (w whereHelpernull_Time) IsNotNull() qm.QueryMod { return qmhelper.WhereIsNotNull(w.field) }
+func (w whereHelpernull_Time) LT(x null.Time) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.LT, x)
+}
+func (w whereHelpernull_Time) LTE(x null.Time) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.LTE, x)
+}
+func (w whereHelpernull_Time) GT(x null.Time) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.GT, x)
+}
+func (w whereHelpernull_Time) GTE(x null.Time) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.GTE, x)
 }
 
 var ServiceWhere = struct {
-	ID   whereHelperint64
-	Code whereHelperstring
-	Name whereHelperstring
+	ID            whereHelperint64
+	Code          whereHelperstring
+	Name          whereHelperstring
+	Confirmed     whereHelperbool
+	Team          whereHelpernull_String
+	OnCall        whereHelpernull_String
+	SlackChannel  whereHelpernull_String
+	Email         whereHelpernull_String
+	RepositoryURL whereHelpernull_String
+	Tier          whereHelpernull_Int
+	Lifecycle     whereHelperstring
+	SunsetDate    whereHelpernull_Time
+	Environment   whereHelperstring
+	Version       whereHelpernull_String
 }{
-	ID:   whereHelperint64{field: "\"service\".\"id\""},
-	Code: whereHelperstring{field: "\"service\".\"code\""},
-	Name: whereHelperstring{field: "\"service\".\"name\""},
+	ID:            whereHelperint64{field: "\"service\".\"id\""},
+	Code:          whereHelperstring{field: "\"service\".\"code\""},
+	Name:          whereHelperstring{field: "\"service\".\"name\""},
+	Confirmed:     whereHelperbool{field: "\"service\".\"confirmed\""},
+	Team:          whereHelpernull_String{field: "\"service\".\"team\""},
+	OnCall:        whereHelpernull_String{field: "\"service\".\"on_call\""},
+	SlackChannel:  whereHelpernull_String{field: "\"service\".\"slack_channel\""},
+	Email:         whereHelpernull_String{field: "\"service\".\"email\""},
+	RepositoryURL: whereHelpernull_String{field: "\"service\".\"repository_url\""},
+	Tier:          whereHelpernull_Int{field: "\"service\".\"tier\""},
+	Lifecycle:     whereHelperstring{field: "\"service\".\"lifecycle\""},
+	SunsetDate:    whereHelpernull_Time{field: "\"service\".\"sunset_date\""},
+	Environment:   whereHelperstring{field: "\"service\".\"environment\""},
+	Version:       whereHelpernull_String{field: "\"service\".\"version\""},
 }
 
 // ServiceRels is where relationship names are stored.
 var ServiceRels = struct {
 	ServiceEndpoints string
+	ServiceLabels    string
 }{
 	ServiceEndpoints: "ServiceEndpoints",
+	ServiceLabels:    "ServiceLabels",
 }
 
 // serviceR is where relationships are stored.
 type serviceR struct {
 	ServiceEndpoints ServiceEndpointSlice `boil:"ServiceEndpoints" json:"ServiceEndpoints" toml:"ServiceEndpoints" yaml:"ServiceEndpoints"`
+	ServiceLabels    ServiceLabelSlice    `boil:"ServiceLabels" json:"ServiceLabels" toml:"ServiceLabels" yaml:"ServiceLabels"`
 }
 
 // NewStruct creates a new relationship struct
@@ -120,9 +188,9 @@ func (*serviceR) NewStruct() *serviceR {
 type serviceL struct{}
 
 var (
-	serviceAllColumns            = []string{"id", "code", "name"}
-	serviceColumnsWithoutDefault = []string{"code", "name"}
-	serviceColumnsWithDefault    = []string{"id"}
+	serviceAllColumns            = []string{"id", "code", "name", "confirmed", "team", "on_call", "slack_channel", "email", "repository_url", "tier", "lifecycle", "sunset_date", "environment", "version"}
+	serviceColumnsWithoutDefault = []string{"code", "name", "team", "on_call", "slack_channel", "email", "repository_url", "tier", "sunset_date", "version"}
+	serviceColumnsWithDefault    = []string{"id", "confirmed", "lifecycle", "environment"}
 	servicePrimaryKeyColumns     = []string{"id"}
 )
 
@@ -422,6 +490,27 @@ func (o *Service) ServiceEndpoints(mods ...qm.QueryMod) serviceEndpointQuery {
 	return query
 }
 
+// ServiceLabels retrieves all the service_label's ServiceLabels with an executor.
+func (o *Service) ServiceLabels(mods ...qm.QueryMod) serviceLabelQuery {
+	var queryMods []qm.QueryMod
+	if len(mods) != 0 {
+		queryMods = append(queryMods, mods...)
+	}
+
+	queryMods = append(queryMods,
+		qm.Where("\"service_label\".\"service_id\"=?", o.ID),
+	)
+
+	query := ServiceLabels(queryMods...)
+	queries.SetFrom(query.Query, "\"service_label\"")
+
+	if len(queries.GetSelect(query.Query)) == 0 {
+		queries.SetSelect(query.Query, []string{"\"service_label\".*"})
+	}
+
+	return query
+}
+
 // LoadServiceEndpoints allows an eager lookup of values, cached into the
 // loaded structs of the objects. This is for a 1-M or N-M relationship.
 func (serviceL) LoadServiceEndpoints(ctx context.Context, e boil.ContextExecutor, singular bool, maybeService interface{}, mods queries.Applicator) error {
@@ -520,6 +609,104 @@ func (serviceL) LoadServiceEndpoints(ctx context.Context, e boil.ContextExecutor
 	return nil
 }
 
+// LoadServiceLabels allows an eager lookup of values, cached into the
+// loaded structs of the objects. This is for a 1-M or N-M relationship.
+func (serviceL) LoadServiceLabels(ctx context.Context, e boil.ContextExecutor, singular bool, maybeService interface{}, mods queries.Applicator) error {
+	var slice []*Service
+	var object *Service
+
+	if singular {
+		object = maybeService.(*Service)
+	} else {
+		slice = *maybeService.(*[]*Service)
+	}
+
+	args := make([]interface{}, 0, 1)
+	if singular {
+		if object.R == nil {
+			object.R = &serviceR{}
+		}
+		args = append(args, object.ID)
+	} else {
+	Outer:
+		for _, obj := range slice {
+			if obj.R == nil {
+				obj.R = &serviceR{}
+			}
+
+			for _, a := range args {
+				if a == obj.ID {
+					continue Outer
+				}
+			}
+
+			args = append(args, obj.ID)
+		}
+	}
+
+	if len(args) == 0 {
+		return nil
+	}
+
+	query := NewQuery(
+		qm.From(`service_label`),
+		qm.WhereIn(`service_label.service_id in ?`, args...),
+	)
+	if mods != nil {
+		mods.Apply(query)
+	}
+
+	results, err := query.QueryContext(ctx, e)
+	if err != nil {
+		return errors.Wrap(err, "failed to eager load service_label")
+	}
+
+	var resultSlice []*ServiceLabel
+	if err = queries.Bind(results, &resultSlice); err != nil {
+		return errors.Wrap(err, "failed to bind eager loaded slice service_label")
+	}
+
+	if err = results.Close(); err != nil {
+		return errors.Wrap(err, "failed to close results in eager load on service_label")
+	}
+	if err = results.Err(); err != nil {
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for service_label")
+	}
+
+	if len(serviceLabelAfterSelectHooks) != 0 {
+		for _, obj := range resultSlice {
+			if err := obj.doAfterSelectHooks(ctx, e); err != nil {
+				return err
+			}
+		}
+	}
+	if singular {
+		object.R.ServiceLabels = resultSlice
+		for _, foreign := range resultSlice {
+			if foreign.R == nil {
+				foreign.R = &serviceLabelR{}
+			}
+			foreign.R.Service = object
+		}
+		return nil
+	}
+
+	for _, foreign := range resultSlice {
+		for _, local := range slice {
+			if local.ID == foreign.ServiceID {
+				local.R.ServiceLabels = append(local.R.ServiceLabels, foreign)
+				if foreign.R == nil {
+					foreign.R = &serviceLabelR{}
+				}
+				foreign.R.Service = local
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
 // AddServiceEndpoints adds the given related objects to the existing relationships
 // of the service, optionally inserting them as new records.
 // Appends related to o.R.ServiceEndpoints.
@@ -573,6 +760,59 @@ func (o *Service) AddServiceEndpoints(ctx context.Context, exec boil.ContextExec
 	return nil
 }
 
+// AddServiceLabels adds the given related objects to the existing relationships
+// of the service, optionally inserting them as new records.
+// Appends related to o.R.ServiceLabels.
+// Sets related.R.Service appropriately.
+func (o *Service) AddServiceLabels(ctx context.Context, exec boil.ContextExecutor, insert bool, related ...*ServiceLabel) error {
+	var err error
+	for _, rel := range related {
+		if insert {
+			rel.ServiceID = o.ID
+			if err = rel.Insert(ctx, exec, boil.Infer()); err != nil {
+				return errors.Wrap(err, "failed to insert into foreign table")
+			}
+		} else {
+			updateQuery := fmt.Sprintf(
+				"UPDATE \"service_label\" SET %s WHERE %s",
+				strmangle.SetParamNames("\"", "\"", 1, []string{"service_id"}),
+				strmangle.WhereClause("\"", "\"", 2, serviceLabelPrimaryKeyColumns),
+			)
+			values := []interface{}{o.ID, rel.ID}
+
+			if boil.IsDebug(ctx) {
+				writer := boil.DebugWriterFrom(ctx)
+				fmt.Fprintln(writer, updateQuery)
+				fmt.Fprintln(writer, values)
+			}
+			if _, err = exec.ExecContext(ctx, updateQuery, values...); err != nil {
+				return errors.Wrap(err, "failed to update foreign table")
+			}
+
+			rel.ServiceID = o.ID
+		}
+	}
+
+	if o.R == nil {
+		o.R = &serviceR{
+			ServiceLabels: related,
+		}
+	} else {
+		o.R.ServiceLabels = append(o.R.ServiceLabels, related...)
+	}
+
+	for _, rel := range related {
+		if rel.R == nil {
+			rel.R = &serviceLabelR{
+				Service: o,
+			}
+		} else {
+			rel.R.Service = o
+		}
+	}
+	return nil
+}
+
 // Services retrieves all the records using an executor.
 func Services(mods ...qm.QueryMod) serviceQuery {
 	mods = append(mods, qm.From("\"service\""))
@@ -14,6 +14,7 @@ import (
 	"time"
 
 	"github.com/friendsofgo/errors"
+	"github.com/volatiletech/null/v8"
 	"github.com/volatiletech/sqlboiler/v4/boil"
 	"github.com/volatiletech/sqlboiler/v4/queries"
 	"github.com/volatiletech/sqlboiler/v4/queries/qm"
@@ -23,57 +24,94 @@ import (
 
 // ServiceEndpoint is an object representing the database table.
 type ServiceEndpoint struct {
-	ID        int64  `boil:"id" json:"id" toml:"id" yaml:"id"`
-	ServiceID int64  `boil:"service_id" json:"service_id" toml:"service_id" yaml:"service_id"`
-	Code      string `boil:"code" json:"code" toml:"code" yaml:"code"`
-	Name      string `boil:"name" json:"name" toml:"name" yaml:"name"`
+	ID         int64       `boil:"id" json:"id" toml:"id" yaml:"id"`
+	ServiceID  int64       `boil:"service_id" json:"service_id" toml:"service_id" yaml:"service_id"`
+	Code       string      `boil:"code" json:"code" toml:"code" yaml:"code"`
+	Name       string      `boil:"name" json:"name" toml:"name" yaml:"name"`
+	Confirmed  bool        `boil:"confirmed" json:"confirmed" toml:"confirmed" yaml:"confirmed"`
+	Tier       null.Int    `boil:"tier" json:"tier,omitempty" toml:"tier" yaml:"tier,omitempty"`
+	Lifecycle  string      `boil:"lifecycle" json:"lifecycle" toml:"lifecycle" yaml:"lifecycle"`
+	SunsetDate null.Time   `boil:"sunset_date" json:"sunset_date,omitempty" toml:"sunset_date" yaml:"sunset_date,omitempty"`
+	Version    null.String `boil:"version" json:"version,omitempty" toml:"version" yaml:"version,omitempty"`
 
 	R *serviceEndpointR `boil:"-" json:"-" toml:"-" yaml:"-"`
 	L serviceEndpointL  `boil:"-" json:"-" toml:"-" yaml:"-"`
 }
 
 var ServiceEndpointColumns = struct {
-	ID        string
-	ServiceID string
-	Code      string
-	Name      string
+	ID         string
+	ServiceID  string
+	Code       string
+	Name       string
+	Confirmed  string
+	Tier       string
+	Lifecycle  string
+	SunsetDate string
+	Version    string
 }{
-	ID:        "id",
-	ServiceID: "service_id",
-	Code:      "code",
-	Name:      "name",
+	ID:         "id",
+	ServiceID:  "service_id",
+	Code:       "code",
+	Name:       "name",
+	Confirmed:  "confirmed",
+	Tier:       "tier",
+	Lifecycle:  "lifecycle",
+	SunsetDate: "sunset_date",
+	Version:    "version",
 }
 
 // Generated where
 
 var ServiceEndpointWhere = struct {
-	ID        whereHelperint64
-	ServiceID whereHelperint64
-	Code      whereHelperstring
-	Name      whereHelperstring
+	ID         whereHelperint64
+	ServiceID  whereHelperint64
+	Code       whereHelperstring
+	Name       whereHelperstring
+	Confirmed  whereHelperbool
+	Tier       whereHelpernull_Int
+	Lifecycle  whereHelperstring
+	SunsetDate whereHelpernull_Time
+	Version    whereHelpernull_String
 }{
-	ID:        whereHelperint64{field: "\"service_endpoint\".\"id\""},
-	ServiceID: whereHelperint64{field: "\"service_endpoint\".\"service_id\""},
-	Code:      whereHelperstring{field: "\"service_endpoint\".\"code\""},
-	Name:      whereHelperstring{field: "\"service_endpoint\".\"name\""},
+	ID:         whereHelperint64{field: "\"service_endpoint\".\"id\""},
+	ServiceID:  whereHelperint64{field: "\"service_endpoint\".\"service_id\""},
+	Code:       whereHelperstring{field: "\"service_endpoint\".\"code\""},
+	Name:       whereHelperstring{field: "\"service_endpoint\".\"name\""},
+	Confirmed:  whereHelperbool{field: "\"service_endpoint\".\"confirmed\""},
+	Tier:       whereHelpernull_Int{field: "\"service_endpoint\".\"tier\""},
+	Lifecycle:  whereHelperstring{field: "\"service_endpoint\".\"lifecycle\""},
+	SunsetDate: whereHelpernull_Time{field: "\"service_endpoint\".\"sunset_date\""},
+	Version:    whereHelpernull_String{field: "\"service_endpoint\".\"version\""},
 }
 
 // ServiceEndpointRels is where relationship names are stored.
 var ServiceEndpointRels = struct {
 	Service                                              string
+	DependencyServiceEndpointClientDependencies          string
 	DependencyServiceEndpointServiceEndpointDependencies string
 	ServiceEndpointDependencies                          string
+	ServiceEndpointLabels                                string
+	TopicConsumers                                       string
+	TopicProducers                                       string
 }{
 	Service: "Service",
+	DependencyServiceEndpointClientDependencies:          "DependencyServiceEndpointClientDependencies",
 	DependencyServiceEndpointServiceEndpointDependencies: "DependencyServiceEndpointServiceEndpointDependencies",
 	ServiceEndpointDependencies:                          "ServiceEndpointDependencies",
+	ServiceEndpointLabels:                                "ServiceEndpointLabels",
+	TopicConsumers:                                       "TopicConsumers",
+	TopicProducers:                                       "TopicProducers",
 }
 
 // serviceEndpointR is where relationships are stored.
 type serviceEndpointR struct {
 	Service                                              *Service                       `boil:"Service" json:"Service" toml:"Service" yaml:"Service"`
+	DependencyServiceEndpointClientDependencies          ClientDependencySlice          `boil:"DependencyServiceEndpointClientDependencies" json:"DependencyServiceEndpointClientDependencies" toml:"DependencyServiceEndpointClientDependencies" yaml:"DependencyServiceEndpointClientDependencies"`
 	DependencyServiceEndpointServiceEndpointDependencies ServiceEndpointDependencySlice `boil:"DependencyServiceEndpointServiceEndpointDependencies" json:"DependencyServiceEndpointServiceEndpointDependencies" toml:"DependencyServiceEndpointServiceEndpointDependencies" yaml:"DependencyServiceEndpointServiceEndpointDependencies"`
 	ServiceEndpointDependencies                          ServiceEndpointDependencySlice `boil:"ServiceEndpointDependencies" json:"ServiceEndpointDependencies" toml:"ServiceEndpointDependencies" yaml:"ServiceEndpointDependencies"`
+	ServiceEndpointLabels                                ServiceEndpointLabelSlice      `boil:"ServiceEndpointLabels" json:"ServiceEndpointLabels" toml:"ServiceEndpointLabels" yaml:"ServiceEndpointLabels"`
+	TopicConsumers                                       TopicConsumerSlice             `boil:"TopicConsumers" json:"TopicConsumers" toml:"TopicConsumers" yaml:"TopicConsumers"`
+	TopicProducers                                       TopicProducerSlice             `boil:"TopicProducers" json:"TopicProducers" toml:"TopicProducers" yaml:"TopicProducers"`
 }
 
 // NewStruct creates a new relationship struct
@@ -85,9 +123,9 @@ func (*serviceEndpointR) NewStruct() *serviceEndpointR {
 type serviceEndpointL struct{}
 
 var (
-	serviceEndpointAllColumns            = []string{"id", "service_id", "code", "name"}
-	serviceEndpointColumnsWithoutDefault = []string{"service_id", "code", "name"}
-	serviceEndpointColumnsWithDefault    = []string{"id"}
+	serviceEndpointAllColumns            = []string{"id", "service_id", "code", "name", "confirmed", "tier", "lifecycle", "sunset_date", "version"}
+	serviceEndpointColumnsWithoutDefault = []string{"service_id", "code", "name", "tier", "sunset_date", "version"}
+	serviceEndpointColumnsWithDefault    = []string{"id", "confirmed", "lifecycle"}
 	serviceEndpointPrimaryKeyColumns     = []string{"id"}
 )
 
@@ -380,6 +418,27 @@ func (o *ServiceEndpoint) Service(mods ...qm.QueryMod) serviceQuery {
 	return query
 }
 
+// DependencyServiceEndpointClientDependencies retrieves all the client_dependency's ClientDependencies with an executor via dependency_service_endpoint_id column.
+func (o *ServiceEndpoint) DependencyServiceEndpointClientDependencies(mods ...qm.QueryMod) clientDependencyQuery {
+	var queryMods []qm.QueryMod
+	if len(mods) != 0 {
+		queryMods = append(queryMods, mods...)
+	}
+
+	queryMods = append(queryMods,
+		qm.Where("\"client_dependency\".\"dependency_service_endpoint_id\"=?", o.ID),
+	)
+
+	query := ClientDependencies(queryMods...)
+	queries.SetFrom(query.Query, "\"client_dependency\"")
+
+	if len(queries.GetSelect(query.Query)) == 0 {
+		queries.SetSelect(query.Query, []string{"\"client_dependency\".*"})
+	}
+
+	return query
+}
+
 // DependencyServiceEndpointServiceEndpointDependencies retrieves all the service_endpoint_dependency's ServiceEndpointDependencies with an executor via dependency_service_endpoint_id column.
 func (o *ServiceEndpoint) DependencyServiceEndpointServiceEndpointDependencies(mods ...qm.QueryMod) serviceEndpointDependencyQuery {
 	var queryMods []qm.QueryMod
@@ -422,6 +481,69 @@ func (o *ServiceEndpoint) ServiceEndpointDependencies(mods ...qm.QueryMod) servi
 	return query
 }
 
+// ServiceEndpointLabels retrieves all the service_endpoint_label's ServiceEndpointLabels with an executor.
+func (o *ServiceEndpoint) ServiceEndpointLabels(mods ...qm.QueryMod) serviceEndpointLabelQuery {
+	var queryMods []qm.QueryMod
+	if len(mods) != 0 {
+		queryMods = append(queryMods, mods...)
+	}
+
+	queryMods = append(queryMods,
+		qm.Where("\"service_endpoint_label\".\"service_endpoint_id\"=?", o.ID),
+	)
+
+	query := ServiceEndpointLabels(queryMods...)
+	queries.SetFrom(query.Query, "\"service_endpoint_label\"")
+
+	if len(queries.GetSelect(query.Query)) == 0 {
+		queries.SetSelect(query.Query, []string{"\"service_endpoint_label\".*"})
+	}
+
+	return query
+}
+
+// TopicConsumers retrieves all the topic_consumer's TopicConsumers with an executor.
+func (o *ServiceEndpoint) TopicConsumers(mods ...qm.QueryMod) topicConsumerQuery {
+	var queryMods []qm.QueryMod
+	if len(mods) != 0 {
+		queryMods = append(queryMods, mods...)
+	}
+
+	queryMods = append(queryMods,
+		qm.Where("\"topic_consumer\".\"service_endpoint_id\"=?", o.ID),
+	)
+
+	query := TopicConsumers(queryMods...)
+	queries.SetFrom(query.Query, "\"topic_consumer\"")
+
+	if len(queries.GetSelect(query.Query)) == 0 {
+		queries.SetSelect(query.Query, []string{"\"topic_consumer\".*"})
+	}
+
+	return query
+}
+
+// TopicProducers retrieves all the topic_producer's TopicProducers with an executor.
+func (o *ServiceEndpoint) TopicProducers(mods ...qm.QueryMod) topicProducerQuery {
+	var queryMods []qm.QueryMod
+	if len(mods) != 0 {
+		queryMods = append(queryMods, mods...)
+	}
+
+	queryMods = append(queryMods,
+		qm.Where("\"topic_producer\".\"service_endpoint_id\"=?", o.ID),
+	)
+
+	query := TopicProducers(queryMods...)
+	queries.SetFrom(query.Query, "\"topic_producer\"")
+
+	if len(queries.GetSelect(query.Query)) == 0 {
+		queries.SetSelect(query.Query, []string{"\"topic_producer\".*"})
+	}
+
+	return query
+}
+
 // LoadService allows an eager lookup of values, cached into the
 // loaded structs of the objects. This is for an N-1 relationship.
 func (serviceEndpointL) LoadService(ctx context.Context, e boil.ContextExecutor, singular bool, maybeServiceEndpoint interface{}, mods queries.Applicator) error {
@@ -439,8 +561,405 @@ func (serviceEndpointL) LoadService(ctx context.Context, e boil.ContextExecutor,
 		if object.R == nil {
 			object.R = &serviceEndpointR{}
 		}
-		args = append(args, object.ServiceID)
-
+		args = append(args, object.ServiceID)
+
+	} else {
+	Outer:
+		for _, obj := range slice {
+			if obj.R == nil {
+				obj.R = &serviceEndpointR{}
+			}
+
+			for _, a := range args {
+				if a == obj.ServiceID {
+					continue Outer
+				}
+			}
+
+			args = append(args, obj.ServiceID)
+
+		}
+	}
+
+	if len(args) == 0 {
+		return nil
+	}
+
+	query := NewQuery(
+		qm.From(`service`),
+		qm.WhereIn(`service.id in ?`, args...),
+	)
+	if mods != nil {
+		mods.Apply(query)
+	}
+
+	results, err := query.QueryContext(ctx, e)
+	if err != nil {
+		return errors.Wrap(err, "failed to eager load Service")
+	}
+
+	var resultSlice []*Service
+	if err = queries.Bind(results, &resultSlice); err != nil {
+		return errors.Wrap(err, "failed to bind eager loaded slice Service")
+	}
+
+	if err = results.Close(); err != nil {
+		return errors.Wrap(err, "failed to close results of eager load for service")
+	}
+	if err = results.Err(); err != nil {
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for service")
+	}
+
+	if len(serviceEndpointAfterSelectHooks) != 0 {
+		for _, obj := range resultSlice {
+			if err := obj.doAfterSelectHooks(ctx, e); err != nil {
+				return err
+			}
+		}
+	}
+
+	if len(resultSlice) == 0 {
+		return nil
+	}
+
+	if singular {
+		foreign := resultSlice[0]
+		object.R.Service = foreign
+		if foreign.R == nil {
+			foreign.R = &serviceR{}
+		}
+		foreign.R.ServiceEndpoints = append(foreign.R.ServiceEndpoints, object)
+		return nil
+	}
+
+	for _, local := range slice {
+		for _, foreign := range resultSlice {
+			if local.ServiceID == foreign.ID {
+				local.R.Service = foreign
+				if foreign.R == nil {
+					foreign.R = &serviceR{}
+				}
+				foreign.R.ServiceEndpoints = append(foreign.R.ServiceEndpoints, local)
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
+// LoadDependencyServiceEndpointClientDependencies allows an eager lookup of values, cached into the
+// loaded structs of the objects. This is for a 1-M or N-M relationship.
+func (serviceEndpointL) LoadDependencyServiceEndpointClientDependencies(ctx context.Context, e boil.ContextExecutor, singular bool, maybeServiceEndpoint interface{}, mods queries.Applicator) error {
+	var slice []*ServiceEndpoint
+	var object *ServiceEndpoint
+
+	if singular {
+		object = maybeServiceEndpoint.(*ServiceEndpoint)
+	} else {
+		slice = *maybeServiceEndpoint.(*[]*ServiceEndpoint)
+	}
+
+	args := make([]interface{}, 0, 1)
+	if singular {
+		if object.R == nil {
+			object.R = &serviceEndpointR{}
+		}
+		args = append(args, object.ID)
+	} else {
+	Outer:
+		for _, obj := range slice {
+			if obj.R == nil {
+				obj.R = &serviceEndpointR{}
+			}
+
+			for _, a := range args {
+				if a == obj.ID {
+					continue Outer
+				}
+			}
+
+			args = append(args, obj.ID)
+		}
+	}
+
+	if len(args) == 0 {
+		return nil
+	}
+
+	query := NewQuery(
+		qm.From(`client_dependency`),
+		qm.WhereIn(`client_dependency.dependency_service_endpoint_id in ?`, args...),
+	)
+	if mods != nil {
+		mods.Apply(query)
+	}
+
+	results, err := query.QueryContext(ctx, e)
+	if err != nil {
+		return errors.Wrap(err, "failed to eager load client_dependency")
+	}
+
+	var resultSlice []*ClientDependency
+	if err = queries.Bind(results, &resultSlice); err != nil {
+		return errors.Wrap(err, "failed to bind eager loaded slice client_dependency")
+	}
+
+	if err = results.Close(); err != nil {
+		return errors.Wrap(err, "failed to close results in eager load on client_dependency")
+	}
+	if err = results.Err(); err != nil {
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for client_dependency")
+	}
+
+	if len(clientDependencyAfterSelectHooks) != 0 {
+		for _, obj := range resultSlice {
+			if err := obj.doAfterSelectHooks(ctx, e); err != nil {
+				return err
+			}
+		}
+	}
+	if singular {
+		object.R.DependencyServiceEndpointClientDependencies = resultSlice
+		for _, foreign := range resultSlice {
+			if foreign.R == nil {
+				foreign.R = &clientDependencyR{}
+			}
+			foreign.R.DependencyServiceEndpoint = object
+		}
+		return nil
+	}
+
+	for _, foreign := range resultSlice {
+		for _, local := range slice {
+			if local.ID == foreign.DependencyServiceEndpointID {
+				local.R.DependencyServiceEndpointClientDependencies = append(local.R.DependencyServiceEndpointClientDependencies, foreign)
+				if foreign.R == nil {
+					foreign.R = &clientDependencyR{}
+				}
+				foreign.R.DependencyServiceEndpoint = local
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
+// LoadDependencyServiceEndpointServiceEndpointDependencies allows an eager lookup of values, cached into the
+// loaded structs of the objects. This is for a 1-M or N-M relationship.
+func (serviceEndpointL) LoadDependencyServiceEndpointServiceEndpointDependencies(ctx context.Context, e boil.ContextExecutor, singular bool, maybeServiceEndpoint interface{}, mods queries.Applicator) error {
+	var slice []*ServiceEndpoint
+	var object *ServiceEndpoint
+
+	if singular {
+		object = maybeServiceEndpoint.(*ServiceEndpoint)
+	} else {
+		slice = *maybeServiceEndpoint.(*[]*ServiceEndpoint)
+	}
+
+	args := make([]interface{}, 0, 1)
+	if singular {
+		if object.R == nil {
+			object.R = &serviceEndpointR{}
+		}
+		args = append(args, object.ID)
+	} else {
+	Outer:
+		for _, obj := range slice {
+			if obj.R == nil {
+				obj.R = &serviceEndpointR{}
+			}
+
+			for _, a := range args {
+				if a == obj.ID {
+					continue Outer
+				}
+			}
+
+			args = append(args, obj.ID)
+		}
+	}
+
+	if len(args) == 0 {
+		return nil
+	}
+
+	query := NewQuery(
+		qm.From(`service_endpoint_dependency`),
+		qm.WhereIn(`service_endpoint_dependency.dependency_service_endpoint_id in ?`, args...),
+	)
+	if mods != nil {
+		mods.Apply(query)
+	}
+
+	results, err := query.QueryContext(ctx, e)
+	if err != nil {
+		return errors.Wrap(err, "failed to eager load service_endpoint_dependency")
+	}
+
+	var resultSlice []*ServiceEndpointDependency
+	if err = queries.Bind(results, &resultSlice); err != nil {
+		return errors.Wrap(err, "failed to bind eager loaded slice service_endpoint_dependency")
+	}
+
+	if err = results.Close(); err != nil {
+		return errors.Wrap(err, "failed to close results in eager load on service_endpoint_dependency")
+	}
+	if err = results.Err(); err != nil {
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for service_endpoint_dependency")
+	}
+
+	if len(serviceEndpointDependencyAfterSelectHooks) != 0 {
+		for _, obj := range resultSlice {
+			if err := obj.doAfterSelectHooks(ctx, e); err != nil {
+				return err
+			}
+		}
+	}
+	if singular {
+		object.R.DependencyServiceEndpointServiceEndpointDependencies = resultSlice
+		for _, foreign := range resultSlice {
+			if foreign.R == nil {
+				foreign.R = &serviceEndpointDependencyR{}
+			}
+			foreign.R.DependencyServiceEndpoint = object
+		}
+		return nil
+	}
+
+	for _, foreign := range resultSlice {
+		for _, local := range slice {
+			if local.ID == foreign.DependencyServiceEndpointID {
+				local.R.DependencyServiceEndpointServiceEndpointDependencies = append(local.R.DependencyServiceEndpointServiceEndpointDependencies, foreign)
+				if foreign.R == nil {
+					foreign.R = &serviceEndpointDependencyR{}
+				}
+				foreign.R.DependencyServiceEndpoint = local
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
+// LoadServiceEndpointDependencies allows an eager lookup of values, cached into the
+// loaded structs of the objects. This is for a 1-M or N-M relationship.
+func (serviceEndpointL) LoadServiceEndpointDependencies(ctx context.Context, e boil.ContextExecutor, singular bool, maybeServiceEndpoint interface{}, mods queries.Applicator) error {
+	var slice []*ServiceEndpoint
+	var object *ServiceEndpoint
+
+	if singular {
+		object = maybeServiceEndpoint.(*ServiceEndpoint)
+	} else {
+		slice = *maybeServiceEndpoint.(*[]*ServiceEndpoint)
+	}
+
+	args := make([]interface{}, 0, 1)
+	if singular {
+		if object.R == nil {
+			object.R = &serviceEndpointR{}
+		}
+		args = append(args, object.ID)
+	} else {
+	Outer:
+		for _, obj := range slice {
+			if obj.R == nil {
+				obj.R = &serviceEndpointR{}
+			}
+
+			for _, a := range args {
+				if a == obj.ID {
+					continue Outer
+				}
+			}
+
+			args = append(args, obj.ID)
+		}
+	}
+
+	if len(args) == 0 {
+		return nil
+	}
+
+	query := NewQuery(
+		qm.From(`service_endpoint_dependency`),
+		qm.WhereIn(`service_endpoint_dependency.service_endpoint_id in ?`, args...),
+	)
+	if mods != nil {
+		mods.Apply(query)
+	}
+
+	results, err := query.QueryContext(ctx, e)
+	if err != nil {
+		return errors.Wrap(err, "failed to eager load service_endpoint_dependency")
+	}
+
+	var resultSlice []*ServiceEndpointDependency
+	if err = queries.Bind(results, &resultSlice); err != nil {
+		return errors.Wrap(err, "failed to bind eager loaded slice service_endpoint_dependency")
+	}
+
+	if err = results.Close(); err != nil {
+		return errors.Wrap(err, "failed to close results in eager load on service_endpoint_dependency")
+	}
+	if err = results.Err(); err != nil {
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for service_endpoint_dependency")
+	}
+
+	if len(serviceEndpointDependencyAfterSelectHooks) != 0 {
+		for _, obj := range resultSlice {
+			if err := obj.doAfterSelectHooks(ctx, e); err != nil {
+				return err
+			}
+		}
+	}
+	if singular {
+		object.R.ServiceEndpointDependencies = resultSlice
+		for _, foreign := range resultSlice {
+			if foreign.R == nil {
+				foreign.R = &serviceEndpointDependencyR{}
+			}
+			foreign.R.ServiceEndpoint = object
+		}
+		return nil
+	}
+
+	for _, foreign := range resultSlice {
+		for _, local := range slice {
+			if local.ID == foreign.ServiceEndpointID {
+				local.R.ServiceEndpointDependencies = append(local.R.ServiceEndpointDependencies, foreign)
+				if foreign.R == nil {
+					foreign.R = &serviceEndpointDependencyR{}
+				}
+				foreign.R.ServiceEndpoint = local
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
+// LoadServiceEndpointLabels allows an eager lookup of values, cached into the
+// loaded structs of the objects. This is for a 1-M or N-M relationship.
+func (serviceEndpointL) LoadServiceEndpointLabels(ctx context.Context, e boil.ContextExecutor, singular bool, maybeServiceEndpoint interface{}, mods queries.Applicator) error {
+	var slice []*ServiceEndpoint
+	var object *ServiceEndpoint
+
+	if singular {
+		object = maybeServiceEndpoint.(*ServiceEndpoint)
+	} else {
+		slice = *maybeServiceEndpoint.(*[]*ServiceEndpoint)
+	}
+
+	args := make([]interface{}, 0, 1)
+	if singular {
+		if object.R == nil {
+			object.R = &serviceEndpointR{}
+		}
+		args = append(args, object.ID)
 	} else {
 	Outer:
 		for _, obj := range slice {
@@ -449,13 +968,12 @@ func (serviceEndpointL) LoadService(ctx context.Context, e boil.ContextExecutor,
 			}
 
 			for _, a := range args {
-				if a == obj.ServiceID {
+				if a == obj.ID {
 					continue Outer
 				}
 			}
 
-			args = append(args, obj.ServiceID)
-
+			args = append(args, obj.ID)
 		}
 	}
 
@@ -464,8 +982,8 @@ func (serviceEndpointL) LoadService(ctx context.Context, e boil.ContextExecutor,
 	}
 
 	query := NewQuery(
-		qm.From(`service`),
-		qm.WhereIn(`service.id in ?`, args...),
+		qm.From(`service_endpoint_label`),
+		qm.WhereIn(`service_endpoint_label.service_endpoint_id in ?`, args...),
 	)
 	if mods != nil {
 		mods.Apply(query)
@@ -473,51 +991,47 @@ func (serviceEndpointL) LoadService(ctx context.Context, e boil.ContextExecutor,
 
 	results, err := query.QueryContext(ctx, e)
 	if err != nil {
-		return errors.Wrap(err, "failed to eager load Service")
+		return errors.Wrap(err, "failed to eager load service_endpoint_label")
 	}
 
-	var resultSlice []*Service
+	var resultSlice []*ServiceEndpointLabel
 	if err = queries.Bind(results, &resultSlice); err != nil {
-		return errors.Wrap(err, "failed to bind eager loaded slice Service")
+		return errors.Wrap(err, "failed to bind eager loaded slice service_endpoint_label")
 	}
 
 	if err = results.Close(); err != nil {
-		return errors.Wrap(err, "failed to close results of eager load for service")
+		return errors.Wrap(err, "failed to close results in eager load on service_endpoint_label")
 	}
 	if err = results.Err(); err != nil {
-		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for service")
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for service_endpoint_label")
 	}
 
-	if len(serviceEndpointAfterSelectHooks) != 0 {
+	if len(serviceEndpointLabelAfterSelectHooks) != 0 {
 		for _, obj := range resultSlice {
 			if err := obj.doAfterSelectHooks(ctx, e); err != nil {
 				return err
 			}
 		}
 	}
-
-	if len(resultSlice) == 0 {
-		return nil
-	}
-
 	if singular {
-		foreign := resultSlice[0]
-		object.R.Service = foreign
-		if foreign.R == nil {
-			foreign.R = &serviceR{}
+		object.R.ServiceEndpointLabels = resultSlice
+		for _, foreign := range resultSlice {
+			if foreign.R == nil {
+				foreign.R = &serviceEndpointLabelR{}
+			}
+			foreign.R.ServiceEndpoint = object
 		}
-		foreign.R.ServiceEndpoints = append(foreign.R.ServiceEndpoints, object)
 		return nil
 	}
 
-	for _, local := range slice {
-		for _, foreign := range resultSlice {
-			if local.ServiceID == foreign.ID {
-				local.R.Service = foreign
+	for _, foreign := range resultSlice {
+		for _, local := range slice {
+			if local.ID == foreign.ServiceEndpointID {
+				local.R.ServiceEndpointLabels = append(local.R.ServiceEndpointLabels, foreign)
 				if foreign.R == nil {
-					foreign.R = &serviceR{}
+					foreign.R = &serviceEndpointLabelR{}
 				}
-				foreign.R.ServiceEndpoints = append(foreign.R.ServiceEndpoints, local)
+				foreign.R.ServiceEndpoint = local
 				break
 			}
 		}
@@ -526,9 +1040,9 @@ func (serviceEndpointL) LoadService(ctx context.Context, e boil.ContextExecutor,
 	return nil
 }
 
-// LoadDependencyServiceEndpointServiceEndpointDependencies allows an eager lookup of values, cached into the
+// LoadTopicConsumers allows an eager lookup of values, cached into the
 // loaded structs of the objects. This is for a 1-M or N-M relationship.
-func (serviceEndpointL) LoadDependencyServiceEndpointServiceEndpointDependencies(ctx context.Context, e boil.ContextExecutor, singular bool, maybeServiceEndpoint interface{}, mods queries.Applicator) error {
+func (serviceEndpointL) LoadTopicConsumers(ctx context.Context, e boil.ContextExecutor, singular bool, maybeServiceEndpoint interface{}, mods queries.Applicator) error {
 	var slice []*ServiceEndpoint
 	var object *ServiceEndpoint
 
@@ -566,8 +1080,8 @@ func (serviceEndpointL) LoadDependencyServiceEndpointServiceEndpointDependencies
 	}
 
 	query := NewQuery(
-		qm.From(`service_endpoint_dependency`),
-		qm.WhereIn(`service_endpoint_dependency.dependency_service_endpoint_id in ?`, args...),
+		qm.From(`topic_consumer`),
+		qm.WhereIn(`topic_consumer.service_endpoint_id in ?`, args...),
 	)
 	if mods != nil {
 		mods.Apply(query)
@@ -575,22 +1089,22 @@ func (serviceEndpointL) LoadDependencyServiceEndpointServiceEndpointDependencies
 
 	results, err := query.QueryContext(ctx, e)
 	if err != nil {
-		return errors.Wrap(err, "failed to eager load service_endpoint_dependency")
+		return errors.Wrap(err, "failed to eager load topic_consumer")
 	}
 
-	var resultSlice []*ServiceEndpointDependency
+	var resultSlice []*TopicConsumer
 	if err = queries.Bind(results, &resultSlice); err != nil {
-		return errors.Wrap(err, "failed to bind eager loaded slice service_endpoint_dependency")
+		return errors.Wrap(err, "failed to bind eager loaded slice topic_consumer")
 	}
 
 	if err = results.Close(); err != nil {
-		return errors.Wrap(err, "failed to close results in eager load on service_endpoint_dependency")
+		return errors.Wrap(err, "failed to close results in eager load on topic_consumer")
 	}
 	if err = results.Err(); err != nil {
-		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for service_endpoint_dependency")
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for topic_consumer")
 	}
 
-	if len(serviceEndpointDependencyAfterSelectHooks) != 0 {
+	if len(topicConsumerAfterSelectHooks) != 0 {
 		for _, obj := range resultSlice {
 			if err := obj.doAfterSelectHooks(ctx, e); err != nil {
 				return err
@@ -598,24 +1112,24 @@ func (serviceEndpointL) LoadDependencyServiceEndpointServiceEndpointDependencies
 		}
 	}
 	if singular {
-		object.R.DependencyServiceEndpointServiceEndpointDependencies = resultSlice
+		object.R.TopicConsumers = resultSlice
 		for _, foreign := range resultSlice {
 			if foreign.R == nil {
-				foreign.R = &serviceEndpointDependencyR{}
+				foreign.R = &topicConsumerR{}
 			}
-			foreign.R.DependencyServiceEndpoint = object
+			foreign.R.ServiceEndpoint = object
 		}
 		return nil
 	}
 
 	for _, foreign := range resultSlice {
 		for _, local := range slice {
-			if local.ID == foreign.DependencyServiceEndpointID {
-				local.R.DependencyServiceEndpointServiceEndpointDependencies = append(local.R.DependencyServiceEndpointServiceEndpointDependencies, foreign)
+			if local.ID == foreign.ServiceEndpointID {
+				local.R.TopicConsumers = append(local.R.TopicConsumers, foreign)
 				if foreign.R == nil {
-					foreign.R = &serviceEndpointDependencyR{}
+					foreign.R = &topicConsumerR{}
 				}
-				foreign.R.DependencyServiceEndpoint = local
+				foreign.R.ServiceEndpoint = local
 				break
 			}
 		}
@@ -624,9 +1138,9 @@ func (serviceEndpointL) LoadDependencyServiceEndpointServiceEndpointDependencies
 	return nil
 }
 
-// LoadServiceEndpointDependencies allows an eager lookup of values, cached into the
+// LoadTopicProducers allows an eager lookup of values, cached into the
 // loaded structs of the objects. This is for a 1-M or N-M relationship.
-func (serviceEndpointL) LoadServiceEndpointDependencies(ctx context.Context, e boil.ContextExecutor, singular bool, maybeServiceEndpoint interface{}, mods queries.Applicator) error {
+func (serviceEndpointL) LoadTopicProducers(ctx context.Context, e boil.ContextExecutor, singular bool, maybeServiceEndpoint interface{}, mods queries.Applicator) error {
 	var slice []*ServiceEndpoint
 	var object *ServiceEndpoint
 
@@ -664,8 +1178,8 @@ func (serviceEndpointL) LoadServiceEndpointDependencies(ctx context.Context, e b
 	}
 
 	query := NewQuery(
-		qm.From(`service_endpoint_dependency`),
-		qm.WhereIn(`service_endpoint_dependency.service_endpoint_id in ?`, args...),
+		qm.From(`topic_producer`),
+		qm.WhereIn(`topic_producer.service_endpoint_id in ?`, args...),
 	)
 	if mods != nil {
 		mods.Apply(query)
@@ -673,22 +1187,22 @@ func (serviceEndpointL) LoadServiceEndpointDependencies(ctx context.Context, e b
 
 	results, err := query.QueryContext(ctx, e)
 	if err != nil {
-		return errors.Wrap(err, "failed to eager load service_endpoint_dependency")
+		return errors.Wrap(err, "failed to eager load topic_producer")
 	}
 
-	var resultSlice []*ServiceEndpointDependency
+	var resultSlice []*TopicProducer
 	if err = queries.Bind(results, &resultSlice); err != nil {
-		return errors.Wrap(err, "failed to bind eager loaded slice service_endpoint_dependency")
+		return errors.Wrap(err, "failed to bind eager loaded slice topic_producer")
 	}
 
 	if err = results.Close(); err != nil {
-		return errors.Wrap(err, "failed to close results in eager load on service_endpoint_dependency")
+		return errors.Wrap(err, "failed to close results in eager load on topic_producer")
 	}
 	if err = results.Err(); err != nil {
-		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for service_endpoint_dependency")
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for topic_producer")
 	}
 
-	if len(serviceEndpointDependencyAfterSelectHooks) != 0 {
+	if len(topicProducerAfterSelectHooks) != 0 {
 		for _, obj := range resultSlice {
 			if err := obj.doAfterSelectHooks(ctx, e); err != nil {
 				return err
@@ -696,10 +1210,10 @@ func (serviceEndpointL) LoadServiceEndpointDependencies(ctx context.Context, e b
 		}
 	}
 	if singular {
-		object.R.ServiceEndpointDependencies = resultSlice
+		object.R.TopicProducers = resultSlice
 		for _, foreign := range resultSlice {
 			if foreign.R == nil {
-				foreign.R = &serviceEndpointDependencyR{}
+				foreign.R = &topicProducerR{}
 			}
 			foreign.R.ServiceEndpoint = object
 		}
@@ -709,9 +1223,9 @@ func (serviceEndpointL) LoadServiceEndpointDependencies(ctx context.Context, e b
 	for _, foreign := range resultSlice {
 		for _, local := range slice {
 			if local.ID == foreign.ServiceEndpointID {
-				local.R.ServiceEndpointDependencies = append(local.R.ServiceEndpointDependencies, foreign)
+				local.R.TopicProducers = append(local.R.TopicProducers, foreign)
 				if foreign.R == nil {
-					foreign.R = &serviceEndpointDependencyR{}
+					foreign.R = &topicProducerR{}
 				}
 				foreign.R.ServiceEndpoint = local
 				break
@@ -769,6 +1283,59 @@ func (o *ServiceEndpoint) SetService(ctx context.Context, exec boil.ContextExecu
 	return nil
 }
 
+// AddDependencyServiceEndpointClientDependencies adds the given related objects to the existing relationships
+// of the service_endpoint, optionally inserting them as new records.
+// Appends related to o.R.DependencyServiceEndpointClientDependencies.
+// Sets related.R.DependencyServiceEndpoint appropriately.
+func (o *ServiceEndpoint) AddDependencyServiceEndpointClientDependencies(ctx context.Context, exec boil.ContextExecutor, insert bool, related ...*ClientDependency) error {
+	var err error
+	for _, rel := range related {
+		if insert {
+			rel.DependencyServiceEndpointID = o.ID
+			if err = rel.Insert(ctx, exec, boil.Infer()); err != nil {
+				return errors.Wrap(err, "failed to insert into foreign table")
+			}
+		} else {
+			updateQuery := fmt.Sprintf(
+				"UPDATE \"client_dependency\" SET %s WHERE %s",
+				strmangle.SetParamNames("\"", "\"", 1, []string{"dependency_service_endpoint_id"}),
+				strmangle.WhereClause("\"", "\"", 2, clientDependencyPrimaryKeyColumns),
+			)
+			values := []interface{}{o.ID, rel.ID}
+
+			if boil.IsDebug(ctx) {
+				writer := boil.DebugWriterFrom(ctx)
+				fmt.Fprintln(writer, updateQuery)
+				fmt.Fprintln(writer, values)
+			}
+			if _, err = exec.ExecContext(ctx, updateQuery, values...); err != nil {
+				return errors.Wrap(err, "failed to update foreign table")
+			}
+
+			rel.DependencyServiceEndpointID = o.ID
+		}
+	}
+
+	if o.R == nil {
+		o.R = &serviceEndpointR{
+			DependencyServiceEndpointClientDependencies: related,
+		}
+	} else {
+		o.R.DependencyServiceEndpointClientDependencies = append(o.R.DependencyServiceEndpointClientDependencies, related...)
+	}
+
+	for _, rel := range related {
+		if rel.R == nil {
+			rel.R = &clientDependencyR{
+				DependencyServiceEndpoint: o,
+			}
+		} else {
+			rel.R.DependencyServiceEndpoint = o
+		}
+	}
+	return nil
+}
+
 // AddDependencyServiceEndpointServiceEndpointDependencies adds the given related objects to the existing relationships
 // of the service_endpoint, optionally inserting them as new records.
 // Appends related to o.R.DependencyServiceEndpointServiceEndpointDependencies.
@@ -875,6 +1442,165 @@ func (o *ServiceEndpoint) AddServiceEndpointDependencies(ctx context.Context, ex
 	return nil
 }
 
+// AddServiceEndpointLabels adds the given related objects to the existing relationships
+// of the service_endpoint, optionally inserting them as new records.
+// Appends related to o.R.ServiceEndpointLabels.
+// Sets related.R.ServiceEndpoint appropriately.
+func (o *ServiceEndpoint) AddServiceEndpointLabels(ctx context.Context, exec boil.ContextExecutor, insert bool, related ...*ServiceEndpointLabel) error {
+	var err error
+	for _, rel := range related {
+		if insert {
+			rel.ServiceEndpointID = o.ID
+			if err = rel.Insert(ctx, exec, boil.Infer()); err != nil {
+				return errors.Wrap(err, "failed to insert into foreign table")
+			}
+		} else {
+			updateQuery := fmt.Sprintf(
+				"UPDATE \"service_endpoint_label\" SET %s WHERE %s",
+				strmangle.SetParamNames("\"", "\"", 1, []string{"service_endpoint_id"}),
+				strmangle.WhereClause("\"", "\"", 2, serviceEndpointLabelPrimaryKeyColumns),
+			)
+			values := []interface{}{o.ID, rel.ID}
+
+			if boil.IsDebug(ctx) {
+				writer := boil.DebugWriterFrom(ctx)
+				fmt.Fprintln(writer, updateQuery)
+				fmt.Fprintln(writer, values)
+			}
+			if _, err = exec.ExecContext(ctx, updateQuery, values...); err != nil {
+				return errors.Wrap(err, "failed to update foreign table")
+			}
+
+			rel.ServiceEndpointID = o.ID
+		}
+	}
+
+	if o.R == nil {
+		o.R = &serviceEndpointR{
+			ServiceEndpointLabels: related,
+		}
+	} else {
+		o.R.ServiceEndpointLabels = append(o.R.ServiceEndpointLabels, related...)
+	}
+
+	for _, rel := range related {
+		if rel.R == nil {
+			rel.R = &serviceEndpointLabelR{
+				ServiceEndpoint: o,
+			}
+		} else {
+			rel.R.ServiceEndpoint = o
+		}
+	}
+	return nil
+}
+
+// AddTopicConsumers adds the given related objects to the existing relationships
+// of the service_endpoint, optionally inserting them as new records.
+// Appends related to o.R.TopicConsumers.
+// Sets related.R.ServiceEndpoint appropriately.
+func (o *ServiceEndpoint) AddTopicConsumers(ctx context.Context, exec boil.ContextExecutor, insert bool, related ...*TopicConsumer) error {
+	var err error
+	for _, rel := range related {
+		if insert {
+			rel.ServiceEndpointID = o.ID
+			if err = rel.Insert(ctx, exec, boil.Infer()); err != nil {
+				return errors.Wrap(err, "failed to insert into foreign table")
+			}
+		} else {
+			updateQuery := fmt.Sprintf(
+				"UPDATE \"topic_consumer\" SET %s WHERE %s",
+				strmangle.SetParamNames("\"", "\"", 1, []string{"service_endpoint_id"}),
+				strmangle.WhereClause("\"", "\"", 2, topicConsumerPrimaryKeyColumns),
+			)
+			values := []interface{}{o.ID, rel.ID}
+
+			if boil.IsDebug(ctx) {
+				writer := boil.DebugWriterFrom(ctx)
+				fmt.Fprintln(writer, updateQuery)
+				fmt.Fprintln(writer, values)
+			}
+			if _, err = exec.ExecContext(ctx, updateQuery, values...); err != nil {
+				return errors.Wrap(err, "failed to update foreign table")
+			}
+
+			rel.ServiceEndpointID = o.ID
+		}
+	}
+
+	if o.R == nil {
+		o.R = &serviceEndpointR{
+			TopicConsumers: related,
+		}
+	} else {
+		o.R.TopicConsumers = append(o.R.TopicConsumers, related...)
+	}
+
+	for _, rel := range related {
+		if rel.R == nil {
+			rel.R = &topicConsumerR{
+				ServiceEndpoint: o,
+			}
+		} else {
+			rel.R.ServiceEndpoint = o
+		}
+	}
+	return nil
+}
+
+// AddTopicProducers adds the given related objects to the existing relationships
+// of the service_endpoint, optionally inserting them as new records.
+// Appends related to o.R.TopicProducers.
+// Sets related.R.ServiceEndpoint appropriately.
+func (o *ServiceEndpoint) AddTopicProducers(ctx context.Context, exec boil.ContextExecutor, insert bool, related ...*TopicProducer) error {
+	var err error
+	for _, rel := range related {
+		if insert {
+			rel.ServiceEndpointID = o.ID
+			if err = rel.Insert(ctx, exec, boil.Infer()); err != nil {
+				return errors.Wrap(err, "failed to insert into foreign table")
+			}
+		} else {
+			updateQuery := fmt.Sprintf(
+				"UPDATE \"topic_producer\" SET %s WHERE %s",
+				strmangle.SetParamNames("\"", "\"", 1, []string{"service_endpoint_id"}),
+				strmangle.WhereClause("\"", "\"", 2, topicProducerPrimaryKeyColumns),
+			)
+			values := []interface{}{o.ID, rel.ID}
+
+			if boil.IsDebug(ctx) {
+				writer := boil.DebugWriterFrom(ctx)
+				fmt.Fprintln(writer, updateQuery)
+				fmt.Fprintln(writer, values)
+			}
+			if _, err = exec.ExecContext(ctx, updateQuery, values...); err != nil {
+				return errors.Wrap(err, "failed to update foreign table")
+			}
+
+			rel.ServiceEndpointID = o.ID
+		}
+	}
+
+	if o.R == nil {
+		o.R = &serviceEndpointR{
+			TopicProducers: related,
+		}
+	} else {
+		o.R.TopicProducers = append(o.R.TopicProducers, related...)
+	}
+
+	for _, rel := range related {
+		if rel.R == nil {
+			rel.R = &topicProducerR{
+				ServiceEndpoint: o,
+			}
+		} else {
+			rel.R.ServiceEndpoint = o
+		}
+	}
+	return nil
+}
+
 // ServiceEndpoints retrieves all the records using an executor.
 func ServiceEndpoints(mods ...qm.QueryMod) serviceEndpointQuery {
 	mods = append(mods, qm.From("\"service_endpoint\""))
@@ -14,6 +14,7 @@ import (
 	"time"
 
 	"github.com/friendsofgo/errors"
+	"github.com/volatiletech/null/v8"
 	"github.com/volatiletech/sqlboiler/v4/boil"
 	"github.com/volatiletech/sqlboiler/v4/queries"
 	"github.com/volatiletech/sqlboiler/v4/queries/qm"
@@ -23,9 +24,10 @@ import (
 
 // ServiceEndpointDependency is an object representing the database table.
 type ServiceEndpointDependency struct {
-	ID                          int64 `boil:"id" json:"id" toml:"id" yaml:"id"`
-	ServiceEndpointID           int64 `boil:"service_endpoint_id" json:"service_endpoint_id" toml:"service_endpoint_id" yaml:"service_endpoint_id"`
-	DependencyServiceEndpointID int64 `boil:"dependency_service_endpoint_id" json:"dependency_service_endpoint_id" toml:"dependency_service_endpoint_id" yaml:"dependency_service_endpoint_id"`
+	ID                          int64       `boil:"id" json:"id" toml:"id" yaml:"id"`
+	ServiceEndpointID           int64       `boil:"service_endpoint_id" json:"service_endpoint_id" toml:"service_endpoint_id" yaml:"service_endpoint_id"`
+	DependencyServiceEndpointID int64       `boil:"dependency_service_endpoint_id" json:"dependency_service_endpoint_id" toml:"dependency_service_endpoint_id" yaml:"dependency_service_endpoint_id"`
+	VersionConstraint           null.String `boil:"version_constraint" json:"version_constraint,omitempty" toml:"version_constraint" yaml:"version_constraint,omitempty"`
 
 	R *serviceEndpointDependencyR `boil:"-" json:"-" toml:"-" yaml:"-"`
 	L serviceEndpointDependencyL  `boil:"-" json:"-" toml:"-" yaml:"-"`
@@ -35,10 +37,12 @@ var ServiceEndpointDependencyColumns = struct {
 	ID                          string
 	ServiceEndpointID           string
 	DependencyServiceEndpointID string
+	VersionConstraint           string
 }{
 	ID:                          "id",
 	ServiceEndpointID:           "service_endpoint_id",
 	DependencyServiceEndpointID: "dependency_service_endpoint_id",
+	VersionConstraint:           "version_constraint",
 }
 
 // Generated where
@@ -47,10 +51,12 @@ var ServiceEndpointDependencyWhere = struct {
 	ID                          whereHelperint64
 	ServiceEndpointID           whereHelperint64
 	DependencyServiceEndpointID whereHelperint64
+	VersionConstraint           whereHelpernull_String
 }{
 	ID:                          whereHelperint64{field: "\"service_endpoint_dependency\".\"id\""},
 	ServiceEndpointID:           whereHelperint64{field: "\"service_endpoint_dependency\".\"service_endpoint_id\""},
 	DependencyServiceEndpointID: whereHelperint64{field: "\"service_endpoint_dependency\".\"dependency_service_endpoint_id\""},
+	VersionConstraint:           whereHelpernull_String{field: "\"service_endpoint_dependency\".\"version_constraint\""},
 }
 
 // ServiceEndpointDependencyRels is where relationship names are stored.
@@ -77,8 +83,8 @@ func (*serviceEndpointDependencyR) NewStruct() *serviceEndpointDependencyR {
 type serviceEndpointDependencyL struct{}
 
 var (
-	serviceEndpointDependencyAllColumns            = []string{"id", "service_endpoint_id", "dependency_service_endpoint_id"}
-	serviceEndpointDependencyColumnsWithoutDefault = []string{"service_endpoint_id", "dependency_service_endpoint_id"}
+	serviceEndpointDependencyAllColumns            = []string{"id", "service_endpoint_id", "dependency_service_endpoint_id", "version_constraint"}
+	serviceEndpointDependencyColumnsWithoutDefault = []string{"service_endpoint_id", "dependency_service_endpoint_id", "version_constraint"}
 	serviceEndpointDependencyColumnsWithDefault    = []string{"id"}
 	serviceEndpointDependencyPrimaryKeyColumns     = []string{"id"}
 )
@@ -0,0 +1,899 @@
+// Code generated by SQLBoiler 4.2.0 (https://github.com/volatiletech/sqlboiler). DO NOT EDIT.
+// This file is meant to be re-generated in place and/or deleted at any time.
+
+package models
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/friendsofgo/errors"
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+	"github.com/volatiletech/sqlboiler/v4/queries/qmhelper"
+	"github.com/volatiletech/strmangle"
+)
+
+// ServiceHistory is an object representing the database table.
+type ServiceHistory struct {
+	ID          int64       `boil:"id" json:"id" toml:"id" yaml:"id"`
+	ServiceCode string      `boil:"service_code" json:"service_code" toml:"service_code" yaml:"service_code"`
+	Version     int         `boil:"version" json:"version" toml:"version" yaml:"version"`
+	ChangeType  string      `boil:"change_type" json:"change_type" toml:"change_type" yaml:"change_type"`
+	Actor       string      `boil:"actor" json:"actor" toml:"actor" yaml:"actor"`
+	ChangedAt   time.Time   `boil:"changed_at" json:"changed_at" toml:"changed_at" yaml:"changed_at"`
+	Snapshot    null.String `boil:"snapshot" json:"snapshot,omitempty" toml:"snapshot" yaml:"snapshot,omitempty"`
+	Environment string      `boil:"environment" json:"environment" toml:"environment" yaml:"environment"`
+
+	R *serviceHistoryR `boil:"-" json:"-" toml:"-" yaml:"-"`
+	L serviceHistoryL  `boil:"-" json:"-" toml:"-" yaml:"-"`
+}
+
+var ServiceHistoryColumns = struct {
+	ID          string
+	ServiceCode string
+	Version     string
+	ChangeType  string
+	Actor       string
+	ChangedAt   string
+	Snapshot    string
+	Environment string
+}{
+	ID:          "id",
+	ServiceCode: "service_code",
+	Version:     "version",
+	ChangeType:  "change_type",
+	Actor:       "actor",
+	ChangedAt:   "changed_at",
+	Snapshot:    "snapshot",
+	Environment: "environment",
+}
+
+// Generated where
+
+var ServiceHistoryWhere = struct {
+	ID          whereHelperint64
+	ServiceCode whereHelperstring
+	Version     whereHelperint
+	ChangeType  whereHelperstring
+	Actor       whereHelperstring
+	ChangedAt   whereHelpertime_Time
+	Snapshot    whereHelpernull_String
+	Environment whereHelperstring
+}{
+	ID:          whereHelperint64{field: "\"service_history\".\"id\""},
+	ServiceCode: whereHelperstring{field: "\"service_history\".\"service_code\""},
+	Version:     whereHelperint{field: "\"service_history\".\"version\""},
+	ChangeType:  whereHelperstring{field: "\"service_history\".\"change_type\""},
+	Actor:       whereHelperstring{field: "\"service_history\".\"actor\""},
+	ChangedAt:   whereHelpertime_Time{field: "\"service_history\".\"changed_at\""},
+	Snapshot:    whereHelpernull_String{field: "\"service_history\".\"snapshot\""},
+	Environment: whereHelperstring{field: "\"service_history\".\"environment\""},
+}
+
+// ServiceHistoryRels is where relationship names are stored.
+var ServiceHistoryRels = struct {
+}{}
+
+// serviceHistoryR is where relationships are stored.
+type serviceHistoryR struct {
+}
+
+// NewStruct creates a new relationship struct
+func (*serviceHistoryR) NewStruct() *serviceHistoryR {
+	return &serviceHistoryR{}
+}
+
+// serviceHistoryL is where Load methods for each relationship are stored.
+type serviceHistoryL struct{}
+
+var (
+	serviceHistoryAllColumns            = []string{"id", "service_code", "version", "change_type", "actor", "changed_at", "snapshot", "environment"}
+	serviceHistoryColumnsWithoutDefault = []string{"service_code", "version", "change_type", "actor", "changed_at", "snapshot"}
+	serviceHistoryColumnsWithDefault    = []string{"id", "environment"}
+	serviceHistoryPrimaryKeyColumns     = []string{"id"}
+)
+
+type (
+	// ServiceHistorySlice is an alias for a slice of pointers to ServiceHistory.
+	// This should generally be used opposed to []ServiceHistory.
+	ServiceHistorySlice []*ServiceHistory
+	// ServiceHistoryHook is the signature for custom ServiceHistory hook methods
+	ServiceHistoryHook func(context.Context, boil.ContextExecutor, *ServiceHistory) error
+
+	serviceHistoryQuery struct {
+		*queries.Query
+	}
+)
+
+// Cache for insert, update and upsert
+var (
+	serviceHistoryType                 = reflect.TypeOf(&ServiceHistory{})
+	serviceHistoryMapping              = queries.MakeStructMapping(serviceHistoryType)
+	serviceHistoryPrimaryKeyMapping, _ = queries.BindMapping(serviceHistoryType, serviceHistoryMapping, serviceHistoryPrimaryKeyColumns)
+	serviceHistoryInsertCacheMut       sync.RWMutex
+	serviceHistoryInsertCache          = make(map[string]insertCache)
+	serviceHistoryUpdateCacheMut       sync.RWMutex
+	serviceHistoryUpdateCache          = make(map[string]updateCache)
+	serviceHistoryUpsertCacheMut       sync.RWMutex
+	serviceHistoryUpsertCache          = make(map[string]insertCache)
+)
+
+var (
+	// Force time package dependency for automated UpdatedAt/CreatedAt.
+	_ = time.Second
+	// Force qmhelper dependency for where clause generation (which doesn't
+	// always happen)
+	_ = qmhelper.Where
+)
+
+var serviceHistoryBeforeInsertHooks []ServiceHistoryHook
+var serviceHistoryBeforeUpdateHooks []ServiceHistoryHook
+var serviceHistoryBeforeDeleteHooks []ServiceHistoryHook
+var serviceHistoryBeforeUpsertHooks []ServiceHistoryHook
+
+var serviceHistoryAfterInsertHooks []ServiceHistoryHook
+var serviceHistoryAfterSelectHooks []ServiceHistoryHook
+var serviceHistoryAfterUpdateHooks []ServiceHistoryHook
+var serviceHistoryAfterDeleteHooks []ServiceHistoryHook
+var serviceHistoryAfterUpsertHooks []ServiceHistoryHook
+
+// doBeforeInsertHooks executes all "before insert" hooks.
+func (o *ServiceHistory) doBeforeInsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range serviceHistoryBeforeInsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeUpdateHooks executes all "before Update" hooks.
+func (o *ServiceHistory) doBeforeUpdateHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range serviceHistoryBeforeUpdateHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeDeleteHooks executes all "before Delete" hooks.
+func (o *ServiceHistory) doBeforeDeleteHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range serviceHistoryBeforeDeleteHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeUpsertHooks executes all "before Upsert" hooks.
+func (o *ServiceHistory) doBeforeUpsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range serviceHistoryBeforeUpsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterInsertHooks executes all "after Insert" hooks.
+func (o *ServiceHistory) doAfterInsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range serviceHistoryAfterInsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterSelectHooks executes all "after Select" hooks.
+func (o *ServiceHistory) doAfterSelectHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range serviceHistoryAfterSelectHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterUpdateHooks executes all "after Update" hooks.
+func (o *ServiceHistory) doAfterUpdateHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range serviceHistoryAfterUpdateHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterDeleteHooks executes all "after Delete" hooks.
+func (o *ServiceHistory) doAfterDeleteHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range serviceHistoryAfterDeleteHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterUpsertHooks executes all "after Upsert" hooks.
+func (o *ServiceHistory) doAfterUpsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range serviceHistoryAfterUpsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// AddServiceHistoryHook registers your hook function for all future operations.
+func AddServiceHistoryHook(hookPoint boil.HookPoint, serviceHistoryHook ServiceHistoryHook) {
+	switch hookPoint {
+	case boil.BeforeInsertHook:
+		serviceHistoryBeforeInsertHooks = append(serviceHistoryBeforeInsertHooks, serviceHistoryHook)
+	case boil.BeforeUpdateHook:
+		serviceHistoryBeforeUpdateHooks = append(serviceHistoryBeforeUpdateHooks, serviceHistoryHook)
+	case boil.BeforeDeleteHook:
+		serviceHistoryBeforeDeleteHooks = append(serviceHistoryBeforeDeleteHooks, serviceHistoryHook)
+	case boil.BeforeUpsertHook:
+		serviceHistoryBeforeUpsertHooks = append(serviceHistoryBeforeUpsertHooks, serviceHistoryHook)
+	case boil.AfterInsertHook:
+		serviceHistoryAfterInsertHooks = append(serviceHistoryAfterInsertHooks, serviceHistoryHook)
+	case boil.AfterSelectHook:
+		serviceHistoryAfterSelectHooks = append(serviceHistoryAfterSelectHooks, serviceHistoryHook)
+	case boil.AfterUpdateHook:
+		serviceHistoryAfterUpdateHooks = append(serviceHistoryAfterUpdateHooks, serviceHistoryHook)
+	case boil.AfterDeleteHook:
+		serviceHistoryAfterDeleteHooks = append(serviceHistoryAfterDeleteHooks, serviceHistoryHook)
+	case boil.AfterUpsertHook:
+		serviceHistoryAfterUpsertHooks = append(serviceHistoryAfterUpsertHooks, serviceHistoryHook)
+	}
+}
+
+// One returns a single serviceHistory record from the query.
+func (q serviceHistoryQuery) One(ctx context.Context, exec boil.ContextExecutor) (*ServiceHistory, error) {
+	o := &ServiceHistory{}
+
+	queries.SetLimit(q.Query, 1)
+
+	err := q.Bind(ctx, exec, o)
+	if err != nil {
+		if errors.Cause(err) == sql.ErrNoRows {
+			return nil, sql.ErrNoRows
+		}
+		return nil, errors.Wrap(err, "models: failed to execute a one query for service_history")
+	}
+
+	if err := o.doAfterSelectHooks(ctx, exec); err != nil {
+		return o, err
+	}
+
+	return o, nil
+}
+
+// All returns all ServiceHistory records from the query.
+func (q serviceHistoryQuery) All(ctx context.Context, exec boil.ContextExecutor) (ServiceHistorySlice, error) {
+	var o []*ServiceHistory
+
+	err := q.Bind(ctx, exec, &o)
+	if err != nil {
+		return nil, errors.Wrap(err, "models: failed to assign all query results to ServiceHistory slice")
+	}
+
+	if len(serviceHistoryAfterSelectHooks) != 0 {
+		for _, obj := range o {
+			if err := obj.doAfterSelectHooks(ctx, exec); err != nil {
+				return o, err
+			}
+		}
+	}
+
+	return o, nil
+}
+
+// Count returns the count of all ServiceHistory records in the query.
+func (q serviceHistoryQuery) Count(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	var count int64
+
+	queries.SetSelect(q.Query, nil)
+	queries.SetCount(q.Query)
+
+	err := q.Query.QueryRowContext(ctx, exec).Scan(&count)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to count service_history rows")
+	}
+
+	return count, nil
+}
+
+// Exists checks if the row exists in the table.
+func (q serviceHistoryQuery) Exists(ctx context.Context, exec boil.ContextExecutor) (bool, error) {
+	var count int64
+
+	queries.SetSelect(q.Query, nil)
+	queries.SetCount(q.Query)
+	queries.SetLimit(q.Query, 1)
+
+	err := q.Query.QueryRowContext(ctx, exec).Scan(&count)
+	if err != nil {
+		return false, errors.Wrap(err, "models: failed to check if service_history exists")
+	}
+
+	return count > 0, nil
+}
+
+// ServiceHistories retrieves all the records using an executor.
+func ServiceHistories(mods ...qm.QueryMod) serviceHistoryQuery {
+	mods = append(mods, qm.From("\"service_history\""))
+	return serviceHistoryQuery{NewQuery(mods...)}
+}
+
+// FindServiceHistory retrieves a single record by ID with an executor.
+// If selectCols is empty Find will return all columns.
+func FindServiceHistory(ctx context.Context, exec boil.ContextExecutor, iD int64, selectCols ...string) (*ServiceHistory, error) {
+	serviceHistoryObj := &ServiceHistory{}
+
+	sel := "*"
+	if len(selectCols) > 0 {
+		sel = strings.Join(strmangle.IdentQuoteSlice(dialect.LQ, dialect.RQ, selectCols), ",")
+	}
+	query := fmt.Sprintf(
+		"select %s from \"service_history\" where \"id\"=$1", sel,
+	)
+
+	q := queries.Raw(query, iD)
+
+	err := q.Bind(ctx, exec, serviceHistoryObj)
+	if err != nil {
+		if errors.Cause(err) == sql.ErrNoRows {
+			return nil, sql.ErrNoRows
+		}
+		return nil, errors.Wrap(err, "models: unable to select from service_history")
+	}
+
+	return serviceHistoryObj, nil
+}
+
+// Insert a single record using an executor.
+// See boil.Columns.InsertColumnSet documentation to understand column list inference for inserts.
+func (o *ServiceHistory) Insert(ctx context.Context, exec boil.ContextExecutor, columns boil.Columns) error {
+	if o == nil {
+		return errors.New("models: no service_history provided for insertion")
+	}
+
+	var err error
+
+	if err := o.doBeforeInsertHooks(ctx, exec); err != nil {
+		return err
+	}
+
+	nzDefaults := queries.NonZeroDefaultSet(serviceHistoryColumnsWithDefault, o)
+
+	key := makeCacheKey(columns, nzDefaults)
+	serviceHistoryInsertCacheMut.RLock()
+	cache, cached := serviceHistoryInsertCache[key]
+	serviceHistoryInsertCacheMut.RUnlock()
+
+	if !cached {
+		wl, returnColumns := columns.InsertColumnSet(
+			serviceHistoryAllColumns,
+			serviceHistoryColumnsWithDefault,
+			serviceHistoryColumnsWithoutDefault,
+			nzDefaults,
+		)
+
+		cache.valueMapping, err = queries.BindMapping(serviceHistoryType, serviceHistoryMapping, wl)
+		if err != nil {
+			return err
+		}
+		cache.retMapping, err = queries.BindMapping(serviceHistoryType, serviceHistoryMapping, returnColumns)
+		if err != nil {
+			return err
+		}
+		if len(wl) != 0 {
+			cache.query = fmt.Sprintf("INSERT INTO \"service_history\" (\"%s\") %%sVALUES (%s)%%s", strings.Join(wl, "\",\""), strmangle.Placeholders(dialect.UseIndexPlaceholders, len(wl), 1, 1))
+		} else {
+			cache.query = "INSERT INTO \"service_history\" %sDEFAULT VALUES%s"
+		}
+
+		var queryOutput, queryReturning string
+
+		if len(cache.retMapping) != 0 {
+			queryReturning = fmt.Sprintf(" RETURNING \"%s\"", strings.Join(returnColumns, "\",\""))
+		}
+
+		cache.query = fmt.Sprintf(cache.query, queryOutput, queryReturning)
+	}
+
+	value := reflect.Indirect(reflect.ValueOf(o))
+	vals := queries.ValuesFromMapping(value, cache.valueMapping)
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.query)
+		fmt.Fprintln(writer, vals)
+	}
+
+	if len(cache.retMapping) != 0 {
+		err = exec.QueryRowContext(ctx, cache.query, vals...).Scan(queries.PtrsFromMapping(value, cache.retMapping)...)
+	} else {
+		_, err = exec.ExecContext(ctx, cache.query, vals...)
+	}
+
+	if err != nil {
+		return errors.Wrap(err, "models: unable to insert into service_history")
+	}
+
+	if !cached {
+		serviceHistoryInsertCacheMut.Lock()
+		serviceHistoryInsertCache[key] = cache
+		serviceHistoryInsertCacheMut.Unlock()
+	}
+
+	return o.doAfterInsertHooks(ctx, exec)
+}
+
+// Update uses an executor to update the ServiceHistory.
+// See boil.Columns.UpdateColumnSet documentation to understand column list inference for updates.
+// Update does not automatically update the record in case of default values. Use .Reload() to refresh the records.
+func (o *ServiceHistory) Update(ctx context.Context, exec boil.ContextExecutor, columns boil.Columns) (int64, error) {
+	var err error
+	if err = o.doBeforeUpdateHooks(ctx, exec); err != nil {
+		return 0, err
+	}
+	key := makeCacheKey(columns, nil)
+	serviceHistoryUpdateCacheMut.RLock()
+	cache, cached := serviceHistoryUpdateCache[key]
+	serviceHistoryUpdateCacheMut.RUnlock()
+
+	if !cached {
+		wl := columns.UpdateColumnSet(
+			serviceHistoryAllColumns,
+			serviceHistoryPrimaryKeyColumns,
+		)
+
+		if !columns.IsWhitelist() {
+			wl = strmangle.SetComplement(wl, []string{"created_at"})
+		}
+		if len(wl) == 0 {
+			return 0, errors.New("models: unable to update service_history, could not build whitelist")
+		}
+
+		cache.query = fmt.Sprintf("UPDATE \"service_history\" SET %s WHERE %s",
+			strmangle.SetParamNames("\"", "\"", 1, wl),
+			strmangle.WhereClause("\"", "\"", len(wl)+1, serviceHistoryPrimaryKeyColumns),
+		)
+		cache.valueMapping, err = queries.BindMapping(serviceHistoryType, serviceHistoryMapping, append(wl, serviceHistoryPrimaryKeyColumns...))
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	values := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(o)), cache.valueMapping)
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.query)
+		fmt.Fprintln(writer, values)
+	}
+	var result sql.Result
+	result, err = exec.ExecContext(ctx, cache.query, values...)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to update service_history row")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to get rows affected by update for service_history")
+	}
+
+	if !cached {
+		serviceHistoryUpdateCacheMut.Lock()
+		serviceHistoryUpdateCache[key] = cache
+		serviceHistoryUpdateCacheMut.Unlock()
+	}
+
+	return rowsAff, o.doAfterUpdateHooks(ctx, exec)
+}
+
+// UpdateAll updates all rows with the specified column values.
+func (q serviceHistoryQuery) UpdateAll(ctx context.Context, exec boil.ContextExecutor, cols M) (int64, error) {
+	queries.SetUpdate(q.Query, cols)
+
+	result, err := q.Query.ExecContext(ctx, exec)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to update all for service_history")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to retrieve rows affected for service_history")
+	}
+
+	return rowsAff, nil
+}
+
+// UpdateAll updates all rows with the specified column values, using an executor.
+func (o ServiceHistorySlice) UpdateAll(ctx context.Context, exec boil.ContextExecutor, cols M) (int64, error) {
+	ln := int64(len(o))
+	if ln == 0 {
+		return 0, nil
+	}
+
+	if len(cols) == 0 {
+		return 0, errors.New("models: update all requires at least one column argument")
+	}
+
+	colNames := make([]string, len(cols))
+	args := make([]interface{}, len(cols))
+
+	i := 0
+	for name, value := range cols {
+		colNames[i] = name
+		args[i] = value
+		i++
+	}
+
+	// Append all of the primary key values for each column
+	for _, obj := range o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), serviceHistoryPrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := fmt.Sprintf("UPDATE \"service_history\" SET %s WHERE %s",
+		strmangle.SetParamNames("\"", "\"", 1, colNames),
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), len(colNames)+1, serviceHistoryPrimaryKeyColumns, len(o)))
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, args...)
+	}
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to update all in serviceHistory slice")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to retrieve rows affected all in update all serviceHistory")
+	}
+	return rowsAff, nil
+}
+
+// Upsert attempts an insert using an executor, and does an update or ignore on conflict.
+// See boil.Columns documentation for how to properly use updateColumns and insertColumns.
+func (o *ServiceHistory) Upsert(ctx context.Context, exec boil.ContextExecutor, updateOnConflict bool, conflictColumns []string, updateColumns, insertColumns boil.Columns) error {
+	if o == nil {
+		return errors.New("models: no service_history provided for upsert")
+	}
+
+	if err := o.doBeforeUpsertHooks(ctx, exec); err != nil {
+		return err
+	}
+
+	nzDefaults := queries.NonZeroDefaultSet(serviceHistoryColumnsWithDefault, o)
+
+	// Build cache key in-line uglily - mysql vs psql problems
+	buf := strmangle.GetBuffer()
+	if updateOnConflict {
+		buf.WriteByte('t')
+	} else {
+		buf.WriteByte('f')
+	}
+	buf.WriteByte('.')
+	for _, c := range conflictColumns {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	buf.WriteString(strconv.Itoa(updateColumns.Kind))
+	for _, c := range updateColumns.Cols {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	buf.WriteString(strconv.Itoa(insertColumns.Kind))
+	for _, c := range insertColumns.Cols {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	for _, c := range nzDefaults {
+		buf.WriteString(c)
+	}
+	key := buf.String()
+	strmangle.PutBuffer(buf)
+
+	serviceHistoryUpsertCacheMut.RLock()
+	cache, cached := serviceHistoryUpsertCache[key]
+	serviceHistoryUpsertCacheMut.RUnlock()
+
+	var err error
+
+	if !cached {
+		insert, ret := insertColumns.InsertColumnSet(
+			serviceHistoryAllColumns,
+			serviceHistoryColumnsWithDefault,
+			serviceHistoryColumnsWithoutDefault,
+			nzDefaults,
+		)
+		update := updateColumns.UpdateColumnSet(
+			serviceHistoryAllColumns,
+			serviceHistoryPrimaryKeyColumns,
+		)
+
+		if updateOnConflict && len(update) == 0 {
+			return errors.New("models: unable to upsert service_history, could not build update column list")
+		}
+
+		conflict := conflictColumns
+		if len(conflict) == 0 {
+			conflict = make([]string, len(serviceHistoryPrimaryKeyColumns))
+			copy(conflict, serviceHistoryPrimaryKeyColumns)
+		}
+		cache.query = buildUpsertQueryPostgres(dialect, "\"service_history\"", updateOnConflict, ret, update, conflict, insert)
+
+		cache.valueMapping, err = queries.BindMapping(serviceHistoryType, serviceHistoryMapping, insert)
+		if err != nil {
+			return err
+		}
+		if len(ret) != 0 {
+			cache.retMapping, err = queries.BindMapping(serviceHistoryType, serviceHistoryMapping, ret)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	value := reflect.Indirect(reflect.ValueOf(o))
+	vals := queries.ValuesFromMapping(value, cache.valueMapping)
+	var returns []interface{}
+	if len(cache.retMapping) != 0 {
+		returns = queries.PtrsFromMapping(value, cache.retMapping)
+	}
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.query)
+		fmt.Fprintln(writer, vals)
+	}
+	if len(cache.retMapping) != 0 {
+		err = exec.QueryRowContext(ctx, cache.query, vals...).Scan(returns...)
+		if err == sql.ErrNoRows {
+			err = nil // Postgres doesn't return anything when there's no update
+		}
+	} else {
+		_, err = exec.ExecContext(ctx, cache.query, vals...)
+	}
+	if err != nil {
+		return errors.Wrap(err, "models: unable to upsert service_history")
+	}
+
+	if !cached {
+		serviceHistoryUpsertCacheMut.Lock()
+		serviceHistoryUpsertCache[key] = cache
+		serviceHistoryUpsertCacheMut.Unlock()
+	}
+
+	return o.doAfterUpsertHooks(ctx, exec)
+}
+
+// Delete deletes a single ServiceHistory record with an executor.
+// Delete will match against the primary key column to find the record to delete.
+func (o *ServiceHistory) Delete(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if o == nil {
+		return 0, errors.New("models: no ServiceHistory provided for delete")
+	}
+
+	if err := o.doBeforeDeleteHooks(ctx, exec); err != nil {
+		return 0, err
+	}
+
+	args := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(o)), serviceHistoryPrimaryKeyMapping)
+	sql := "DELETE FROM \"service_history\" WHERE \"id\"=$1"
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, args...)
+	}
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to delete from service_history")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to get rows affected by delete for service_history")
+	}
+
+	if err := o.doAfterDeleteHooks(ctx, exec); err != nil {
+		return 0, err
+	}
+
+	return rowsAff, nil
+}
+
+// DeleteAll deletes all matching rows.
+func (q serviceHistoryQuery) DeleteAll(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if q.Query == nil {
+		return 0, errors.New("models: no serviceHistoryQuery provided for delete all")
+	}
+
+	queries.SetDelete(q.Query)
+
+	result, err := q.Query.ExecContext(ctx, exec)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to delete all from service_history")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to get rows affected by deleteall for service_history")
+	}
+
+	return rowsAff, nil
+}
+
+// DeleteAll deletes all rows in the slice, using an executor.
+func (o ServiceHistorySlice) DeleteAll(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if len(o) == 0 {
+		return 0, nil
+	}
+
+	if len(serviceHistoryBeforeDeleteHooks) != 0 {
+		for _, obj := range o {
+			if err := obj.doBeforeDeleteHooks(ctx, exec); err != nil {
+				return 0, err
+			}
+		}
+	}
+
+	var args []interface{}
+	for _, obj := range o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), serviceHistoryPrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := "DELETE FROM \"service_history\" WHERE " +
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), 1, serviceHistoryPrimaryKeyColumns, len(o))
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, args)
+	}
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to delete all from serviceHistory slice")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to get rows affected by deleteall for service_history")
+	}
+
+	if len(serviceHistoryAfterDeleteHooks) != 0 {
+		for _, obj := range o {
+			if err := obj.doAfterDeleteHooks(ctx, exec); err != nil {
+				return 0, err
+			}
+		}
+	}
+
+	return rowsAff, nil
+}
+
+// Reload refetches the object from the database
+// using the primary keys with an executor.
+func (o *ServiceHistory) Reload(ctx context.Context, exec boil.ContextExecutor) error {
+	ret, err := FindServiceHistory(ctx, exec, o.ID)
+	if err != nil {
+		return err
+	}
+
+	*o = *ret
+	return nil
+}
+
+// ReloadAll refetches every row with matching primary key column values
+// and overwrites the original object slice with the newly updated slice.
+func (o *ServiceHistorySlice) ReloadAll(ctx context.Context, exec boil.ContextExecutor) error {
+	if o == nil || len(*o) == 0 {
+		return nil
+	}
+
+	slice := ServiceHistorySlice{}
+	var args []interface{}
+	for _, obj := range *o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), serviceHistoryPrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := "SELECT \"service_history\".* FROM \"service_history\" WHERE " +
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), 1, serviceHistoryPrimaryKeyColumns, len(*o))
+
+	q := queries.Raw(sql, args...)
+
+	err := q.Bind(ctx, exec, &slice)
+	if err != nil {
+		return errors.Wrap(err, "models: unable to reload all in ServiceHistorySlice")
+	}
+
+	*o = slice
+
+	return nil
+}
+
+// ServiceHistoryExists checks if the ServiceHistory row exists.
+func ServiceHistoryExists(ctx context.Context, exec boil.ContextExecutor, iD int64) (bool, error) {
+	var exists bool
+	sql := "select exists(select 1 from \"service_history\" where \"id\"=$1 limit 1)"
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, iD)
+	}
+	row := exec.QueryRowContext(ctx, sql, iD)
+
+	err := row.Scan(&exists)
+	if err != nil {
+		return false, errors.Wrap(err, "models: unable to check if service_history exists")
+	}
+
+	return exists, nil
+}
@@ -0,0 +1,1210 @@
+// Code generated by SQLBoiler 4.2.0 (https://github.com/volatiletech/sqlboiler). DO NOT EDIT.
+// This file is meant to be re-generated in place and/or deleted at any time.
+
+package models
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/friendsofgo/errors"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+	"github.com/volatiletech/sqlboiler/v4/queries/qmhelper"
+	"github.com/volatiletech/strmangle"
+)
+
+// TopicConsumer is an object representing the database table.
+type TopicConsumer struct {
+	ID                int64 `boil:"id" json:"id" toml:"id" yaml:"id"`
+	TopicID           int64 `boil:"topic_id" json:"topic_id" toml:"topic_id" yaml:"topic_id"`
+	ServiceEndpointID int64 `boil:"service_endpoint_id" json:"service_endpoint_id" toml:"service_endpoint_id" yaml:"service_endpoint_id"`
+
+	R *topicConsumerR `boil:"-" json:"-" toml:"-" yaml:"-"`
+	L topicConsumerL  `boil:"-" json:"-" toml:"-" yaml:"-"`
+}
+
+var TopicConsumerColumns = struct {
+	ID                string
+	TopicID           string
+	ServiceEndpointID string
+}{
+	ID:                "id",
+	TopicID:           "topic_id",
+	ServiceEndpointID: "service_endpoint_id",
+}
+
+// Generated where
+
+var TopicConsumerWhere = struct {
+	ID                whereHelperint64
+	TopicID           whereHelperint64
+	ServiceEndpointID whereHelperint64
+}{
+	ID:                whereHelperint64{field: "\"topic_consumer\".\"id\""},
+	TopicID:           whereHelperint64{field: "\"topic_consumer\".\"topic_id\""},
+	ServiceEndpointID: whereHelperint64{field: "\"topic_consumer\".\"service_endpoint_id\""},
+}
+
+// TopicConsumerRels is where relationship names are stored.
+var TopicConsumerRels = struct {
+	ServiceEndpoint string
+	Topic           string
+}{
+	ServiceEndpoint: "ServiceEndpoint",
+	Topic:           "Topic",
+}
+
+// topicConsumerR is where relationships are stored.
+type topicConsumerR struct {
+	ServiceEndpoint *ServiceEndpoint `boil:"ServiceEndpoint" json:"ServiceEndpoint" toml:"ServiceEndpoint" yaml:"ServiceEndpoint"`
+	Topic           *Topic           `boil:"Topic" json:"Topic" toml:"Topic" yaml:"Topic"`
+}
+
+// NewStruct creates a new relationship struct
+func (*topicConsumerR) NewStruct() *topicConsumerR {
+	return &topicConsumerR{}
+}
+
+// topicConsumerL is where Load methods for each relationship are stored.
+type topicConsumerL struct{}
+
+var (
+	topicConsumerAllColumns            = []string{"id", "topic_id", "service_endpoint_id"}
+	topicConsumerColumnsWithoutDefault = []string{"topic_id", "service_endpoint_id"}
+	topicConsumerColumnsWithDefault    = []string{"id"}
+	topicConsumerPrimaryKeyColumns     = []string{"id"}
+)
+
+type (
+	// TopicConsumerSlice is an alias for a slice of pointers to TopicConsumer.
+	// This should generally be used opposed to []TopicConsumer.
+	TopicConsumerSlice []*TopicConsumer
+	// TopicConsumerHook is the signature for custom TopicConsumer hook methods
+	TopicConsumerHook func(context.Context, boil.ContextExecutor, *TopicConsumer) error
+
+	topicConsumerQuery struct {
+		*queries.Query
+	}
+)
+
+// Cache for insert, update and upsert
+var (
+	topicConsumerType                 = reflect.TypeOf(&TopicConsumer{})
+	topicConsumerMapping              = queries.MakeStructMapping(topicConsumerType)
+	topicConsumerPrimaryKeyMapping, _ = queries.BindMapping(topicConsumerType, topicConsumerMapping, topicConsumerPrimaryKeyColumns)
+	topicConsumerInsertCacheMut       sync.RWMutex
+	topicConsumerInsertCache          = make(map[string]insertCache)
+	topicConsumerUpdateCacheMut       sync.RWMutex
+	topicConsumerUpdateCache          = make(map[string]updateCache)
+	topicConsumerUpsertCacheMut       sync.RWMutex
+	topicConsumerUpsertCache          = make(map[string]insertCache)
+)
+
+var (
+	// Force time package dependency for automated UpdatedAt/CreatedAt.
+	_ = time.Second
+	// Force qmhelper dependency for where clause generation (which doesn't
+	// always happen)
+	_ = qmhelper.Where
+)
+
+var topicConsumerBeforeInsertHooks []TopicConsumerHook
+var topicConsumerBeforeUpdateHooks []TopicConsumerHook
+var topicConsumerBeforeDeleteHooks []TopicConsumerHook
+var topicConsumerBeforeUpsertHooks []TopicConsumerHook
+
+var topicConsumerAfterInsertHooks []TopicConsumerHook
+var topicConsumerAfterSelectHooks []TopicConsumerHook
+var topicConsumerAfterUpdateHooks []TopicConsumerHook
+var topicConsumerAfterDeleteHooks []TopicConsumerHook
+var topicConsumerAfterUpsertHooks []TopicConsumerHook
+
+// doBeforeInsertHooks executes all "before insert" hooks.
+func (o *TopicConsumer) doBeforeInsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range topicConsumerBeforeInsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeUpdateHooks executes all "before Update" hooks.
+func (o *TopicConsumer) doBeforeUpdateHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range topicConsumerBeforeUpdateHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeDeleteHooks executes all "before Delete" hooks.
+func (o *TopicConsumer) doBeforeDeleteHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range topicConsumerBeforeDeleteHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeUpsertHooks executes all "before Upsert" hooks.
+func (o *TopicConsumer) doBeforeUpsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range topicConsumerBeforeUpsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterInsertHooks executes all "after Insert" hooks.
+func (o *TopicConsumer) doAfterInsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range topicConsumerAfterInsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterSelectHooks executes all "after Select" hooks.
+func (o *TopicConsumer) doAfterSelectHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range topicConsumerAfterSelectHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterUpdateHooks executes all "after Update" hooks.
+func (o *TopicConsumer) doAfterUpdateHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range topicConsumerAfterUpdateHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterDeleteHooks executes all "after Delete" hooks.
+func (o *TopicConsumer) doAfterDeleteHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range topicConsumerAfterDeleteHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterUpsertHooks executes all "after Upsert" hooks.
+func (o *TopicConsumer) doAfterUpsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range topicConsumerAfterUpsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// AddTopicConsumerHook registers your hook function for all future operations.
+func AddTopicConsumerHook(hookPoint boil.HookPoint, topicConsumerHook TopicConsumerHook) {
+	switch hookPoint {
+	case boil.BeforeInsertHook:
+		topicConsumerBeforeInsertHooks = append(topicConsumerBeforeInsertHooks, topicConsumerHook)
+	case boil.BeforeUpdateHook:
+		topicConsumerBeforeUpdateHooks = append(topicConsumerBeforeUpdateHooks, topicConsumerHook)
+	case boil.BeforeDeleteHook:
+		topicConsumerBeforeDeleteHooks = append(topicConsumerBeforeDeleteHooks, topicConsumerHook)
+	case boil.BeforeUpsertHook:
+		topicConsumerBeforeUpsertHooks = append(topicConsumerBeforeUpsertHooks, topicConsumerHook)
+	case boil.AfterInsertHook:
+		topicConsumerAfterInsertHooks = append(topicConsumerAfterInsertHooks, topicConsumerHook)
+	case boil.AfterSelectHook:
+		topicConsumerAfterSelectHooks = append(topicConsumerAfterSelectHooks, topicConsumerHook)
+	case boil.AfterUpdateHook:
+		topicConsumerAfterUpdateHooks = append(topicConsumerAfterUpdateHooks, topicConsumerHook)
+	case boil.AfterDeleteHook:
+		topicConsumerAfterDeleteHooks = append(topicConsumerAfterDeleteHooks, topicConsumerHook)
+	case boil.AfterUpsertHook:
+		topicConsumerAfterUpsertHooks = append(topicConsumerAfterUpsertHooks, topicConsumerHook)
+	}
+}
+
+// One returns a single topicConsumer record from the query.
+func (q topicConsumerQuery) One(ctx context.Context, exec boil.ContextExecutor) (*TopicConsumer, error) {
+	o := &TopicConsumer{}
+
+	queries.SetLimit(q.Query, 1)
+
+	err := q.Bind(ctx, exec, o)
+	if err != nil {
+		if errors.Cause(err) == sql.ErrNoRows {
+			return nil, sql.ErrNoRows
+		}
+		return nil, errors.Wrap(err, "models: failed to execute a one query for topic_consumer")
+	}
+
+	if err := o.doAfterSelectHooks(ctx, exec); err != nil {
+		return o, err
+	}
+
+	return o, nil
+}
+
+// All returns all TopicConsumer records from the query.
+func (q topicConsumerQuery) All(ctx context.Context, exec boil.ContextExecutor) (TopicConsumerSlice, error) {
+	var o []*TopicConsumer
+
+	err := q.Bind(ctx, exec, &o)
+	if err != nil {
+		return nil, errors.Wrap(err, "models: failed to assign all query results to TopicConsumer slice")
+	}
+
+	if len(topicConsumerAfterSelectHooks) != 0 {
+		for _, obj := range o {
+			if err := obj.doAfterSelectHooks(ctx, exec); err != nil {
+				return o, err
+			}
+		}
+	}
+
+	return o, nil
+}
+
+// Count returns the count of all TopicConsumer records in the query.
+func (q topicConsumerQuery) Count(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	var count int64
+
+	queries.SetSelect(q.Query, nil)
+	queries.SetCount(q.Query)
+
+	err := q.Query.QueryRowContext(ctx, exec).Scan(&count)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to count topic_consumer rows")
+	}
+
+	return count, nil
+}
+
+// Exists checks if the row exists in the table.
+func (q topicConsumerQuery) Exists(ctx context.Context, exec boil.ContextExecutor) (bool, error) {
+	var count int64
+
+	queries.SetSelect(q.Query, nil)
+	queries.SetCount(q.Query)
+	queries.SetLimit(q.Query, 1)
+
+	err := q.Query.QueryRowContext(ctx, exec).Scan(&count)
+	if err != nil {
+		return false, errors.Wrap(err, "models: failed to check if topic_consumer exists")
+	}
+
+	return count > 0, nil
+}
+
+// ServiceEndpoint pointed to by the foreign key.
+func (o *TopicConsumer) ServiceEndpoint(mods ...qm.QueryMod) serviceEndpointQuery {
+	queryMods := []qm.QueryMod{
+		qm.Where("\"id\" = ?", o.ServiceEndpointID),
+	}
+
+	queryMods = append(queryMods, mods...)
+
+	query := ServiceEndpoints(queryMods...)
+	queries.SetFrom(query.Query, "\"service_endpoint\"")
+
+	return query
+}
+
+// Topic pointed to by the foreign key.
+func (o *TopicConsumer) Topic(mods ...qm.QueryMod) topicQuery {
+	queryMods := []qm.QueryMod{
+		qm.Where("\"id\" = ?", o.TopicID),
+	}
+
+	queryMods = append(queryMods, mods...)
+
+	query := Topics(queryMods...)
+	queries.SetFrom(query.Query, "\"topic\"")
+
+	return query
+}
+
+// LoadServiceEndpoint allows an eager lookup of values, cached into the
+// loaded structs of the objects. This is for an N-1 relationship.
+func (topicConsumerL) LoadServiceEndpoint(ctx context.Context, e boil.ContextExecutor, singular bool, maybeTopicConsumer interface{}, mods queries.Applicator) error {
+	var slice []*TopicConsumer
+	var object *TopicConsumer
+
+	if singular {
+		object = maybeTopicConsumer.(*TopicConsumer)
+	} else {
+		slice = *maybeTopicConsumer.(*[]*TopicConsumer)
+	}
+
+	args := make([]interface{}, 0, 1)
+	if singular {
+		if object.R == nil {
+			object.R = &topicConsumerR{}
+		}
+		args = append(args, object.ServiceEndpointID)
+
+	} else {
+	Outer:
+		for _, obj := range slice {
+			if obj.R == nil {
+				obj.R = &topicConsumerR{}
+			}
+
+			for _, a := range args {
+				if a == obj.ServiceEndpointID {
+					continue Outer
+				}
+			}
+
+			args = append(args, obj.ServiceEndpointID)
+
+		}
+	}
+
+	if len(args) == 0 {
+		return nil
+	}
+
+	query := NewQuery(
+		qm.From(`service_endpoint`),
+		qm.WhereIn(`service_endpoint.id in ?`, args...),
+	)
+	if mods != nil {
+		mods.Apply(query)
+	}
+
+	results, err := query.QueryContext(ctx, e)
+	if err != nil {
+		return errors.Wrap(err, "failed to eager load ServiceEndpoint")
+	}
+
+	var resultSlice []*ServiceEndpoint
+	if err = queries.Bind(results, &resultSlice); err != nil {
+		return errors.Wrap(err, "failed to bind eager loaded slice ServiceEndpoint")
+	}
+
+	if err = results.Close(); err != nil {
+		return errors.Wrap(err, "failed to close results of eager load for service_endpoint")
+	}
+	if err = results.Err(); err != nil {
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for service_endpoint")
+	}
+
+	if len(topicConsumerAfterSelectHooks) != 0 {
+		for _, obj := range resultSlice {
+			if err := obj.doAfterSelectHooks(ctx, e); err != nil {
+				return err
+			}
+		}
+	}
+
+	if len(resultSlice) == 0 {
+		return nil
+	}
+
+	if singular {
+		foreign := resultSlice[0]
+		object.R.ServiceEndpoint = foreign
+		if foreign.R == nil {
+			foreign.R = &serviceEndpointR{}
+		}
+		foreign.R.TopicConsumers = append(foreign.R.TopicConsumers, object)
+		return nil
+	}
+
+	for _, local := range slice {
+		for _, foreign := range resultSlice {
+			if local.ServiceEndpointID == foreign.ID {
+				local.R.ServiceEndpoint = foreign
+				if foreign.R == nil {
+					foreign.R = &serviceEndpointR{}
+				}
+				foreign.R.TopicConsumers = append(foreign.R.TopicConsumers, local)
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
+// LoadTopic allows an eager lookup of values, cached into the
+// loaded structs of the objects. This is for an N-1 relationship.
+func (topicConsumerL) LoadTopic(ctx context.Context, e boil.ContextExecutor, singular bool, maybeTopicConsumer interface{}, mods queries.Applicator) error {
+	var slice []*TopicConsumer
+	var object *TopicConsumer
+
+	if singular {
+		object = maybeTopicConsumer.(*TopicConsumer)
+	} else {
+		slice = *maybeTopicConsumer.(*[]*TopicConsumer)
+	}
+
+	args := make([]interface{}, 0, 1)
+	if singular {
+		if object.R == nil {
+			object.R = &topicConsumerR{}
+		}
+		args = append(args, object.TopicID)
+
+	} else {
+	Outer:
+		for _, obj := range slice {
+			if obj.R == nil {
+				obj.R = &topicConsumerR{}
+			}
+
+			for _, a := range args {
+				if a == obj.TopicID {
+					continue Outer
+				}
+			}
+
+			args = append(args, obj.TopicID)
+
+		}
+	}
+
+	if len(args) == 0 {
+		return nil
+	}
+
+	query := NewQuery(
+		qm.From(`topic`),
+		qm.WhereIn(`topic.id in ?`, args...),
+	)
+	if mods != nil {
+		mods.Apply(query)
+	}
+
+	results, err := query.QueryContext(ctx, e)
+	if err != nil {
+		return errors.Wrap(err, "failed to eager load Topic")
+	}
+
+	var resultSlice []*Topic
+	if err = queries.Bind(results, &resultSlice); err != nil {
+		return errors.Wrap(err, "failed to bind eager loaded slice Topic")
+	}
+
+	if err = results.Close(); err != nil {
+		return errors.Wrap(err, "failed to close results of eager load for topic")
+	}
+	if err = results.Err(); err != nil {
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for topic")
+	}
+
+	if len(topicConsumerAfterSelectHooks) != 0 {
+		for _, obj := range resultSlice {
+			if err := obj.doAfterSelectHooks(ctx, e); err != nil {
+				return err
+			}
+		}
+	}
+
+	if len(resultSlice) == 0 {
+		return nil
+	}
+
+	if singular {
+		foreign := resultSlice[0]
+		object.R.Topic = foreign
+		if foreign.R == nil {
+			foreign.R = &topicR{}
+		}
+		foreign.R.TopicConsumers = append(foreign.R.TopicConsumers, object)
+		return nil
+	}
+
+	for _, local := range slice {
+		for _, foreign := range resultSlice {
+			if local.TopicID == foreign.ID {
+				local.R.Topic = foreign
+				if foreign.R == nil {
+					foreign.R = &topicR{}
+				}
+				foreign.R.TopicConsumers = append(foreign.R.TopicConsumers, local)
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
+// SetServiceEndpoint of the topicConsumer to the related item.
+// Sets o.R.ServiceEndpoint to related.
+// Adds o to related.R.TopicConsumers.
+func (o *TopicConsumer) SetServiceEndpoint(ctx context.Context, exec boil.ContextExecutor, insert bool, related *ServiceEndpoint) error {
+	var err error
+	if insert {
+		if err = related.Insert(ctx, exec, boil.Infer()); err != nil {
+			return errors.Wrap(err, "failed to insert into foreign table")
+		}
+	}
+
+	updateQuery := fmt.Sprintf(
+		"UPDATE \"topic_consumer\" SET %s WHERE %s",
+		strmangle.SetParamNames("\"", "\"", 1, []string{"service_endpoint_id"}),
+		strmangle.WhereClause("\"", "\"", 2, topicConsumerPrimaryKeyColumns),
+	)
+	values := []interface{}{related.ID, o.ID}
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, updateQuery)
+		fmt.Fprintln(writer, values)
+	}
+	if _, err = exec.ExecContext(ctx, updateQuery, values...); err != nil {
+		return errors.Wrap(err, "failed to update local table")
+	}
+
+	o.ServiceEndpointID = related.ID
+	if o.R == nil {
+		o.R = &topicConsumerR{
+			ServiceEndpoint: related,
+		}
+	} else {
+		o.R.ServiceEndpoint = related
+	}
+
+	if related.R == nil {
+		related.R = &serviceEndpointR{
+			TopicConsumers: TopicConsumerSlice{o},
+		}
+	} else {
+		related.R.TopicConsumers = append(related.R.TopicConsumers, o)
+	}
+
+	return nil
+}
+
+// SetTopic of the topicConsumer to the related item.
+// Sets o.R.Topic to related.
+// Adds o to related.R.TopicConsumers.
+func (o *TopicConsumer) SetTopic(ctx context.Context, exec boil.ContextExecutor, insert bool, related *Topic) error {
+	var err error
+	if insert {
+		if err = related.Insert(ctx, exec, boil.Infer()); err != nil {
+			return errors.Wrap(err, "failed to insert into foreign table")
+		}
+	}
+
+	updateQuery := fmt.Sprintf(
+		"UPDATE \"topic_consumer\" SET %s WHERE %s",
+		strmangle.SetParamNames("\"", "\"", 1, []string{"topic_id"}),
+		strmangle.WhereClause("\"", "\"", 2, topicConsumerPrimaryKeyColumns),
+	)
+	values := []interface{}{related.ID, o.ID}
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, updateQuery)
+		fmt.Fprintln(writer, values)
+	}
+	if _, err = exec.ExecContext(ctx, updateQuery, values...); err != nil {
+		return errors.Wrap(err, "failed to update local table")
+	}
+
+	o.TopicID = related.ID
+	if o.R == nil {
+		o.R = &topicConsumerR{
+			Topic: related,
+		}
+	} else {
+		o.R.Topic = related
+	}
+
+	if related.R == nil {
+		related.R = &topicR{
+			TopicConsumers: TopicConsumerSlice{o},
+		}
+	} else {
+		related.R.TopicConsumers = append(related.R.TopicConsumers, o)
+	}
+
+	return nil
+}
+
+// TopicConsumers retrieves all the records using an executor.
+func TopicConsumers(mods ...qm.QueryMod) topicConsumerQuery {
+	mods = append(mods, qm.From("\"topic_consumer\""))
+	return topicConsumerQuery{NewQuery(mods...)}
+}
+
+// FindTopicConsumer retrieves a single record by ID with an executor.
+// If selectCols is empty Find will return all columns.
+func FindTopicConsumer(ctx context.Context, exec boil.ContextExecutor, iD int64, selectCols ...string) (*TopicConsumer, error) {
+	topicConsumerObj := &TopicConsumer{}
+
+	sel := "*"
+	if len(selectCols) > 0 {
+		sel = strings.Join(strmangle.IdentQuoteSlice(dialect.LQ, dialect.RQ, selectCols), ",")
+	}
+	query := fmt.Sprintf(
+		"select %s from \"topic_consumer\" where \"id\"=$1", sel,
+	)
+
+	q := queries.Raw(query, iD)
+
+	err := q.Bind(ctx, exec, topicConsumerObj)
+	if err != nil {
+		if errors.Cause(err) == sql.ErrNoRows {
+			return nil, sql.ErrNoRows
+		}
+		return nil, errors.Wrap(err, "models: unable to select from topic_consumer")
+	}
+
+	return topicConsumerObj, nil
+}
+
+// Insert a single record using an executor.
+// See boil.Columns.InsertColumnSet documentation to understand column list inference for inserts.
+func (o *TopicConsumer) Insert(ctx context.Context, exec boil.ContextExecutor, columns boil.Columns) error {
+	if o == nil {
+		return errors.New("models: no topic_consumer provided for insertion")
+	}
+
+	var err error
+
+	if err := o.doBeforeInsertHooks(ctx, exec); err != nil {
+		return err
+	}
+
+	nzDefaults := queries.NonZeroDefaultSet(topicConsumerColumnsWithDefault, o)
+
+	key := makeCacheKey(columns, nzDefaults)
+	topicConsumerInsertCacheMut.RLock()
+	cache, cached := topicConsumerInsertCache[key]
+	topicConsumerInsertCacheMut.RUnlock()
+
+	if !cached {
+		wl, returnColumns := columns.InsertColumnSet(
+			topicConsumerAllColumns,
+			topicConsumerColumnsWithDefault,
+			topicConsumerColumnsWithoutDefault,
+			nzDefaults,
+		)
+
+		cache.valueMapping, err = queries.BindMapping(topicConsumerType, topicConsumerMapping, wl)
+		if err != nil {
+			return err
+		}
+		cache.retMapping, err = queries.BindMapping(topicConsumerType, topicConsumerMapping, returnColumns)
+		if err != nil {
+			return err
+		}
+		if len(wl) != 0 {
+			cache.query = fmt.Sprintf("INSERT INTO \"topic_consumer\" (\"%s\") %%sVALUES (%s)%%s", strings.Join(wl, "\",\""), strmangle.Placeholders(dialect.UseIndexPlaceholders, len(wl), 1, 1))
+		} else {
+			cache.query = "INSERT INTO \"topic_consumer\" %sDEFAULT VALUES%s"
+		}
+
+		var queryOutput, queryReturning string
+
+		if len(cache.retMapping) != 0 {
+			queryReturning = fmt.Sprintf(" RETURNING \"%s\"", strings.Join(returnColumns, "\",\""))
+		}
+
+		cache.query = fmt.Sprintf(cache.query, queryOutput, queryReturning)
+	}
+
+	value := reflect.Indirect(reflect.ValueOf(o))
+	vals := queries.ValuesFromMapping(value, cache.valueMapping)
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.query)
+		fmt.Fprintln(writer, vals)
+	}
+
+	if len(cache.retMapping) != 0 {
+		err = exec.QueryRowContext(ctx, cache.query, vals...).Scan(queries.PtrsFromMapping(value, cache.retMapping)...)
+	} else {
+		_, err = exec.ExecContext(ctx, cache.query, vals...)
+	}
+
+	if err != nil {
+		return errors.Wrap(err, "models: unable to insert into topic_consumer")
+	}
+
+	if !cached {
+		topicConsumerInsertCacheMut.Lock()
+		topicConsumerInsertCache[key] = cache
+		topicConsumerInsertCacheMut.Unlock()
+	}
+
+	return o.doAfterInsertHooks(ctx, exec)
+}
+
+// Update uses an executor to update the TopicConsumer.
+// See boil.Columns.UpdateColumnSet documentation to understand column list inference for updates.
+// Update does not automatically update the record in case of default values. Use .Reload() to refresh the records.
+func (o *TopicConsumer) Update(ctx context.Context, exec boil.ContextExecutor, columns boil.Columns) (int64, error) {
+	var err error
+	if err = o.doBeforeUpdateHooks(ctx, exec); err != nil {
+		return 0, err
+	}
+	key := makeCacheKey(columns, nil)
+	topicConsumerUpdateCacheMut.RLock()
+	cache, cached := topicConsumerUpdateCache[key]
+	topicConsumerUpdateCacheMut.RUnlock()
+
+	if !cached {
+		wl := columns.UpdateColumnSet(
+			topicConsumerAllColumns,
+			topicConsumerPrimaryKeyColumns,
+		)
+
+		if !columns.IsWhitelist() {
+			wl = strmangle.SetComplement(wl, []string{"created_at"})
+		}
+		if len(wl) == 0 {
+			return 0, errors.New("models: unable to update topic_consumer, could not build whitelist")
+		}
+
+		cache.query = fmt.Sprintf("UPDATE \"topic_consumer\" SET %s WHERE %s",
+			strmangle.SetParamNames("\"", "\"", 1, wl),
+			strmangle.WhereClause("\"", "\"", len(wl)+1, topicConsumerPrimaryKeyColumns),
+		)
+		cache.valueMapping, err = queries.BindMapping(topicConsumerType, topicConsumerMapping, append(wl, topicConsumerPrimaryKeyColumns...))
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	values := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(o)), cache.valueMapping)
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.query)
+		fmt.Fprintln(writer, values)
+	}
+	var result sql.Result
+	result, err = exec.ExecContext(ctx, cache.query, values...)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to update topic_consumer row")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to get rows affected by update for topic_consumer")
+	}
+
+	if !cached {
+		topicConsumerUpdateCacheMut.Lock()
+		topicConsumerUpdateCache[key] = cache
+		topicConsumerUpdateCacheMut.Unlock()
+	}
+
+	return rowsAff, o.doAfterUpdateHooks(ctx, exec)
+}
+
+// UpdateAll updates all rows with the specified column values.
+func (q topicConsumerQuery) UpdateAll(ctx context.Context, exec boil.ContextExecutor, cols M) (int64, error) {
+	queries.SetUpdate(q.Query, cols)
+
+	result, err := q.Query.ExecContext(ctx, exec)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to update all for topic_consumer")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to retrieve rows affected for topic_consumer")
+	}
+
+	return rowsAff, nil
+}
+
+// UpdateAll updates all rows with the specified column values, using an executor.
+func (o TopicConsumerSlice) UpdateAll(ctx context.Context, exec boil.ContextExecutor, cols M) (int64, error) {
+	ln := int64(len(o))
+	if ln == 0 {
+		return 0, nil
+	}
+
+	if len(cols) == 0 {
+		return 0, errors.New("models: update all requires at least one column argument")
+	}
+
+	colNames := make([]string, len(cols))
+	args := make([]interface{}, len(cols))
+
+	i := 0
+	for name, value := range cols {
+		colNames[i] = name
+		args[i] = value
+		i++
+	}
+
+	// Append all of the primary key values for each column
+	for _, obj := range o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), topicConsumerPrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := fmt.Sprintf("UPDATE \"topic_consumer\" SET %s WHERE %s",
+		strmangle.SetParamNames("\"", "\"", 1, colNames),
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), len(colNames)+1, topicConsumerPrimaryKeyColumns, len(o)))
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, args...)
+	}
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to update all in topicConsumer slice")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to retrieve rows affected all in update all topicConsumer")
+	}
+	return rowsAff, nil
+}
+
+// Upsert attempts an insert using an executor, and does an update or ignore on conflict.
+// See boil.Columns documentation for how to properly use updateColumns and insertColumns.
+func (o *TopicConsumer) Upsert(ctx context.Context, exec boil.ContextExecutor, updateOnConflict bool, conflictColumns []string, updateColumns, insertColumns boil.Columns) error {
+	if o == nil {
+		return errors.New("models: no topic_consumer provided for upsert")
+	}
+
+	if err := o.doBeforeUpsertHooks(ctx, exec); err != nil {
+		return err
+	}
+
+	nzDefaults := queries.NonZeroDefaultSet(topicConsumerColumnsWithDefault, o)
+
+	// Build cache key in-line uglily - mysql vs psql problems
+	buf := strmangle.GetBuffer()
+	if updateOnConflict {
+		buf.WriteByte('t')
+	} else {
+		buf.WriteByte('f')
+	}
+	buf.WriteByte('.')
+	for _, c := range conflictColumns {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	buf.WriteString(strconv.Itoa(updateColumns.Kind))
+	for _, c := range updateColumns.Cols {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	buf.WriteString(strconv.Itoa(insertColumns.Kind))
+	for _, c := range insertColumns.Cols {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	for _, c := range nzDefaults {
+		buf.WriteString(c)
+	}
+	key := buf.String()
+	strmangle.PutBuffer(buf)
+
+	topicConsumerUpsertCacheMut.RLock()
+	cache, cached := topicConsumerUpsertCache[key]
+	topicConsumerUpsertCacheMut.RUnlock()
+
+	var err error
+
+	if !cached {
+		insert, ret := insertColumns.InsertColumnSet(
+			topicConsumerAllColumns,
+			topicConsumerColumnsWithDefault,
+			topicConsumerColumnsWithoutDefault,
+			nzDefaults,
+		)
+		update := updateColumns.UpdateColumnSet(
+			topicConsumerAllColumns,
+			topicConsumerPrimaryKeyColumns,
+		)
+
+		if updateOnConflict && len(update) == 0 {
+			return errors.New("models: unable to upsert topic_consumer, could not build update column list")
+		}
+
+		conflict := conflictColumns
+		if len(conflict) == 0 {
+			conflict = make([]string, len(topicConsumerPrimaryKeyColumns))
+			copy(conflict, topicConsumerPrimaryKeyColumns)
+		}
+		cache.query = buildUpsertQueryPostgres(dialect, "\"topic_consumer\"", updateOnConflict, ret, update, conflict, insert)
+
+		cache.valueMapping, err = queries.BindMapping(topicConsumerType, topicConsumerMapping, insert)
+		if err != nil {
+			return err
+		}
+		if len(ret) != 0 {
+			cache.retMapping, err = queries.BindMapping(topicConsumerType, topicConsumerMapping, ret)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	value := reflect.Indirect(reflect.ValueOf(o))
+	vals := queries.ValuesFromMapping(value, cache.valueMapping)
+	var returns []interface{}
+	if len(cache.retMapping) != 0 {
+		returns = queries.PtrsFromMapping(value, cache.retMapping)
+	}
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.query)
+		fmt.Fprintln(writer, vals)
+	}
+	if len(cache.retMapping) != 0 {
+		err = exec.QueryRowContext(ctx, cache.query, vals...).Scan(returns...)
+		if err == sql.ErrNoRows {
+			err = nil // Postgres doesn't return anything when there's no update
+		}
+	} else {
+		_, err = exec.ExecContext(ctx, cache.query, vals...)
+	}
+	if err != nil {
+		return errors.Wrap(err, "models: unable to upsert topic_consumer")
+	}
+
+	if !cached {
+		topicConsumerUpsertCacheMut.Lock()
+		topicConsumerUpsertCache[key] = cache
+		topicConsumerUpsertCacheMut.Unlock()
+	}
+
+	return o.doAfterUpsertHooks(ctx, exec)
+}
+
+// Delete deletes a single TopicConsumer record with an executor.
+// Delete will match against the primary key column to find the record to delete.
+func (o *TopicConsumer) Delete(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if o == nil {
+		return 0, errors.New("models: no TopicConsumer provided for delete")
+	}
+
+	if err := o.doBeforeDeleteHooks(ctx, exec); err != nil {
+		return 0, err
+	}
+
+	args := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(o)), topicConsumerPrimaryKeyMapping)
+	sql := "DELETE FROM \"topic_consumer\" WHERE \"id\"=$1"
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, args...)
+	}
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to delete from topic_consumer")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to get rows affected by delete for topic_consumer")
+	}
+
+	if err := o.doAfterDeleteHooks(ctx, exec); err != nil {
+		return 0, err
+	}
+
+	return rowsAff, nil
+}
+
+// DeleteAll deletes all matching rows.
+func (q topicConsumerQuery) DeleteAll(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if q.Query == nil {
+		return 0, errors.New("models: no topicConsumerQuery provided for delete all")
+	}
+
+	queries.SetDelete(q.Query)
+
+	result, err := q.Query.ExecContext(ctx, exec)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to delete all from topic_consumer")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to get rows affected by deleteall for topic_consumer")
+	}
+
+	return rowsAff, nil
+}
+
+// DeleteAll deletes all rows in the slice, using an executor.
+func (o TopicConsumerSlice) DeleteAll(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if len(o) == 0 {
+		return 0, nil
+	}
+
+	if len(topicConsumerBeforeDeleteHooks) != 0 {
+		for _, obj := range o {
+			if err := obj.doBeforeDeleteHooks(ctx, exec); err != nil {
+				return 0, err
+			}
+		}
+	}
+
+	var args []interface{}
+	for _, obj := range o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), topicConsumerPrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := "DELETE FROM \"topic_consumer\" WHERE " +
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), 1, topicConsumerPrimaryKeyColumns, len(o))
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, args)
+	}
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to delete all from topicConsumer slice")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to get rows affected by deleteall for topic_consumer")
+	}
+
+	if len(topicConsumerAfterDeleteHooks) != 0 {
+		for _, obj := range o {
+			if err := obj.doAfterDeleteHooks(ctx, exec); err != nil {
+				return 0, err
+			}
+		}
+	}
+
+	return rowsAff, nil
+}
+
+// Reload refetches the object from the database
+// using the primary keys with an executor.
+func (o *TopicConsumer) Reload(ctx context.Context, exec boil.ContextExecutor) error {
+	ret, err := FindTopicConsumer(ctx, exec, o.ID)
+	if err != nil {
+		return err
+	}
+
+	*o = *ret
+	return nil
+}
+
+// ReloadAll refetches every row with matching primary key column values
+// and overwrites the original object slice with the newly updated slice.
+func (o *TopicConsumerSlice) ReloadAll(ctx context.Context, exec boil.ContextExecutor) error {
+	if o == nil || len(*o) == 0 {
+		return nil
+	}
+
+	slice := TopicConsumerSlice{}
+	var args []interface{}
+	for _, obj := range *o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), topicConsumerPrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := "SELECT \"topic_consumer\".* FROM \"topic_consumer\" WHERE " +
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), 1, topicConsumerPrimaryKeyColumns, len(*o))
+
+	q := queries.Raw(sql, args...)
+
+	err := q.Bind(ctx, exec, &slice)
+	if err != nil {
+		return errors.Wrap(err, "models: unable to reload all in TopicConsumerSlice")
+	}
+
+	*o = slice
+
+	return nil
+}
+
+// TopicConsumerExists checks if the TopicConsumer row exists.
+func TopicConsumerExists(ctx context.Context, exec boil.ContextExecutor, iD int64) (bool, error) {
+	var exists bool
+	sql := "select exists(select 1 from \"topic_consumer\" where \"id\"=$1 limit 1)"
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, iD)
+	}
+	row := exec.QueryRowContext(ctx, sql, iD)
+
+	err := row.Scan(&exists)
+	if err != nil {
+		return false, errors.Wrap(err, "models: unable to check if topic_consumer exists")
+	}
+
+	return exists, nil
+}
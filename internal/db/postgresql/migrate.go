@@ -26,5 +26,8 @@ func Migrate(db *sqlx.DB, dbURL *dburl.URL, migrationDir string) {
 	if err != nil {
 		log.Fatal(err)
 	}
-	m.Steps(2)
+	err = m.Up()
+	if err != nil && err != gomigrate.ErrNoChange {
+		log.Fatal(err)
+	}
 }
@@ -0,0 +1,92 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"github.com/jmoiron/sqlx"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/yashap/crius/internal/errors"
+	"go.uber.org/zap"
+)
+
+// Transactor runs work in a database transaction that repositories join, so that changes made through several
+// repositories, or through several calls to one repository, are committed or rolled back together
+type Transactor interface {
+	// InTransaction begins a transaction and runs fn in it. The transaction is committed if fn succeeds, and otherwise
+	// it is rolled back, and fn's error is returned
+	InTransaction(fn func(tx *sql.Tx) error) error
+}
+
+// NewTransactor creates a Transactor that begins its transactions on the database
+func NewTransactor(database *sqlx.DB, logger *zap.SugaredLogger) Transactor {
+	return &transactor{db: database, logger: logger}
+}
+
+type transactor struct {
+	db     *sqlx.DB
+	logger *zap.SugaredLogger
+}
+
+func (t *transactor) InTransaction(fn func(tx *sql.Tx) error) error {
+	tx, err := t.db.BeginTx(context.Background(), nil)
+	if err != nil {
+		msg := "Failed to begin transaction"
+		t.logger.Errorw(msg, "err", err.Error())
+		return errors.DatabaseError(msg, &err)
+	}
+	err = fn(tx)
+	if err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+	err = tx.Commit()
+	if err != nil {
+		msg := "Failed to commit transaction"
+		t.logger.Errorw(msg, "err", err.Error())
+		return errors.DatabaseError(msg, &err)
+	}
+	return nil
+}
+
+// Tx is a transaction that a repository makes its changes in
+type Tx interface {
+	boil.ContextExecutor
+	Commit() error
+	Rollback() error
+}
+
+// Begin begins a transaction on the database for a repository's changes. If the repository joined a transaction that
+// is already open, that transaction is used instead, and committing or rolling it back is left to whoever began it
+func Begin(database *sqlx.DB, joined *sql.Tx) (Tx, error) {
+	if joined != nil {
+		return joinedTx{joined}, nil
+	}
+	tx, err := database.BeginTx(context.Background(), nil)
+	if err != nil {
+		return nil, err
+	}
+	return tx, nil
+}
+
+// Executor is what a repository runs its queries with: the transaction that it joined, if it joined one, so that it
+// sees the transaction's changes, and otherwise the database
+func Executor(database *sqlx.DB, joined *sql.Tx) boil.ContextExecutor {
+	if joined != nil {
+		return joined
+	}
+	return database
+}
+
+// joinedTx is a transaction that a repository joined. A repository commits or rolls back its own changes, but those of
+// a joined transaction are only committed or rolled back with the rest of it
+type joinedTx struct {
+	*sql.Tx
+}
+
+func (joinedTx) Commit() error {
+	return nil
+}
+
+func (joinedTx) Rollback() error {
+	return nil
+}
@@ -0,0 +1,80 @@
+package client
+
+import (
+	"sort"
+
+	"github.com/yashap/crius/internal/domain/service"
+)
+
+// Dependent is a Client that depends on the starting point of a search of the dependency graph, either by calling one
+// of the starting Endpoints directly, or by calling an Endpoint that depends on them
+type Dependent struct {
+	// ClientCode is the Code of the dependent Client
+	ClientCode Code
+	// ClientName is the friendly name of the dependent Client
+	ClientName Name
+	// Distance is the number of dependency hops from the Client to the starting point. Clients that call a starting
+	// Endpoint directly have a Distance of 1
+	Distance int
+	// Path is the chain of Endpoints from the starting point to the Client. It starts with one of the starting Endpoints,
+	// and ends with the Endpoint that the Client calls
+	Path []service.EndpointRef
+}
+
+// MakeDependents finds the Dependents among clients, given the Endpoints that a search started from, and the
+// dependents found by walking the graph upstream from them. Each Client is reported once, with its shortest Path
+func MakeDependents(start []service.EndpointRef, dependents []service.Dependency, clients []Client) []Dependent {
+	// For every Endpoint that a Client could call, the shortest Path from the starting point to it
+	paths := make(map[service.EndpointRef][]service.EndpointRef)
+	for _, ref := range start {
+		paths[ref] = []service.EndpointRef{ref}
+	}
+	for _, dependent := range dependents {
+		if _, ok := paths[dependent.Endpoint]; !ok {
+			paths[dependent.Endpoint] = dependent.Path
+		}
+	}
+	clientDependents := make([]Dependent, 0)
+	for _, c := range clients {
+		var shortest []service.EndpointRef
+		for _, ref := range dependencyRefs(c.Dependencies) {
+			path, ok := paths[ref]
+			if ok && (shortest == nil || len(path) < len(shortest)) {
+				shortest = path
+			}
+		}
+		if shortest != nil {
+			clientDependents = append(clientDependents, Dependent{
+				ClientCode: c.Code,
+				ClientName: c.Name,
+				Distance:   len(shortest),
+				Path:       shortest,
+			})
+		}
+	}
+	sort.Slice(clientDependents, func(i, j int) bool {
+		a, b := clientDependents[i], clientDependents[j]
+		if a.Distance != b.Distance {
+			return a.Distance < b.Distance
+		}
+		return a.ClientCode < b.ClientCode
+	})
+	return clientDependents
+}
+
+// dependencyRefs flattens a Client's dependencies into the Endpoints that it calls, sorted
+func dependencyRefs(dependencies map[service.Code][]service.EndpointCode) []service.EndpointRef {
+	refs := make([]service.EndpointRef, 0)
+	for serviceCode, endpointCodes := range dependencies {
+		for _, endpointCode := range endpointCodes {
+			refs = append(refs, service.EndpointRef{ServiceCode: serviceCode, EndpointCode: endpointCode})
+		}
+	}
+	sort.Slice(refs, func(i, j int) bool {
+		if refs[i].ServiceCode != refs[j].ServiceCode {
+			return refs[i].ServiceCode < refs[j].ServiceCode
+		}
+		return refs[i].EndpointCode < refs[j].EndpointCode
+	})
+	return refs
+}
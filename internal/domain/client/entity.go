@@ -0,0 +1,48 @@
+package client
+
+import "github.com/yashap/crius/internal/domain/service"
+
+// Code is a code that uniquely identifies a Client
+type Code = string
+
+// Name is the human-readable/friendly name of a Client
+type Name = string
+
+// Client represents a frontend or client application, like a web or mobile app. It calls the Endpoints of Services,
+// but has no Endpoints of its own, so it is only ever a source of dependencies, never a target
+type Client struct {
+	// ID uniquely identifies this client
+	ID *int64
+	// Code is a unique code for the client. For example, "ios_v5" for version 5 of an iOS app
+	Code Code
+	// Name is a friendly name for the client. For example, "iOS App v5" for version 5 of an iOS app
+	Name Name
+	// Dependencies are the Endpoints that the client calls. The keys are Service Codes, and the values are the Codes of
+	// Endpoints of that Service
+	Dependencies map[service.Code][]service.EndpointCode
+}
+
+// MakeClient constructs a Client
+func MakeClient(
+	id *int64,
+	code Code,
+	name Name,
+	dependencies map[service.Code][]service.EndpointCode,
+) Client {
+	return Client{
+		ID:           id,
+		Code:         code,
+		Name:         name,
+		Dependencies: dependencies,
+	}
+}
+
+// DependsOn returns whether the Client calls the Endpoint
+func (c Client) DependsOn(ref service.EndpointRef) bool {
+	for _, endpointCode := range c.Dependencies[ref.ServiceCode] {
+		if endpointCode == ref.EndpointCode {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,24 @@
+package client
+
+import (
+	"time"
+
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/yashap/crius/internal/domain/service"
+)
+
+// Recorder records the history of Clients. Like a service.Recorder, a Repository calls it within the transaction that
+// changes a Client, so that the change and its record are committed (or rolled back) together
+type Recorder interface {
+	// RecordClient records that the Client with the Code, in the Environment, was changed by the Actor at changedAt,
+	// leaving it as current (which is nil if the Client was deleted). It runs within the transaction exec, which is
+	// still open, so current is exactly what the transaction will commit
+	RecordClient(
+		exec boil.ContextExecutor,
+		env service.Environment,
+		code Code,
+		current *Client,
+		actor service.Actor,
+		changedAt time.Time,
+	) error
+}
@@ -0,0 +1,130 @@
+package client
+
+import (
+	"database/sql"
+	"fmt"
+	"github.com/jmoiron/sqlx"
+	"github.com/xo/dburl"
+	"github.com/yashap/crius/internal/domain/service"
+	"github.com/yashap/crius/internal/errors"
+	"go.uber.org/zap"
+	"log"
+	"sort"
+)
+
+// Repository is a Client repository. Like service.Repository, the mental model is that it represents a collection of
+// Client instances, and every change that it makes to Clients is recorded by its Recorder, in the same transaction
+type Repository interface {
+	// InEnvironment returns a Repository of the Clients in the given Environment. A new Repository holds the Clients in
+	// the service.DefaultEnvironment
+	InEnvironment(env service.Environment) Repository
+	// InTransaction returns a Repository that makes its changes in the transaction tx, which is already open, so that
+	// they are committed or rolled back along with the rest of it
+	InTransaction(tx *sql.Tx) Repository
+	// AsActor returns a Repository that records its changes to Clients as made by the given Actor. A new Repository
+	// records them as made by the service.AnonymousActor
+	AsActor(actor service.Actor) Repository
+	// Save saves a Client, fully replacing any previous version of it, including its dependencies
+	Save(c *Client) error
+	// FindByCode finds a Client by its Code
+	FindByCode(code Code) (*Client, error)
+	// FindAll finds every Client, sorted by Code
+	FindAll() ([]Client, error)
+	// FindByDependencies finds every Client that depends on at least one of the Endpoints, sorted by Code
+	FindByDependencies(refs []service.EndpointRef) ([]Client, error)
+	// Delete deletes a Client by its Code, along with its dependencies
+	Delete(code Code) error
+	// FindEnvironments finds every Environment that has at least one Client, sorted
+	FindEnvironments() ([]service.Environment, error)
+}
+
+func NewRepository(
+	dbURL *dburl.URL,
+	db *sqlx.DB,
+	logger *zap.SugaredLogger,
+	recorder Recorder,
+) Repository {
+	if dbURL.Driver == "postgres" {
+		return &postgresRepository{
+			db:          db,
+			logger:      logger,
+			environment: service.DefaultEnvironment,
+			recorder:    recorder,
+			actor:       service.AnonymousActor,
+		}
+	} else if dbURL.Driver == "mysql" {
+		return &mysqlRepository{
+			db:          db,
+			logger:      logger,
+			environment: service.DefaultEnvironment,
+			recorder:    recorder,
+			actor:       service.AnonymousActor,
+		}
+	}
+	log.Fatalf("Unsupported database: %s", dbURL.Driver)
+	return nil
+}
+
+// endpointServiceCodes returns the Codes of every Service that the Endpoints belong to
+func endpointServiceCodes(refs []service.EndpointRef) []interface{} {
+	seen := make(map[service.Code]bool)
+	codes := make([]interface{}, 0)
+	for _, ref := range refs {
+		if !seen[ref.ServiceCode] {
+			seen[ref.ServiceCode] = true
+			codes = append(codes, ref.ServiceCode)
+		}
+	}
+	return codes
+}
+
+// resolveEndpointIDs maps the dependencies of the Client to the ids of their Endpoints. known maps the Codes of
+// existing Services to the Codes and ids of their Endpoints. Every dependency that doesn't resolve is listed in the
+// returned error
+func resolveEndpointIDs(c *Client, known map[service.Code]map[service.EndpointCode]int64) ([]int64, error) {
+	details := make([]errors.Detail, 0)
+	ids := make([]int64, 0)
+	seen := make(map[int64]bool)
+	for _, ref := range dependencyRefs(c.Dependencies) {
+		endpointIDs, serviceExists := known[ref.ServiceCode]
+		id, endpointExists := endpointIDs[ref.EndpointCode]
+		if endpointExists {
+			if !seen[id] {
+				seen[id] = true
+				ids = append(ids, id)
+			}
+			continue
+		}
+		detail := errors.Detail{
+			"client_code":              c.Code,
+			"dependency_service_code":  ref.ServiceCode,
+			"dependency_endpoint_code": ref.EndpointCode,
+		}
+		if !serviceExists {
+			detail["sub_code"] = errors.ServiceNotFoundSubCode.String()
+			detail["message"] = fmt.Sprintf("Service with code %s not found", ref.ServiceCode)
+		} else {
+			detail["sub_code"] = errors.EndpointNotFoundSubCode.String()
+			detail["message"] = fmt.Sprintf(
+				"Endpoint with code %s not found on service %s",
+				ref.EndpointCode,
+				ref.ServiceCode,
+			)
+		}
+		details = append(details, detail)
+	}
+	if len(details) > 0 {
+		return nil, errors.UnresolvedDependencies(
+			fmt.Sprintf("%d dependencies of client %s don't exist", len(details), c.Code),
+			details,
+		)
+	}
+	return ids, nil
+}
+
+// addDependency adds an Endpoint to a Client's dependencies, keeping the Endpoint Codes of each Service sorted
+func addDependency(dependencies map[service.Code][]service.EndpointCode, ref service.EndpointRef) {
+	endpointCodes := append(dependencies[ref.ServiceCode], ref.EndpointCode)
+	sort.Strings(endpointCodes)
+	dependencies[ref.ServiceCode] = endpointCodes
+}
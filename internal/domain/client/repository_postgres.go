@@ -0,0 +1,321 @@
+package client
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"github.com/jmoiron/sqlx"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+	"github.com/yashap/crius/internal/db"
+	pgdao "github.com/yashap/crius/internal/db/postgresql/dao"
+	"github.com/yashap/crius/internal/domain/service"
+	"github.com/yashap/crius/internal/errors"
+	"go.uber.org/zap"
+	"time"
+)
+
+type postgresRepository struct {
+	db          *sqlx.DB
+	tx          *sql.Tx
+	logger      *zap.SugaredLogger
+	environment service.Environment
+	recorder    Recorder
+	actor       service.Actor
+}
+
+func (r *postgresRepository) InEnvironment(env service.Environment) Repository {
+	return &postgresRepository{
+		db:          r.db,
+		tx:          r.tx,
+		logger:      r.logger,
+		environment: env,
+		recorder:    r.recorder,
+		actor:       r.actor,
+	}
+}
+
+func (r *postgresRepository) InTransaction(tx *sql.Tx) Repository {
+	return &postgresRepository{
+		db:          r.db,
+		tx:          tx,
+		logger:      r.logger,
+		environment: r.environment,
+		recorder:    r.recorder,
+		actor:       r.actor,
+	}
+}
+
+func (r *postgresRepository) AsActor(actor service.Actor) Repository {
+	return &postgresRepository{
+		db:          r.db,
+		tx:          r.tx,
+		logger:      r.logger,
+		environment: r.environment,
+		recorder:    r.recorder,
+		actor:       actor,
+	}
+}
+
+// executor is what the Repository runs its queries with: the transaction that it joined, if it joined one, and
+// otherwise the database
+func (r *postgresRepository) executor() boil.ContextExecutor {
+	return db.Executor(r.db, r.tx)
+}
+
+func (r *postgresRepository) Save(c *Client) error {
+	known, err := r.findEndpointIDs(dependencyRefs(c.Dependencies))
+	if err != nil {
+		return err
+	}
+	endpointIDs, err := resolveEndpointIDs(c, known)
+	if err != nil {
+		return err
+	}
+	tx, err := db.Begin(r.db, r.tx)
+	if err != nil {
+		msg := "Failed to begin transaction when saving client"
+		r.logger.Errorw(msg, "err", err.Error(), "clientCode", c.Code)
+		return errors.DatabaseError(msg, &err)
+	}
+	clientDAO := pgdao.Client{Code: c.Code, Name: c.Name, Environment: r.environment}
+	err = r.upsertClient(tx, &clientDAO)
+	if err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+	c.ID = &clientDAO.ID
+	// We want to fully replace the client, so its dependencies are deleted, and then recreated
+	_, err = pgdao.ClientDependencies(qm.Where("client_id = ?", clientDAO.ID)).DeleteAll(context.Background(), tx)
+	if err != nil {
+		msg := "Failed to delete client dependencies"
+		r.logger.Errorw(msg, "err", err.Error(), "clientID", clientDAO.ID)
+		_ = tx.Rollback()
+		return errors.DatabaseError(msg, &err)
+	}
+	for _, endpointID := range endpointIDs {
+		dependencyDAO := pgdao.ClientDependency{ClientID: clientDAO.ID, DependencyServiceEndpointID: endpointID}
+		err = dependencyDAO.Insert(context.Background(), tx, boil.Infer())
+		if err != nil {
+			msg := "Failed to insert client dependency"
+			r.logger.Errorw(msg,
+				"err", err.Error(),
+				"clientID", clientDAO.ID,
+				"dependencyServiceEndpointID", endpointID,
+			)
+			_ = tx.Rollback()
+			return errors.DatabaseError(msg, &err)
+		}
+	}
+	err = r.record(tx, c.Code)
+	if err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+	err = tx.Commit()
+	if err != nil {
+		msg := "Failed to commit transaction when saving client"
+		r.logger.Errorw(msg, "err", err.Error(), "clientCode", c.Code)
+		return errors.DatabaseError(msg, &err)
+	}
+	return nil
+}
+
+func (r *postgresRepository) FindByCode(code Code) (*Client, error) {
+	return r.findByCode(r.executor(), code)
+}
+
+func (r *postgresRepository) FindAll() ([]Client, error) {
+	return r.findClients()
+}
+
+func (r *postgresRepository) FindByDependencies(refs []service.EndpointRef) ([]Client, error) {
+	known, err := r.findEndpointIDs(refs)
+	if err != nil {
+		return nil, err
+	}
+	endpointIDs := make([]interface{}, 0)
+	for _, ref := range refs {
+		if id, ok := known[ref.ServiceCode][ref.EndpointCode]; ok {
+			endpointIDs = append(endpointIDs, id)
+		}
+	}
+	if len(endpointIDs) == 0 {
+		return make([]Client, 0), nil
+	}
+	return r.findClients(qm.WhereIn(
+		"id in (SELECT client_id FROM client_dependency WHERE dependency_service_endpoint_id in ?)",
+		endpointIDs...,
+	))
+}
+
+func (r *postgresRepository) Delete(code Code) error {
+	tx, err := db.Begin(r.db, r.tx)
+	if err != nil {
+		msg := "Failed to begin transaction when deleting client"
+		r.logger.Errorw(msg, "err", err.Error(), "code", code)
+		return errors.DatabaseError(msg, &err)
+	}
+	clientDAO, err := pgdao.Clients(
+		qm.Where("environment = ?", r.environment),
+		qm.And("code = ?", code),
+	).One(context.Background(), tx)
+	if err == sql.ErrNoRows {
+		_ = tx.Rollback()
+		return errors.ClientNotFound(fmt.Sprintf("Client with code %s not found", code), nil)
+	} else if err != nil {
+		msg := "Failed to find client by code"
+		r.logger.Errorw(msg, "err", err.Error(), "code", code)
+		_ = tx.Rollback()
+		return errors.DatabaseError(msg, &err)
+	}
+	// Dependencies are deleted by cascade
+	_, err = clientDAO.Delete(context.Background(), tx)
+	if err != nil {
+		msg := "Failed to delete client"
+		r.logger.Errorw(msg, "err", err.Error(), "code", code)
+		_ = tx.Rollback()
+		return errors.DatabaseError(msg, &err)
+	}
+	err = r.record(tx, code)
+	if err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+	err = tx.Commit()
+	if err != nil {
+		msg := "Failed to commit transaction when deleting client"
+		r.logger.Errorw(msg, "err", err.Error(), "code", code)
+		return errors.DatabaseError(msg, &err)
+	}
+	return nil
+}
+
+func (r *postgresRepository) FindEnvironments() ([]service.Environment, error) {
+	clientDAOs, err := pgdao.Clients(
+		qm.Select("distinct environment"),
+		qm.OrderBy("environment"),
+	).All(context.Background(), r.executor())
+	if err != nil {
+		msg := "Failed to find the environments of clients"
+		r.logger.Errorw(msg, "err", err.Error())
+		return nil, errors.DatabaseError(msg, &err)
+	}
+	envs := make([]service.Environment, len(clientDAOs))
+	for idx, clientDAO := range clientDAOs {
+		envs[idx] = clientDAO.Environment
+	}
+	return envs, nil
+}
+
+// findByCode finds a Client by its Code with exec, or nil if there is none
+func (r *postgresRepository) findByCode(exec boil.ContextExecutor, code Code) (*Client, error) {
+	clientDAO, err := pgdao.Clients(
+		r.loadDependencyMod(),
+		qm.Where("environment = ?", r.environment),
+		qm.And("code = ?", code),
+	).One(context.Background(), exec)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	} else if err != nil {
+		msg := "Failed to find client by code"
+		r.logger.Errorw(msg, "err", err.Error(), "code", code)
+		return nil, errors.DatabaseError(msg, &err)
+	}
+	client := r.makeClient(clientDAO)
+	return &client, nil
+}
+
+// record records the Client with the Code, as it is in the transaction exec, with the Repository's Recorder
+func (r *postgresRepository) record(exec boil.ContextExecutor, code Code) error {
+	current, err := r.findByCode(exec, code)
+	if err != nil {
+		return err
+	}
+	return r.recorder.RecordClient(exec, r.environment, code, current, r.actor, time.Now().UTC())
+}
+
+// findClients finds the Clients that match the query mods, sorted by Code
+func (r *postgresRepository) findClients(mods ...qm.QueryMod) ([]Client, error) {
+	clientDAOs, err := pgdao.Clients(
+		append(mods, r.loadDependencyMod(), qm.Where("environment = ?", r.environment), qm.OrderBy("code"))...,
+	).All(context.Background(), r.executor())
+	if err != nil {
+		msg := "Failed to find clients"
+		r.logger.Errorw(msg, "err", err.Error())
+		return nil, errors.DatabaseError(msg, &err)
+	}
+	clients := make([]Client, len(clientDAOs))
+	for idx, clientDAO := range clientDAOs {
+		clients[idx] = r.makeClient(clientDAO)
+	}
+	return clients, nil
+}
+
+// loadDependencyMod loads a client DAO's dependencies, along with the endpoints and services that they are on
+func (r *postgresRepository) loadDependencyMod() qm.QueryMod {
+	return qm.Load(qm.Rels(
+		pgdao.ClientRels.ClientDependencies,
+		pgdao.ClientDependencyRels.DependencyServiceEndpoint,
+		pgdao.ServiceEndpointRels.Service,
+	))
+}
+
+// makeClient builds a Client from a client DAO, which must have been loaded with loadDependencyMod
+func (r *postgresRepository) makeClient(clientDAO *pgdao.Client) Client {
+	dependencies := make(map[service.Code][]service.EndpointCode)
+	for _, dependencyDAO := range clientDAO.R.ClientDependencies {
+		endpointDAO := dependencyDAO.R.DependencyServiceEndpoint
+		addDependency(dependencies, service.EndpointRef{
+			ServiceCode:  endpointDAO.R.Service.Code,
+			EndpointCode: endpointDAO.Code,
+		})
+	}
+	return MakeClient(&clientDAO.ID, clientDAO.Code, clientDAO.Name, dependencies)
+}
+
+// findEndpointIDs maps the Codes of the Services that exist, out of those that the Endpoints belong to, to the Codes
+// and ids of their Endpoints
+func (r *postgresRepository) findEndpointIDs(
+	refs []service.EndpointRef,
+) (map[service.Code]map[service.EndpointCode]int64, error) {
+	known := make(map[service.Code]map[service.EndpointCode]int64)
+	serviceCodes := endpointServiceCodes(refs)
+	if len(serviceCodes) == 0 {
+		return known, nil
+	}
+	serviceDAOs, err := pgdao.Services(
+		qm.Load(pgdao.ServiceRels.ServiceEndpoints),
+		qm.Where("environment = ?", r.environment),
+		qm.WhereIn("code in ?", serviceCodes...),
+	).All(context.Background(), r.executor())
+	if err != nil {
+		msg := "Failed to find services by codes"
+		r.logger.Errorw(msg, "err", err.Error(), "codes", serviceCodes)
+		return nil, errors.DatabaseError(msg, &err)
+	}
+	for _, serviceDAO := range serviceDAOs {
+		known[serviceDAO.Code] = make(map[service.EndpointCode]int64)
+		for _, endpointDAO := range serviceDAO.R.ServiceEndpoints {
+			known[serviceDAO.Code][endpointDAO.Code] = endpointDAO.ID
+		}
+	}
+	return known, nil
+}
+
+func (r *postgresRepository) upsertClient(exec boil.ContextExecutor, client *pgdao.Client) error {
+	err := client.Upsert(
+		context.Background(),
+		exec,
+		true,
+		[]string{"environment", "code"},
+		boil.Whitelist("name"),
+		boil.Infer(),
+	)
+	if err != nil {
+		msg := "Failed to upsert client"
+		r.logger.Errorw(msg, "err", err.Error(), "clientCode", client.Code)
+		return errors.DatabaseError(msg, &err)
+	}
+	return nil
+}
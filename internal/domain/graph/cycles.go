@@ -0,0 +1,334 @@
+package graph
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/yashap/crius/internal/domain/service"
+)
+
+// Cycles are the dependency cycles in a Graph. Each cycle is an ordered path that starts and ends with the same node,
+// where each node depends on the next one
+type Cycles struct {
+	// Endpoints are cycles between Endpoints
+	Endpoints [][]service.EndpointRef
+	// Services are cycles between Services, in the Graph with its Services collapsed into single nodes. A Service's
+	// Endpoints depending on each other does not count as a Service cycle
+	Services [][]service.Code
+}
+
+// Cycles finds the cycles in the Graph, one for each strongly connected component that has a cycle. Strongly
+// connected components can have many overlapping cycles, so the shortest one through the component's first node is
+// returned
+func (g Graph) Cycles() Cycles {
+	endpoints, endpointGraph := g.endpointDigraph()
+	services, serviceGraph := g.serviceDigraph()
+	cycles := Cycles{
+		Endpoints: make([][]service.EndpointRef, 0),
+		Services:  make([][]service.Code, 0),
+	}
+	for _, cycle := range serviceGraph.cycles() {
+		cycles.Services = append(cycles.Services, services.codes(cycle))
+	}
+	for _, cycle := range endpointGraph.cycles() {
+		cycles.Endpoints = append(cycles.Endpoints, endpoints.refs(cycle))
+	}
+	return cycles
+}
+
+// IntroducedCycles finds the cycles in after that are new since before. There is one for each dependency that is in
+// after but not before, and that closes a cycle
+func IntroducedCycles(before Graph, after Graph) Cycles {
+	endpoints, endpointGraph := after.endpointDigraph()
+	services, serviceGraph := after.serviceDigraph()
+	cycles := Cycles{
+		Endpoints: make([][]service.EndpointRef, 0),
+		Services:  make([][]service.Code, 0),
+	}
+
+	beforeServiceEdges := make(map[[2]service.Code]bool)
+	for _, edge := range before.ServiceEdges() {
+		beforeServiceEdges[[2]service.Code{edge.From, edge.To}] = true
+	}
+	newServiceEdges := make([][2]int, 0)
+	for _, edge := range after.ServiceEdges() {
+		if edge.From != edge.To && !beforeServiceEdges[[2]service.Code{edge.From, edge.To}] {
+			newServiceEdges = append(newServiceEdges, [2]int{services.index[edge.From], services.index[edge.To]})
+		}
+	}
+	for _, cycle := range serviceGraph.closedCycles(newServiceEdges) {
+		cycles.Services = append(cycles.Services, services.codes(cycle))
+	}
+
+	beforeEdges := make(map[service.DependencyEdge]bool)
+	for _, edge := range before.Edges() {
+		beforeEdges[edge] = true
+	}
+	newEdges := make([][2]int, 0)
+	for _, edge := range after.Edges() {
+		if !beforeEdges[edge] {
+			newEdges = append(newEdges, [2]int{endpoints.index[edge.From], endpoints.index[edge.To]})
+		}
+	}
+	for _, cycle := range endpointGraph.closedCycles(newEdges) {
+		cycles.Endpoints = append(cycles.Endpoints, endpoints.refs(cycle))
+	}
+	return cycles
+}
+
+// Replace returns a copy of the Graph with a Service added, or replacing the existing Service with the same Code. The
+// Service's dependencies on Endpoints that aren't in the Graph get unconfirmed placeholders, like the ones that
+// service.Repository's Save can create for them, so that those dependencies are still in the Graph. The TopicEdges of
+// Endpoints that the Service no longer has are dropped
+func (g Graph) Replace(svc service.Service) Graph {
+	services := make([]service.Service, 0, len(g.Services)+1)
+	for _, existing := range g.Services {
+		if existing.Code != svc.Code {
+			services = append(services, existing)
+		}
+	}
+	services = append(services, svc)
+	known := make(map[service.EndpointRef]bool)
+	index := make(map[service.Code]int)
+	for idx, existing := range services {
+		index[existing.Code] = idx
+		for _, endpoint := range existing.Endpoints {
+			known[service.EndpointRef{ServiceCode: existing.Code, EndpointCode: endpoint.Code}] = true
+		}
+	}
+	for _, edge := range svc.DependencyEdges() {
+		if known[edge.To] {
+			continue
+		}
+		known[edge.To] = true
+		placeholder := service.Endpoint{
+			Code:         edge.To.EndpointCode,
+			Name:         edge.To.EndpointCode,
+			Dependencies: make(map[service.Code][]service.EndpointCode),
+			Labels:       make(service.Labels),
+			Lifecycle:    service.Lifecycle{State: service.Active},
+		}
+		if idx, ok := index[edge.To.ServiceCode]; ok {
+			// Copy the Endpoints, so that the Graph's own Service is left alone
+			endpoints := services[idx].Endpoints
+			services[idx].Endpoints = append(append(make([]service.Endpoint, 0, len(endpoints)+1), endpoints...), placeholder)
+			continue
+		}
+		placeholderService := service.MakeService(
+			nil,
+			edge.To.ServiceCode,
+			edge.To.ServiceCode,
+			[]service.Endpoint{placeholder},
+			service.Ownership{},
+		)
+		placeholderService.Labels = make(service.Labels)
+		placeholderService.Lifecycle = service.Lifecycle{State: service.Active}
+		index[edge.To.ServiceCode] = len(services)
+		services = append(services, placeholderService)
+	}
+	return build(services, g.TopicEdges)
+}
+
+// endpointNodes numbers the Endpoints of a Graph, in order
+type endpointNodes struct {
+	index map[service.EndpointRef]int
+	nodes []service.EndpointRef
+}
+
+func (n endpointNodes) refs(path []int) []service.EndpointRef {
+	refs := make([]service.EndpointRef, len(path))
+	for idx, node := range path {
+		refs[idx] = n.nodes[node]
+	}
+	return refs
+}
+
+// serviceNodes numbers the Services of a Graph, in order
+type serviceNodes struct {
+	index map[service.Code]int
+	nodes []service.Code
+}
+
+func (n serviceNodes) codes(path []int) []service.Code {
+	codes := make([]service.Code, len(path))
+	for idx, node := range path {
+		codes[idx] = n.nodes[node]
+	}
+	return codes
+}
+
+func (g Graph) endpointDigraph() (endpointNodes, digraph) {
+	nodes := endpointNodes{index: make(map[service.EndpointRef]int)}
+	for _, svc := range g.Services {
+		for _, endpoint := range svc.Endpoints {
+			ref := service.EndpointRef{ServiceCode: svc.Code, EndpointCode: endpoint.Code}
+			nodes.index[ref] = len(nodes.nodes)
+			nodes.nodes = append(nodes.nodes, ref)
+		}
+	}
+	d := make(digraph, len(nodes.nodes))
+	for _, edge := range g.Edges() {
+		from := nodes.index[edge.From]
+		d[from] = append(d[from], nodes.index[edge.To])
+	}
+	return nodes, d
+}
+
+func (g Graph) serviceDigraph() (serviceNodes, digraph) {
+	nodes := serviceNodes{index: make(map[service.Code]int)}
+	for _, svc := range g.Services {
+		nodes.index[svc.Code] = len(nodes.nodes)
+		nodes.nodes = append(nodes.nodes, svc.Code)
+	}
+	d := make(digraph, len(nodes.nodes))
+	for _, edge := range g.ServiceEdges() {
+		if edge.From != edge.To {
+			from := nodes.index[edge.From]
+			d[from] = append(d[from], nodes.index[edge.To])
+		}
+	}
+	return nodes, d
+}
+
+// digraph is a directed graph, whose nodes are numbered from zero. Each node maps to the nodes it has edges to
+type digraph [][]int
+
+// cycles finds one cycle per strongly connected component that has one, ordered by the first node of the component
+func (d digraph) cycles() [][]int {
+	cycles := make([][]int, 0)
+	for _, component := range d.stronglyConnectedComponents() {
+		inComponent := make(map[int]bool)
+		for _, node := range component {
+			inComponent[node] = true
+		}
+		first := component[0]
+		if cycle := d.path(first, first, func(node int) bool { return inComponent[node] }); cycle != nil {
+			cycles = append(cycles, cycle)
+		}
+	}
+	sort.Slice(cycles, func(i, j int) bool { return cycles[i][0] < cycles[j][0] })
+	return cycles
+}
+
+// closedCycles finds the cycles closed by the given edges, which must be in the digraph. Each edge closes a cycle if
+// there is a path back from where it goes to where it comes from. The same cycle is only returned once, no matter how
+// many of the edges are in it
+func (d digraph) closedCycles(edges [][2]int) [][]int {
+	cycles := make([][]int, 0)
+	seen := make(map[string]bool)
+	for _, edge := range edges {
+		from, to := edge[0], edge[1]
+		var cycle []int
+		if from == to {
+			cycle = []int{from, from}
+		} else if back := d.path(to, from, func(int) bool { return true }); back != nil {
+			cycle = append([]int{from}, back...)
+		} else {
+			continue
+		}
+		key := cycleKey(cycle)
+		if !seen[key] {
+			seen[key] = true
+			cycles = append(cycles, cycle)
+		}
+	}
+	return cycles
+}
+
+// path finds the shortest path of at least one edge from one node to another, only passing through allowed nodes. It
+// returns nil if there is no such path
+func (d digraph) path(from int, to int, allowed func(int) bool) []int {
+	parents := make(map[int]int)
+	// When looking for a path from a node back to itself, it must stay unvisited, so that it can be reached again
+	visited := map[int]bool{from: from != to}
+	queue := []int{from}
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+		for _, next := range d[current] {
+			if visited[next] || !allowed(next) {
+				continue
+			}
+			visited[next] = true
+			parents[next] = current
+			if next == to {
+				path := []int{to}
+				for node := current; node != from; node = parents[node] {
+					path = append(path, node)
+				}
+				path = append(path, from)
+				for i, j := 0, len(path)-1; i < j; i, j = i+1, j-1 {
+					path[i], path[j] = path[j], path[i]
+				}
+				return path
+			}
+			queue = append(queue, next)
+		}
+	}
+	return nil
+}
+
+// stronglyConnectedComponents finds the strongly connected components of the digraph, using Tarjan's algorithm. The
+// nodes of each component are sorted
+func (d digraph) stronglyConnectedComponents() [][]int {
+	index := make([]int, len(d))
+	lowLink := make([]int, len(d))
+	onStack := make([]bool, len(d))
+	for node := range index {
+		index[node] = -1
+	}
+	stack := make([]int, 0)
+	nextIndex := 0
+	components := make([][]int, 0)
+
+	var visit func(node int)
+	visit = func(node int) {
+		index[node] = nextIndex
+		lowLink[node] = nextIndex
+		nextIndex++
+		stack = append(stack, node)
+		onStack[node] = true
+		for _, next := range d[node] {
+			if index[next] == -1 {
+				visit(next)
+				if lowLink[next] < lowLink[node] {
+					lowLink[node] = lowLink[next]
+				}
+			} else if onStack[next] && index[next] < lowLink[node] {
+				lowLink[node] = index[next]
+			}
+		}
+		if lowLink[node] == index[node] {
+			component := make([]int, 0)
+			for {
+				member := stack[len(stack)-1]
+				stack = stack[:len(stack)-1]
+				onStack[member] = false
+				component = append(component, member)
+				if member == node {
+					break
+				}
+			}
+			sort.Ints(component)
+			components = append(components, component)
+		}
+	}
+	for node := range d {
+		if index[node] == -1 {
+			visit(node)
+		}
+	}
+	return components
+}
+
+// cycleKey identifies a cycle regardless of which of its nodes it starts from
+func cycleKey(cycle []int) string {
+	nodes := cycle[:len(cycle)-1]
+	start := 0
+	for idx, node := range nodes {
+		if node < nodes[start] {
+			start = idx
+		}
+	}
+	return fmt.Sprint(append(append([]int{}, nodes[start:]...), nodes[:start]...))
+}
@@ -0,0 +1,186 @@
+package graph
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/yashap/crius/internal/domain/service"
+	"github.com/yashap/crius/internal/domain/topic"
+)
+
+func TestCycles(t *testing.T) {
+	tests := []struct {
+		name     string
+		services []service.Service
+		topics   []topic.Topic
+		want     Cycles
+	}{
+		{
+			name: "finds no cycles in a graph without any",
+			services: []service.Service{
+				makeService("a", makeEndpoint("GET /a", ref("b", "GET /b"))),
+				makeService("b", makeEndpoint("GET /b")),
+			},
+			want: Cycles{Endpoints: [][]service.EndpointRef{}, Services: [][]service.Code{}},
+		},
+		{
+			name: "finds endpoint and service cycles",
+			services: []service.Service{
+				makeService("a", makeEndpoint("GET /a", ref("b", "GET /b"))),
+				makeService("b", makeEndpoint("GET /b", ref("c", "GET /c"))),
+				makeService("c", makeEndpoint("GET /c", ref("a", "GET /a"))),
+			},
+			want: Cycles{
+				Endpoints: [][]service.EndpointRef{
+					{ref("a", "GET /a"), ref("b", "GET /b"), ref("c", "GET /c"), ref("a", "GET /a")},
+				},
+				Services: [][]service.Code{{"a", "b", "c", "a"}},
+			},
+		},
+		{
+			name: "finds service cycles that aren't endpoint cycles",
+			services: []service.Service{
+				makeService("a", makeEndpoint("GET /a1", ref("b", "GET /b")), makeEndpoint("GET /a2")),
+				makeService("b", makeEndpoint("GET /b", ref("a", "GET /a2"))),
+			},
+			want: Cycles{
+				Endpoints: [][]service.EndpointRef{},
+				Services:  [][]service.Code{{"a", "b", "a"}},
+			},
+		},
+		{
+			name: "doesn't count endpoints of a service depending on each other as a service cycle",
+			services: []service.Service{
+				makeService("a", makeEndpoint("GET /a1", ref("a", "GET /a2")), makeEndpoint("GET /a2", ref("a", "GET /a1"))),
+			},
+			want: Cycles{
+				Endpoints: [][]service.EndpointRef{{ref("a", "GET /a1"), ref("a", "GET /a2"), ref("a", "GET /a1")}},
+				Services:  [][]service.Code{},
+			},
+		},
+		{
+			name: "finds cycles through topics",
+			services: []service.Service{
+				makeService("a", makeEndpoint("POST /a", ref("b", "POST /b"))),
+				makeService("b", makeEndpoint("POST /b")),
+			},
+			topics: []topic.Topic{
+				makeTopic("b_done", []service.EndpointRef{ref("b", "POST /b")}, []service.EndpointRef{ref("a", "POST /a")}),
+			},
+			want: Cycles{
+				Endpoints: [][]service.EndpointRef{{ref("a", "POST /a"), ref("b", "POST /b"), ref("a", "POST /a")}},
+				Services:  [][]service.Code{{"a", "b", "a"}},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := New(tt.services, tt.topics).Cycles(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Cycles() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIntroducedCycles(t *testing.T) {
+	before := New(
+		[]service.Service{
+			makeService("a", makeEndpoint("GET /a", ref("b", "GET /b"))),
+			makeService("b", makeEndpoint("GET /b", ref("a", "GET /a")), makeEndpoint("GET /b2")),
+			makeService("c", makeEndpoint("GET /c", ref("b", "GET /b2"))),
+		},
+		nil,
+	)
+	tests := []struct {
+		name    string
+		replace service.Service
+		want    Cycles
+	}{
+		{
+			name:    "ignores cycles that already existed",
+			replace: makeService("c", makeEndpoint("GET /c", ref("a", "GET /a"))),
+			want:    Cycles{Endpoints: [][]service.EndpointRef{}, Services: [][]service.Code{}},
+		},
+		{
+			name: "finds a cycle closed by a new dependency of an endpoint on itself",
+			replace: makeService(
+				"b",
+				makeEndpoint("GET /b", ref("a", "GET /a")),
+				makeEndpoint("GET /b2", ref("b", "GET /b2")),
+			),
+			want: Cycles{
+				Endpoints: [][]service.EndpointRef{{ref("b", "GET /b2"), ref("b", "GET /b2")}},
+				Services:  [][]service.Code{},
+			},
+		},
+		{
+			name: "finds the endpoint and service cycles closed by a new dependency",
+			replace: makeService(
+				"b",
+				makeEndpoint("GET /b", ref("a", "GET /a")),
+				makeEndpoint("GET /b2", ref("c", "GET /c")),
+			),
+			want: Cycles{
+				Endpoints: [][]service.EndpointRef{{ref("b", "GET /b2"), ref("c", "GET /c"), ref("b", "GET /b2")}},
+				Services:  [][]service.Code{{"b", "c", "b"}},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IntroducedCycles(before, before.Replace(tt.replace)); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("IntroducedCycles() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestReplace(t *testing.T) {
+	tests := []struct {
+		name         string
+		replace      service.Service
+		wantServices []service.Code
+		wantEdges    []service.DependencyEdge
+	}{
+		{
+			name:         "replaces a service, dropping the topic edges of endpoints it no longer has",
+			replace:      makeService("orders", makeEndpoint("GET /orders", ref("users", "GET /users"))),
+			wantServices: []service.Code{"mail", "orders", "payments", "users", "web"},
+			wantEdges: []service.DependencyEdge{
+				edge(ref("orders", "GET /orders"), ref("users", "GET /users")),
+				edge(ref("web", "GET /"), ref("users", "GET /users")),
+			},
+		},
+		{
+			name: "adds placeholders for dependencies on endpoints that don't exist yet",
+			replace: makeService(
+				"search",
+				makeEndpoint("GET /search", ref("users", "GET /users/{id}"), ref("ranking", "POST /rank")),
+			),
+			wantServices: []service.Code{"mail", "orders", "payments", "ranking", "search", "users", "web"},
+			wantEdges: []service.DependencyEdge{
+				edge(ref("orders", "POST /orders"), ref("mail", "POST /send")),
+				edge(ref("orders", "POST /orders"), ref("payments", "POST /charges")),
+				edge(ref("search", "GET /search"), ref("ranking", "POST /rank")),
+				edge(ref("search", "GET /search"), ref("users", "GET /users/{id}")),
+				edge(ref("web", "GET /"), ref("orders", "POST /orders")),
+				edge(ref("web", "GET /"), ref("users", "GET /users")),
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			original := shopGraph()
+			got := original.Replace(tt.replace)
+			if codes := serviceCodes(got); !reflect.DeepEqual(codes, tt.wantServices) {
+				t.Errorf("Replace() services = %v, want %v", codes, tt.wantServices)
+			}
+			if edges := got.Edges(); !reflect.DeepEqual(edges, tt.wantEdges) {
+				t.Errorf("Replace() edges = %v, want %v", edges, tt.wantEdges)
+			}
+			if !reflect.DeepEqual(original, shopGraph()) {
+				t.Errorf("Replace() changed the original Graph to %v", original)
+			}
+		})
+	}
+}
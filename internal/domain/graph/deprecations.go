@@ -0,0 +1,62 @@
+package graph
+
+import (
+	"sort"
+
+	"github.com/yashap/crius/internal/domain/service"
+)
+
+// Deprecation is a Deprecated or Retired Endpoint, along with the Endpoints that still depend on it
+type Deprecation struct {
+	// Endpoint is the Deprecated or Retired Endpoint
+	Endpoint service.EndpointRef
+	// Lifecycle is the Endpoint's Lifecycle, which may be inherited from its Service
+	Lifecycle service.Lifecycle
+	// Callers are the Endpoints that depend on Endpoint directly, sorted by Service Code and then by Endpoint Code.
+	// Endpoints of the same Service are included
+	Callers []service.EndpointRef
+}
+
+// Deprecations finds every Endpoint that is Deprecated or Retired, either itself or through its Service, along with
+// the Endpoints that still depend on it. Producing to a Topic isn't calling its consumers, so TopicEdges don't count.
+// They are sorted by sunset date, soonest first, with those that have no sunset
+// date last, and then by Service Code and Endpoint Code
+func (g Graph) Deprecations() []Deprecation {
+	callers := make(map[service.EndpointRef][]service.EndpointRef)
+	for _, edge := range g.DependencyEdges() {
+		callers[edge.To] = append(callers[edge.To], edge.From)
+	}
+	deprecations := make([]Deprecation, 0)
+	for _, svc := range g.Services {
+		for _, endpoint := range svc.Endpoints {
+			lifecycle := svc.EndpointLifecycle(endpoint)
+			if lifecycle.State != service.Deprecated && lifecycle.State != service.Retired {
+				continue
+			}
+			ref := service.EndpointRef{ServiceCode: svc.Code, EndpointCode: endpoint.Code}
+			endpointCallers := callers[ref]
+			if endpointCallers == nil {
+				endpointCallers = make([]service.EndpointRef, 0)
+			}
+			deprecations = append(deprecations, Deprecation{
+				Endpoint:  ref,
+				Lifecycle: lifecycle,
+				Callers:   endpointCallers,
+			})
+		}
+	}
+	sort.Slice(deprecations, func(i, j int) bool {
+		iSunset, jSunset := deprecations[i].Lifecycle.SunsetDate, deprecations[j].Lifecycle.SunsetDate
+		switch {
+		case iSunset != nil && jSunset != nil && !iSunset.Equal(*jSunset):
+			return iSunset.Before(*jSunset)
+		case iSunset != nil && jSunset == nil:
+			return true
+		case iSunset == nil && jSunset != nil:
+			return false
+		default:
+			return lessRef(deprecations[i].Endpoint, deprecations[j].Endpoint)
+		}
+	})
+	return deprecations
+}
@@ -0,0 +1,158 @@
+package graph
+
+import (
+	"sort"
+
+	"github.com/yashap/crius/internal/domain/service"
+)
+
+// Diff is the difference between two versions of the dependency graph. Services are matched up by Code, and Endpoints
+// by the Code of their Service and their own Code, so renaming a Service or an Endpoint is not a change to the graph,
+// but changing a Code is a removal and an addition
+type Diff struct {
+	// AddedServices are the Codes of the Services that are new, sorted
+	AddedServices []service.Code
+	// RemovedServices are the Codes of the Services that no longer exist, sorted
+	RemovedServices []service.Code
+	// AddedEndpoints are the Endpoints that are new, including those of new Services, sorted
+	AddedEndpoints []service.EndpointRef
+	// RemovedEndpoints are the Endpoints that no longer exist, including those of removed Services, sorted
+	RemovedEndpoints []service.EndpointRef
+	// Dependencies are the edges between Endpoints (dependencies and TopicEdges) that were added and removed, sorted
+	Dependencies service.DependencyDiff
+}
+
+// Compare finds the difference between two versions of the dependency graph
+func Compare(before Graph, after Graph) Diff {
+	diff := Diff{
+		AddedServices:    make([]service.Code, 0),
+		RemovedServices:  make([]service.Code, 0),
+		AddedEndpoints:   make([]service.EndpointRef, 0),
+		RemovedEndpoints: make([]service.EndpointRef, 0),
+		Dependencies: service.DependencyDiff{
+			Added:   make([]service.DependencyEdge, 0),
+			Removed: make([]service.DependencyEdge, 0),
+		},
+	}
+	beforeServices, beforeEndpoints := before.index()
+	afterServices, afterEndpoints := after.index()
+	for code := range afterServices {
+		if !beforeServices[code] {
+			diff.AddedServices = append(diff.AddedServices, code)
+		}
+	}
+	for code := range beforeServices {
+		if !afterServices[code] {
+			diff.RemovedServices = append(diff.RemovedServices, code)
+		}
+	}
+	for ref := range afterEndpoints {
+		if !beforeEndpoints[ref] {
+			diff.AddedEndpoints = append(diff.AddedEndpoints, ref)
+		}
+	}
+	for ref := range beforeEndpoints {
+		if !afterEndpoints[ref] {
+			diff.RemovedEndpoints = append(diff.RemovedEndpoints, ref)
+		}
+	}
+	beforeEdges, afterEdges := edgeSet(before.Edges()), edgeSet(after.Edges())
+	for _, edge := range after.Edges() {
+		if !beforeEdges[edge] {
+			diff.Dependencies.Added = append(diff.Dependencies.Added, edge)
+		}
+	}
+	for _, edge := range before.Edges() {
+		if !afterEdges[edge] {
+			diff.Dependencies.Removed = append(diff.Dependencies.Removed, edge)
+		}
+	}
+	sort.Strings(diff.AddedServices)
+	sort.Strings(diff.RemovedServices)
+	sortRefs(diff.AddedEndpoints)
+	sortRefs(diff.RemovedEndpoints)
+	return diff
+}
+
+// Union merges two versions of the dependency graph into one, with every Service, Endpoint, dependency and TopicEdge
+// that is in either of them, so that the difference between them can be drawn. Where a Service or Endpoint is in both, its
+// details (like its Name) are taken from after
+func Union(before Graph, after Graph) Graph {
+	services := make(map[service.Code]service.Service)
+	for _, g := range []Graph{before, after} {
+		for _, svc := range g.Services {
+			merged, ok := services[svc.Code]
+			if !ok {
+				services[svc.Code] = svc
+				continue
+			}
+			endpoints := make(map[service.EndpointCode]service.Endpoint)
+			for _, endpoint := range merged.Endpoints {
+				endpoints[endpoint.Code] = endpoint
+			}
+			for _, endpoint := range svc.Endpoints {
+				if previous, ok := endpoints[endpoint.Code]; ok {
+					endpoint.Dependencies = mergeDependencies(previous.Dependencies, endpoint.Dependencies)
+				}
+				endpoints[endpoint.Code] = endpoint
+			}
+			svc.Endpoints = make([]service.Endpoint, 0, len(endpoints))
+			for _, endpoint := range endpoints {
+				svc.Endpoints = append(svc.Endpoints, endpoint)
+			}
+			sort.Slice(svc.Endpoints, func(i, j int) bool { return svc.Endpoints[i].Code < svc.Endpoints[j].Code })
+			services[svc.Code] = svc
+		}
+	}
+	merged := make([]service.Service, 0, len(services))
+	for _, svc := range services {
+		merged = append(merged, svc)
+	}
+	return build(merged, append(append(make([]service.DependencyEdge, 0), before.TopicEdges...), after.TopicEdges...))
+}
+
+// index returns the set of Service Codes in the Graph, and the set of its Endpoints
+func (g Graph) index() (map[service.Code]bool, map[service.EndpointRef]bool) {
+	services := make(map[service.Code]bool)
+	endpoints := make(map[service.EndpointRef]bool)
+	for _, svc := range g.Services {
+		services[svc.Code] = true
+		for _, endpoint := range svc.Endpoints {
+			endpoints[service.EndpointRef{ServiceCode: svc.Code, EndpointCode: endpoint.Code}] = true
+		}
+	}
+	return services, endpoints
+}
+
+func edgeSet(edges []service.DependencyEdge) map[service.DependencyEdge]bool {
+	set := make(map[service.DependencyEdge]bool)
+	for _, edge := range edges {
+		set[edge] = true
+	}
+	return set
+}
+
+// mergeDependencies merges two maps of dependencies, from Service Codes to Endpoint Codes, without duplicates
+func mergeDependencies(
+	a map[service.Code][]service.EndpointCode,
+	b map[service.Code][]service.EndpointCode,
+) map[service.Code][]service.EndpointCode {
+	seen := make(map[service.EndpointRef]bool)
+	merged := make(map[service.Code][]service.EndpointCode)
+	for _, dependencies := range []map[service.Code][]service.EndpointCode{a, b} {
+		for serviceCode, endpointCodes := range dependencies {
+			for _, endpointCode := range endpointCodes {
+				ref := service.EndpointRef{ServiceCode: serviceCode, EndpointCode: endpointCode}
+				if !seen[ref] {
+					seen[ref] = true
+					merged[serviceCode] = append(merged[serviceCode], endpointCode)
+				}
+			}
+		}
+	}
+	return merged
+}
+
+func sortRefs(refs []service.EndpointRef) {
+	sort.Slice(refs, func(i, j int) bool { return lessRef(refs[i], refs[j]) })
+}
@@ -0,0 +1,116 @@
+package graph
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/yashap/crius/internal/domain/service"
+	"github.com/yashap/crius/internal/domain/topic"
+)
+
+func TestCompare(t *testing.T) {
+	before := shopGraph()
+	tests := []struct {
+		name  string
+		after Graph
+		want  Diff
+	}{
+		{
+			name:  "finds no difference between identical graphs",
+			after: shopGraph(),
+			want:  emptyDiff(),
+		},
+		{
+			name: "finds added and removed services, endpoints and dependencies",
+			after: New(
+				[]service.Service{
+					makeService("web", makeEndpoint("GET /", ref("orders", "POST /orders"), ref("search", "GET /search"))),
+					makeService("orders", makeEndpoint("POST /orders", ref("payments", "POST /charges"))),
+					makeService("payments", makeEndpoint("POST /charges"), makeEndpoint("POST /refunds")),
+					makeService("search", makeEndpoint("GET /search")),
+					makeService("mail", makeEndpoint("POST /send")),
+				},
+				[]topic.Topic{
+					makeTopic(
+						"order_placed",
+						[]service.EndpointRef{ref("orders", "POST /orders")},
+						[]service.EndpointRef{ref("mail", "POST /send")},
+					),
+				},
+			),
+			want: Diff{
+				AddedServices:    []service.Code{"search"},
+				RemovedServices:  []service.Code{"users"},
+				AddedEndpoints:   []service.EndpointRef{ref("payments", "POST /refunds"), ref("search", "GET /search")},
+				RemovedEndpoints: []service.EndpointRef{ref("orders", "GET /orders"), ref("users", "GET /users")},
+				Dependencies: service.DependencyDiff{
+					Added:   []service.DependencyEdge{edge(ref("web", "GET /"), ref("search", "GET /search"))},
+					Removed: []service.DependencyEdge{edge(ref("web", "GET /"), ref("users", "GET /users"))},
+				},
+			},
+		},
+		{
+			name:  "finds removed topic edges",
+			after: New(shopGraph().Services, nil),
+			want: func() Diff {
+				diff := emptyDiff()
+				diff.Dependencies.Removed = []service.DependencyEdge{
+					edge(ref("orders", "POST /orders"), ref("mail", "POST /send")),
+				}
+				return diff
+			}(),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Compare(before, tt.after); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Compare() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestUnion(t *testing.T) {
+	before := New(
+		[]service.Service{
+			makeService("a", makeEndpoint("GET /a", ref("b", "GET /b1"))),
+			makeService("b", makeEndpoint("GET /b1"), makeEndpoint("GET /b2")),
+		},
+		nil,
+	)
+	after := New(
+		[]service.Service{
+			makeService("a", makeEndpoint("GET /a", ref("b", "GET /b2"))),
+			makeService("b", makeEndpoint("GET /b2")),
+			makeService("c", makeEndpoint("GET /c")),
+		},
+		[]topic.Topic{
+			makeTopic("b_done", []service.EndpointRef{ref("b", "GET /b2")}, []service.EndpointRef{ref("c", "GET /c")}),
+		},
+	)
+	union := Union(before, after)
+	if codes, want := serviceCodes(union), []service.Code{"a", "b", "c"}; !reflect.DeepEqual(codes, want) {
+		t.Errorf("Union() services = %v, want %v", codes, want)
+	}
+	want := []service.DependencyEdge{
+		edge(ref("a", "GET /a"), ref("b", "GET /b1")),
+		edge(ref("a", "GET /a"), ref("b", "GET /b2")),
+		edge(ref("b", "GET /b2"), ref("c", "GET /c")),
+	}
+	if edges := union.Edges(); !reflect.DeepEqual(edges, want) {
+		t.Errorf("Union() edges = %v, want %v", edges, want)
+	}
+}
+
+func emptyDiff() Diff {
+	return Diff{
+		AddedServices:    []service.Code{},
+		RemovedServices:  []service.Code{},
+		AddedEndpoints:   []service.EndpointRef{},
+		RemovedEndpoints: []service.EndpointRef{},
+		Dependencies: service.DependencyDiff{
+			Added:   []service.DependencyEdge{},
+			Removed: []service.DependencyEdge{},
+		},
+	}
+}
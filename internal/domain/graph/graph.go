@@ -0,0 +1,255 @@
+package graph
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/yashap/crius/internal/domain/service"
+	"github.com/yashap/crius/internal/domain/topic"
+	"github.com/yashap/crius/internal/errors"
+)
+
+// Query describes which part of the dependency graph to select
+type Query struct {
+	// Root, if set, narrows the graph down to the Service with this Code, and whatever is reachable from its Endpoints.
+	// Otherwise the whole graph is selected, unless there is a Selector
+	Root *service.Code
+	// Selector, if set, narrows the graph down to the Endpoints whose Labels (merged over their Service's Labels) match
+	// it, and whatever is reachable from them. Services whose own Labels match are kept even if none of their Endpoints
+	// are. Combined with a Root, only the Root's matching Endpoints are walked from
+	Selector service.Selector
+	// Direction is the direction to walk the graph in, starting from the Root or the Selector's matches
+	Direction service.Direction
+	// MaxDepth is the maximum number of dependency hops to walk from the starting Endpoints. Zero means there is no
+	// limit
+	MaxDepth int
+}
+
+// Graph is the dependency graph between Endpoints, made up of the Services that the Endpoints belong to
+type Graph struct {
+	// Services are the Services in the graph, sorted by Code. Their Endpoints' Dependencies only refer to Endpoints
+	// that are also in the graph
+	Services []service.Service
+	// TopicEdges are the edges from each Endpoint that produces to a Topic to each Endpoint that consumes from it,
+	// sorted, and only between Endpoints that are in the graph. Like the service_endpoint_edge view, the Graph is
+	// walked through them just like through dependencies
+	TopicEdges []service.DependencyEdge
+}
+
+// ServiceEdge is the dependency of one Service on another, made up of all the dependencies between their Endpoints
+type ServiceEdge struct {
+	// From is the Code of the Service that has the dependency
+	From service.Code
+	// To is the Code of the Service that is depended on
+	To service.Code
+	// Count is the number of Endpoint dependencies that make up this Service dependency
+	Count int
+}
+
+// New constructs a Graph from Services, and the Topics that their Endpoints produce to and consume from. Dependencies
+// on Endpoints that are not among the Services are dropped, as are the producers and consumers of Topics that are not
+func New(services []service.Service, topics []topic.Topic) Graph {
+	topicEdges := make([]service.DependencyEdge, 0)
+	for _, t := range topics {
+		for _, producer := range t.Producers {
+			for _, consumer := range t.Consumers {
+				topicEdges = append(topicEdges, service.DependencyEdge{From: producer, To: consumer})
+			}
+		}
+	}
+	return build(services, topicEdges)
+}
+
+// Select selects the part of the Graph described by the Query
+func (g Graph) Select(query Query) (Graph, error) {
+	if query.Root == nil && query.Selector == nil {
+		return g, nil
+	}
+	alwaysKeep := make(map[service.Code]bool)
+	distances := make(map[service.EndpointRef]int)
+	queue := make([]service.EndpointRef, 0)
+	for _, svc := range g.Services {
+		if query.Root != nil && svc.Code != *query.Root {
+			continue
+		}
+		if query.Root != nil || query.Selector.Matches(svc.Labels) {
+			alwaysKeep[svc.Code] = true
+		}
+		for _, endpoint := range svc.Endpoints {
+			if query.Selector != nil && !query.Selector.Matches(service.MergeLabels(svc.Labels, endpoint.Labels)) {
+				continue
+			}
+			ref := service.EndpointRef{ServiceCode: svc.Code, EndpointCode: endpoint.Code}
+			distances[ref] = 0
+			queue = append(queue, ref)
+		}
+	}
+	if query.Root != nil && !alwaysKeep[*query.Root] {
+		return Graph{}, errors.ServiceNotFound(fmt.Sprintf("Service with code %s not found", *query.Root), nil)
+	}
+	adjacent := g.adjacency(query.Direction)
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+		if query.MaxDepth > 0 && distances[current] >= query.MaxDepth {
+			continue
+		}
+		for _, next := range adjacent[current] {
+			if _, ok := distances[next]; !ok {
+				distances[next] = distances[current] + 1
+				queue = append(queue, next)
+			}
+		}
+	}
+	return g.filter(
+		g.Services,
+		func(ref service.EndpointRef) bool {
+			_, ok := distances[ref]
+			return ok
+		},
+		alwaysKeep,
+	), nil
+}
+
+// Edges returns every edge between Endpoints in the Graph, which are its dependencies along with its TopicEdges, sorted
+// by the Endpoint they are from, and then by the Endpoint they are to
+func (g Graph) Edges() []service.DependencyEdge {
+	seen := make(map[service.DependencyEdge]bool)
+	edges := make([]service.DependencyEdge, 0)
+	for _, edge := range append(g.DependencyEdges(), g.TopicEdges...) {
+		if !seen[edge] {
+			seen[edge] = true
+			edges = append(edges, edge)
+		}
+	}
+	sortEdges(edges)
+	return edges
+}
+
+// DependencyEdges returns the dependencies between Endpoints in the Graph, without its TopicEdges, sorted by the
+// Endpoint they are from, and then by the Endpoint they are to
+func (g Graph) DependencyEdges() []service.DependencyEdge {
+	edges := make([]service.DependencyEdge, 0)
+	for _, svc := range g.Services {
+		for _, endpoint := range svc.Endpoints {
+			from := service.EndpointRef{ServiceCode: svc.Code, EndpointCode: endpoint.Code}
+			for depServiceCode, depEndpointCodes := range endpoint.Dependencies {
+				for _, depEndpointCode := range depEndpointCodes {
+					edges = append(edges, service.DependencyEdge{
+						From: from,
+						To:   service.EndpointRef{ServiceCode: depServiceCode, EndpointCode: depEndpointCode},
+					})
+				}
+			}
+		}
+	}
+	sortEdges(edges)
+	return edges
+}
+
+// ServiceEdges collapses the dependencies between Endpoints into dependencies between Services, sorted by the Service
+// they are from, and then by the Service they are to. Dependencies between Endpoints of the same Service are included
+func (g Graph) ServiceEdges() []ServiceEdge {
+	counts := make(map[[2]service.Code]int)
+	for _, edge := range g.Edges() {
+		counts[[2]service.Code{edge.From.ServiceCode, edge.To.ServiceCode}]++
+	}
+	serviceEdges := make([]ServiceEdge, 0, len(counts))
+	for key, count := range counts {
+		serviceEdges = append(serviceEdges, ServiceEdge{From: key[0], To: key[1], Count: count})
+	}
+	sort.Slice(serviceEdges, func(i, j int) bool {
+		if serviceEdges[i].From != serviceEdges[j].From {
+			return serviceEdges[i].From < serviceEdges[j].From
+		}
+		return serviceEdges[i].To < serviceEdges[j].To
+	})
+	return serviceEdges
+}
+
+// adjacency maps each Endpoint to the Endpoints next to it, when walking the Graph in the given direction
+func (g Graph) adjacency(direction service.Direction) map[service.EndpointRef][]service.EndpointRef {
+	adjacent := make(map[service.EndpointRef][]service.EndpointRef)
+	for _, edge := range g.Edges() {
+		if direction == service.Upstream {
+			adjacent[edge.To] = append(adjacent[edge.To], edge.From)
+		} else {
+			adjacent[edge.From] = append(adjacent[edge.From], edge.To)
+		}
+	}
+	return adjacent
+}
+
+// build constructs a Graph from Services, and the edges between their Endpoints through Topics. Dependencies and
+// TopicEdges on Endpoints that are not among the Services are dropped
+func build(services []service.Service, topicEdges []service.DependencyEdge) Graph {
+	known := make(map[service.EndpointRef]bool)
+	for _, svc := range services {
+		for _, endpoint := range svc.Endpoints {
+			known[service.EndpointRef{ServiceCode: svc.Code, EndpointCode: endpoint.Code}] = true
+		}
+	}
+	return Graph{TopicEdges: topicEdges}.filter(services, func(ref service.EndpointRef) bool { return known[ref] }, nil)
+}
+
+// filter builds a Graph out of the Endpoints of services that keep returns true for, and the dependencies and
+// TopicEdges between them. Services with no Endpoints left are dropped, unless their Code is in alwaysKeep
+func (g Graph) filter(
+	services []service.Service,
+	keep func(service.EndpointRef) bool,
+	alwaysKeep map[service.Code]bool,
+) Graph {
+	filtered := make([]service.Service, 0)
+	for _, svc := range services {
+		endpoints := make([]service.Endpoint, 0)
+		for _, endpoint := range svc.Endpoints {
+			if !keep(service.EndpointRef{ServiceCode: svc.Code, EndpointCode: endpoint.Code}) {
+				continue
+			}
+			dependencies := make(map[service.Code][]service.EndpointCode)
+			for depServiceCode, depEndpointCodes := range endpoint.Dependencies {
+				for _, depEndpointCode := range depEndpointCodes {
+					if keep(service.EndpointRef{ServiceCode: depServiceCode, EndpointCode: depEndpointCode}) {
+						dependencies[depServiceCode] = append(dependencies[depServiceCode], depEndpointCode)
+					}
+				}
+			}
+			endpoint.Dependencies = dependencies
+			endpoints = append(endpoints, endpoint)
+		}
+		if len(endpoints) == 0 && !alwaysKeep[svc.Code] {
+			continue
+		}
+		sort.Slice(endpoints, func(i, j int) bool { return endpoints[i].Code < endpoints[j].Code })
+		svc.Endpoints = endpoints
+		filtered = append(filtered, svc)
+	}
+	sort.Slice(filtered, func(i, j int) bool { return filtered[i].Code < filtered[j].Code })
+	seen := make(map[service.DependencyEdge]bool)
+	topicEdges := make([]service.DependencyEdge, 0)
+	for _, edge := range g.TopicEdges {
+		if keep(edge.From) && keep(edge.To) && !seen[edge] {
+			seen[edge] = true
+			topicEdges = append(topicEdges, edge)
+		}
+	}
+	sortEdges(topicEdges)
+	return Graph{Services: filtered, TopicEdges: topicEdges}
+}
+
+// sortEdges sorts edges by the Endpoint they are from, and then by the Endpoint they are to
+func sortEdges(edges []service.DependencyEdge) {
+	sort.Slice(edges, func(i, j int) bool {
+		if edges[i].From != edges[j].From {
+			return lessRef(edges[i].From, edges[j].From)
+		}
+		return lessRef(edges[i].To, edges[j].To)
+	})
+}
+
+func lessRef(a service.EndpointRef, b service.EndpointRef) bool {
+	if a.ServiceCode != b.ServiceCode {
+		return a.ServiceCode < b.ServiceCode
+	}
+	return a.EndpointCode < b.EndpointCode
+}
@@ -0,0 +1,225 @@
+package graph
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/yashap/crius/internal/domain/service"
+	"github.com/yashap/crius/internal/domain/topic"
+	"github.com/yashap/crius/internal/errors"
+)
+
+func TestNew(t *testing.T) {
+	tests := []struct {
+		name     string
+		services []service.Service
+		topics   []topic.Topic
+		want     []service.DependencyEdge
+	}{
+		{
+			name: "drops dependencies on unknown endpoints",
+			services: []service.Service{
+				makeService("web", makeEndpoint("GET /", ref("users", "GET /users"), ref("users", "GET /missing"))),
+				makeService("users", makeEndpoint("GET /users")),
+			},
+			want: []service.DependencyEdge{edge(ref("web", "GET /"), ref("users", "GET /users"))},
+		},
+		{
+			name: "walks from producers to consumers through topics",
+			services: []service.Service{
+				makeService("orders", makeEndpoint("POST /orders")),
+				makeService("mail", makeEndpoint("POST /send")),
+				makeService("audit", makeEndpoint("POST /events")),
+			},
+			topics: []topic.Topic{
+				makeTopic(
+					"order_placed",
+					[]service.EndpointRef{ref("orders", "POST /orders")},
+					[]service.EndpointRef{ref("mail", "POST /send"), ref("audit", "POST /events"), ref("gone", "GET /")},
+				),
+			},
+			want: []service.DependencyEdge{
+				edge(ref("orders", "POST /orders"), ref("audit", "POST /events")),
+				edge(ref("orders", "POST /orders"), ref("mail", "POST /send")),
+			},
+		},
+		{
+			name: "counts an edge that is both a dependency and a topic edge once",
+			services: []service.Service{
+				makeService("orders", makeEndpoint("POST /orders", ref("mail", "POST /send"))),
+				makeService("mail", makeEndpoint("POST /send")),
+			},
+			topics: []topic.Topic{
+				makeTopic(
+					"order_placed",
+					[]service.EndpointRef{ref("orders", "POST /orders")},
+					[]service.EndpointRef{ref("mail", "POST /send")},
+				),
+			},
+			want: []service.DependencyEdge{edge(ref("orders", "POST /orders"), ref("mail", "POST /send"))},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := New(tt.services, tt.topics).Edges(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("New().Edges() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSelect(t *testing.T) {
+	web, orders, users := "web", "orders", "users"
+	critical, err := service.ParseSelector("tier=critical")
+	if err != nil {
+		t.Fatalf("ParseSelector() error = %v", err)
+	}
+	tests := []struct {
+		name         string
+		query        Query
+		wantServices []service.Code
+		wantEdges    []service.DependencyEdge
+	}{
+		{
+			name:         "selects the whole graph without a root or selector",
+			query:        Query{},
+			wantServices: []service.Code{"mail", "orders", "payments", "users", "web"},
+			wantEdges:    shopGraph().Edges(),
+		},
+		{
+			name:         "walks downstream from the root, through topics",
+			query:        Query{Root: &orders},
+			wantServices: []service.Code{"mail", "orders", "payments"},
+			wantEdges: []service.DependencyEdge{
+				edge(ref("orders", "POST /orders"), ref("mail", "POST /send")),
+				edge(ref("orders", "POST /orders"), ref("payments", "POST /charges")),
+			},
+		},
+		{
+			name:         "walks upstream from the root",
+			query:        Query{Root: &users, Direction: service.Upstream},
+			wantServices: []service.Code{"users", "web"},
+			wantEdges:    []service.DependencyEdge{edge(ref("web", "GET /"), ref("users", "GET /users"))},
+		},
+		{
+			name:         "stops at the max depth",
+			query:        Query{Root: &web, MaxDepth: 1},
+			wantServices: []service.Code{"orders", "users", "web"},
+			wantEdges: []service.DependencyEdge{
+				edge(ref("web", "GET /"), ref("orders", "POST /orders")),
+				edge(ref("web", "GET /"), ref("users", "GET /users")),
+			},
+		},
+		{
+			name:         "walks from the endpoints whose merged labels match the selector",
+			query:        Query{Selector: critical},
+			wantServices: []service.Code{"mail", "orders", "payments"},
+			wantEdges: []service.DependencyEdge{
+				edge(ref("orders", "POST /orders"), ref("mail", "POST /send")),
+				edge(ref("orders", "POST /orders"), ref("payments", "POST /charges")),
+			},
+		},
+		{
+			name:         "keeps the root even if none of its endpoints match the selector",
+			query:        Query{Root: &users, Selector: critical},
+			wantServices: []service.Code{"users"},
+			wantEdges:    []service.DependencyEdge{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := shopGraph().Select(tt.query)
+			if err != nil {
+				t.Fatalf("Select() error = %v", err)
+			}
+			if codes := serviceCodes(got); !reflect.DeepEqual(codes, tt.wantServices) {
+				t.Errorf("Select() services = %v, want %v", codes, tt.wantServices)
+			}
+			if edges := got.Edges(); !reflect.DeepEqual(edges, tt.wantEdges) {
+				t.Errorf("Select() edges = %v, want %v", edges, tt.wantEdges)
+			}
+		})
+	}
+
+	t.Run("fails if the root doesn't exist", func(t *testing.T) {
+		missing := "missing"
+		_, err := shopGraph().Select(Query{Root: &missing})
+		if e, ok := err.(*errors.Error); !ok || e.SubCode != errors.ServiceNotFoundSubCode {
+			t.Errorf("Select() error = %v, want a ServiceNotFound error", err)
+		}
+	})
+}
+
+// shopGraph is a small dependency graph, where the web storefront calls the orders and users services, and placing an
+// order charges a payment and produces to a topic that the mail service consumes:
+//
+//	web:GET / -> orders:POST /orders -> payments:POST /charges
+//	          -> users:GET /users       (order_placed) -> mail:POST /send
+func shopGraph() Graph {
+	orders := makeService(
+		"orders",
+		makeEndpoint("GET /orders"),
+		makeEndpoint("POST /orders", ref("payments", "POST /charges")),
+	)
+	orders.Endpoints[1].Labels = service.Labels{"tier": "critical"}
+	payments := makeService("payments", makeEndpoint("POST /charges"))
+	payments.Labels = service.Labels{"tier": "critical"}
+	return New(
+		[]service.Service{
+			makeService("web", makeEndpoint("GET /", ref("orders", "POST /orders"), ref("users", "GET /users"))),
+			orders,
+			payments,
+			makeService("users", makeEndpoint("GET /users")),
+			makeService("mail", makeEndpoint("POST /send")),
+		},
+		[]topic.Topic{
+			makeTopic(
+				"order_placed",
+				[]service.EndpointRef{ref("orders", "POST /orders")},
+				[]service.EndpointRef{ref("mail", "POST /send")},
+			),
+		},
+	)
+}
+
+func makeService(code service.Code, endpoints ...service.Endpoint) service.Service {
+	svc := service.MakeService(nil, code, code, endpoints, service.Ownership{})
+	svc.Labels = make(service.Labels)
+	return svc
+}
+
+func makeEndpoint(code service.EndpointCode, dependencies ...service.EndpointRef) service.Endpoint {
+	endpoint := service.Endpoint{
+		Code:         code,
+		Name:         code,
+		Dependencies: make(map[service.Code][]service.EndpointCode),
+		Labels:       make(service.Labels),
+	}
+	for _, dependency := range dependencies {
+		endpoint.Dependencies[dependency.ServiceCode] = append(
+			endpoint.Dependencies[dependency.ServiceCode],
+			dependency.EndpointCode,
+		)
+	}
+	return endpoint
+}
+
+func makeTopic(code topic.Code, producers []service.EndpointRef, consumers []service.EndpointRef) topic.Topic {
+	return topic.MakeTopic(nil, code, code, producers, consumers)
+}
+
+func ref(serviceCode service.Code, endpointCode service.EndpointCode) service.EndpointRef {
+	return service.EndpointRef{ServiceCode: serviceCode, EndpointCode: endpointCode}
+}
+
+func edge(from service.EndpointRef, to service.EndpointRef) service.DependencyEdge {
+	return service.DependencyEdge{From: from, To: to}
+}
+
+func serviceCodes(g Graph) []service.Code {
+	codes := make([]service.Code, 0, len(g.Services))
+	for _, svc := range g.Services {
+		codes = append(codes, svc.Code)
+	}
+	return codes
+}
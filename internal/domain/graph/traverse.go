@@ -0,0 +1,79 @@
+package graph
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/yashap/crius/internal/domain/service"
+	"github.com/yashap/crius/internal/errors"
+)
+
+// Traverse walks the Graph in the given direction from the starting point described by the query, like
+// service.Repository's FindDependencies and FindDependents do for the stored dependency graph. It finds the Endpoints
+// that the starting Endpoints depend on (Downstream), or that depend on them (Upstream), up to the query's MaxDepth.
+// The starting Endpoints themselves are never returned, and the results are sorted by Distance, and then by Service
+// Code and Endpoint Code
+func (g Graph) Traverse(query service.DependencyQuery, direction service.Direction) ([]service.Dependency, error) {
+	var root *service.Service
+	endpoints := make(map[service.EndpointRef]service.Endpoint)
+	teams := make(map[service.Code]*service.Team)
+	for idx, svc := range g.Services {
+		if svc.Code == query.ServiceCode {
+			root = &g.Services[idx]
+		}
+		teams[svc.Code] = svc.Ownership.Team
+		for _, endpoint := range svc.Endpoints {
+			endpoints[service.EndpointRef{ServiceCode: svc.Code, EndpointCode: endpoint.Code}] = endpoint
+		}
+	}
+	if root == nil {
+		return nil, errors.ServiceNotFound(fmt.Sprintf("Service with code %s not found", query.ServiceCode), nil)
+	}
+	paths := make(map[service.EndpointRef][]service.EndpointRef)
+	queue := make([]service.EndpointRef, 0)
+	for _, endpoint := range root.Endpoints {
+		if query.EndpointCode == nil || *query.EndpointCode == endpoint.Code {
+			ref := service.EndpointRef{ServiceCode: root.Code, EndpointCode: endpoint.Code}
+			paths[ref] = []service.EndpointRef{ref}
+			queue = append(queue, ref)
+		}
+	}
+	if query.EndpointCode != nil && len(queue) == 0 {
+		return nil, errors.EndpointNotFound(
+			fmt.Sprintf("Endpoint with code %s not found on service %s", *query.EndpointCode, query.ServiceCode),
+			nil,
+		)
+	}
+	adjacent := g.adjacency(direction)
+	dependencies := make([]service.Dependency, 0)
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+		distance := len(paths[current]) - 1
+		if query.MaxDepth > 0 && distance >= query.MaxDepth {
+			continue
+		}
+		for _, next := range adjacent[current] {
+			if _, ok := paths[next]; ok {
+				continue
+			}
+			path := append(append(make([]service.EndpointRef, 0, distance+2), paths[current]...), next)
+			paths[next] = path
+			queue = append(queue, next)
+			dependencies = append(dependencies, service.Dependency{
+				Endpoint:     next,
+				EndpointName: endpoints[next].Name,
+				ServiceTeam:  teams[next.ServiceCode],
+				Distance:     distance + 1,
+				Path:         path,
+			})
+		}
+	}
+	sort.SliceStable(dependencies, func(i, j int) bool {
+		if dependencies[i].Distance != dependencies[j].Distance {
+			return dependencies[i].Distance < dependencies[j].Distance
+		}
+		return lessRef(dependencies[i].Endpoint, dependencies[j].Endpoint)
+	})
+	return dependencies, nil
+}
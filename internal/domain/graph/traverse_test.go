@@ -0,0 +1,107 @@
+package graph
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/yashap/crius/internal/domain/service"
+	"github.com/yashap/crius/internal/errors"
+)
+
+func TestTraverse(t *testing.T) {
+	placeOrder, missing := "POST /orders", "GET /missing"
+	tests := []struct {
+		name      string
+		query     service.DependencyQuery
+		direction service.Direction
+		want      []service.Dependency
+	}{
+		{
+			name:      "finds dependencies by distance, through topics",
+			query:     service.DependencyQuery{ServiceCode: "web"},
+			direction: service.Downstream,
+			want: []service.Dependency{
+				dependency(ref("web", "GET /"), ref("orders", "POST /orders")),
+				dependency(ref("web", "GET /"), ref("users", "GET /users")),
+				dependency(ref("web", "GET /"), ref("orders", "POST /orders"), ref("mail", "POST /send")),
+				dependency(ref("web", "GET /"), ref("orders", "POST /orders"), ref("payments", "POST /charges")),
+			},
+		},
+		{
+			name:      "stops at the max depth",
+			query:     service.DependencyQuery{ServiceCode: "web", MaxDepth: 1},
+			direction: service.Downstream,
+			want: []service.Dependency{
+				dependency(ref("web", "GET /"), ref("orders", "POST /orders")),
+				dependency(ref("web", "GET /"), ref("users", "GET /users")),
+			},
+		},
+		{
+			name:      "finds dependents of a single endpoint",
+			query:     service.DependencyQuery{ServiceCode: "orders", EndpointCode: &placeOrder},
+			direction: service.Upstream,
+			want:      []service.Dependency{dependency(ref("orders", "POST /orders"), ref("web", "GET /"))},
+		},
+		{
+			name:      "finds dependents through topics",
+			query:     service.DependencyQuery{ServiceCode: "mail"},
+			direction: service.Upstream,
+			want: []service.Dependency{
+				dependency(ref("mail", "POST /send"), ref("orders", "POST /orders")),
+				dependency(ref("mail", "POST /send"), ref("orders", "POST /orders"), ref("web", "GET /")),
+			},
+		},
+		{
+			name:      "finds nothing from endpoints without dependencies",
+			query:     service.DependencyQuery{ServiceCode: "payments"},
+			direction: service.Downstream,
+			want:      []service.Dependency{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := shopGraph().Traverse(tt.query, tt.direction)
+			if err != nil {
+				t.Fatalf("Traverse() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Traverse() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	notFound := []struct {
+		name  string
+		query service.DependencyQuery
+		want  error
+	}{
+		{
+			name:  "fails if the service doesn't exist",
+			query: service.DependencyQuery{ServiceCode: "missing"},
+			want:  errors.ServiceNotFound("", nil),
+		},
+		{
+			name:  "fails if the endpoint doesn't exist",
+			query: service.DependencyQuery{ServiceCode: "web", EndpointCode: &missing},
+			want:  errors.EndpointNotFound("", nil),
+		},
+	}
+	for _, tt := range notFound {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := shopGraph().Traverse(tt.query, service.Downstream)
+			if e, ok := err.(*errors.Error); !ok || e.SubCode != tt.want.(*errors.Error).SubCode {
+				t.Errorf("Traverse() error = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+// dependency is the Dependency reached by walking the path, which starts with the starting Endpoint
+func dependency(path ...service.EndpointRef) service.Dependency {
+	return service.Dependency{
+		Endpoint:     path[len(path)-1],
+		EndpointName: path[len(path)-1].EndpointCode,
+		Distance:     len(path) - 1,
+		Path:         path,
+	}
+}
@@ -0,0 +1,70 @@
+package graph
+
+import (
+	"github.com/yashap/crius/internal/domain/service"
+)
+
+// StalePin is a dependency that is pinned to Versions of the Endpoint it depends on that no longer exist, because the
+// Endpoint's Version has moved on
+type StalePin struct {
+	// From is the Endpoint that has the dependency
+	From service.EndpointRef
+	// To is the Endpoint that is depended on
+	To service.EndpointRef
+	// Constraint is the Version that From's dependencies on To's Service are pinned to
+	Constraint service.Version
+	// Version is To's Version, which may be inherited from its Service, and doesn't match Constraint
+	Version service.Version
+}
+
+// StalePins finds every dependency that is pinned to Versions that the Endpoint it depends on doesn't have. Endpoints
+// with no Version (neither their own, nor their Service's) can't be checked, so dependencies on them are never
+// reported. The StalePins are sorted by the Endpoint they are from, and then by the Endpoint they are to
+func (g Graph) StalePins() []StalePin {
+	versions := make(map[service.EndpointRef]service.Version)
+	constraints := make(map[service.EndpointRef]map[service.Code]service.Version)
+	for _, svc := range g.Services {
+		for _, endpoint := range svc.Endpoints {
+			ref := service.EndpointRef{ServiceCode: svc.Code, EndpointCode: endpoint.Code}
+			if version := svc.EndpointVersion(endpoint); version != nil {
+				versions[ref] = *version
+			}
+			constraints[ref] = endpoint.VersionConstraints
+		}
+	}
+	stalePins := make([]StalePin, 0)
+	// DependencyEdges are already sorted by the Endpoint they are from, and then by the Endpoint they are to
+	for _, edge := range g.DependencyEdges() {
+		constraint, ok := constraints[edge.From][edge.To.ServiceCode]
+		if !ok {
+			continue
+		}
+		version, ok := versions[edge.To]
+		if !ok {
+			continue
+		}
+		if !versionsOverlap(constraint, version) {
+			stalePins = append(stalePins, StalePin{
+				From:       edge.From,
+				To:         edge.To,
+				Constraint: constraint,
+				Version:    version,
+			})
+		}
+	}
+	return stalePins
+}
+
+// versionsOverlap returns whether some semantic version matches both Versions. Versions are validated before they are
+// saved, so ones that can't be parsed are treated as matching, rather than being reported
+func versionsOverlap(a service.Version, b service.Version) bool {
+	aRange, err := service.ParseVersion(a)
+	if err != nil {
+		return true
+	}
+	bRange, err := service.ParseVersion(b)
+	if err != nil {
+		return true
+	}
+	return aRange.Overlaps(bRange)
+}
@@ -0,0 +1,76 @@
+package graph
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/yashap/crius/internal/domain/service"
+)
+
+func TestStalePins(t *testing.T) {
+	// pinned makes an Endpoint of the caller service, which depends on payments:POST /charges, pinned to constraint
+	pinned := func(constraint service.Version) service.Service {
+		endpoint := makeEndpoint("GET /", ref("payments", "POST /charges"))
+		endpoint.VersionConstraints = map[service.Code]service.Version{"payments": constraint}
+		return makeService("caller", endpoint)
+	}
+	// payments makes the payments service, with a Version of its own, and optionally one for its Endpoint
+	payments := func(version *service.Version, endpointVersion *service.Version) service.Service {
+		svc := makeService("payments", makeEndpoint("POST /charges"))
+		svc.Version = version
+		svc.Endpoints[0].Version = endpointVersion
+		return svc
+	}
+	v1, v2, v3 := "1.4.0", "2.1.0", "^3"
+	tests := []struct {
+		name     string
+		services []service.Service
+		want     []StalePin
+	}{
+		{
+			name:     "ignores pins that match the service's version",
+			services: []service.Service{pinned("^2"), payments(&v2, nil)},
+			want:     []StalePin{},
+		},
+		{
+			name:     "reports pins that don't match the service's version",
+			services: []service.Service{pinned("^2"), payments(&v1, nil)},
+			want: []StalePin{{
+				From:       ref("caller", "GET /"),
+				To:         ref("payments", "POST /charges"),
+				Constraint: "^2",
+				Version:    v1,
+			}},
+		},
+		{
+			name:     "checks the endpoint's own version over its service's",
+			services: []service.Service{pinned("^2"), payments(&v2, &v3)},
+			want: []StalePin{{
+				From:       ref("caller", "GET /"),
+				To:         ref("payments", "POST /charges"),
+				Constraint: "^2",
+				Version:    v3,
+			}},
+		},
+		{
+			name:     "ignores pins on endpoints without a version",
+			services: []service.Service{pinned("^2"), payments(nil, nil)},
+			want:     []StalePin{},
+		},
+		{
+			name: "ignores dependencies that aren't pinned",
+			services: []service.Service{
+				makeService("caller", makeEndpoint("GET /", ref("payments", "POST /charges"))),
+				payments(&v1, nil),
+			},
+			want: []StalePin{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := New(tt.services, nil).StalePins(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("StalePins() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,100 @@
+package graph
+
+import (
+	"sort"
+
+	"github.com/yashap/crius/internal/domain/service"
+)
+
+// Violation is a dependency of a more critical Endpoint on a less critical one, i.e. one with a higher Tier. It can be
+// direct, or reachable through other Endpoints
+type Violation struct {
+	// From is the more critical Endpoint, which has the dependency
+	From service.EndpointRef
+	// FromTier is the Tier of From
+	FromTier service.Tier
+	// To is the less critical Endpoint, which is depended on
+	To service.EndpointRef
+	// ToTier is the Tier of To
+	ToTier service.Tier
+	// Path is the shortest path of dependencies from From to To, starting with From and ending with To
+	Path []service.EndpointRef
+}
+
+// Direct returns whether From depends on To directly, rather than through other Endpoints
+func (v Violation) Direct() bool {
+	return len(v.Path) == 2
+}
+
+// Violations finds every Endpoint that depends on a less critical Endpoint. If transitive is set, dependencies that
+// are only reachable through other Endpoints count too, otherwise only direct dependencies do. Endpoints with no Tier
+// (neither their own, nor their Service's) are never reported, but violations are still found through them. The
+// Violations are sorted by the Endpoint they are from, and then by the Endpoint they are to
+func (g Graph) Violations(transitive bool) []Violation {
+	tiers := make(map[service.EndpointRef]service.Tier)
+	for _, svc := range g.Services {
+		for _, endpoint := range svc.Endpoints {
+			if tier := svc.EndpointTier(endpoint); tier != nil {
+				tiers[service.EndpointRef{ServiceCode: svc.Code, EndpointCode: endpoint.Code}] = *tier
+			}
+		}
+	}
+	adjacent := g.adjacency(service.Downstream)
+	violations := make([]Violation, 0)
+	for _, svc := range g.Services {
+		for _, endpoint := range svc.Endpoints {
+			from := service.EndpointRef{ServiceCode: svc.Code, EndpointCode: endpoint.Code}
+			fromTier, ok := tiers[from]
+			if !ok {
+				continue
+			}
+			for _, path := range reachablePaths(adjacent, from, transitive) {
+				to := path[len(path)-1]
+				if toTier, ok := tiers[to]; ok && toTier > fromTier {
+					violations = append(violations, Violation{
+						From:     from,
+						FromTier: fromTier,
+						To:       to,
+						ToTier:   toTier,
+						Path:     path,
+					})
+				}
+			}
+		}
+	}
+	sort.Slice(violations, func(i, j int) bool {
+		if violations[i].From != violations[j].From {
+			return lessRef(violations[i].From, violations[j].From)
+		}
+		return lessRef(violations[i].To, violations[j].To)
+	})
+	return violations
+}
+
+// reachablePaths finds the shortest path from an Endpoint to each other Endpoint reachable from it. If transitive is
+// not set, only the Endpoints it directly depends on are reached
+func reachablePaths(
+	adjacent map[service.EndpointRef][]service.EndpointRef,
+	from service.EndpointRef,
+	transitive bool,
+) [][]service.EndpointRef {
+	paths := make([][]service.EndpointRef, 0)
+	visited := map[service.EndpointRef][]service.EndpointRef{from: {from}}
+	queue := []service.EndpointRef{from}
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+		for _, next := range adjacent[current] {
+			if _, ok := visited[next]; ok {
+				continue
+			}
+			path := append(append(make([]service.EndpointRef, 0, len(visited[current])+1), visited[current]...), next)
+			visited[next] = path
+			paths = append(paths, path)
+			if transitive {
+				queue = append(queue, next)
+			}
+		}
+	}
+	return paths
+}
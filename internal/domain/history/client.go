@@ -0,0 +1,123 @@
+package history
+
+import (
+	"reflect"
+	"sort"
+	"time"
+
+	"github.com/yashap/crius/internal/domain/client"
+	"github.com/yashap/crius/internal/domain/service"
+)
+
+// ClientChange is a single entry in the append-only history of a client.Client. Like a Change, it records the whole
+// Client as it was right after the change was made
+type ClientChange struct {
+	// ID uniquely identifies this change
+	ID *int64
+	// ClientCode is the Code of the Client that was changed
+	ClientCode client.Code
+	// Version numbers the Changes to a Client, starting at 1, and keeps counting up across deletes
+	Version int
+	// Type is the kind of change that was made
+	Type ChangeType
+	// Actor is whoever made the change
+	Actor Actor
+	// ChangedAt is when the change was made
+	ChangedAt time.Time
+	// Snapshot is the Client right after the change was made. It is nil for Deleted Changes
+	Snapshot *client.Client
+}
+
+// NextClient builds the ClientChange that takes the history of a Client from the latest recorded ClientChange to the
+// Client's current state, like Next does for a Service. If nothing changed, NextClient returns nil
+func NextClient(
+	code client.Code,
+	latest *ClientChange,
+	current *client.Client,
+	actor Actor,
+	changedAt time.Time,
+) *ClientChange {
+	var previous *client.Client
+	version := 1
+	if latest != nil {
+		previous = latest.Snapshot
+		version = latest.Version + 1
+	}
+	current = normalizeClient(current)
+	if reflect.DeepEqual(normalizeClient(previous), current) {
+		return nil
+	}
+	return &ClientChange{
+		ClientCode: code,
+		Version:    version,
+		Type:       changeType(previous != nil, current != nil),
+		Actor:      actor,
+		ChangedAt:  changedAt,
+		Snapshot:   current,
+	}
+}
+
+// Clients reconstructs the Clients that existed at some moment from the latest ClientChange to each of them as of then,
+// leaving out those whose latest ClientChange deleted them
+func Clients(latest []ClientChange) []client.Client {
+	clients := make([]client.Client, 0, len(latest))
+	for _, change := range latest {
+		if change.Snapshot != nil {
+			clients = append(clients, *change.Snapshot)
+		}
+	}
+	sort.Slice(clients, func(i, j int) bool { return clients[i].Code < clients[j].Code })
+	return clients
+}
+
+// UnlinkClients builds the ClientChanges that drop the dependencies of Clients on Endpoints that were removed, given
+// the latest ClientChange to each Client. Force deleting an Endpoint also deletes the dependencies of Clients on it,
+// without the Clients being saved, so this is how those changes are recorded
+func UnlinkClients(
+	latest []ClientChange,
+	removed []service.EndpointRef,
+	actor Actor,
+	changedAt time.Time,
+) []ClientChange {
+	isRemoved := make(map[service.EndpointRef]bool)
+	for _, ref := range removed {
+		isRemoved[ref] = true
+	}
+	changes := make([]ClientChange, 0)
+	for idx := range latest {
+		previous := latest[idx].Snapshot
+		if previous == nil {
+			continue
+		}
+		dependencies := make(map[service.Code][]service.EndpointCode)
+		for serviceCode, endpointCodes := range previous.Dependencies {
+			for _, endpointCode := range endpointCodes {
+				if !isRemoved[service.EndpointRef{ServiceCode: serviceCode, EndpointCode: endpointCode}] {
+					dependencies[serviceCode] = append(dependencies[serviceCode], endpointCode)
+				}
+			}
+		}
+		current := client.MakeClient(nil, previous.Code, previous.Name, dependencies)
+		if change := NextClient(previous.Code, &latest[idx], &current, actor, changedAt); change != nil {
+			changes = append(changes, *change)
+		}
+	}
+	return changes
+}
+
+// normalizeClient copies a Client without its ID, and with the Endpoint Codes of its dependencies sorted, so that
+// Clients that are the same compare as equal
+func normalizeClient(c *client.Client) *client.Client {
+	if c == nil {
+		return nil
+	}
+	dependencies := make(map[service.Code][]service.EndpointCode)
+	for serviceCode, endpointCodes := range c.Dependencies {
+		if len(endpointCodes) > 0 {
+			dependencies[serviceCode] = append(make([]service.EndpointCode, 0, len(endpointCodes)), endpointCodes...)
+			sort.Strings(dependencies[serviceCode])
+		}
+	}
+	normalized := client.MakeClient(nil, c.Code, c.Name, dependencies)
+	return &normalized
+}
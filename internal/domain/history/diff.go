@@ -0,0 +1,232 @@
+package history
+
+import (
+	"reflect"
+	"sort"
+	"time"
+
+	"github.com/yashap/crius/internal/domain/service"
+)
+
+// FieldChange is a change to a single field of a Service or Endpoint. Before and After are nil when the field wasn't
+// set. Pointers are dereferenced, so they hold plain values like strings, ints, Labels and times
+type FieldChange struct {
+	// Field is the name of the field, as it is named in the API. For example, "name" or "sunset_date"
+	Field string
+	// Before is the value of the field before the change
+	Before interface{}
+	// After is the value of the field after the change
+	After interface{}
+}
+
+// EndpointDiff is the difference between two versions of an Endpoint, not counting its dependencies
+type EndpointDiff struct {
+	// Code is the Code of the Endpoint
+	Code service.EndpointCode
+	// Fields are the fields of the Endpoint that changed
+	Fields []FieldChange
+}
+
+// Diff is the difference between two versions of a Service
+type Diff struct {
+	// Fields are the fields of the Service itself that changed
+	Fields []FieldChange
+	// AddedEndpoints are the Codes of the Endpoints that are new, sorted
+	AddedEndpoints []service.EndpointCode
+	// RemovedEndpoints are the Codes of the Endpoints that no longer exist, sorted
+	RemovedEndpoints []service.EndpointCode
+	// ChangedEndpoints are the Endpoints whose fields changed, sorted by Code. Added Endpoints are included, with every
+	// field that they set, and so are removed ones, with every field that they had set
+	ChangedEndpoints []EndpointDiff
+	// Dependencies are the dependencies of the Service's Endpoints that were added and removed
+	Dependencies service.DependencyDiff
+}
+
+// Empty returns whether nothing changed
+func (d Diff) Empty() bool {
+	return len(d.Fields) == 0 &&
+		len(d.AddedEndpoints) == 0 &&
+		len(d.RemovedEndpoints) == 0 &&
+		len(d.ChangedEndpoints) == 0 &&
+		len(d.Dependencies.Added) == 0 &&
+		len(d.Dependencies.Removed) == 0
+}
+
+// Compare finds the difference between two versions of a Service. Either may be nil, for a Service that doesn't exist
+// (yet, or any more). IDs are ignored, as are the orders of Endpoints and of their dependencies
+func Compare(before *service.Service, after *service.Service) Diff {
+	diff := Diff{
+		Fields:           compareFields(serviceFields(before), serviceFields(after)),
+		AddedEndpoints:   make([]service.EndpointCode, 0),
+		RemovedEndpoints: make([]service.EndpointCode, 0),
+		ChangedEndpoints: make([]EndpointDiff, 0),
+	}
+	beforeEndpoints, afterEndpoints := endpointsByCode(before), endpointsByCode(after)
+	codes := make([]service.EndpointCode, 0, len(beforeEndpoints)+len(afterEndpoints))
+	for code := range beforeEndpoints {
+		codes = append(codes, code)
+	}
+	for code := range afterEndpoints {
+		if _, ok := beforeEndpoints[code]; !ok {
+			codes = append(codes, code)
+		}
+	}
+	sort.Strings(codes)
+	for _, code := range codes {
+		beforeEndpoint, inBefore := beforeEndpoints[code]
+		afterEndpoint, inAfter := afterEndpoints[code]
+		if !inBefore {
+			diff.AddedEndpoints = append(diff.AddedEndpoints, code)
+		} else if !inAfter {
+			diff.RemovedEndpoints = append(diff.RemovedEndpoints, code)
+		}
+		fields := compareFields(endpointFields(beforeEndpoint), endpointFields(afterEndpoint))
+		if len(fields) > 0 {
+			diff.ChangedEndpoints = append(diff.ChangedEndpoints, EndpointDiff{Code: code, Fields: fields})
+		}
+	}
+	diff.Dependencies = compareDependencies(before, after)
+	return diff
+}
+
+// field is the name and (dereferenced) value of a single field of a Service or Endpoint
+type field struct {
+	name  string
+	value interface{}
+}
+
+// serviceFields lists the fields of a Service that are tracked in its history, in the order they are reported. It is
+// nil for a nil Service
+func serviceFields(s *service.Service) []field {
+	if s == nil {
+		return nil
+	}
+	return []field{
+		{"name", s.Name},
+		{"confirmed", s.Confirmed},
+		{"team", stringValue(s.Ownership.Team)},
+		{"on_call", stringValue(s.Ownership.OnCall)},
+		{"slack_channel", stringValue(s.Ownership.SlackChannel)},
+		{"email", stringValue(s.Ownership.Email)},
+		{"repository_url", stringValue(s.Ownership.RepositoryURL)},
+		{"labels", labelsValue(s.Labels)},
+		{"tier", tierValue(s.Tier)},
+		{"lifecycle", s.Lifecycle.State},
+		{"sunset_date", timeValue(s.Lifecycle.SunsetDate)},
+		{"version", stringValue(s.Version)},
+	}
+}
+
+// endpointFields lists the fields of an Endpoint that are tracked in its Service's history, in the order they are
+// reported. It is nil for a nil Endpoint
+func endpointFields(e *service.Endpoint) []field {
+	if e == nil {
+		return nil
+	}
+	return []field{
+		{"name", e.Name},
+		{"confirmed", e.Confirmed},
+		{"labels", labelsValue(e.Labels)},
+		{"tier", tierValue(e.Tier)},
+		{"lifecycle", e.Lifecycle.State},
+		{"sunset_date", timeValue(e.Lifecycle.SunsetDate)},
+		{"version", stringValue(e.Version)},
+		{"version_constraints", versionConstraintsValue(e.VersionConstraints)},
+	}
+}
+
+// compareFields finds the fields that differ between two lists of the same fields. Either list may be nil, in which
+// case all of its fields are treated as unset
+func compareFields(before []field, after []field) []FieldChange {
+	changes := make([]FieldChange, 0)
+	for idx := 0; idx < len(before) || idx < len(after); idx++ {
+		var name string
+		var beforeValue, afterValue interface{}
+		if idx < len(before) {
+			name, beforeValue = before[idx].name, before[idx].value
+		}
+		if idx < len(after) {
+			name, afterValue = after[idx].name, after[idx].value
+		}
+		if !reflect.DeepEqual(beforeValue, afterValue) {
+			changes = append(changes, FieldChange{Field: name, Before: beforeValue, After: afterValue})
+		}
+	}
+	return changes
+}
+
+// compareDependencies finds the dependencies of a Service's Endpoints that were added and removed between two versions
+// of it
+func compareDependencies(before *service.Service, after *service.Service) service.DependencyDiff {
+	beforeEdges, afterEdges := make(map[service.DependencyEdge]bool), make(map[service.DependencyEdge]bool)
+	if before != nil {
+		for _, edge := range before.DependencyEdges() {
+			beforeEdges[edge] = true
+		}
+	}
+	diff := service.DependencyDiff{Added: make([]service.DependencyEdge, 0), Removed: make([]service.DependencyEdge, 0)}
+	if after != nil {
+		for _, edge := range after.DependencyEdges() {
+			afterEdges[edge] = true
+			if !beforeEdges[edge] {
+				diff.Added = append(diff.Added, edge)
+			}
+		}
+	}
+	if before != nil {
+		for _, edge := range before.DependencyEdges() {
+			if !afterEdges[edge] {
+				diff.Removed = append(diff.Removed, edge)
+			}
+		}
+	}
+	return diff
+}
+
+// endpointsByCode indexes the Endpoints of a Service, which may be nil, by their Codes
+func endpointsByCode(s *service.Service) map[service.EndpointCode]*service.Endpoint {
+	endpoints := make(map[service.EndpointCode]*service.Endpoint)
+	if s != nil {
+		for idx := range s.Endpoints {
+			endpoints[s.Endpoints[idx].Code] = &s.Endpoints[idx]
+		}
+	}
+	return endpoints
+}
+
+func stringValue(s *string) interface{} {
+	if s == nil {
+		return nil
+	}
+	return *s
+}
+
+func tierValue(tier *service.Tier) interface{} {
+	if tier == nil {
+		return nil
+	}
+	return *tier
+}
+
+func timeValue(t *time.Time) interface{} {
+	if t == nil {
+		return nil
+	}
+	return t.UTC()
+}
+
+// labelsValue treats nil and empty Labels as unset, so that they compare equal
+func labelsValue(labels service.Labels) interface{} {
+	if len(labels) == 0 {
+		return nil
+	}
+	return labels
+}
+
+// versionConstraintsValue treats nil and empty version constraints as unset, so that they compare equal
+func versionConstraintsValue(constraints map[service.Code]service.Version) interface{} {
+	if len(constraints) == 0 {
+		return nil
+	}
+	return constraints
+}
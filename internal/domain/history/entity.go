@@ -0,0 +1,140 @@
+package history
+
+import (
+	"sort"
+	"time"
+
+	"github.com/yashap/crius/internal/domain/service"
+)
+
+// ChangeType is the kind of change that was made to a Service, or to a Topic or Client
+type ChangeType = string
+
+const (
+	// Created Changes record a Service being saved for the first time, or for the first time since it was deleted
+	Created ChangeType = "created"
+	// Updated Changes record any change to an existing Service, including to its Endpoints and their dependencies
+	Updated ChangeType = "updated"
+	// Deleted Changes record a Service being deleted
+	Deleted ChangeType = "deleted"
+)
+
+// Actor is whoever made a Change. For example, a username, or the name of the CI job that saved a Service
+type Actor = service.Actor
+
+// BackfillActor is the Actor of the Changes that record Services that were last saved before history was recorded
+const BackfillActor Actor = "backfill"
+
+// Change is a single entry in the append-only history of a Service. Each Change records the whole Service as it was
+// right after the change was made, so the history of a Service is the sequence of its versions
+type Change struct {
+	// ID uniquely identifies this change
+	ID *int64
+	// ServiceCode is the Code of the Service that was changed
+	ServiceCode service.Code
+	// Version numbers the Changes to a Service, starting at 1. It keeps counting up across deletes, so a Service that is
+	// deleted and then saved again picks up where it left off
+	Version int
+	// Type is the kind of change that was made
+	Type ChangeType
+	// Actor is whoever made the change
+	Actor Actor
+	// ChangedAt is when the change was made
+	ChangedAt time.Time
+	// Snapshot is the Service right after the change was made. It is nil for Deleted Changes
+	Snapshot *service.Service
+}
+
+// Entry is a Change, along with how it differs from the Change before it
+type Entry struct {
+	// Change is the Change
+	Change Change
+	// Diff is the difference between the Service before and after the Change
+	Diff Diff
+}
+
+// Next builds the Change that takes the history of a Service from the latest recorded Change to the Service's current
+// state. latest is nil if nothing has been recorded yet, and current is nil if the Service doesn't exist. If nothing
+// changed, Next returns nil
+func Next(
+	code service.Code,
+	latest *Change,
+	current *service.Service,
+	actor Actor,
+	changedAt time.Time,
+) *Change {
+	var previous *service.Service
+	version := 1
+	if latest != nil {
+		previous = latest.Snapshot
+		version = latest.Version + 1
+	}
+	if Compare(previous, current).Empty() {
+		return nil
+	}
+	return &Change{
+		ServiceCode: code,
+		Version:     version,
+		Type:        changeType(previous != nil, current != nil),
+		Actor:       actor,
+		ChangedAt:   changedAt,
+		Snapshot:    current,
+	}
+}
+
+// changeType is the kind of change that was made, given whether the changed thing existed before and after it
+func changeType(existedBefore bool, existsAfter bool) ChangeType {
+	if !existedBefore {
+		return Created
+	} else if !existsAfter {
+		return Deleted
+	}
+	return Updated
+}
+
+// RemovedEndpoints lists the Endpoints that a Change removed from its Service, given the Change before it, which is nil
+// if there is none
+func RemovedEndpoints(previous *Change, change Change) []service.EndpointRef {
+	removed := make([]service.EndpointRef, 0)
+	if previous == nil || previous.Snapshot == nil {
+		return removed
+	}
+	remaining := make(map[service.EndpointCode]bool)
+	if change.Snapshot != nil {
+		for _, endpoint := range change.Snapshot.Endpoints {
+			remaining[endpoint.Code] = true
+		}
+	}
+	for _, endpoint := range previous.Snapshot.Endpoints {
+		if !remaining[endpoint.Code] {
+			removed = append(removed, service.EndpointRef{ServiceCode: change.ServiceCode, EndpointCode: endpoint.Code})
+		}
+	}
+	return removed
+}
+
+// Services reconstructs the Services that existed at some moment from the latest Change to each of them as of then.
+// Services whose latest Change deleted them are left out. Dependencies on Endpoints that didn't exist at that moment are
+// kept, so they should be dropped by building a graph.Graph out of the Services
+func Services(latest []Change) []service.Service {
+	services := make([]service.Service, 0, len(latest))
+	for _, change := range latest {
+		if change.Snapshot != nil {
+			services = append(services, *change.Snapshot)
+		}
+	}
+	sort.Slice(services, func(i, j int) bool { return services[i].Code < services[j].Code })
+	return services
+}
+
+// MakeEntries pairs each of a Service's Changes, which must be sorted by Version, with how it differs from the Change
+// before it
+func MakeEntries(changes []Change) []Entry {
+	entries := make([]Entry, len(changes))
+	var previous *service.Service
+	for idx, change := range changes {
+		entries[idx] = Entry{Change: change, Diff: Compare(previous, change.Snapshot)}
+		previous = change.Snapshot
+	}
+	return entries
+}
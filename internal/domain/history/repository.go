@@ -0,0 +1,77 @@
+package history
+
+import (
+	"github.com/jmoiron/sqlx"
+	"github.com/xo/dburl"
+	"github.com/yashap/crius/internal/domain/client"
+	"github.com/yashap/crius/internal/domain/service"
+	"github.com/yashap/crius/internal/domain/topic"
+	"go.uber.org/zap"
+	"log"
+	"time"
+)
+
+// Repository is a Change repository. Like service.Repository, the mental model is that it represents a collection of
+// Change instances, but this collection is append-only: Changes are never updated or deleted. It is also the
+// service.Recorder of the service.Repository, so each change to a Service is appended in the transaction that made it,
+// and likewise the topic.Recorder and client.Recorder, keeping a TopicChange and ClientChange history. When a Service
+// loses Endpoints, the Topics and Clients that lose their links to them are recorded as changed too
+type Repository interface {
+	service.Recorder
+	topic.Recorder
+	client.Recorder
+	// InEnvironment returns a Repository of the Changes to the Services in the given Environment. A new Repository holds
+	// the Changes to the Services in the service.DefaultEnvironment
+	InEnvironment(env service.Environment) Repository
+	// Append appends a Change to the history of its Service. It fails if the Service already has a Change with the same
+	// Version, so concurrent Changes to a Service can't both be recorded as the same Version
+	Append(change *Change) error
+	// FindByID finds a Change by its ID, or nil if there is none
+	FindByID(id int64) (*Change, error)
+	// FindByServiceCode finds every Change to the Service with the Code, sorted by Version
+	FindByServiceCode(code service.Code) ([]Change, error)
+	// FindLatest finds the latest Change to the Service with the Code, or nil if nothing has been recorded for it
+	FindLatest(code service.Code) (*Change, error)
+	// FindLatestAsOf finds the latest Change to each Service that was made at or before the given moment, sorted by
+	// Service Code
+	FindLatestAsOf(at time.Time) ([]Change, error)
+	// Backfill records each of the Services that has no history yet with a Created Change by the BackfillActor, and
+	// returns those Changes. Services that were last saved before history was recorded have none, and haven't changed
+	// since, so they are recorded as they are now, as of the moment that history began: that of the earliest recorded
+	// Change, or now if nothing has been recorded yet
+	Backfill(services []service.Service) ([]Change, error)
+	// FindLatestTopicsAsOf finds the latest TopicChange to each Topic that was made at or before the given moment,
+	// sorted by Topic Code
+	FindLatestTopicsAsOf(at time.Time) ([]TopicChange, error)
+	// FindLatestClientsAsOf finds the latest ClientChange to each Client that was made at or before the given moment,
+	// sorted by Client Code
+	FindLatestClientsAsOf(at time.Time) ([]ClientChange, error)
+	// BackfillTopics records each of the Topics that has no history yet, like Backfill does for Services. Their history
+	// began with that of Topics, so they are recorded as of the earliest recorded TopicChange, or now
+	BackfillTopics(topics []topic.Topic) ([]TopicChange, error)
+	// BackfillClients records each of the Clients that has no history yet, like Backfill does for Services. Their
+	// history began with that of Clients, so they are recorded as of the earliest recorded ClientChange, or now
+	BackfillClients(clients []client.Client) ([]ClientChange, error)
+}
+
+func NewRepository(
+	dbURL *dburl.URL,
+	db *sqlx.DB,
+	logger *zap.SugaredLogger,
+) Repository {
+	if dbURL.Driver == "postgres" {
+		return &postgresRepository{
+			db:          db,
+			logger:      logger,
+			environment: service.DefaultEnvironment,
+		}
+	} else if dbURL.Driver == "mysql" {
+		return &mysqlRepository{
+			db:          db,
+			logger:      logger,
+			environment: service.DefaultEnvironment,
+		}
+	}
+	log.Fatalf("Unsupported database: %s", dbURL.Driver)
+	return nil
+}
@@ -0,0 +1,616 @@
+package history
+
+import (
+	"context"
+	"database/sql"
+	"github.com/jmoiron/sqlx"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+	pgdao "github.com/yashap/crius/internal/db/postgresql/dao"
+	"github.com/yashap/crius/internal/domain/client"
+	"github.com/yashap/crius/internal/domain/service"
+	"github.com/yashap/crius/internal/domain/topic"
+	"github.com/yashap/crius/internal/errors"
+	"go.uber.org/zap"
+	"time"
+)
+
+type postgresRepository struct {
+	db          *sqlx.DB
+	logger      *zap.SugaredLogger
+	environment service.Environment
+}
+
+func (r *postgresRepository) InEnvironment(env service.Environment) Repository {
+	return &postgresRepository{db: r.db, logger: r.logger, environment: env}
+}
+
+func (r *postgresRepository) Record(
+	exec boil.ContextExecutor,
+	env service.Environment,
+	code service.Code,
+	current *service.Service,
+	actor Actor,
+	changedAt time.Time,
+) error {
+	scoped := &postgresRepository{db: r.db, logger: r.logger, environment: env}
+	latest, err := scoped.findLatest(exec, code)
+	if err != nil {
+		return err
+	}
+	change := Next(code, latest, current, actor, changedAt)
+	if change == nil {
+		return nil
+	}
+	err = scoped.append(exec, change)
+	if err != nil {
+		return err
+	}
+	return scoped.unlink(exec, RemovedEndpoints(latest, *change), actor, changedAt)
+}
+
+// unlink records the Topics and Clients that lost their links to the removed Endpoints with exec, as changed by the
+// Actor at changedAt
+func (r *postgresRepository) unlink(
+	exec boil.ContextExecutor,
+	removed []service.EndpointRef,
+	actor Actor,
+	changedAt time.Time,
+) error {
+	if len(removed) == 0 {
+		return nil
+	}
+	latestTopics, err := r.findLatestTopics(exec, nil)
+	if err != nil {
+		return err
+	}
+	for _, change := range UnlinkTopics(latestTopics, removed, actor, changedAt) {
+		err = r.appendTopic(exec, &change)
+		if err != nil {
+			return err
+		}
+	}
+	latestClients, err := r.findLatestClients(exec, nil)
+	if err != nil {
+		return err
+	}
+	for _, change := range UnlinkClients(latestClients, removed, actor, changedAt) {
+		err = r.appendClient(exec, &change)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *postgresRepository) Append(change *Change) error {
+	return r.append(r.db, change)
+}
+
+// append inserts a Change with exec, and sets its ID
+func (r *postgresRepository) append(exec boil.ContextExecutor, change *Change) error {
+	snapshot, err := encodeSnapshot(change.Snapshot)
+	if err != nil {
+		msg := "Failed to encode service snapshot"
+		r.logger.Errorw(msg, "err", err.Error(), "serviceCode", change.ServiceCode)
+		return errors.UnclassifiedError(msg, &err)
+	}
+	changeDAO := pgdao.ServiceHistory{
+		ServiceCode: change.ServiceCode,
+		Version:     change.Version,
+		ChangeType:  change.Type,
+		Actor:       change.Actor,
+		ChangedAt:   change.ChangedAt.UTC(),
+		Snapshot:    snapshot,
+		Environment: r.environment,
+	}
+	err = changeDAO.Insert(context.Background(), exec, boil.Infer())
+	if err != nil {
+		msg := "Failed to insert service history"
+		r.logger.Errorw(msg, "err", err.Error(), "serviceCode", change.ServiceCode, "version", change.Version)
+		return errors.DatabaseError(msg, &err)
+	}
+	change.ID = &changeDAO.ID
+	return nil
+}
+
+func (r *postgresRepository) FindByID(id int64) (*Change, error) {
+	changeDAO, err := pgdao.ServiceHistories(
+		qm.Where("environment = ?", r.environment),
+		qm.And("id = ?", id),
+	).One(context.Background(), r.db)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	} else if err != nil {
+		msg := "Failed to find service history by id"
+		r.logger.Errorw(msg, "err", err.Error(), "id", id)
+		return nil, errors.DatabaseError(msg, &err)
+	}
+	change, err := r.makeChange(changeDAO)
+	if err != nil {
+		return nil, err
+	}
+	return &change, nil
+}
+
+func (r *postgresRepository) FindByServiceCode(code service.Code) ([]Change, error) {
+	changeDAOs, err := pgdao.ServiceHistories(
+		qm.Where("environment = ?", r.environment),
+		qm.And("service_code = ?", code),
+		qm.OrderBy("version"),
+	).All(context.Background(), r.db)
+	if err != nil {
+		msg := "Failed to find service history by service code"
+		r.logger.Errorw(msg, "err", err.Error(), "serviceCode", code)
+		return nil, errors.DatabaseError(msg, &err)
+	}
+	changes := make([]Change, len(changeDAOs))
+	for idx, changeDAO := range changeDAOs {
+		changes[idx], err = r.makeChange(changeDAO)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return changes, nil
+}
+
+func (r *postgresRepository) FindLatest(code service.Code) (*Change, error) {
+	return r.findLatest(r.db, code)
+}
+
+// findLatest finds the latest Change to the Service with the Code with exec, or nil if nothing has been recorded for it
+func (r *postgresRepository) findLatest(exec boil.ContextExecutor, code service.Code) (*Change, error) {
+	changeDAO, err := pgdao.ServiceHistories(
+		qm.Where("environment = ?", r.environment),
+		qm.And("service_code = ?", code),
+		qm.OrderBy("version desc"),
+	).One(context.Background(), exec)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	} else if err != nil {
+		msg := "Failed to find latest service history by service code"
+		r.logger.Errorw(msg, "err", err.Error(), "serviceCode", code)
+		return nil, errors.DatabaseError(msg, &err)
+	}
+	change, err := r.makeChange(changeDAO)
+	if err != nil {
+		return nil, err
+	}
+	return &change, nil
+}
+
+func (r *postgresRepository) FindLatestAsOf(at time.Time) ([]Change, error) {
+	changeDAOs, err := pgdao.ServiceHistories(
+		qm.Where("environment = ?", r.environment),
+		qm.And("changed_at <= ?", at.UTC()),
+		qm.And(
+			"version = (select max(h.version) from service_history h where h.environment = service_history.environment "+
+				"and h.service_code = service_history.service_code and h.changed_at <= ?)",
+			at.UTC(),
+		),
+		qm.OrderBy("service_code"),
+	).All(context.Background(), r.db)
+	if err != nil {
+		msg := "Failed to find service history as of a moment"
+		r.logger.Errorw(msg, "err", err.Error(), "at", at)
+		return nil, errors.DatabaseError(msg, &err)
+	}
+	changes := make([]Change, len(changeDAOs))
+	for idx, changeDAO := range changeDAOs {
+		changes[idx], err = r.makeChange(changeDAO)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return changes, nil
+}
+
+func (r *postgresRepository) Backfill(services []service.Service) ([]Change, error) {
+	tx, err := r.db.BeginTx(context.Background(), nil)
+	if err != nil {
+		msg := "Failed to begin transaction when backfilling service history"
+		r.logger.Errorw(msg, "err", err.Error())
+		return nil, errors.DatabaseError(msg, &err)
+	}
+	changedAt, err := r.findHistoryStart(tx)
+	if err != nil {
+		_ = tx.Rollback()
+		return nil, err
+	}
+	changes := make([]Change, 0)
+	for idx := range services {
+		latest, err := r.findLatest(tx, services[idx].Code)
+		if err != nil {
+			_ = tx.Rollback()
+			return nil, err
+		}
+		if latest != nil {
+			continue
+		}
+		change := Next(services[idx].Code, nil, &services[idx], BackfillActor, changedAt)
+		err = r.append(tx, change)
+		if err != nil {
+			_ = tx.Rollback()
+			return nil, err
+		}
+		changes = append(changes, *change)
+	}
+	err = tx.Commit()
+	if err != nil {
+		msg := "Failed to commit transaction when backfilling service history"
+		r.logger.Errorw(msg, "err", err.Error())
+		return nil, errors.DatabaseError(msg, &err)
+	}
+	return changes, nil
+}
+
+// findHistoryStart finds the moment that history began to be recorded, which is when the earliest Change in any
+// Environment was made, or now if nothing has been recorded yet
+func (r *postgresRepository) findHistoryStart(exec boil.ContextExecutor) (time.Time, error) {
+	changeDAO, err := pgdao.ServiceHistories(qm.OrderBy("changed_at")).One(context.Background(), exec)
+	if err == sql.ErrNoRows {
+		return time.Now().UTC(), nil
+	} else if err != nil {
+		msg := "Failed to find earliest service history"
+		r.logger.Errorw(msg, "err", err.Error())
+		return time.Time{}, errors.DatabaseError(msg, &err)
+	}
+	return changeDAO.ChangedAt, nil
+}
+
+// makeChange builds a Change from a service history DAO, decoding its Snapshot
+func (r *postgresRepository) makeChange(changeDAO *pgdao.ServiceHistory) (Change, error) {
+	snapshot, err := decodeSnapshot(changeDAO.Snapshot)
+	if err != nil {
+		msg := "Failed to decode service snapshot"
+		r.logger.Errorw(msg, "err", err.Error(), "serviceCode", changeDAO.ServiceCode, "version", changeDAO.Version)
+		return Change{}, errors.DatabaseError(msg, &err)
+	}
+	return Change{
+		ID:          &changeDAO.ID,
+		ServiceCode: changeDAO.ServiceCode,
+		Version:     changeDAO.Version,
+		Type:        changeDAO.ChangeType,
+		Actor:       changeDAO.Actor,
+		ChangedAt:   changeDAO.ChangedAt,
+		Snapshot:    snapshot,
+	}, nil
+}
+
+func (r *postgresRepository) RecordTopic(
+	exec boil.ContextExecutor,
+	env service.Environment,
+	code topic.Code,
+	current *topic.Topic,
+	actor Actor,
+	changedAt time.Time,
+) error {
+	scoped := &postgresRepository{db: r.db, logger: r.logger, environment: env}
+	latest, err := scoped.findLatestTopic(exec, code)
+	if err != nil {
+		return err
+	}
+	change := NextTopic(code, latest, current, actor, changedAt)
+	if change == nil {
+		return nil
+	}
+	return scoped.appendTopic(exec, change)
+}
+
+// appendTopic inserts a TopicChange with exec, and sets its ID
+func (r *postgresRepository) appendTopic(exec boil.ContextExecutor, change *TopicChange) error {
+	snapshot, err := encodeTopicSnapshot(change.Snapshot)
+	if err != nil {
+		msg := "Failed to encode topic snapshot"
+		r.logger.Errorw(msg, "err", err.Error(), "topicCode", change.TopicCode)
+		return errors.UnclassifiedError(msg, &err)
+	}
+	changeDAO := pgdao.TopicHistory{
+		Environment: r.environment,
+		TopicCode:   change.TopicCode,
+		Version:     change.Version,
+		ChangeType:  change.Type,
+		Actor:       change.Actor,
+		ChangedAt:   change.ChangedAt.UTC(),
+		Snapshot:    snapshot,
+	}
+	err = changeDAO.Insert(context.Background(), exec, boil.Infer())
+	if err != nil {
+		msg := "Failed to insert topic history"
+		r.logger.Errorw(msg, "err", err.Error(), "topicCode", change.TopicCode, "version", change.Version)
+		return errors.DatabaseError(msg, &err)
+	}
+	change.ID = &changeDAO.ID
+	return nil
+}
+
+func (r *postgresRepository) FindLatestTopicsAsOf(at time.Time) ([]TopicChange, error) {
+	return r.findLatestTopics(r.db, &at)
+}
+
+// findLatestTopic finds the latest TopicChange to the Topic with the Code with exec, or nil if nothing has been
+// recorded for it
+func (r *postgresRepository) findLatestTopic(exec boil.ContextExecutor, code topic.Code) (*TopicChange, error) {
+	changeDAO, err := pgdao.TopicHistories(
+		qm.Where("environment = ?", r.environment),
+		qm.And("topic_code = ?", code),
+		qm.OrderBy("version desc"),
+	).One(context.Background(), exec)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	} else if err != nil {
+		msg := "Failed to find latest topic history by topic code"
+		r.logger.Errorw(msg, "err", err.Error(), "topicCode", code)
+		return nil, errors.DatabaseError(msg, &err)
+	}
+	change, err := r.makeTopicChange(changeDAO)
+	if err != nil {
+		return nil, err
+	}
+	return &change, nil
+}
+
+// findLatestTopics finds the latest TopicChange to each Topic with exec, sorted by Topic Code. If at is set, only the
+// TopicChanges made at or before it are considered
+func (r *postgresRepository) findLatestTopics(exec boil.ContextExecutor, at *time.Time) ([]TopicChange, error) {
+	mods := []qm.QueryMod{qm.Where("environment = ?", r.environment)}
+	latest := "select max(h.version) from topic_history h where h.environment = topic_history.environment " +
+		"and h.topic_code = topic_history.topic_code"
+	if at != nil {
+		mods = append(
+			mods,
+			qm.And("changed_at <= ?", at.UTC()),
+			qm.And("version = ("+latest+" and h.changed_at <= ?)", at.UTC()),
+		)
+	} else {
+		mods = append(mods, qm.And("version = ("+latest+")"))
+	}
+	changeDAOs, err := pgdao.TopicHistories(
+		append(mods, qm.OrderBy("topic_code"))...,
+	).All(context.Background(), exec)
+	if err != nil {
+		msg := "Failed to find latest topic history"
+		r.logger.Errorw(msg, "err", err.Error(), "at", at)
+		return nil, errors.DatabaseError(msg, &err)
+	}
+	changes := make([]TopicChange, len(changeDAOs))
+	for idx, changeDAO := range changeDAOs {
+		changes[idx], err = r.makeTopicChange(changeDAO)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return changes, nil
+}
+
+func (r *postgresRepository) BackfillTopics(topics []topic.Topic) ([]TopicChange, error) {
+	tx, err := r.db.BeginTx(context.Background(), nil)
+	if err != nil {
+		msg := "Failed to begin transaction when backfilling topic history"
+		r.logger.Errorw(msg, "err", err.Error())
+		return nil, errors.DatabaseError(msg, &err)
+	}
+	changedAt := time.Now().UTC()
+	earliest, err := pgdao.TopicHistories(qm.OrderBy("changed_at")).One(context.Background(), tx)
+	if err == nil {
+		changedAt = earliest.ChangedAt
+	} else if err != sql.ErrNoRows {
+		msg := "Failed to find earliest topic history"
+		r.logger.Errorw(msg, "err", err.Error())
+		_ = tx.Rollback()
+		return nil, errors.DatabaseError(msg, &err)
+	}
+	changes := make([]TopicChange, 0)
+	for idx := range topics {
+		latest, err := r.findLatestTopic(tx, topics[idx].Code)
+		if err != nil {
+			_ = tx.Rollback()
+			return nil, err
+		}
+		if latest != nil {
+			continue
+		}
+		change := NextTopic(topics[idx].Code, nil, &topics[idx], BackfillActor, changedAt)
+		err = r.appendTopic(tx, change)
+		if err != nil {
+			_ = tx.Rollback()
+			return nil, err
+		}
+		changes = append(changes, *change)
+	}
+	err = tx.Commit()
+	if err != nil {
+		msg := "Failed to commit transaction when backfilling topic history"
+		r.logger.Errorw(msg, "err", err.Error())
+		return nil, errors.DatabaseError(msg, &err)
+	}
+	return changes, nil
+}
+
+// makeTopicChange builds a TopicChange from a topic history DAO, decoding its Snapshot
+func (r *postgresRepository) makeTopicChange(changeDAO *pgdao.TopicHistory) (TopicChange, error) {
+	snapshot, err := decodeTopicSnapshot(changeDAO.Snapshot)
+	if err != nil {
+		msg := "Failed to decode topic snapshot"
+		r.logger.Errorw(msg, "err", err.Error(), "topicCode", changeDAO.TopicCode, "version", changeDAO.Version)
+		return TopicChange{}, errors.DatabaseError(msg, &err)
+	}
+	return TopicChange{
+		ID:        &changeDAO.ID,
+		TopicCode: changeDAO.TopicCode,
+		Version:   changeDAO.Version,
+		Type:      changeDAO.ChangeType,
+		Actor:     changeDAO.Actor,
+		ChangedAt: changeDAO.ChangedAt,
+		Snapshot:  snapshot,
+	}, nil
+}
+
+func (r *postgresRepository) RecordClient(
+	exec boil.ContextExecutor,
+	env service.Environment,
+	code client.Code,
+	current *client.Client,
+	actor Actor,
+	changedAt time.Time,
+) error {
+	scoped := &postgresRepository{db: r.db, logger: r.logger, environment: env}
+	latest, err := scoped.findLatestClient(exec, code)
+	if err != nil {
+		return err
+	}
+	change := NextClient(code, latest, current, actor, changedAt)
+	if change == nil {
+		return nil
+	}
+	return scoped.appendClient(exec, change)
+}
+
+// appendClient inserts a ClientChange with exec, and sets its ID
+func (r *postgresRepository) appendClient(exec boil.ContextExecutor, change *ClientChange) error {
+	snapshot, err := encodeClientSnapshot(change.Snapshot)
+	if err != nil {
+		msg := "Failed to encode client snapshot"
+		r.logger.Errorw(msg, "err", err.Error(), "clientCode", change.ClientCode)
+		return errors.UnclassifiedError(msg, &err)
+	}
+	changeDAO := pgdao.ClientHistory{
+		Environment: r.environment,
+		ClientCode:  change.ClientCode,
+		Version:     change.Version,
+		ChangeType:  change.Type,
+		Actor:       change.Actor,
+		ChangedAt:   change.ChangedAt.UTC(),
+		Snapshot:    snapshot,
+	}
+	err = changeDAO.Insert(context.Background(), exec, boil.Infer())
+	if err != nil {
+		msg := "Failed to insert client history"
+		r.logger.Errorw(msg, "err", err.Error(), "clientCode", change.ClientCode, "version", change.Version)
+		return errors.DatabaseError(msg, &err)
+	}
+	change.ID = &changeDAO.ID
+	return nil
+}
+
+func (r *postgresRepository) FindLatestClientsAsOf(at time.Time) ([]ClientChange, error) {
+	return r.findLatestClients(r.db, &at)
+}
+
+// findLatestClient finds the latest ClientChange to the Client with the Code with exec, or nil if nothing has been
+// recorded for it
+func (r *postgresRepository) findLatestClient(exec boil.ContextExecutor, code client.Code) (*ClientChange, error) {
+	changeDAO, err := pgdao.ClientHistories(
+		qm.Where("environment = ?", r.environment),
+		qm.And("client_code = ?", code),
+		qm.OrderBy("version desc"),
+	).One(context.Background(), exec)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	} else if err != nil {
+		msg := "Failed to find latest client history by client code"
+		r.logger.Errorw(msg, "err", err.Error(), "clientCode", code)
+		return nil, errors.DatabaseError(msg, &err)
+	}
+	change, err := r.makeClientChange(changeDAO)
+	if err != nil {
+		return nil, err
+	}
+	return &change, nil
+}
+
+// findLatestClients finds the latest ClientChange to each Client with exec, sorted by Client Code. If at is set, only
+// the ClientChanges made at or before it are considered
+func (r *postgresRepository) findLatestClients(exec boil.ContextExecutor, at *time.Time) ([]ClientChange, error) {
+	mods := []qm.QueryMod{qm.Where("environment = ?", r.environment)}
+	latest := "select max(h.version) from client_history h where h.environment = client_history.environment " +
+		"and h.client_code = client_history.client_code"
+	if at != nil {
+		mods = append(
+			mods,
+			qm.And("changed_at <= ?", at.UTC()),
+			qm.And("version = ("+latest+" and h.changed_at <= ?)", at.UTC()),
+		)
+	} else {
+		mods = append(mods, qm.And("version = ("+latest+")"))
+	}
+	changeDAOs, err := pgdao.ClientHistories(
+		append(mods, qm.OrderBy("client_code"))...,
+	).All(context.Background(), exec)
+	if err != nil {
+		msg := "Failed to find latest client history"
+		r.logger.Errorw(msg, "err", err.Error(), "at", at)
+		return nil, errors.DatabaseError(msg, &err)
+	}
+	changes := make([]ClientChange, len(changeDAOs))
+	for idx, changeDAO := range changeDAOs {
+		changes[idx], err = r.makeClientChange(changeDAO)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return changes, nil
+}
+
+func (r *postgresRepository) BackfillClients(clients []client.Client) ([]ClientChange, error) {
+	tx, err := r.db.BeginTx(context.Background(), nil)
+	if err != nil {
+		msg := "Failed to begin transaction when backfilling client history"
+		r.logger.Errorw(msg, "err", err.Error())
+		return nil, errors.DatabaseError(msg, &err)
+	}
+	changedAt := time.Now().UTC()
+	earliest, err := pgdao.ClientHistories(qm.OrderBy("changed_at")).One(context.Background(), tx)
+	if err == nil {
+		changedAt = earliest.ChangedAt
+	} else if err != sql.ErrNoRows {
+		msg := "Failed to find earliest client history"
+		r.logger.Errorw(msg, "err", err.Error())
+		_ = tx.Rollback()
+		return nil, errors.DatabaseError(msg, &err)
+	}
+	changes := make([]ClientChange, 0)
+	for idx := range clients {
+		latest, err := r.findLatestClient(tx, clients[idx].Code)
+		if err != nil {
+			_ = tx.Rollback()
+			return nil, err
+		}
+		if latest != nil {
+			continue
+		}
+		change := NextClient(clients[idx].Code, nil, &clients[idx], BackfillActor, changedAt)
+		err = r.appendClient(tx, change)
+		if err != nil {
+			_ = tx.Rollback()
+			return nil, err
+		}
+		changes = append(changes, *change)
+	}
+	err = tx.Commit()
+	if err != nil {
+		msg := "Failed to commit transaction when backfilling client history"
+		r.logger.Errorw(msg, "err", err.Error())
+		return nil, errors.DatabaseError(msg, &err)
+	}
+	return changes, nil
+}
+
+// makeClientChange builds a ClientChange from a client history DAO, decoding its Snapshot
+func (r *postgresRepository) makeClientChange(changeDAO *pgdao.ClientHistory) (ClientChange, error) {
+	snapshot, err := decodeClientSnapshot(changeDAO.Snapshot)
+	if err != nil {
+		msg := "Failed to decode client snapshot"
+		r.logger.Errorw(msg, "err", err.Error(), "clientCode", changeDAO.ClientCode, "version", changeDAO.Version)
+		return ClientChange{}, errors.DatabaseError(msg, &err)
+	}
+	return ClientChange{
+		ID:         &changeDAO.ID,
+		ClientCode: changeDAO.ClientCode,
+		Version:    changeDAO.Version,
+		Type:       changeDAO.ChangeType,
+		Actor:      changeDAO.Actor,
+		ChangedAt:  changeDAO.ChangedAt,
+		Snapshot:   snapshot,
+	}, nil
+}
@@ -0,0 +1,263 @@
+package history
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/volatiletech/null/v8"
+	"github.com/yashap/crius/internal/domain/client"
+	"github.com/yashap/crius/internal/domain/service"
+	"github.com/yashap/crius/internal/domain/topic"
+)
+
+// snapshotFormat is the format of the snapshots that encodeSnapshot, encodeTopicSnapshot and encodeClientSnapshot
+// store. Snapshots in any other format can't be decoded
+const snapshotFormat = 1
+
+// snapshot is how a Service is stored in the snapshot column of the service_history table. It is kept apart from
+// service.Service, so that the stored history doesn't change shape whenever the entity does, and it leaves out database
+// IDs, which mean nothing once the Service has been deleted and saved again
+type snapshot struct {
+	Format    int                `json:"format"`
+	Code      service.Code       `json:"code"`
+	Name      service.Name       `json:"name"`
+	Confirmed bool               `json:"confirmed"`
+	Ownership snapshotOwnership  `json:"ownership"`
+	Labels    service.Labels     `json:"labels"`
+	Tier      *service.Tier      `json:"tier"`
+	Lifecycle snapshotLifecycle  `json:"lifecycle"`
+	Version   *service.Version   `json:"version"`
+	Endpoints []snapshotEndpoint `json:"endpoints"`
+}
+
+// snapshotOwnership is how a Service's Ownership is stored in a snapshot
+type snapshotOwnership struct {
+	Team          *service.Team `json:"team"`
+	OnCall        *string       `json:"on_call"`
+	SlackChannel  *string       `json:"slack_channel"`
+	Email         *string       `json:"email"`
+	RepositoryURL *string       `json:"repository_url"`
+}
+
+// snapshotLifecycle is how the Lifecycle of a Service or Endpoint is stored in a snapshot
+type snapshotLifecycle struct {
+	State      service.LifecycleState `json:"state"`
+	SunsetDate *time.Time             `json:"sunset_date"`
+}
+
+// snapshotEndpoint is how an Endpoint is stored in a snapshot, as part of its Service
+type snapshotEndpoint struct {
+	Code               service.EndpointCode                    `json:"code"`
+	Name               service.EndpointName                    `json:"name"`
+	Confirmed          bool                                    `json:"confirmed"`
+	Dependencies       map[service.Code][]service.EndpointCode `json:"dependencies"`
+	Labels             service.Labels                          `json:"labels"`
+	Tier               *service.Tier                           `json:"tier"`
+	Lifecycle          snapshotLifecycle                       `json:"lifecycle"`
+	Version            *service.Version                        `json:"version"`
+	VersionConstraints map[service.Code]service.Version        `json:"version_constraints"`
+}
+
+func makeSnapshotLifecycle(lifecycle service.Lifecycle) snapshotLifecycle {
+	return snapshotLifecycle{State: lifecycle.State, SunsetDate: lifecycle.SunsetDate}
+}
+
+func (l snapshotLifecycle) toEntity() service.Lifecycle {
+	return service.Lifecycle{State: l.State, SunsetDate: l.SunsetDate}
+}
+
+// encodeSnapshot encodes a Snapshot as JSON, for storage in the snapshot column. A nil Snapshot is stored as NULL
+func encodeSnapshot(svc *service.Service) (null.String, error) {
+	if svc == nil {
+		return null.String{}, nil
+	}
+	s := snapshot{
+		Format:    snapshotFormat,
+		Code:      svc.Code,
+		Name:      svc.Name,
+		Confirmed: svc.Confirmed,
+		Ownership: snapshotOwnership{
+			Team:          svc.Ownership.Team,
+			OnCall:        svc.Ownership.OnCall,
+			SlackChannel:  svc.Ownership.SlackChannel,
+			Email:         svc.Ownership.Email,
+			RepositoryURL: svc.Ownership.RepositoryURL,
+		},
+		Labels:    svc.Labels,
+		Tier:      svc.Tier,
+		Lifecycle: makeSnapshotLifecycle(svc.Lifecycle),
+		Version:   svc.Version,
+		Endpoints: make([]snapshotEndpoint, len(svc.Endpoints)),
+	}
+	for idx, endpoint := range svc.Endpoints {
+		s.Endpoints[idx] = snapshotEndpoint{
+			Code:               endpoint.Code,
+			Name:               endpoint.Name,
+			Confirmed:          endpoint.Confirmed,
+			Dependencies:       endpoint.Dependencies,
+			Labels:             endpoint.Labels,
+			Tier:               endpoint.Tier,
+			Lifecycle:          makeSnapshotLifecycle(endpoint.Lifecycle),
+			Version:            endpoint.Version,
+			VersionConstraints: endpoint.VersionConstraints,
+		}
+	}
+	encoded, err := json.Marshal(s)
+	return null.StringFrom(string(encoded)), err
+}
+
+// decodeSnapshot decodes a Snapshot that was encoded by encodeSnapshot. The Snapshot has no IDs
+func decodeSnapshot(encoded null.String) (*service.Service, error) {
+	if !encoded.Valid {
+		return nil, nil
+	}
+	var s snapshot
+	err := json.Unmarshal([]byte(encoded.String), &s)
+	if err != nil {
+		return nil, err
+	}
+	if s.Format != snapshotFormat {
+		return nil, fmt.Errorf("unsupported service snapshot format %d", s.Format)
+	}
+	svc := service.MakeService(
+		nil,
+		s.Code,
+		s.Name,
+		make([]service.Endpoint, len(s.Endpoints)),
+		service.Ownership{
+			Team:          s.Ownership.Team,
+			OnCall:        s.Ownership.OnCall,
+			SlackChannel:  s.Ownership.SlackChannel,
+			Email:         s.Ownership.Email,
+			RepositoryURL: s.Ownership.RepositoryURL,
+		},
+	)
+	svc.Confirmed = s.Confirmed
+	svc.Labels = s.Labels
+	svc.Tier = s.Tier
+	svc.Lifecycle = s.Lifecycle.toEntity()
+	svc.Version = s.Version
+	for idx, endpoint := range s.Endpoints {
+		svc.Endpoints[idx] = service.Endpoint{
+			Code:               endpoint.Code,
+			Name:               endpoint.Name,
+			Dependencies:       endpoint.Dependencies,
+			Confirmed:          endpoint.Confirmed,
+			Labels:             endpoint.Labels,
+			Tier:               endpoint.Tier,
+			Lifecycle:          endpoint.Lifecycle.toEntity(),
+			Version:            endpoint.Version,
+			VersionConstraints: endpoint.VersionConstraints,
+		}
+	}
+	return &svc, nil
+}
+
+// topicSnapshot is how a Topic is stored in the snapshot column of the topic_history table. Like a snapshot, it leaves
+// out database IDs
+type topicSnapshot struct {
+	Format    int                   `json:"format"`
+	Code      topic.Code            `json:"code"`
+	Name      topic.Name            `json:"name"`
+	Producers []snapshotEndpointRef `json:"producers"`
+	Consumers []snapshotEndpointRef `json:"consumers"`
+}
+
+// snapshotEndpointRef is how a reference to an Endpoint is stored in a snapshot
+type snapshotEndpointRef struct {
+	ServiceCode  service.Code         `json:"service_code"`
+	EndpointCode service.EndpointCode `json:"endpoint_code"`
+}
+
+func makeSnapshotEndpointRefs(refs []service.EndpointRef) []snapshotEndpointRef {
+	snapshotRefs := make([]snapshotEndpointRef, len(refs))
+	for idx, ref := range refs {
+		snapshotRefs[idx] = snapshotEndpointRef{ServiceCode: ref.ServiceCode, EndpointCode: ref.EndpointCode}
+	}
+	return snapshotRefs
+}
+
+func toEndpointRefs(snapshotRefs []snapshotEndpointRef) []service.EndpointRef {
+	refs := make([]service.EndpointRef, len(snapshotRefs))
+	for idx, ref := range snapshotRefs {
+		refs[idx] = service.EndpointRef{ServiceCode: ref.ServiceCode, EndpointCode: ref.EndpointCode}
+	}
+	return refs
+}
+
+// encodeTopicSnapshot encodes a Topic as JSON, for storage in the snapshot column. A nil Topic is stored as NULL
+func encodeTopicSnapshot(t *topic.Topic) (null.String, error) {
+	if t == nil {
+		return null.String{}, nil
+	}
+	encoded, err := json.Marshal(topicSnapshot{
+		Format:    snapshotFormat,
+		Code:      t.Code,
+		Name:      t.Name,
+		Producers: makeSnapshotEndpointRefs(t.Producers),
+		Consumers: makeSnapshotEndpointRefs(t.Consumers),
+	})
+	return null.StringFrom(string(encoded)), err
+}
+
+// decodeTopicSnapshot decodes a Topic that was encoded by encodeTopicSnapshot. The Topic has no ID
+func decodeTopicSnapshot(encoded null.String) (*topic.Topic, error) {
+	if !encoded.Valid {
+		return nil, nil
+	}
+	var s topicSnapshot
+	err := json.Unmarshal([]byte(encoded.String), &s)
+	if err != nil {
+		return nil, err
+	}
+	if s.Format != snapshotFormat {
+		return nil, fmt.Errorf("unsupported topic snapshot format %d", s.Format)
+	}
+	t := topic.MakeTopic(nil, s.Code, s.Name, toEndpointRefs(s.Producers), toEndpointRefs(s.Consumers))
+	return &t, nil
+}
+
+// clientSnapshot is how a Client is stored in the snapshot column of the client_history table. Like a snapshot, it
+// leaves out database IDs
+type clientSnapshot struct {
+	Format       int                                     `json:"format"`
+	Code         client.Code                             `json:"code"`
+	Name         client.Name                             `json:"name"`
+	Dependencies map[service.Code][]service.EndpointCode `json:"dependencies"`
+}
+
+// encodeClientSnapshot encodes a Client as JSON, for storage in the snapshot column. A nil Client is stored as NULL
+func encodeClientSnapshot(c *client.Client) (null.String, error) {
+	if c == nil {
+		return null.String{}, nil
+	}
+	encoded, err := json.Marshal(clientSnapshot{
+		Format:       snapshotFormat,
+		Code:         c.Code,
+		Name:         c.Name,
+		Dependencies: c.Dependencies,
+	})
+	return null.StringFrom(string(encoded)), err
+}
+
+// decodeClientSnapshot decodes a Client that was encoded by encodeClientSnapshot. The Client has no ID
+func decodeClientSnapshot(encoded null.String) (*client.Client, error) {
+	if !encoded.Valid {
+		return nil, nil
+	}
+	var s clientSnapshot
+	err := json.Unmarshal([]byte(encoded.String), &s)
+	if err != nil {
+		return nil, err
+	}
+	if s.Format != snapshotFormat {
+		return nil, fmt.Errorf("unsupported client snapshot format %d", s.Format)
+	}
+	dependencies := s.Dependencies
+	if dependencies == nil {
+		dependencies = make(map[service.Code][]service.EndpointCode)
+	}
+	c := client.MakeClient(nil, s.Code, s.Name, dependencies)
+	return &c, nil
+}
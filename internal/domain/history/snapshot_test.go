@@ -0,0 +1,174 @@
+package history
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/volatiletech/null/v8"
+	"github.com/yashap/crius/internal/domain/client"
+	"github.com/yashap/crius/internal/domain/service"
+	"github.com/yashap/crius/internal/domain/topic"
+)
+
+func TestSnapshot(t *testing.T) {
+	serviceID, endpointID := int64(7), int64(42)
+	team, tier, version, endpointVersion := "logistics", 1, "2.1.0", "2.2.0"
+	sunsetDate := time.Date(2030, 1, 31, 0, 0, 0, 0, time.UTC)
+	svc := service.Service{
+		ID:        &serviceID,
+		Code:      "trips",
+		Name:      "Trips",
+		Confirmed: true,
+		Ownership: service.Ownership{Team: &team},
+		Labels:    service.Labels{"domain": "logistics"},
+		Tier:      &tier,
+		Lifecycle: service.Lifecycle{State: service.Deprecated, SunsetDate: &sunsetDate},
+		Version:   &version,
+		Endpoints: []service.Endpoint{
+			{
+				ID:                 &endpointID,
+				Code:               "GET /trips/{id}",
+				Name:               "Get trip",
+				Dependencies:       map[service.Code][]service.EndpointCode{"locations": {"GET /locations/{id}"}},
+				Confirmed:          true,
+				Labels:             service.Labels{"pci": "false"},
+				Lifecycle:          service.Lifecycle{State: service.Active},
+				Version:            &endpointVersion,
+				VersionConstraints: map[service.Code]service.Version{"locations": "^1"},
+			},
+		},
+	}
+	// Snapshots leave out IDs
+	want := svc
+	want.ID = nil
+	want.Endpoints = []service.Endpoint{svc.Endpoints[0]}
+	want.Endpoints[0].ID = nil
+
+	t.Run("round trips a Service, without its IDs", func(t *testing.T) {
+		encoded, err := encodeSnapshot(&svc)
+		if err != nil {
+			t.Fatalf("encodeSnapshot() error = %v", err)
+		}
+		if strings.Contains(encoded.String, "42") {
+			t.Errorf("encodeSnapshot() = %s, want no IDs", encoded.String)
+		}
+		got, err := decodeSnapshot(encoded)
+		if err != nil {
+			t.Fatalf("decodeSnapshot() error = %v", err)
+		}
+		if !reflect.DeepEqual(*got, want) {
+			t.Errorf("decodeSnapshot() = %+v, want %+v", *got, want)
+		}
+	})
+
+	t.Run("rejects snapshots with no format", func(t *testing.T) {
+		unformatted, err := json.Marshal(svc)
+		if err != nil {
+			t.Fatalf("json.Marshal() error = %v", err)
+		}
+		got, err := decodeSnapshot(null.StringFrom(string(unformatted)))
+		if err == nil {
+			t.Errorf("decodeSnapshot() = %+v, want an error", got)
+		}
+	})
+
+	t.Run("stores deleted Services as NULL", func(t *testing.T) {
+		encoded, err := encodeSnapshot(nil)
+		if err != nil || encoded.Valid {
+			t.Errorf("encodeSnapshot(nil) = %v, %v, want NULL", encoded, err)
+		}
+		got, err := decodeSnapshot(encoded)
+		if err != nil || got != nil {
+			t.Errorf("decodeSnapshot(NULL) = %v, %v, want nil", got, err)
+		}
+	})
+}
+
+func TestTopicAndClientSnapshots(t *testing.T) {
+	topicID, clientID := int64(7), int64(42)
+	tp := topic.MakeTopic(
+		&topicID,
+		"trip_completed",
+		"Trip Completed",
+		[]service.EndpointRef{{ServiceCode: "trips", EndpointCode: "POST /trips/{id}/complete"}},
+		[]service.EndpointRef{{ServiceCode: "billing", EndpointCode: "consume trip_completed"}},
+	)
+	cl := client.MakeClient(
+		&clientID,
+		"ios_v5",
+		"iOS App v5",
+		map[service.Code][]service.EndpointCode{"trips": {"GET /trips/{id}"}},
+	)
+
+	t.Run("round trips a Topic, without its ID", func(t *testing.T) {
+		encoded, err := encodeTopicSnapshot(&tp)
+		if err != nil {
+			t.Fatalf("encodeTopicSnapshot() error = %v", err)
+		}
+		got, err := decodeTopicSnapshot(encoded)
+		if err != nil {
+			t.Fatalf("decodeTopicSnapshot() error = %v", err)
+		}
+		want := tp
+		want.ID = nil
+		if !reflect.DeepEqual(*got, want) {
+			t.Errorf("decodeTopicSnapshot() = %+v, want %+v", *got, want)
+		}
+	})
+
+	t.Run("round trips a Client, without its ID", func(t *testing.T) {
+		encoded, err := encodeClientSnapshot(&cl)
+		if err != nil {
+			t.Fatalf("encodeClientSnapshot() error = %v", err)
+		}
+		got, err := decodeClientSnapshot(encoded)
+		if err != nil {
+			t.Fatalf("decodeClientSnapshot() error = %v", err)
+		}
+		want := cl
+		want.ID = nil
+		if !reflect.DeepEqual(*got, want) {
+			t.Errorf("decodeClientSnapshot() = %+v, want %+v", *got, want)
+		}
+	})
+
+	t.Run("unlinks Topics and Clients from removed Endpoints", func(t *testing.T) {
+		changedAt := time.Date(2030, 1, 31, 0, 0, 0, 0, time.UTC)
+		removed := []service.EndpointRef{
+			{ServiceCode: "trips", EndpointCode: "POST /trips/{id}/complete"},
+			{ServiceCode: "trips", EndpointCode: "GET /trips/{id}"},
+		}
+		topicChanges := UnlinkTopics(
+			[]TopicChange{{TopicCode: tp.Code, Version: 1, Type: Created, Snapshot: &tp}},
+			removed,
+			"ci",
+			changedAt,
+		)
+		if len(topicChanges) != 1 || topicChanges[0].Version != 2 || topicChanges[0].Type != Updated ||
+			len(topicChanges[0].Snapshot.Producers) != 0 || len(topicChanges[0].Snapshot.Consumers) != 1 {
+			t.Errorf("UnlinkTopics() = %+v, want the Topic without its producer", topicChanges)
+		}
+		clientChanges := UnlinkClients(
+			[]ClientChange{{ClientCode: cl.Code, Version: 1, Type: Created, Snapshot: &cl}},
+			removed,
+			"ci",
+			changedAt,
+		)
+		if len(clientChanges) != 1 || clientChanges[0].Version != 2 ||
+			len(clientChanges[0].Snapshot.Dependencies) != 0 {
+			t.Errorf("UnlinkClients() = %+v, want the Client without its dependency", clientChanges)
+		}
+		unaffected := UnlinkTopics(
+			[]TopicChange{{TopicCode: tp.Code, Version: 1, Type: Created, Snapshot: &tp}},
+			[]service.EndpointRef{{ServiceCode: "trips", EndpointCode: "GET /trips"}},
+			"ci",
+			changedAt,
+		)
+		if len(unaffected) != 0 {
+			t.Errorf("UnlinkTopics() = %+v, want no changes", unaffected)
+		}
+	})
+}
@@ -0,0 +1,130 @@
+package history
+
+import (
+	"reflect"
+	"sort"
+	"time"
+
+	"github.com/yashap/crius/internal/domain/service"
+	"github.com/yashap/crius/internal/domain/topic"
+)
+
+// TopicChange is a single entry in the append-only history of a topic.Topic. Like a Change, it records the whole Topic
+// as it was right after the change was made
+type TopicChange struct {
+	// ID uniquely identifies this change
+	ID *int64
+	// TopicCode is the Code of the Topic that was changed
+	TopicCode topic.Code
+	// Version numbers the Changes to a Topic, starting at 1, and keeps counting up across deletes
+	Version int
+	// Type is the kind of change that was made
+	Type ChangeType
+	// Actor is whoever made the change
+	Actor Actor
+	// ChangedAt is when the change was made
+	ChangedAt time.Time
+	// Snapshot is the Topic right after the change was made. It is nil for Deleted Changes
+	Snapshot *topic.Topic
+}
+
+// NextTopic builds the TopicChange that takes the history of a Topic from the latest recorded TopicChange to the
+// Topic's current state, like Next does for a Service. If nothing changed, NextTopic returns nil
+func NextTopic(
+	code topic.Code,
+	latest *TopicChange,
+	current *topic.Topic,
+	actor Actor,
+	changedAt time.Time,
+) *TopicChange {
+	var previous *topic.Topic
+	version := 1
+	if latest != nil {
+		previous = latest.Snapshot
+		version = latest.Version + 1
+	}
+	current = normalizeTopic(current)
+	if reflect.DeepEqual(normalizeTopic(previous), current) {
+		return nil
+	}
+	return &TopicChange{
+		TopicCode: code,
+		Version:   version,
+		Type:      changeType(previous != nil, current != nil),
+		Actor:     actor,
+		ChangedAt: changedAt,
+		Snapshot:  current,
+	}
+}
+
+// Topics reconstructs the Topics that existed at some moment from the latest TopicChange to each of them as of then,
+// leaving out those whose latest TopicChange deleted them
+func Topics(latest []TopicChange) []topic.Topic {
+	topics := make([]topic.Topic, 0, len(latest))
+	for _, change := range latest {
+		if change.Snapshot != nil {
+			topics = append(topics, *change.Snapshot)
+		}
+	}
+	sort.Slice(topics, func(i, j int) bool { return topics[i].Code < topics[j].Code })
+	return topics
+}
+
+// UnlinkTopics builds the TopicChanges that unlink Topics from Endpoints that were removed, given the latest
+// TopicChange to each Topic. Removing an Endpoint also removes its links to Topics, without the Topics being saved, so
+// this is how those changes are recorded
+func UnlinkTopics(
+	latest []TopicChange,
+	removed []service.EndpointRef,
+	actor Actor,
+	changedAt time.Time,
+) []TopicChange {
+	isRemoved := make(map[service.EndpointRef]bool)
+	for _, ref := range removed {
+		isRemoved[ref] = true
+	}
+	keep := func(refs []service.EndpointRef) []service.EndpointRef {
+		kept := make([]service.EndpointRef, 0, len(refs))
+		for _, ref := range refs {
+			if !isRemoved[ref] {
+				kept = append(kept, ref)
+			}
+		}
+		return kept
+	}
+	changes := make([]TopicChange, 0)
+	for idx := range latest {
+		previous := latest[idx].Snapshot
+		if previous == nil {
+			continue
+		}
+		producers, consumers := keep(previous.Producers), keep(previous.Consumers)
+		current := topic.MakeTopic(nil, previous.Code, previous.Name, producers, consumers)
+		if change := NextTopic(previous.Code, &latest[idx], &current, actor, changedAt); change != nil {
+			changes = append(changes, *change)
+		}
+	}
+	return changes
+}
+
+// normalizeTopic copies a Topic without its ID, and with its producers and consumers sorted, so that Topics that are
+// the same compare as equal
+func normalizeTopic(t *topic.Topic) *topic.Topic {
+	if t == nil {
+		return nil
+	}
+	normalized := topic.MakeTopic(nil, t.Code, t.Name, sortedEndpointRefs(t.Producers), sortedEndpointRefs(t.Consumers))
+	return &normalized
+}
+
+// sortedEndpointRefs copies refs, sorted by the Code of their Service, and then by their own Code
+func sortedEndpointRefs(refs []service.EndpointRef) []service.EndpointRef {
+	sorted := append(make([]service.EndpointRef, 0, len(refs)), refs...)
+	sort.Slice(sorted, func(i, j int) bool {
+		if sorted[i].ServiceCode != sorted[j].ServiceCode {
+			return sorted[i].ServiceCode < sorted[j].ServiceCode
+		}
+		return sorted[i].EndpointCode < sorted[j].EndpointCode
+	})
+	return sorted
+}
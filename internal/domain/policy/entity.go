@@ -0,0 +1,138 @@
+package policy
+
+import (
+	"fmt"
+
+	"github.com/yashap/crius/internal/domain/service"
+	"github.com/yashap/crius/internal/errors"
+)
+
+// Code is a code that uniquely identifies a Rule
+type Code = string
+
+// Effect is what a Rule does to the dependencies that it matches
+type Effect = string
+
+const (
+	// Deny forbids Endpoints that match the Rule's From from depending on Endpoints that match its To
+	Deny Effect = "deny"
+	// Allow forbids Endpoints that don't match the Rule's From from depending on Endpoints that match its To. In other
+	// words, only Endpoints that match From may depend on Endpoints that match To
+	Allow Effect = "allow"
+)
+
+// Enforcement is what happens when a save breaks a Rule
+type Enforcement = string
+
+const (
+	// Reject rejects the save
+	Reject Enforcement = "reject"
+	// Warn lets the save through, but reports the broken Rule
+	Warn Enforcement = "warn"
+)
+
+// Source is where a Rule came from
+type Source = string
+
+const (
+	// APISource Rules were saved through the API, and can be changed or deleted through it
+	APISource Source = "api"
+	// FileSource Rules were loaded from a policy file, and are replaced whenever it is loaded. They can't be changed or
+	// deleted through the API
+	FileSource Source = "file"
+)
+
+// Matcher matches Endpoints by their Code, their Service's Code, and their Labels (merged over their Service's
+// Labels). An Endpoint must meet every condition that is set to match, and a Matcher with no conditions matches every
+// Endpoint
+type Matcher struct {
+	// ServiceCodes, if set, only matches Endpoints of Services with one of these Codes
+	ServiceCodes []service.Code
+	// EndpointCodes, if set, only matches Endpoints with one of these Codes
+	EndpointCodes []service.EndpointCode
+	// Selector, if set, only matches Endpoints whose Labels match it
+	Selector service.Selector
+}
+
+// Rule is a declarative rule about which Endpoints may depend on which other Endpoints
+type Rule struct {
+	// ID uniquely identifies this rule
+	ID *int64
+	// Code is a unique code for the rule. For example, "ledger_only_called_by_payments"
+	Code Code
+	// Description describes the rule, and why it exists. It is reported along with any violations of the rule
+	Description string
+	// Effect is whether the rule denies dependencies from From to To, or only allows dependencies on To from From
+	Effect Effect
+	// Enforcement is whether saves that break the rule are rejected, or only warned about
+	Enforcement Enforcement
+	// From matches the Endpoints that have dependencies
+	From Matcher
+	// To matches the Endpoints that are depended on
+	To Matcher
+	// Source is where the rule came from
+	Source Source
+}
+
+// MakeRule constructs a Rule
+func MakeRule(
+	id *int64,
+	code Code,
+	description string,
+	effect Effect,
+	enforcement Enforcement,
+	from Matcher,
+	to Matcher,
+	source Source,
+) Rule {
+	return Rule{
+		ID:          id,
+		Code:        code,
+		Description: description,
+		Effect:      effect,
+		Enforcement: enforcement,
+		From:        from,
+		To:          to,
+		Source:      source,
+	}
+}
+
+// Matches returns whether an Endpoint of a Service meets every condition of the Matcher
+func (m Matcher) Matches(svc service.Service, endpoint service.Endpoint) bool {
+	if len(m.ServiceCodes) > 0 && !contains(m.ServiceCodes, svc.Code) {
+		return false
+	}
+	if len(m.EndpointCodes) > 0 && !contains(m.EndpointCodes, endpoint.Code) {
+		return false
+	}
+	return m.Selector == nil || m.Selector.Matches(service.MergeLabels(svc.Labels, endpoint.Labels))
+}
+
+// Validate checks that the Rule has a Code, and a known Effect and Enforcement, returning an InvalidInput error if not
+func (r Rule) Validate() error {
+	if r.Code == "" {
+		return errors.InvalidInput("policy rule code must not be empty", nil)
+	}
+	if r.Effect != Allow && r.Effect != Deny {
+		return errors.InvalidInput(
+			fmt.Sprintf("effect of policy rule %s must be %s or %s", r.Code, Allow, Deny),
+			nil,
+		)
+	}
+	if r.Enforcement != Reject && r.Enforcement != Warn {
+		return errors.InvalidInput(
+			fmt.Sprintf("enforcement of policy rule %s must be %s or %s", r.Code, Reject, Warn),
+			nil,
+		)
+	}
+	return nil
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,87 @@
+package policy
+
+import (
+	"encoding/json"
+	"github.com/jmoiron/sqlx"
+	"github.com/xo/dburl"
+	"github.com/yashap/crius/internal/domain/service"
+	"go.uber.org/zap"
+	"log"
+)
+
+// Repository is a Rule repository. Like service.Repository, the mental model is that it represents a collection of
+// Rule instances
+type Repository interface {
+	// Save saves a Rule, fully replacing any previous version of it with the same Code
+	Save(r *Rule) error
+	// FindByCode finds a Rule by its Code
+	FindByCode(code Code) (*Rule, error)
+	// FindAll finds every Rule, from every Source, sorted by Code
+	FindAll() ([]Rule, error)
+	// Delete deletes a Rule by its Code
+	Delete(code Code) error
+	// ReplaceSource fully replaces the Rules from a Source, deleting any that aren't among the given Rules. Rules from
+	// other Sources are left alone, unless they share a Code with one of the given Rules, in which case they are
+	// replaced by it
+	ReplaceSource(source Source, rules []Rule) error
+}
+
+func NewRepository(
+	dbURL *dburl.URL,
+	db *sqlx.DB,
+	logger *zap.SugaredLogger,
+) Repository {
+	if dbURL.Driver == "postgres" {
+		return &postgresRepository{
+			db:     db,
+			logger: logger,
+		}
+	} else if dbURL.Driver == "mysql" {
+		return &mysqlRepository{
+			db:     db,
+			logger: logger,
+		}
+	}
+	log.Fatalf("Unsupported database: %s", dbURL.Driver)
+	return nil
+}
+
+// storedMatcher is how a Matcher is stored, as JSON, in the from_matcher and to_matcher columns
+type storedMatcher struct {
+	ServiceCodes  []service.Code         `json:"service_codes,omitempty"`
+	EndpointCodes []service.EndpointCode `json:"endpoint_codes,omitempty"`
+	Selector      string                 `json:"selector,omitempty"`
+}
+
+// encodeMatcher encodes a Matcher as JSON, for storage
+func encodeMatcher(m Matcher) (string, error) {
+	stored := storedMatcher{ServiceCodes: m.ServiceCodes, EndpointCodes: m.EndpointCodes}
+	if m.Selector != nil {
+		stored.Selector = m.Selector.String()
+	}
+	encoded, err := json.Marshal(stored)
+	return string(encoded), err
+}
+
+// decodeMatcher decodes a Matcher that was encoded by encodeMatcher
+func decodeMatcher(encoded string) (Matcher, error) {
+	var stored storedMatcher
+	err := json.Unmarshal([]byte(encoded), &stored)
+	if err != nil {
+		return Matcher{}, err
+	}
+	m := Matcher{ServiceCodes: stored.ServiceCodes, EndpointCodes: stored.EndpointCodes}
+	if stored.Selector != "" {
+		m.Selector, err = service.ParseSelector(stored.Selector)
+	}
+	return m, err
+}
+
+// ruleCodes returns the Codes of the Rules
+func ruleCodes(rules []Rule) []interface{} {
+	codes := make([]interface{}, len(rules))
+	for idx, rule := range rules {
+		codes[idx] = rule.Code
+	}
+	return codes
+}
@@ -0,0 +1,184 @@
+package policy
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"github.com/jmoiron/sqlx"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+	mysqldao "github.com/yashap/crius/internal/db/mysql/dao"
+	"github.com/yashap/crius/internal/errors"
+	"go.uber.org/zap"
+)
+
+type mysqlRepository struct {
+	db     *sqlx.DB
+	logger *zap.SugaredLogger
+}
+
+func (r *mysqlRepository) Save(rule *Rule) error {
+	ruleDAO, err := r.makeRuleDAO(rule)
+	if err != nil {
+		return err
+	}
+	err = r.upsertRule(r.db, ruleDAO)
+	if err != nil {
+		return err
+	}
+	rule.ID = &ruleDAO.ID
+	return nil
+}
+
+func (r *mysqlRepository) FindByCode(code Code) (*Rule, error) {
+	ruleDAO, err := mysqldao.PolicyRules(qm.Where("code = ?", code)).One(context.Background(), r.db)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	} else if err != nil {
+		msg := "Failed to find policy rule by code"
+		r.logger.Errorw(msg, "err", err.Error(), "code", code)
+		return nil, errors.DatabaseError(msg, &err)
+	}
+	rule, err := r.makeRule(ruleDAO)
+	if err != nil {
+		return nil, err
+	}
+	return &rule, nil
+}
+
+func (r *mysqlRepository) FindAll() ([]Rule, error) {
+	ruleDAOs, err := mysqldao.PolicyRules(qm.OrderBy("code")).All(context.Background(), r.db)
+	if err != nil {
+		msg := "Failed to find policy rules"
+		r.logger.Errorw(msg, "err", err.Error())
+		return nil, errors.DatabaseError(msg, &err)
+	}
+	rules := make([]Rule, len(ruleDAOs))
+	for idx, ruleDAO := range ruleDAOs {
+		rules[idx], err = r.makeRule(ruleDAO)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return rules, nil
+}
+
+func (r *mysqlRepository) Delete(code Code) error {
+	deleted, err := mysqldao.PolicyRules(qm.Where("code = ?", code)).DeleteAll(context.Background(), r.db)
+	if err != nil {
+		msg := "Failed to delete policy rule"
+		r.logger.Errorw(msg, "err", err.Error(), "code", code)
+		return errors.DatabaseError(msg, &err)
+	}
+	if deleted == 0 {
+		return errors.PolicyRuleNotFound(fmt.Sprintf("Policy rule with code %s not found", code), nil)
+	}
+	return nil
+}
+
+func (r *mysqlRepository) ReplaceSource(source Source, rules []Rule) error {
+	tx, err := r.db.BeginTx(context.Background(), nil)
+	if err != nil {
+		msg := "Failed to begin transaction when replacing policy rules"
+		r.logger.Errorw(msg, "err", err.Error(), "source", source)
+		return errors.DatabaseError(msg, &err)
+	}
+	mods := []qm.QueryMod{qm.Where("source = ?", source)}
+	if len(rules) > 0 {
+		mods = append(mods, qm.AndNotIn("code not in ?", ruleCodes(rules)...))
+	}
+	_, err = mysqldao.PolicyRules(mods...).DeleteAll(context.Background(), tx)
+	if err != nil {
+		msg := "Failed to delete policy rules"
+		r.logger.Errorw(msg, "err", err.Error(), "source", source)
+		_ = tx.Rollback()
+		return errors.DatabaseError(msg, &err)
+	}
+	for idx := range rules {
+		rules[idx].Source = source
+		ruleDAO, err := r.makeRuleDAO(&rules[idx])
+		if err != nil {
+			_ = tx.Rollback()
+			return err
+		}
+		err = r.upsertRule(tx, ruleDAO)
+		if err != nil {
+			_ = tx.Rollback()
+			return err
+		}
+		rules[idx].ID = &ruleDAO.ID
+	}
+	err = tx.Commit()
+	if err != nil {
+		msg := "Failed to commit transaction when replacing policy rules"
+		r.logger.Errorw(msg, "err", err.Error(), "source", source)
+		return errors.DatabaseError(msg, &err)
+	}
+	return nil
+}
+
+// makeRuleDAO builds a policy rule DAO from a Rule, encoding its Matchers
+func (r *mysqlRepository) makeRuleDAO(rule *Rule) (*mysqldao.PolicyRule, error) {
+	from, err := encodeMatcher(rule.From)
+	if err != nil {
+		msg := "Failed to encode policy rule matcher"
+		r.logger.Errorw(msg, "err", err.Error(), "code", rule.Code)
+		return nil, errors.UnclassifiedError(msg, &err)
+	}
+	to, err := encodeMatcher(rule.To)
+	if err != nil {
+		msg := "Failed to encode policy rule matcher"
+		r.logger.Errorw(msg, "err", err.Error(), "code", rule.Code)
+		return nil, errors.UnclassifiedError(msg, &err)
+	}
+	return &mysqldao.PolicyRule{
+		Code:        rule.Code,
+		Description: rule.Description,
+		Effect:      rule.Effect,
+		Enforcement: rule.Enforcement,
+		FromMatcher: from,
+		ToMatcher:   to,
+		Source:      rule.Source,
+	}, nil
+}
+
+// makeRule builds a Rule from a policy rule DAO, decoding its Matchers
+func (r *mysqlRepository) makeRule(ruleDAO *mysqldao.PolicyRule) (Rule, error) {
+	from, err := decodeMatcher(ruleDAO.FromMatcher)
+	if err != nil {
+		msg := "Failed to decode policy rule matcher"
+		r.logger.Errorw(msg, "err", err.Error(), "code", ruleDAO.Code)
+		return Rule{}, errors.DatabaseError(msg, &err)
+	}
+	to, err := decodeMatcher(ruleDAO.ToMatcher)
+	if err != nil {
+		msg := "Failed to decode policy rule matcher"
+		r.logger.Errorw(msg, "err", err.Error(), "code", ruleDAO.Code)
+		return Rule{}, errors.DatabaseError(msg, &err)
+	}
+	return MakeRule(
+		&ruleDAO.ID,
+		ruleDAO.Code,
+		ruleDAO.Description,
+		ruleDAO.Effect,
+		ruleDAO.Enforcement,
+		from,
+		to,
+		ruleDAO.Source,
+	), nil
+}
+
+func (r *mysqlRepository) upsertRule(exec boil.ContextExecutor, rule *mysqldao.PolicyRule) error {
+	err := rule.Upsert(
+		context.Background(),
+		exec,
+		boil.Whitelist("description", "effect", "enforcement", "from_matcher", "to_matcher", "source"),
+		boil.Infer(),
+	)
+	if err != nil {
+		msg := "Failed to upsert policy rule"
+		r.logger.Errorw(msg, "err", err.Error(), "code", rule.Code)
+		return errors.DatabaseError(msg, &err)
+	}
+	return nil
+}
@@ -0,0 +1,186 @@
+package policy
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"github.com/jmoiron/sqlx"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+	pgdao "github.com/yashap/crius/internal/db/postgresql/dao"
+	"github.com/yashap/crius/internal/errors"
+	"go.uber.org/zap"
+)
+
+type postgresRepository struct {
+	db     *sqlx.DB
+	logger *zap.SugaredLogger
+}
+
+func (r *postgresRepository) Save(rule *Rule) error {
+	ruleDAO, err := r.makeRuleDAO(rule)
+	if err != nil {
+		return err
+	}
+	err = r.upsertRule(r.db, ruleDAO)
+	if err != nil {
+		return err
+	}
+	rule.ID = &ruleDAO.ID
+	return nil
+}
+
+func (r *postgresRepository) FindByCode(code Code) (*Rule, error) {
+	ruleDAO, err := pgdao.PolicyRules(qm.Where("code = ?", code)).One(context.Background(), r.db)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	} else if err != nil {
+		msg := "Failed to find policy rule by code"
+		r.logger.Errorw(msg, "err", err.Error(), "code", code)
+		return nil, errors.DatabaseError(msg, &err)
+	}
+	rule, err := r.makeRule(ruleDAO)
+	if err != nil {
+		return nil, err
+	}
+	return &rule, nil
+}
+
+func (r *postgresRepository) FindAll() ([]Rule, error) {
+	ruleDAOs, err := pgdao.PolicyRules(qm.OrderBy("code")).All(context.Background(), r.db)
+	if err != nil {
+		msg := "Failed to find policy rules"
+		r.logger.Errorw(msg, "err", err.Error())
+		return nil, errors.DatabaseError(msg, &err)
+	}
+	rules := make([]Rule, len(ruleDAOs))
+	for idx, ruleDAO := range ruleDAOs {
+		rules[idx], err = r.makeRule(ruleDAO)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return rules, nil
+}
+
+func (r *postgresRepository) Delete(code Code) error {
+	deleted, err := pgdao.PolicyRules(qm.Where("code = ?", code)).DeleteAll(context.Background(), r.db)
+	if err != nil {
+		msg := "Failed to delete policy rule"
+		r.logger.Errorw(msg, "err", err.Error(), "code", code)
+		return errors.DatabaseError(msg, &err)
+	}
+	if deleted == 0 {
+		return errors.PolicyRuleNotFound(fmt.Sprintf("Policy rule with code %s not found", code), nil)
+	}
+	return nil
+}
+
+func (r *postgresRepository) ReplaceSource(source Source, rules []Rule) error {
+	tx, err := r.db.BeginTx(context.Background(), nil)
+	if err != nil {
+		msg := "Failed to begin transaction when replacing policy rules"
+		r.logger.Errorw(msg, "err", err.Error(), "source", source)
+		return errors.DatabaseError(msg, &err)
+	}
+	mods := []qm.QueryMod{qm.Where("source = ?", source)}
+	if len(rules) > 0 {
+		mods = append(mods, qm.AndNotIn("code not in ?", ruleCodes(rules)...))
+	}
+	_, err = pgdao.PolicyRules(mods...).DeleteAll(context.Background(), tx)
+	if err != nil {
+		msg := "Failed to delete policy rules"
+		r.logger.Errorw(msg, "err", err.Error(), "source", source)
+		_ = tx.Rollback()
+		return errors.DatabaseError(msg, &err)
+	}
+	for idx := range rules {
+		rules[idx].Source = source
+		ruleDAO, err := r.makeRuleDAO(&rules[idx])
+		if err != nil {
+			_ = tx.Rollback()
+			return err
+		}
+		err = r.upsertRule(tx, ruleDAO)
+		if err != nil {
+			_ = tx.Rollback()
+			return err
+		}
+		rules[idx].ID = &ruleDAO.ID
+	}
+	err = tx.Commit()
+	if err != nil {
+		msg := "Failed to commit transaction when replacing policy rules"
+		r.logger.Errorw(msg, "err", err.Error(), "source", source)
+		return errors.DatabaseError(msg, &err)
+	}
+	return nil
+}
+
+// makeRuleDAO builds a policy rule DAO from a Rule, encoding its Matchers
+func (r *postgresRepository) makeRuleDAO(rule *Rule) (*pgdao.PolicyRule, error) {
+	from, err := encodeMatcher(rule.From)
+	if err != nil {
+		msg := "Failed to encode policy rule matcher"
+		r.logger.Errorw(msg, "err", err.Error(), "code", rule.Code)
+		return nil, errors.UnclassifiedError(msg, &err)
+	}
+	to, err := encodeMatcher(rule.To)
+	if err != nil {
+		msg := "Failed to encode policy rule matcher"
+		r.logger.Errorw(msg, "err", err.Error(), "code", rule.Code)
+		return nil, errors.UnclassifiedError(msg, &err)
+	}
+	return &pgdao.PolicyRule{
+		Code:        rule.Code,
+		Description: rule.Description,
+		Effect:      rule.Effect,
+		Enforcement: rule.Enforcement,
+		FromMatcher: from,
+		ToMatcher:   to,
+		Source:      rule.Source,
+	}, nil
+}
+
+// makeRule builds a Rule from a policy rule DAO, decoding its Matchers
+func (r *postgresRepository) makeRule(ruleDAO *pgdao.PolicyRule) (Rule, error) {
+	from, err := decodeMatcher(ruleDAO.FromMatcher)
+	if err != nil {
+		msg := "Failed to decode policy rule matcher"
+		r.logger.Errorw(msg, "err", err.Error(), "code", ruleDAO.Code)
+		return Rule{}, errors.DatabaseError(msg, &err)
+	}
+	to, err := decodeMatcher(ruleDAO.ToMatcher)
+	if err != nil {
+		msg := "Failed to decode policy rule matcher"
+		r.logger.Errorw(msg, "err", err.Error(), "code", ruleDAO.Code)
+		return Rule{}, errors.DatabaseError(msg, &err)
+	}
+	return MakeRule(
+		&ruleDAO.ID,
+		ruleDAO.Code,
+		ruleDAO.Description,
+		ruleDAO.Effect,
+		ruleDAO.Enforcement,
+		from,
+		to,
+		ruleDAO.Source,
+	), nil
+}
+
+func (r *postgresRepository) upsertRule(exec boil.ContextExecutor, rule *pgdao.PolicyRule) error {
+	err := rule.Upsert(
+		context.Background(),
+		exec,
+		true,
+		[]string{"code"},
+		boil.Whitelist("description", "effect", "enforcement", "from_matcher", "to_matcher", "source"),
+		boil.Infer(),
+	)
+	if err != nil {
+		msg := "Failed to upsert policy rule"
+		r.logger.Errorw(msg, "err", err.Error(), "code", rule.Code)
+		return errors.DatabaseError(msg, &err)
+	}
+	return nil
+}
@@ -0,0 +1,67 @@
+package policy
+
+import (
+	"sort"
+
+	"github.com/yashap/crius/internal/domain/graph"
+	"github.com/yashap/crius/internal/domain/service"
+)
+
+// Violation is a dependency that breaks a Rule
+type Violation struct {
+	// Rule is the Rule that is broken
+	Rule Rule
+	// Dependency is the dependency that breaks it
+	Dependency service.DependencyEdge
+}
+
+// Evaluate finds the dependencies in after, but not in before, that break any of the Rules. Only new dependencies are
+// evaluated, so that Rules can be introduced, or tightened, without blocking saves of Services that already break them.
+// The Violations are sorted by the Code of the Rule they break, and then by their dependency
+func Evaluate(rules []Rule, before graph.Graph, after graph.Graph) []Violation {
+	type node struct {
+		svc      service.Service
+		endpoint service.Endpoint
+	}
+	nodes := make(map[service.EndpointRef]node)
+	for _, svc := range after.Services {
+		for _, endpoint := range svc.Endpoints {
+			nodes[service.EndpointRef{ServiceCode: svc.Code, EndpointCode: endpoint.Code}] = node{svc, endpoint}
+		}
+	}
+	beforeEdges := make(map[service.DependencyEdge]bool)
+	for _, edge := range before.Edges() {
+		beforeEdges[edge] = true
+	}
+	violations := make([]Violation, 0)
+	for _, edge := range after.Edges() {
+		if beforeEdges[edge] {
+			continue
+		}
+		from, to := nodes[edge.From], nodes[edge.To]
+		for _, rule := range rules {
+			if !rule.To.Matches(to.svc, to.endpoint) {
+				continue
+			}
+			fromMatches := rule.From.Matches(from.svc, from.endpoint)
+			if (rule.Effect == Deny && fromMatches) || (rule.Effect == Allow && !fromMatches) {
+				violations = append(violations, Violation{Rule: rule, Dependency: edge})
+			}
+		}
+	}
+	sort.SliceStable(violations, func(i, j int) bool { return violations[i].Rule.Code < violations[j].Rule.Code })
+	return violations
+}
+
+// Partition splits Violations into those of Rules that Reject saves, and those of Rules that only Warn about them
+func Partition(violations []Violation) (rejected []Violation, warned []Violation) {
+	rejected, warned = make([]Violation, 0), make([]Violation, 0)
+	for _, violation := range violations {
+		if violation.Rule.Enforcement == Reject {
+			rejected = append(rejected, violation)
+		} else {
+			warned = append(warned, violation)
+		}
+	}
+	return rejected, warned
+}
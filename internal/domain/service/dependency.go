@@ -0,0 +1,80 @@
+package service
+
+// EndpointRef identifies an Endpoint by the Code of its Service, and its own Code
+type EndpointRef struct {
+	// ServiceCode is the Code of the Service that the Endpoint belongs to
+	ServiceCode Code
+	// EndpointCode is the Code of the Endpoint
+	EndpointCode EndpointCode
+}
+
+// DependencyEdge is a single dependency of one Endpoint on another
+type DependencyEdge struct {
+	// From is the Endpoint that has the dependency
+	From EndpointRef
+	// To is the Endpoint that is depended on
+	To EndpointRef
+}
+
+// DependencyDiff describes how a set of dependencies changed
+type DependencyDiff struct {
+	// Added are the dependencies that are new
+	Added []DependencyEdge
+	// Removed are the dependencies that no longer exist
+	Removed []DependencyEdge
+}
+
+// ClientDependencyEdge is a single dependency of a Client (a frontend or client application, which has no Endpoints of
+// its own) on an Endpoint
+type ClientDependencyEdge struct {
+	// ClientCode is the Code of the Client that has the dependency
+	ClientCode string
+	// To is the Endpoint that is depended on
+	To EndpointRef
+}
+
+// RemovedDependencies are the dependencies on deleted Endpoints, which were deleted along with them
+type RemovedDependencies struct {
+	// Dependencies are the dependencies of other Endpoints
+	Dependencies []DependencyEdge
+	// ClientDependencies are the dependencies of Clients
+	ClientDependencies []ClientDependencyEdge
+}
+
+// Direction is a direction in which the dependency graph can be walked
+type Direction int
+
+const (
+	// Downstream walks from Endpoints to the Endpoints that they depend on
+	Downstream Direction = iota
+	// Upstream walks from Endpoints to the Endpoints that depend on them
+	Upstream
+)
+
+// DependencyQuery describes where to start a search of the dependency graph, and how far to go
+type DependencyQuery struct {
+	// ServiceCode is the Code of the Service to start from
+	ServiceCode Code
+	// EndpointCode, if set, narrows the starting point down to a single Endpoint of the Service. Otherwise all
+	// Endpoints of the Service are used as the starting point
+	EndpointCode *EndpointCode
+	// MaxDepth is the maximum number of dependency hops to follow. Zero means there is no limit
+	MaxDepth int
+}
+
+// Dependency is an Endpoint that was reached while searching the dependency graph. Depending on the direction of the
+// search, it is either something that the starting point depends on, or something that depends on the starting point
+type Dependency struct {
+	// Endpoint is the Endpoint that was reached
+	Endpoint EndpointRef
+	// EndpointName is the friendly name of the Endpoint that was reached
+	EndpointName EndpointName
+	// ServiceTeam is the team that owns the Service of the Endpoint that was reached, if known
+	ServiceTeam *Team
+	// Distance is the number of dependency hops it took to reach the Endpoint. Direct dependencies (or dependents) have
+	// a Distance of 1
+	Distance int
+	// Path is the chain of Endpoints that reached this Endpoint. It starts with one of the starting Endpoints, and ends
+	// with this Endpoint
+	Path []EndpointRef
+}
@@ -12,6 +12,12 @@ type EndpointCode = string
 // EndpointName is the human-readable/friedly name of an Endpoint
 type EndpointName = string
 
+// Team is the name of a team that owns Services
+type Team = string
+
+// Tier is how critical a Service or Endpoint is. Lower Tiers are more critical, with 0 being the most critical
+type Tier = int
+
 // Service represents a service
 type Service struct {
 	// ID uniquely identifies this service
@@ -22,6 +28,35 @@ type Service struct {
 	Name Name
 	// Endpoints is a list of Endpoints that the Service has
 	Endpoints []Endpoint
+	// Confirmed is false for placeholder Services, which were created because something depended on them before they
+	// were registered. They are confirmed when their owner saves them
+	Confirmed bool
+	// Ownership describes who owns the Service, and how to reach them
+	Ownership Ownership
+	// Labels are free-form key/value pairs that describe the Service. For example, "tier": "critical"
+	Labels Labels
+	// Tier is how critical the Service is, if known. It is the default Tier of the Service's Endpoints
+	Tier *Tier
+	// Lifecycle is how far the Service is through its lifecycle. Deprecating or retiring a Service deprecates or retires
+	// all of its Endpoints
+	Lifecycle Lifecycle
+	// Version is the semantic version, or range of versions, of the Service's API, if known. It is the default Version of
+	// the Service's Endpoints
+	Version *Version
+}
+
+// Ownership describes who owns a Service, and how to reach them. Every field is optional
+type Ownership struct {
+	// Team is the team that owns the Service. For example, "logistics"
+	Team *Team
+	// OnCall is how to reach whoever is on call for the Service. For example, a pager rotation or a phone number
+	OnCall *string
+	// SlackChannel is the Slack channel where the owning team can be reached. For example, "#logistics"
+	SlackChannel *string
+	// Email is the email address (usually a mailing list) where the owning team can be reached
+	Email *string
+	// RepositoryURL is the URL of the Service's source code repository
+	RepositoryURL *string
 }
 
 // Endpoint represents an Endpoint of a Service
@@ -35,6 +70,39 @@ type Endpoint struct {
 	Name EndpointName
 	// Dependencies is a map of Dependencies for a given Endpoint. Keys are service codes, values are lists of endpoint codes
 	Dependencies map[Code][]EndpointCode
+	// Confirmed is false for placeholder Endpoints, which were created because something depended on them before they
+	// were registered. They are confirmed when their owner saves them
+	Confirmed bool
+	// Labels are free-form key/value pairs that describe the Endpoint. For example, "pci": "true"
+	Labels Labels
+	// Tier is how critical the Endpoint is, if it differs from its Service's Tier
+	Tier *Tier
+	// Lifecycle is how far the Endpoint is through its lifecycle
+	Lifecycle Lifecycle
+	// Version is the semantic version, or range of versions, of the Endpoint, if it differs from its Service's Version
+	Version *Version
+	// VersionConstraints pin the Versions of the Services in Dependencies that the Endpoint can call, keyed by Service
+	// Code. For example, "payments": "^2". Dependencies on Services that aren't in VersionConstraints aren't pinned
+	VersionConstraints map[Code]Version
+}
+
+// Patch is a partial update to a Service
+type Patch struct {
+	// Name, if set, replaces the Service's Name
+	Name *Name
+	// Ownership's fields, where set, replace those of the Service's Ownership. Unset fields are left alone
+	Ownership Ownership
+	// Labels, if set, replace all of the Service's Labels
+	Labels Labels
+	// Tier, if set, replaces the Service's Tier
+	Tier *Tier
+	// Lifecycle, if set, replaces the Service's Lifecycle
+	Lifecycle *Lifecycle
+	// Version, if set, replaces the Service's Version
+	Version *Version
+	// Endpoints are saved one at a time, replacing any existing Endpoints with the same Codes. The Service's other
+	// Endpoints are left alone
+	Endpoints []Endpoint
 }
 
 // MakeService constructs a Service
@@ -43,11 +111,79 @@ func MakeService(
 	code Code,
 	name Name,
 	endpoints []Endpoint,
+	ownership Ownership,
 ) Service {
 	return Service{
 		ID:        id,
 		Code:      code,
 		Name:      name,
 		Endpoints: endpoints,
+		Ownership: ownership,
+	}
+}
+
+// EndpointTier is the Tier of one of the Service's Endpoints, which is the Endpoint's own Tier if set, and otherwise the
+// Service's Tier. It is nil if neither is set
+func (s Service) EndpointTier(endpoint Endpoint) *Tier {
+	if endpoint.Tier != nil {
+		return endpoint.Tier
+	}
+	return s.Tier
+}
+
+// DependencyEdges returns every dependency of the Service's Endpoints, sorted by the Endpoint they are from, and then by
+// the Endpoint they are to
+func (s Service) DependencyEdges() []DependencyEdge {
+	return dependencyEdges(s.Code, s.Endpoints)
+}
+
+// WithEndpoint returns a copy of the Service with an Endpoint added, or replacing its existing Endpoint with the same
+// Code, like Repository's SaveEndpoint saves it
+func (s Service) WithEndpoint(endpoint Endpoint) Service {
+	endpoints := make([]Endpoint, 0, len(s.Endpoints)+1)
+	for _, existing := range s.Endpoints {
+		if existing.Code != endpoint.Code {
+			endpoints = append(endpoints, existing)
+		}
+	}
+	s.Endpoints = append(endpoints, endpoint)
+	return s
+}
+
+// Apply returns a copy of the Service with the Patch applied, like Repository's Update saves it
+func (p Patch) Apply(s Service) Service {
+	if p.Name != nil {
+		s.Name = *p.Name
+	}
+	if p.Ownership.Team != nil {
+		s.Ownership.Team = p.Ownership.Team
+	}
+	if p.Ownership.OnCall != nil {
+		s.Ownership.OnCall = p.Ownership.OnCall
+	}
+	if p.Ownership.SlackChannel != nil {
+		s.Ownership.SlackChannel = p.Ownership.SlackChannel
+	}
+	if p.Ownership.Email != nil {
+		s.Ownership.Email = p.Ownership.Email
+	}
+	if p.Ownership.RepositoryURL != nil {
+		s.Ownership.RepositoryURL = p.Ownership.RepositoryURL
+	}
+	if p.Labels != nil {
+		s.Labels = p.Labels
+	}
+	if p.Tier != nil {
+		s.Tier = p.Tier
+	}
+	if p.Lifecycle != nil {
+		s.Lifecycle = *p.Lifecycle
+	}
+	if p.Version != nil {
+		s.Version = p.Version
+	}
+	for _, endpoint := range p.Endpoints {
+		s = s.WithEndpoint(endpoint)
 	}
+	return s
 }
@@ -0,0 +1,47 @@
+package service
+
+import (
+	"fmt"
+	"regexp"
+
+	"github.com/yashap/crius/internal/errors"
+)
+
+// Environment is an environment that Services are deployed to, like "staging" or "production". Each Environment has
+// its own Services, along with their Endpoints and Dependencies, so a Service Code can mean a different Service in
+// each Environment
+type Environment = string
+
+// DefaultEnvironment is the Environment used when none is specified
+const DefaultEnvironment Environment = "default"
+
+// environmentPattern is what an Environment must look like, so that it can be used as a single URL path segment
+var environmentPattern = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]{0,126}$`)
+
+// ValidateEnvironment checks that the Environment is made up of lowercase letters, digits, underscores and dashes, and
+// is at most 127 characters long, returning an InvalidInput error if not
+func ValidateEnvironment(env Environment) error {
+	if !environmentPattern.MatchString(env) {
+		return errors.InvalidInput(
+			fmt.Sprintf(
+				"environment %s must be lowercase letters, digits, underscores and dashes, at most 127 characters long",
+				env,
+			),
+			nil,
+		)
+	}
+	return nil
+}
+
+// Promoted returns a copy of the Service without the ids it has in its own Environment, so that it can be saved to
+// another Environment. Its placeholder Endpoints stay placeholders, as they aren't Confirmed
+func (s Service) Promoted() Service {
+	promoted := s
+	promoted.ID = nil
+	promoted.Endpoints = make([]Endpoint, len(s.Endpoints))
+	for idx, endpoint := range s.Endpoints {
+		endpoint.ID = nil
+		promoted.Endpoints[idx] = endpoint
+	}
+	return promoted
+}
@@ -0,0 +1,253 @@
+package service
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/yashap/crius/internal/errors"
+)
+
+// Labels are free-form key/value pairs attached to a Service or an Endpoint, like "tier": "critical" or "pci": "true".
+// Keys and values follow the same syntax as Kubernetes labels
+type Labels = map[string]string
+
+// Operator is how a Requirement compares a label to its values
+type Operator = string
+
+const (
+	// Equals requires the label to be set to the (single) value
+	Equals Operator = "="
+	// NotEquals requires the label to not be set to the (single) value. Labels that aren't set at all match
+	NotEquals Operator = "!="
+	// In requires the label to be set to one of the values
+	In Operator = "in"
+	// NotIn requires the label to not be set to any of the values. Labels that aren't set at all match
+	NotIn Operator = "notin"
+	// Exists requires the label to be set, to any value
+	Exists Operator = "exists"
+	// DoesNotExist requires the label to not be set
+	DoesNotExist Operator = "!"
+)
+
+// Requirement is a single condition on a label, that is part of a Selector
+type Requirement struct {
+	// Key is the key of the label
+	Key string
+	// Operator is how the label is compared to the Values
+	Operator Operator
+	// Values are the values that the label is compared to. They are empty for Exists and DoesNotExist
+	Values []string
+}
+
+// Selector selects things by their labels, like a Kubernetes label selector. Something matches a Selector if it meets
+// every one of its Requirements
+type Selector []Requirement
+
+var (
+	labelNamePattern   = regexp.MustCompile(`^[A-Za-z0-9]([-A-Za-z0-9_.]{0,61}[A-Za-z0-9])?$`)
+	labelPrefixPattern = regexp.MustCompile(`^[a-z0-9]([-a-z0-9.]{0,251}[a-z0-9])?$`)
+	setRequirement     = regexp.MustCompile(`^(\S+)\s+(in|notin)\s+\((.*)\)$`)
+)
+
+// ParseSelector parses a Kubernetes style label selector, like "tier=critical,lang!=php,region in (us,eu),!legacy"
+func ParseSelector(raw string) (Selector, error) {
+	selector := make(Selector, 0)
+	for _, term := range splitSelector(raw) {
+		term = strings.TrimSpace(term)
+		var requirement Requirement
+		if match := setRequirement.FindStringSubmatch(term); match != nil {
+			requirement = Requirement{Key: match[1], Operator: match[2], Values: make([]string, 0)}
+			// An empty set, like "region in ()", has no values, rather than a single empty one
+			if strings.TrimSpace(match[3]) != "" {
+				requirement.Values = strings.Split(match[3], ",")
+			}
+		} else if strings.HasPrefix(term, "!") {
+			requirement = Requirement{Key: strings.TrimSpace(term[1:]), Operator: DoesNotExist}
+		} else if idx := strings.Index(term, "!="); idx >= 0 {
+			requirement = Requirement{Key: term[:idx], Operator: NotEquals, Values: []string{term[idx+2:]}}
+		} else if idx := strings.Index(term, "=="); idx >= 0 {
+			requirement = Requirement{Key: term[:idx], Operator: Equals, Values: []string{term[idx+2:]}}
+		} else if idx := strings.Index(term, "="); idx >= 0 {
+			requirement = Requirement{Key: term[:idx], Operator: Equals, Values: []string{term[idx+1:]}}
+		} else {
+			requirement = Requirement{Key: term, Operator: Exists}
+		}
+		requirement.Key = strings.TrimSpace(requirement.Key)
+		for idx := range requirement.Values {
+			requirement.Values[idx] = strings.TrimSpace(requirement.Values[idx])
+		}
+		err := requirement.validate()
+		if err != nil {
+			return nil, errors.InvalidInput(
+				fmt.Sprintf("label selector term '%s' is invalid: %s", term, err.Error()),
+				nil,
+			)
+		}
+		selector = append(selector, requirement)
+	}
+	if len(selector) == 0 {
+		return nil, errors.InvalidInput("label selector must have at least one term", nil)
+	}
+	return selector, nil
+}
+
+// Matches returns whether the labels meet every Requirement of the Selector
+func (s Selector) Matches(labels Labels) bool {
+	for _, requirement := range s {
+		if !requirement.Matches(labels) {
+			return false
+		}
+	}
+	return true
+}
+
+// Matches returns whether the labels meet the Requirement
+func (r Requirement) Matches(labels Labels) bool {
+	value, ok := labels[r.Key]
+	switch r.Operator {
+	case Exists:
+		return ok
+	case DoesNotExist:
+		return !ok
+	case NotEquals, NotIn:
+		return !ok || !contains(r.Values, value)
+	default:
+		return ok && contains(r.Values, value)
+	}
+}
+
+// String formats the Selector in the syntax that ParseSelector parses
+func (s Selector) String() string {
+	terms := make([]string, len(s))
+	for idx, requirement := range s {
+		terms[idx] = requirement.String()
+	}
+	return strings.Join(terms, ",")
+}
+
+// String formats the Requirement as a single term of a Selector
+func (r Requirement) String() string {
+	switch r.Operator {
+	case Exists:
+		return r.Key
+	case DoesNotExist:
+		return "!" + r.Key
+	case In, NotIn:
+		return fmt.Sprintf("%s %s (%s)", r.Key, r.Operator, strings.Join(r.Values, ","))
+	default:
+		return r.Key + r.Operator + r.Values[0]
+	}
+}
+
+// Negated returns whether the Requirement is met by things that lack a label with one of its values, rather than
+// things that have one
+func (r Requirement) Negated() bool {
+	return r.Operator == NotEquals || r.Operator == NotIn || r.Operator == DoesNotExist
+}
+
+// MergeLabels merges labels, with later ones taking precedence over earlier ones. For example, an Endpoint's labels
+// are merged over its Service's, when matching the Endpoint against a Selector
+func MergeLabels(labels ...Labels) Labels {
+	merged := make(Labels)
+	for _, l := range labels {
+		for key, value := range l {
+			merged[key] = value
+		}
+	}
+	return merged
+}
+
+// ValidateLabels checks that the keys and values of labels are valid, returning an InvalidInput error if not
+func ValidateLabels(labels Labels) error {
+	for _, key := range labelKeys(labels) {
+		if err := validateLabelKey(key); err != nil {
+			return errors.InvalidInput(fmt.Sprintf("label key '%s' is invalid: %s", key, err.Error()), nil)
+		}
+		if err := validateLabelValue(labels[key]); err != nil {
+			return errors.InvalidInput(
+				fmt.Sprintf("value '%s' of label '%s' is invalid: %s", labels[key], key, err.Error()),
+				nil,
+			)
+		}
+	}
+	return nil
+}
+
+func (r Requirement) validate() error {
+	err := validateLabelKey(r.Key)
+	if err != nil {
+		return err
+	}
+	for _, value := range r.Values {
+		err = validateLabelValue(value)
+		if err != nil {
+			return err
+		}
+	}
+	if (r.Operator == In || r.Operator == NotIn) && len(r.Values) == 0 {
+		return fmt.Errorf("'%s' needs at least one value", r.Operator)
+	}
+	return nil
+}
+
+// validateLabelKey checks that a label key is an optional DNS subdomain prefix and a slash, followed by a name of up
+// to 63 alphanumeric characters, dashes, underscores and dots, that starts and ends with an alphanumeric character
+func validateLabelKey(key string) error {
+	name := key
+	if idx := strings.LastIndex(key, "/"); idx >= 0 {
+		if !labelPrefixPattern.MatchString(key[:idx]) {
+			return fmt.Errorf("prefix must be a DNS subdomain of at most 253 characters")
+		}
+		name = key[idx+1:]
+	}
+	if !labelNamePattern.MatchString(name) {
+		return fmt.Errorf(
+			"name must be at most 63 alphanumeric characters, '-', '_' or '.', starting and ending with an alphanumeric",
+		)
+	}
+	return nil
+}
+
+// validateLabelValue checks that a label value is empty, or up to 63 alphanumeric characters, dashes, underscores and
+// dots, that starts and ends with an alphanumeric character
+func validateLabelValue(value string) error {
+	if value != "" && !labelNamePattern.MatchString(value) {
+		return fmt.Errorf(
+			"must be empty, or at most 63 alphanumeric characters, '-', '_' or '.', starting and ending with an alphanumeric",
+		)
+	}
+	return nil
+}
+
+// splitSelector splits a selector into its terms, on the commas that aren't inside of parentheses
+func splitSelector(raw string) []string {
+	terms := make([]string, 0)
+	depth, start := 0, 0
+	for idx, char := range raw {
+		switch char {
+		case '(':
+			depth++
+		case ')':
+			depth--
+		case ',':
+			if depth == 0 {
+				terms = append(terms, raw[start:idx])
+				start = idx + 1
+			}
+		}
+	}
+	if strings.TrimSpace(raw[start:]) != "" || len(terms) > 0 {
+		terms = append(terms, raw[start:])
+	}
+	return terms
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,135 @@
+package service
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/yashap/crius/internal/errors"
+)
+
+func TestParseSelector(t *testing.T) {
+	tests := []struct {
+		name string
+		raw  string
+		want Selector
+	}{
+		{
+			name: "equals",
+			raw:  "tier=critical",
+			want: Selector{{Key: "tier", Operator: Equals, Values: []string{"critical"}}},
+		},
+		{
+			name: "double equals",
+			raw:  "tier==critical",
+			want: Selector{{Key: "tier", Operator: Equals, Values: []string{"critical"}}},
+		},
+		{
+			name: "not equals",
+			raw:  "lang!=php",
+			want: Selector{{Key: "lang", Operator: NotEquals, Values: []string{"php"}}},
+		},
+		{
+			name: "in a set",
+			raw:  "region in (us, eu)",
+			want: Selector{{Key: "region", Operator: In, Values: []string{"us", "eu"}}},
+		},
+		{
+			name: "not in a set",
+			raw:  "region notin (us)",
+			want: Selector{{Key: "region", Operator: NotIn, Values: []string{"us"}}},
+		},
+		{
+			name: "exists",
+			raw:  "example.com/pci",
+			want: Selector{{Key: "example.com/pci", Operator: Exists}},
+		},
+		{
+			name: "does not exist",
+			raw:  "!legacy",
+			want: Selector{{Key: "legacy", Operator: DoesNotExist}},
+		},
+		{
+			name: "equals an empty value",
+			raw:  "owner=",
+			want: Selector{{Key: "owner", Operator: Equals, Values: []string{""}}},
+		},
+		{
+			name: "several terms, splitting on commas outside of sets",
+			raw:  " tier=critical , region in (us,eu),!legacy",
+			want: Selector{
+				{Key: "tier", Operator: Equals, Values: []string{"critical"}},
+				{Key: "region", Operator: In, Values: []string{"us", "eu"}},
+				{Key: "legacy", Operator: DoesNotExist},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseSelector(tt.raw)
+			if err != nil {
+				t.Fatalf("ParseSelector(%q) error = %v", tt.raw, err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseSelector(%q) = %v, want %v", tt.raw, got, tt.want)
+			}
+			if reparsed, err := ParseSelector(got.String()); err != nil || !reflect.DeepEqual(reparsed, got) {
+				t.Errorf("ParseSelector(%q) = %v, %v, want it to parse its own String()", got.String(), reparsed, err)
+			}
+		})
+	}
+
+	invalid := []struct {
+		name string
+		raw  string
+	}{
+		{name: "no terms", raw: " "},
+		{name: "an empty term", raw: "tier=critical,"},
+		{name: "an empty set", raw: "region in ()"},
+		{name: "a blank set", raw: "region notin ( )"},
+		{name: "an invalid key", raw: "-tier=critical"},
+		{name: "an invalid key prefix", raw: "Example.com/pci"},
+		{name: "an invalid value", raw: "tier=not critical"},
+		{name: "an invalid value in a set", raw: "region in (us,e u)"},
+	}
+	for _, tt := range invalid {
+		t.Run("rejects "+tt.name, func(t *testing.T) {
+			_, err := ParseSelector(tt.raw)
+			if e, ok := err.(*errors.Error); !ok || e.StatusCode != 400 {
+				t.Errorf("ParseSelector(%q) error = %v, want an InvalidInput error", tt.raw, err)
+			}
+		})
+	}
+}
+
+func TestSelectorMatches(t *testing.T) {
+	labels := Labels{"tier": "critical", "region": "us"}
+	tests := []struct {
+		raw  string
+		want bool
+	}{
+		{raw: "tier=critical", want: true},
+		{raw: "tier=low", want: false},
+		{raw: "tier!=low", want: true},
+		{raw: "lang!=php", want: true},
+		{raw: "region in (us,eu)", want: true},
+		{raw: "region in (eu)", want: false},
+		{raw: "region notin (eu)", want: true},
+		{raw: "lang notin (php)", want: true},
+		{raw: "region", want: true},
+		{raw: "lang", want: false},
+		{raw: "!lang", want: true},
+		{raw: "!region", want: false},
+		{raw: "tier=critical,region in (eu)", want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.raw, func(t *testing.T) {
+			selector, err := ParseSelector(tt.raw)
+			if err != nil {
+				t.Fatalf("ParseSelector(%q) error = %v", tt.raw, err)
+			}
+			if got := selector.Matches(labels); got != tt.want {
+				t.Errorf("Matches(%v) = %v, want %v", labels, got, tt.want)
+			}
+		})
+	}
+}
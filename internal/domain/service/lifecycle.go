@@ -0,0 +1,84 @@
+package service
+
+import (
+	"time"
+
+	"github.com/yashap/crius/internal/errors"
+)
+
+// LifecycleState is how far a Service or Endpoint is through its lifecycle
+type LifecycleState = string
+
+const (
+	// Active Services and Endpoints are in normal use
+	Active LifecycleState = "active"
+	// Deprecated Services and Endpoints still work, but will be retired on their sunset date, so their callers should
+	// move off of them
+	Deprecated LifecycleState = "deprecated"
+	// Retired Services and Endpoints no longer work, so nothing new may depend on them
+	Retired LifecycleState = "retired"
+)
+
+// Lifecycle is how far a Service or Endpoint is through its lifecycle
+type Lifecycle struct {
+	// State is the lifecycle state. The zero value is treated as Active
+	State LifecycleState
+	// SunsetDate is the date that a Deprecated Service or Endpoint will be retired on, or that a Retired one was retired
+	// on. Active ones don't have one
+	SunsetDate *time.Time
+}
+
+// EndpointLifecycle is the Lifecycle of one of the Service's Endpoints, which is whichever of the Endpoint's own
+// Lifecycle and the Service's is furthest along. If both are Deprecated, it is whichever is sunset first
+func (s Service) EndpointLifecycle(endpoint Endpoint) Lifecycle {
+	own, inherited := endpoint.Lifecycle, s.Lifecycle
+	switch {
+	case lifecycleRank(inherited.State) > lifecycleRank(own.State):
+		return inherited
+	case lifecycleRank(inherited.State) < lifecycleRank(own.State):
+		return own
+	case own.SunsetDate == nil || (inherited.SunsetDate != nil && inherited.SunsetDate.Before(*own.SunsetDate)):
+		return inherited
+	default:
+		return own
+	}
+}
+
+// Validate checks that the Lifecycle's State is known, that Deprecated Lifecycles have a SunsetDate, and that Active
+// ones don't, returning an InvalidInput error if not
+func (l Lifecycle) Validate() error {
+	switch l.State {
+	case Active:
+		if l.SunsetDate != nil {
+			return errors.InvalidInput("active services and endpoints must not have a sunset date", nil)
+		}
+	case Deprecated:
+		if l.SunsetDate == nil {
+			return errors.InvalidInput("deprecated services and endpoints must have a sunset date", nil)
+		}
+	case Retired:
+	default:
+		return errors.InvalidInput("lifecycle must be active, deprecated or retired", nil)
+	}
+	return nil
+}
+
+// lifecycleState is the State of the Lifecycle, defaulting to Active
+func lifecycleState(lifecycle Lifecycle) LifecycleState {
+	if lifecycle.State == "" {
+		return Active
+	}
+	return lifecycle.State
+}
+
+// lifecycleRank orders LifecycleStates by how far through the lifecycle they are
+func lifecycleRank(state LifecycleState) int {
+	switch state {
+	case Deprecated:
+		return 1
+	case Retired:
+		return 2
+	default:
+		return 0
+	}
+}
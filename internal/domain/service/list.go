@@ -0,0 +1,72 @@
+package service
+
+// Summary is a lightweight summary of a Service. Unlike a Service, it does not include the Service's Endpoints
+type Summary struct {
+	// ID uniquely identifies the service
+	ID int64
+	// Code is the unique code of the service
+	Code Code
+	// Name is the friendly name of the service
+	Name Name
+	// EndpointCount is the number of Endpoints that the Service has
+	EndpointCount int
+	// Confirmed is false for placeholder Services, which nobody has registered yet
+	Confirmed bool
+	// UnconfirmedEndpoints are the Codes of the Service's placeholder Endpoints, which nobody has registered yet
+	UnconfirmedEndpoints []EndpointCode
+	// Team is the team that owns the Service, if known
+	Team *Team
+	// Labels are the Service's Labels
+	Labels Labels
+}
+
+// SortField is a field that Services can be sorted by when listing them
+type SortField = string
+
+const (
+	// SortByCode sorts Services by their Code
+	SortByCode SortField = "code"
+	// SortByName sorts Services by their Name (and then by ID, as names need not be unique)
+	SortByName SortField = "name"
+)
+
+// Cursor marks a position in a sorted list of Services, so that the list can be paginated
+type Cursor struct {
+	// SortValue is the value of the sorted field for the last Service on the previous page
+	SortValue string
+	// ID is the ID of the last Service on the previous page
+	ID int64
+}
+
+// ListQuery describes which Services to list, and in what order
+type ListQuery struct {
+	// CodePrefix, if set, only lists Services whose Code starts with this prefix
+	CodePrefix *string
+	// NameContains, if set, only lists Services whose Name contains this substring (ignoring case)
+	NameContains *string
+	// EndpointContains, if set, only lists Services with at least one Endpoint whose Code or Name contains this
+	// substring (ignoring case)
+	EndpointContains *string
+	// Unconfirmed, if set, only lists placeholder Services, and Services with placeholder Endpoints
+	Unconfirmed bool
+	// Team, if set, only lists Services owned by this team
+	Team *Team
+	// Selector, if set, only lists Services whose Labels match it
+	Selector Selector
+	// SortBy is the field to sort by
+	SortBy SortField
+	// Descending sorts in descending, rather than ascending, order
+	Descending bool
+	// After, if set, only lists Services that come after this Cursor
+	After *Cursor
+	// Limit is the maximum number of Services to list
+	Limit int
+}
+
+// SummaryPage is a single page of Service Summaries
+type SummaryPage struct {
+	// Summaries are the Service Summaries on this page
+	Summaries []Summary
+	// Next, if set, is the Cursor to fetch the next page with. It is nil on the last page
+	Next *Cursor
+}
@@ -0,0 +1,29 @@
+package service
+
+import (
+	"time"
+
+	"github.com/volatiletech/sqlboiler/v4/boil"
+)
+
+// Actor is whoever makes a change to a Service. For example, a username, or the name of the CI job that saved it
+type Actor = string
+
+// AnonymousActor is the Actor of changes made by a Repository that wasn't told who is making them
+const AnonymousActor Actor = "anonymous"
+
+// Recorder records the history of Services. A Repository calls it within the transaction that changes a Service, so
+// that the change and its record are committed (or rolled back) together
+type Recorder interface {
+	// Record records that the Service with the Code, in the Environment, was changed by the Actor at changedAt, leaving
+	// it as current (which is nil if the Service was deleted). It runs within the transaction exec, which is still
+	// open, so current is exactly what the transaction will commit
+	Record(
+		exec boil.ContextExecutor,
+		env Environment,
+		code Code,
+		current *Service,
+		actor Actor,
+		changedAt time.Time,
+	) error
+}
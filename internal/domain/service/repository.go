@@ -1,37 +1,600 @@
 package service
 
 import (
+	"database/sql"
+	"fmt"
 	"github.com/jmoiron/sqlx"
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
 	"github.com/xo/dburl"
+	"github.com/yashap/crius/internal/errors"
 	"go.uber.org/zap"
 	"log"
+	"sort"
+	"strings"
 )
 
 // Repository is a Service repository. It is a classic "Domain Driven Design" repository - the mental model is that
-// it represents a collection of models.Service instances
+// it represents a collection of models.Service instances. Each Repository only holds the Services of one Environment.
+// Every change that it makes to Services is recorded by its Recorder, in the same transaction
 type Repository interface {
-	// Save saves a Service
-	Save(s *Service) error
+	// InEnvironment returns a Repository of the Services in the given Environment. A new Repository holds the Services
+	// in the DefaultEnvironment
+	InEnvironment(env Environment) Repository
+	// InTransaction returns a Repository that makes its changes in the transaction tx, which is already open, so that
+	// they are committed or rolled back along with the rest of it
+	InTransaction(tx *sql.Tx) Repository
+	// AsActor returns a Repository that records its changes to Services as made by the given Actor. A new Repository
+	// records them as made by the AnonymousActor
+	AsActor(actor Actor) Repository
+	// Save saves a Service, fully replacing any previous version of it, and returns the changes to its dependencies. If
+	// createPlaceholders is set, dependencies on Services and Endpoints that don't exist yet create unconfirmed
+	// placeholders for them, rather than failing. The Service is confirmed, but its Endpoints that aren't Confirmed are
+	// saved as placeholders
+	Save(s *Service, createPlaceholders bool) (DependencyDiff, error)
+	// SaveAll saves Services like Save, all at once, such as when an Environment's graph is promoted into another. They
+	// can depend on each other's Endpoints, and stop depending on them, whatever order they are in. New dependencies on
+	// retired Endpoints aren't rejected, as the Services are expected to have been checked where they came from. Each
+	// Service's change is recorded once
+	SaveAll(services []Service, createPlaceholders bool) error
+	// SaveEndpoint saves a single Endpoint of an existing Service, leaving the Service's other Endpoints alone
+	SaveEndpoint(serviceCode Code, endpoint *Endpoint) error
+	// Update partially updates an existing Service, as described by the Patch
+	Update(code Code, patch Patch) error
 	// FindByCode finds a Service by its Code
 	FindByCode(code Code) (*Service, error)
+	// FindAll finds every Service, sorted by Code
+	FindAll() ([]Service, error)
+	// FindEndpoint finds a single Endpoint by its Code, and the Code of its Service
+	FindEndpoint(serviceCode Code, endpointCode EndpointCode) (*Endpoint, error)
+	// FindDependencies finds all Endpoints that the Endpoints described by the query depend on, directly or
+	// transitively (up to the query's MaxDepth)
+	FindDependencies(query DependencyQuery) ([]Dependency, error)
+	// FindDependents finds all Endpoints that depend on the Endpoints described by the query, directly or transitively
+	// (up to the query's MaxDepth)
+	FindDependents(query DependencyQuery) ([]Dependency, error)
+	// List lists Summaries of the Services that match the query, one page at a time
+	List(query ListQuery) (SummaryPage, error)
+	// Delete deletes a Service by its Code. If Endpoints of other Services or Clients depend on it, it is only deleted
+	// if force is set, in which case those dependencies are deleted too, and returned
+	Delete(code Code, force bool) (RemovedDependencies, error)
+	// DeleteEndpoint deletes a single Endpoint of a Service. If other Endpoints or Clients depend on it, it is only
+	// deleted if force is set, in which case those dependencies are deleted too, and returned
+	DeleteEndpoint(serviceCode Code, endpointCode EndpointCode, force bool) (RemovedDependencies, error)
 }
 
 func NewRepository(
 	dbURL *dburl.URL,
 	db *sqlx.DB,
 	logger *zap.SugaredLogger,
+	recorder Recorder,
 ) Repository {
 	if dbURL.Driver == "postgres" {
 		return &postgresRepository{
-			db:     db,
-			logger: logger,
+			db:          db,
+			logger:      logger,
+			environment: DefaultEnvironment,
+			recorder:    recorder,
+			actor:       AnonymousActor,
 		}
 	} else if dbURL.Driver == "mysql" {
 		return &mysqlRepository{
-			db:     db,
-			logger: logger,
+			db:          db,
+			logger:      logger,
+			environment: DefaultEnvironment,
+			recorder:    recorder,
+			actor:       AnonymousActor,
 		}
 	}
 	log.Fatalf("Unsupported database: %s", dbURL.Driver)
 	return nil
 }
+
+// traversalStep is a single Endpoint reached by traversing the service_endpoint_edge view
+type traversalStep struct {
+	// EndpointID is the id of the Endpoint that was reached
+	EndpointID int64
+	// Distance is the number of hops it took to reach the Endpoint
+	Distance int
+	// Path is the ids of the Endpoints that reached this Endpoint, starting with a starting Endpoint
+	Path []int64
+}
+
+// traversalEdge is an edge of the service_endpoint_edge view, oriented in the direction of a traversal, along with how
+// many hops from the starting Endpoints the traversal crossed it
+type traversalEdge struct {
+	FromID   int64 `boil:"from_id"`
+	ToID     int64 `boil:"to_id"`
+	Distance int   `boil:"distance"`
+}
+
+// shortestPaths finds the shortest path to each Endpoint that a traversal reached from the Endpoints with the given
+// ids, out of the edges that it crossed. Of the shortest paths to an Endpoint, the smallest is kept, comparing paths
+// Endpoint by Endpoint by their EndpointRefs, like graph.Traverse does, so the two agree. Steps are ordered by
+// Distance, and then by EndpointRef, and never reach the starting Endpoints
+func shortestPaths(startIDs []int64, edges []traversalEdge, endpoints map[int64]endpointInfo) []traversalStep {
+	paths := make(map[int64][]int64)
+	for _, id := range startIDs {
+		paths[id] = []int64{id}
+	}
+	sort.Slice(edges, func(i, j int) bool { return edges[i].Distance < edges[j].Distance })
+	steps := make([]traversalStep, 0)
+	for first := 0; first < len(edges); {
+		distance := edges[first].Distance
+		// Endpoints are reached a hop at a time, so the paths to those that were reached at the previous distance are
+		// all known, and the Endpoints that already have a path were reached sooner
+		best := make(map[int64][]int64)
+		reached := make([]int64, 0)
+		for ; first < len(edges) && edges[first].Distance == distance; first++ {
+			edge := edges[first]
+			from, ok := paths[edge.FromID]
+			if _, visited := paths[edge.ToID]; visited || !ok || len(from) != distance {
+				continue
+			}
+			if previous, ok := best[edge.ToID]; !ok {
+				reached = append(reached, edge.ToID)
+				best[edge.ToID] = from
+			} else if lessPath(from, previous, endpoints) {
+				best[edge.ToID] = from
+			}
+		}
+		sort.Slice(reached, func(i, j int) bool {
+			return lessPath([]int64{reached[i]}, []int64{reached[j]}, endpoints)
+		})
+		for _, id := range reached {
+			path := append(append(make([]int64, 0, distance+1), best[id]...), id)
+			paths[id] = path
+			steps = append(steps, traversalStep{EndpointID: id, Distance: distance, Path: path})
+		}
+	}
+	return steps
+}
+
+// lessPath reports whether the path a, of Endpoint ids, is smaller than the path b, of the same length, comparing them
+// Endpoint by Endpoint by the Codes of their Services, and then by their own Codes
+func lessPath(a []int64, b []int64, endpoints map[int64]endpointInfo) bool {
+	for idx := range a {
+		refA, refB := endpoints[a[idx]].Ref, endpoints[b[idx]].Ref
+		if refA.ServiceCode != refB.ServiceCode {
+			return refA.ServiceCode < refB.ServiceCode
+		}
+		if refA.EndpointCode != refB.EndpointCode {
+			return refA.EndpointCode < refB.EndpointCode
+		}
+	}
+	return false
+}
+
+// traversalColumns returns the service_endpoint_edge columns to walk from and to, when traversing the dependency
+// graph in the given direction
+func traversalColumns(direction Direction) (string, string) {
+	if direction == Upstream {
+		return "dependency_service_endpoint_id", "service_endpoint_id"
+	}
+	return "service_endpoint_id", "dependency_service_endpoint_id"
+}
+
+// endpointInfo holds what we need to know about an Endpoint to describe it in a Dependency
+type endpointInfo struct {
+	Ref         EndpointRef
+	Name        EndpointName
+	ServiceTeam *Team
+}
+
+// makeDependencies converts traversal steps into Dependencies. Steps must be ordered by Distance, and only the first
+// (and thus shortest) path to each Endpoint is kept. The starting Endpoints themselves are never returned
+func makeDependencies(startIDs []int64, steps []traversalStep, endpoints map[int64]endpointInfo) []Dependency {
+	seen := make(map[int64]bool)
+	for _, id := range startIDs {
+		seen[id] = true
+	}
+	dependencies := make([]Dependency, 0)
+	for _, step := range steps {
+		if seen[step.EndpointID] {
+			continue
+		}
+		seen[step.EndpointID] = true
+		path := make([]EndpointRef, len(step.Path))
+		for idx, id := range step.Path {
+			path[idx] = endpoints[id].Ref
+		}
+		dependencies = append(dependencies, Dependency{
+			Endpoint:     endpoints[step.EndpointID].Ref,
+			EndpointName: endpoints[step.EndpointID].Name,
+			ServiceTeam:  endpoints[step.EndpointID].ServiceTeam,
+			Distance:     step.Distance,
+			Path:         path,
+		})
+	}
+	sort.SliceStable(dependencies, func(i, j int) bool {
+		a, b := dependencies[i], dependencies[j]
+		if a.Distance != b.Distance {
+			return a.Distance < b.Distance
+		}
+		if a.Endpoint.ServiceCode != b.Endpoint.ServiceCode {
+			return a.Endpoint.ServiceCode < b.Endpoint.ServiceCode
+		}
+		return a.Endpoint.EndpointCode < b.Endpoint.EndpointCode
+	})
+	return dependencies
+}
+
+// traversalEndpointIDs returns the ids of the starting Endpoints, and of every Endpoint that any of the edges connect
+func traversalEndpointIDs(startIDs []int64, edges []traversalEdge) []interface{} {
+	seen := make(map[int64]bool)
+	ids := make([]interface{}, 0)
+	add := func(id int64) {
+		if !seen[id] {
+			seen[id] = true
+			ids = append(ids, id)
+		}
+	}
+	for _, id := range startIDs {
+		add(id)
+	}
+	for _, edge := range edges {
+		add(edge.FromID)
+		add(edge.ToID)
+	}
+	return ids
+}
+
+// escapeLike escapes the wildcard characters in a LIKE pattern, so that the value is matched literally
+func escapeLike(value string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(value)
+}
+
+// listQueryMods builds the query mods that filter, sort and paginate the service table for a ListQuery. They fetch one
+// more row than the query's Limit, so that callers can tell whether there is another page. likeOperator is the
+// database's case-insensitive LIKE operator
+func listQueryMods(query ListQuery, likeOperator string) []qm.QueryMod {
+	mods := make([]qm.QueryMod, 0)
+	if query.CodePrefix != nil {
+		mods = append(mods, qm.Where("code LIKE ?", escapeLike(*query.CodePrefix)+"%"))
+	}
+	if query.NameContains != nil {
+		mods = append(mods, qm.Where(
+			fmt.Sprintf("name %s ?", likeOperator),
+			"%"+escapeLike(*query.NameContains)+"%",
+		))
+	}
+	if query.Unconfirmed {
+		mods = append(mods, qm.Where(
+			"(confirmed = ? OR EXISTS (SELECT 1 FROM service_endpoint se WHERE se.service_id = service.id AND se.confirmed = ?))",
+			false,
+			false,
+		))
+	}
+	if query.Team != nil {
+		mods = append(mods, qm.Where("team = ?", *query.Team))
+	}
+	for _, requirement := range query.Selector {
+		mods = append(mods, labelRequirementMod(requirement))
+	}
+	if query.EndpointContains != nil {
+		pattern := "%" + escapeLike(*query.EndpointContains) + "%"
+		mods = append(mods, qm.Where(
+			fmt.Sprintf(
+				"EXISTS (SELECT 1 FROM service_endpoint se WHERE se.service_id = service.id AND (se.code %[1]s ? OR se.name %[1]s ?))",
+				likeOperator,
+			),
+			pattern,
+			pattern,
+		))
+	}
+	sortColumn := "code"
+	if query.SortBy == SortByName {
+		sortColumn = "name"
+	}
+	direction, comparison := "ASC", ">"
+	if query.Descending {
+		direction, comparison = "DESC", "<"
+	}
+	if query.After != nil {
+		mods = append(mods, qm.Where(
+			fmt.Sprintf("(%[1]s %[2]s ? OR (%[1]s = ? AND id %[2]s ?))", sortColumn, comparison),
+			query.After.SortValue,
+			query.After.SortValue,
+			query.After.ID,
+		))
+	}
+	return append(
+		mods,
+		qm.OrderBy(fmt.Sprintf("%[1]s %[2]s, id %[2]s", sortColumn, direction)),
+		qm.Limit(query.Limit+1),
+	)
+}
+
+// labelRequirementMod builds a query mod that filters the service table down to the Services whose Labels meet the
+// Requirement
+func labelRequirementMod(requirement Requirement) qm.QueryMod {
+	clause := "EXISTS (SELECT 1 FROM service_label sl WHERE sl.service_id = service.id AND sl.label_key = ?"
+	args := []interface{}{requirement.Key}
+	if len(requirement.Values) > 0 {
+		clause += fmt.Sprintf(
+			" AND sl.label_value IN (%s)",
+			strings.TrimSuffix(strings.Repeat("?,", len(requirement.Values)), ","),
+		)
+		for _, value := range requirement.Values {
+			args = append(args, value)
+		}
+	}
+	clause += ")"
+	if requirement.Negated() {
+		clause = "NOT " + clause
+	}
+	return qm.Where(clause, args...)
+}
+
+// labelKeys returns the keys of labels, sorted
+func labelKeys(labels Labels) []string {
+	keys := make([]string, 0, len(labels))
+	for key := range labels {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// makeSummaryPage builds a SummaryPage from (up to Limit + 1) Summaries fetched for a ListQuery
+func makeSummaryPage(query ListQuery, summaries []Summary) SummaryPage {
+	if len(summaries) <= query.Limit {
+		return SummaryPage{Summaries: summaries}
+	}
+	summaries = summaries[:query.Limit]
+	last := summaries[len(summaries)-1]
+	next := Cursor{SortValue: last.Code, ID: last.ID}
+	if query.SortBy == SortByName {
+		next.SortValue = last.Name
+	}
+	return SummaryPage{Summaries: summaries, Next: &next}
+}
+
+// endpointCount is the number of Endpoints that a Service has
+type endpointCount struct {
+	ServiceID int64 `boil:"service_id"`
+	Count     int   `boil:"endpoint_count"`
+}
+
+// dependentsError is returned when trying to delete something (described by subject) that other Endpoints or Clients
+// depend on, without forcing the deletion
+func dependentsError(subject string, dependents RemovedDependencies) error {
+	details := make([]errors.Detail, 0, len(dependents.Dependencies)+len(dependents.ClientDependencies))
+	for _, dependent := range dependents.Dependencies {
+		details = append(details, errors.Detail{
+			"service_code":             dependent.From.ServiceCode,
+			"endpoint_code":            dependent.From.EndpointCode,
+			"dependency_service_code":  dependent.To.ServiceCode,
+			"dependency_endpoint_code": dependent.To.EndpointCode,
+		})
+	}
+	for _, dependent := range dependents.ClientDependencies {
+		details = append(details, errors.Detail{
+			"client_code":              dependent.ClientCode,
+			"dependency_service_code":  dependent.To.ServiceCode,
+			"dependency_endpoint_code": dependent.To.EndpointCode,
+		})
+	}
+	return errors.HasDependents(
+		fmt.Sprintf(
+			"%s can't be deleted, as %d other endpoint dependencies and %d client dependencies exist on it. Force the "+
+				"deletion to delete them too",
+			subject,
+			len(dependents.Dependencies),
+			len(dependents.ClientDependencies),
+		),
+		details,
+	)
+}
+
+// dependencyServiceCodes returns the Codes of every Service that the Endpoints depend on, sorted
+func dependencyServiceCodes(endpoints []Endpoint) []Code {
+	seen := make(map[Code]bool)
+	codes := make([]Code, 0)
+	for _, endpoint := range endpoints {
+		for depServiceCode := range endpoint.Dependencies {
+			if !seen[depServiceCode] {
+				seen[depServiceCode] = true
+				codes = append(codes, depServiceCode)
+			}
+		}
+	}
+	sort.Strings(codes)
+	return codes
+}
+
+// addEndpointCodes adds the Codes of the Endpoints of Services that are being saved to known, which maps the Codes of
+// existing Services to the Codes of their Endpoints, so that dependencies on them resolve whatever order the Services
+// are saved in
+func addEndpointCodes(known map[Code]map[EndpointCode]bool, services []*Service) {
+	for _, s := range services {
+		if _, ok := known[s.Code]; !ok {
+			known[s.Code] = make(map[EndpointCode]bool)
+		}
+		for _, endpoint := range s.Endpoints {
+			known[s.Code][endpoint.Code] = true
+		}
+	}
+}
+
+// checkDependencies checks that every dependency of the Endpoints, which are being saved to the Service with the given
+// Code, resolves to an Endpoint. known maps the Codes of existing Services to the Codes of their Endpoints. The
+// Endpoints being saved can depend on each other, and unless replace is set, on the Service's existing Endpoints too.
+// If createPlaceholders is set, dependencies on other Services that don't resolve are returned, so that placeholders
+// can be created for them. Every other dependency that doesn't resolve is listed in the returned error
+func checkDependencies(
+	serviceCode Code,
+	endpoints []Endpoint,
+	known map[Code]map[EndpointCode]bool,
+	replace bool,
+	createPlaceholders bool,
+) ([]EndpointRef, error) {
+	own := make(map[EndpointCode]bool)
+	if !replace {
+		for endpointCode := range known[serviceCode] {
+			own[endpointCode] = true
+		}
+	}
+	for _, endpoint := range endpoints {
+		own[endpoint.Code] = true
+	}
+	placeholders := make([]EndpointRef, 0)
+	seenPlaceholders := make(map[EndpointRef]bool)
+	details := make([]errors.Detail, 0)
+	for _, endpoint := range endpoints {
+		depServiceCodes := make([]Code, 0, len(endpoint.Dependencies))
+		for depServiceCode := range endpoint.Dependencies {
+			depServiceCodes = append(depServiceCodes, depServiceCode)
+		}
+		sort.Strings(depServiceCodes)
+		for _, depServiceCode := range depServiceCodes {
+			depEndpointCodes, serviceExists := known[depServiceCode]
+			if depServiceCode == serviceCode {
+				depEndpointCodes, serviceExists = own, true
+			}
+			for _, depEndpointCode := range endpoint.Dependencies[depServiceCode] {
+				if serviceExists && depEndpointCodes[depEndpointCode] {
+					continue
+				}
+				if createPlaceholders && depServiceCode != serviceCode {
+					ref := EndpointRef{ServiceCode: depServiceCode, EndpointCode: depEndpointCode}
+					if !seenPlaceholders[ref] {
+						seenPlaceholders[ref] = true
+						placeholders = append(placeholders, ref)
+					}
+					continue
+				}
+				detail := errors.Detail{
+					"service_code":             serviceCode,
+					"endpoint_code":            endpoint.Code,
+					"dependency_service_code":  depServiceCode,
+					"dependency_endpoint_code": depEndpointCode,
+				}
+				if !serviceExists {
+					detail["sub_code"] = errors.ServiceNotFoundSubCode.String()
+					detail["message"] = fmt.Sprintf("Service with code %s not found", depServiceCode)
+				} else {
+					detail["sub_code"] = errors.EndpointNotFoundSubCode.String()
+					detail["message"] = fmt.Sprintf(
+						"Endpoint with code %s not found on service %s",
+						depEndpointCode,
+						depServiceCode,
+					)
+				}
+				details = append(details, detail)
+			}
+		}
+	}
+	if len(details) > 0 {
+		return nil, errors.UnresolvedDependencies(
+			fmt.Sprintf("%d dependencies of service %s don't exist", len(details), serviceCode),
+			details,
+		)
+	}
+	return placeholders, nil
+}
+
+// checkRetiredDependencies checks that none of the Endpoints, which are being saved to the Service with the given
+// Code, newly depend on a retired Endpoint of another Service. retired holds the retired Endpoints, and existing is the
+// Service before the save, or nil if it is new. Dependencies that the Endpoints already had are kept, so that a Service
+// can still be saved after something it depends on is retired. Every new dependency on a retired Endpoint is listed in
+// the returned error
+func checkRetiredDependencies(
+	serviceCode Code,
+	endpoints []Endpoint,
+	existing *Service,
+	retired map[EndpointRef]bool,
+) error {
+	existingDependencies := make(map[DependencyEdge]bool)
+	if existing != nil {
+		for _, edge := range dependencyEdges(existing.Code, existing.Endpoints) {
+			existingDependencies[edge] = true
+		}
+	}
+	details := make([]errors.Detail, 0)
+	for _, edge := range dependencyEdges(serviceCode, endpoints) {
+		if edge.To.ServiceCode == serviceCode || !retired[edge.To] || existingDependencies[edge] {
+			continue
+		}
+		details = append(details, errors.Detail{
+			"service_code":             edge.From.ServiceCode,
+			"endpoint_code":            edge.From.EndpointCode,
+			"dependency_service_code":  edge.To.ServiceCode,
+			"dependency_endpoint_code": edge.To.EndpointCode,
+			"message": fmt.Sprintf(
+				"Endpoint with code %s on service %s is retired",
+				edge.To.EndpointCode,
+				edge.To.ServiceCode,
+			),
+		})
+	}
+	if len(details) > 0 {
+		return errors.RetiredDependencies(
+			fmt.Sprintf("%d new dependencies of service %s are on retired endpoints", len(details), serviceCode),
+			details,
+		)
+	}
+	return nil
+}
+
+// dependencyEdges returns the dependencies of the Endpoints of the Service with the given Code, sorted
+func dependencyEdges(serviceCode Code, endpoints []Endpoint) []DependencyEdge {
+	edges := make([]DependencyEdge, 0)
+	for _, endpoint := range endpoints {
+		from := EndpointRef{ServiceCode: serviceCode, EndpointCode: endpoint.Code}
+		for depServiceCode, depEndpointCodes := range endpoint.Dependencies {
+			for _, depEndpointCode := range depEndpointCodes {
+				edges = append(edges, DependencyEdge{
+					From: from,
+					To:   EndpointRef{ServiceCode: depServiceCode, EndpointCode: depEndpointCode},
+				})
+			}
+		}
+	}
+	sortDependencyEdges(edges)
+	return edges
+}
+
+// makeLifecycle builds a Lifecycle from the lifecycle and sunset_date columns of a service or endpoint
+func makeLifecycle(state LifecycleState, sunsetDate null.Time) Lifecycle {
+	return Lifecycle{State: state, SunsetDate: sunsetDate.Ptr()}
+}
+
+// sortClientDependencyEdges sorts edges by the Client they are from, and then by the Endpoint they are to
+func sortClientDependencyEdges(edges []ClientDependencyEdge) {
+	sort.Slice(edges, func(i, j int) bool {
+		if edges[i].ClientCode != edges[j].ClientCode {
+			return edges[i].ClientCode < edges[j].ClientCode
+		}
+		if edges[i].To.ServiceCode != edges[j].To.ServiceCode {
+			return edges[i].To.ServiceCode < edges[j].To.ServiceCode
+		}
+		return edges[i].To.EndpointCode < edges[j].To.EndpointCode
+	})
+}
+
+// sortDependencyEdges sorts edges by the Endpoint they are from, and then by the Endpoint they are to
+func sortDependencyEdges(edges []DependencyEdge) {
+	key := func(ref EndpointRef) string {
+		return ref.ServiceCode + "\x00" + ref.EndpointCode
+	}
+	sort.Slice(edges, func(i, j int) bool {
+		if key(edges[i].From) != key(edges[j].From) {
+			return key(edges[i].From) < key(edges[j].From)
+		}
+		return key(edges[i].To) < key(edges[j].To)
+	})
+}
+
+// changedServiceCodes returns the Code of a Service that was changed, along with the Codes of the Services of the other
+// Endpoints that the change affected: those that got placeholders, or lost their dependencies on it
+func changedServiceCodes(code Code, placeholders []EndpointRef, removed []DependencyEdge) []Code {
+	codes := []Code{code}
+	for _, ref := range placeholders {
+		codes = append(codes, ref.ServiceCode)
+	}
+	for _, edge := range removed {
+		codes = append(codes, edge.From.ServiceCode)
+	}
+	return codes
+}
@@ -3,96 +3,514 @@ package service
 import (
 	"context"
 	"database/sql"
+	"fmt"
 	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"github.com/volatiletech/null/v8"
 	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries"
 	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+	"github.com/yashap/crius/internal/db"
 	pgdao "github.com/yashap/crius/internal/db/postgresql/dao"
 	"github.com/yashap/crius/internal/errors"
 	"go.uber.org/zap"
+	"strings"
+	"time"
 )
 
 type postgresRepository struct {
-	db     *sqlx.DB
-	logger *zap.SugaredLogger
+	db          *sqlx.DB
+	tx          *sql.Tx
+	logger      *zap.SugaredLogger
+	environment Environment
+	recorder    Recorder
+	actor       Actor
 }
 
-func (r *postgresRepository) Save(s *Service) error {
-	// TODO: move transaction handling to top level, pass through in Context
-	tx, err := r.db.BeginTx(context.Background(), nil)
+func (r *postgresRepository) InEnvironment(env Environment) Repository {
+	return &postgresRepository{
+		db:          r.db,
+		tx:          r.tx,
+		logger:      r.logger,
+		environment: env,
+		recorder:    r.recorder,
+		actor:       r.actor,
+	}
+}
+
+func (r *postgresRepository) InTransaction(tx *sql.Tx) Repository {
+	return &postgresRepository{
+		db:          r.db,
+		tx:          tx,
+		logger:      r.logger,
+		environment: r.environment,
+		recorder:    r.recorder,
+		actor:       r.actor,
+	}
+}
+
+func (r *postgresRepository) AsActor(actor Actor) Repository {
+	return &postgresRepository{
+		db:          r.db,
+		tx:          r.tx,
+		logger:      r.logger,
+		environment: r.environment,
+		recorder:    r.recorder,
+		actor:       actor,
+	}
+}
+
+// executor is what the Repository runs its queries with: the transaction that it joined, if it joined one, and
+// otherwise the database
+func (r *postgresRepository) executor() boil.ContextExecutor {
+	return db.Executor(r.db, r.tx)
+}
+
+func (r *postgresRepository) Save(s *Service, createPlaceholders bool) (DependencyDiff, error) {
+	return r.saveAll([]*Service{s}, createPlaceholders, true)
+}
+
+func (r *postgresRepository) SaveAll(services []Service, createPlaceholders bool) error {
+	toSave := make([]*Service, len(services))
+	for idx := range services {
+		toSave[idx] = &services[idx]
+	}
+	_, err := r.saveAll(toSave, createPlaceholders, false)
+	return err
+}
+
+// saveAll saves Services in one transaction, each fully replacing any previous version of it, and returns the changes
+// to their dependencies. Their dependencies are checked against the Services being saved as well as the existing ones,
+// and every Service and Endpoint is written before any dependencies are, so the Services can depend on each other in
+// any order. Endpoints that the Services no longer have are only deleted once everything else is saved, so the
+// Services that depended on them have replaced those dependencies by then. New dependencies on retired Endpoints are
+// only rejected if checkRetired is set
+func (r *postgresRepository) saveAll(
+	services []*Service,
+	createPlaceholders bool,
+	checkRetired bool,
+) (DependencyDiff, error) {
+	diff := DependencyDiff{Added: make([]DependencyEdge, 0), Removed: make([]DependencyEdge, 0)}
+	codes := make([]Code, len(services))
+	for idx, s := range services {
+		codes[idx] = s.Code
+	}
+	tx, err := db.Begin(r.db, r.tx)
 	if err != nil {
-		msg := "Failed to begin transaction when saving service"
-		r.logger.Errorw(msg, "err", err.Error(), "serviceCode", s.Code)
-		return errors.DatabaseError(msg, &err)
+		msg := "Failed to begin transaction when saving services"
+		r.logger.Errorw(msg, "err", err.Error(), "serviceCodes", codes)
+		return diff, errors.DatabaseError(msg, &err)
 	}
-	serviceDAO := pgdao.Service{Code: s.Code, Name: s.Name}
-	err = r.upsertService(tx, &serviceDAO)
+	placeholders, err := r.validateServices(tx, services, createPlaceholders, checkRetired)
 	if err != nil {
 		_ = tx.Rollback()
-		return err
+		return diff, err
+	}
+	serviceDAOs := make([]*pgdao.Service, len(services))
+	staleEndpointDAOs := make(pgdao.ServiceEndpointSlice, 0)
+	staleServiceCodes := make([]Code, 0)
+	for idx, s := range services {
+		serviceDAOs[idx], err = r.saveService(tx, s)
+		if err != nil {
+			_ = tx.Rollback()
+			return diff, err
+		}
+		stale, err := r.findStaleEndpoints(tx, s)
+		if err != nil {
+			_ = tx.Rollback()
+			return diff, err
+		}
+		for _, staleEndpointDAO := range stale {
+			for _, dependencyDAO := range staleEndpointDAO.R.ServiceEndpointDependencies {
+				depEndpointDAO := dependencyDAO.R.DependencyServiceEndpoint
+				diff.Removed = append(diff.Removed, DependencyEdge{
+					From: EndpointRef{ServiceCode: s.Code, EndpointCode: staleEndpointDAO.Code},
+					To:   EndpointRef{ServiceCode: depEndpointDAO.R.Service.Code, EndpointCode: depEndpointDAO.Code},
+				})
+			}
+		}
+		if len(stale) > 0 {
+			staleEndpointDAOs = append(staleEndpointDAOs, stale...)
+			staleServiceCodes = append(staleServiceCodes, s.Code)
+		}
+	}
+	// Placeholders are only created once every Service is saved, so that they aren't mistaken for stale Endpoints
+	err = r.createPlaceholders(tx, placeholders)
+	if err != nil {
+		_ = tx.Rollback()
+		return diff, err
+	}
+	for idx, s := range services {
+		serviceDiff, err := r.saveEndpointDependencies(tx, serviceDAOs[idx], s.Endpoints)
+		if err != nil {
+			_ = tx.Rollback()
+			return diff, err
+		}
+		diff.Added = append(diff.Added, serviceDiff.Added...)
+		diff.Removed = append(diff.Removed, serviceDiff.Removed...)
+	}
+	err = r.deleteStaleEndpoints(tx, staleEndpointDAOs, staleServiceCodes)
+	if err != nil {
+		_ = tx.Rollback()
+		return diff, err
+	}
+	changed := append(make([]Code, 0, len(codes)+len(placeholders)), codes...)
+	for _, ref := range placeholders {
+		changed = append(changed, ref.ServiceCode)
+	}
+	err = r.record(tx, changed...)
+	if err != nil {
+		_ = tx.Rollback()
+		return diff, err
+	}
+	err = tx.Commit()
+	if err != nil {
+		msg := "Failed to commit transaction when saving services"
+		r.logger.Errorw(msg, "err", err.Error(), "serviceCodes", codes)
+		return diff, errors.DatabaseError(msg, &err)
+	}
+	sortDependencyEdges(diff.Added)
+	sortDependencyEdges(diff.Removed)
+	return diff, nil
+}
+
+// saveService upserts a Service, along with its labels and Endpoints, and sets its ID. The Endpoints' dependencies are
+// left for the caller to save, and so are the Endpoints that the Service no longer has to delete
+func (r *postgresRepository) saveService(exec boil.ContextExecutor, s *Service) (*pgdao.Service, error) {
+	serviceDAO := pgdao.Service{Code: s.Code, Name: s.Name, Confirmed: true, Environment: r.environment}
+	r.setOwnership(&serviceDAO, s.Ownership)
+	serviceDAO.Tier = null.IntFromPtr(s.Tier)
+	serviceDAO.Lifecycle = lifecycleState(s.Lifecycle)
+	serviceDAO.SunsetDate = null.TimeFromPtr(s.Lifecycle.SunsetDate)
+	serviceDAO.Version = null.StringFromPtr(s.Version)
+	err := r.upsertService(exec, &serviceDAO)
+	if err != nil {
+		return nil, err
 	}
 	s.ID = &serviceDAO.ID
+	s.Confirmed = true
+	err = r.saveServiceLabels(exec, serviceDAO.ID, s.Labels)
+	if err != nil {
+		return nil, err
+	}
+	err = r.upsertEndpoints(exec, &serviceDAO, s.Endpoints)
+	if err != nil {
+		return nil, err
+	}
+	return &serviceDAO, nil
+}
+
+// findStaleEndpoints finds the Endpoints of a saved Service that it no longer has, along with their dependencies
+func (r *postgresRepository) findStaleEndpoints(
+	exec boil.ContextExecutor,
+	s *Service,
+) (pgdao.ServiceEndpointSlice, error) {
 	endpointIDs := make([]interface{}, len(s.Endpoints))
 	for idx, endpoint := range s.Endpoints {
-		endpointDAO := pgdao.ServiceEndpoint{
-			ServiceID: serviceDAO.ID,
-			Code:      endpoint.Code,
-			Name:      endpoint.Name,
+		endpointIDs[idx] = *endpoint.ID
+	}
+	staleEndpointDAOs, err := pgdao.ServiceEndpoints(
+		qm.Load(qm.Rels(
+			pgdao.ServiceEndpointRels.ServiceEndpointDependencies,
+			pgdao.ServiceEndpointDependencyRels.DependencyServiceEndpoint,
+			pgdao.ServiceEndpointRels.Service,
+		)),
+		qm.Where("service_id = ?", *s.ID),
+		qm.AndNotIn("id not in ?", endpointIDs...),
+	).All(context.Background(), exec)
+	if err != nil {
+		msg := "Failed to find endpoints by ids"
+		r.logger.Errorw(msg, "err", err.Error(), "ids", endpointIDs)
+		return nil, errors.DatabaseError(msg, &err)
+	}
+	return staleEndpointDAOs, nil
+}
+
+// deleteStaleEndpoints deletes the Endpoints that the Services with the given Codes no longer have, along with their
+// dependencies. It fails if anything else still depends on them
+func (r *postgresRepository) deleteStaleEndpoints(
+	exec boil.ContextExecutor,
+	staleEndpointDAOs pgdao.ServiceEndpointSlice,
+	serviceCodes []Code,
+) error {
+	staleEndpointIDs := make([]int64, len(staleEndpointDAOs))
+	for idx, staleEndpointDAO := range staleEndpointDAOs {
+		staleEndpointIDs[idx] = staleEndpointDAO.ID
+	}
+	subject := fmt.Sprintf("Endpoints removed from service %s", strings.Join(serviceCodes, ", "))
+	if len(serviceCodes) > 1 {
+		subject = fmt.Sprintf("Endpoints removed from services %s", strings.Join(serviceCodes, ", "))
+	}
+	_, err := r.deleteIncomingDependencies(exec, staleEndpointIDs, false, subject)
+	if err != nil {
+		return err
+	}
+	_, err = staleEndpointDAOs.DeleteAll(context.Background(), exec)
+	if err != nil {
+		msg := "Failed to delete endpoints by ids"
+		r.logger.Errorw(msg, "err", err.Error(), "ids", staleEndpointIDs)
+		return errors.DatabaseError(msg, &err)
+	}
+	return nil
+}
+
+func (r *postgresRepository) SaveEndpoint(serviceCode Code, endpoint *Endpoint) error {
+	endpoints := []Endpoint{*endpoint}
+	_, err := r.validateDependencies(r.executor(), serviceCode, endpoints, false, false)
+	if err != nil {
+		return err
+	}
+	tx, err := db.Begin(r.db, r.tx)
+	if err != nil {
+		msg := "Failed to begin transaction when saving endpoint"
+		r.logger.Errorw(msg, "err", err.Error(), "serviceCode", serviceCode, "code", endpoint.Code)
+		return errors.DatabaseError(msg, &err)
+	}
+	serviceDAO, err := r.findServiceDAOByCode(tx, serviceCode)
+	if err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+	_, err = r.saveEndpoints(tx, serviceDAO, endpoints)
+	if err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+	endpoint.ID = endpoints[0].ID
+	err = r.record(tx, serviceCode)
+	if err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+	err = tx.Commit()
+	if err != nil {
+		msg := "Failed to commit transaction when saving endpoint"
+		r.logger.Errorw(msg, "err", err.Error(), "serviceCode", serviceCode, "code", endpoint.Code)
+		return errors.DatabaseError(msg, &err)
+	}
+	return nil
+}
+
+func (r *postgresRepository) Update(code Code, patch Patch) error {
+	_, err := r.validateDependencies(r.executor(), code, patch.Endpoints, false, false)
+	if err != nil {
+		return err
+	}
+	tx, err := db.Begin(r.db, r.tx)
+	if err != nil {
+		msg := "Failed to begin transaction when updating service"
+		r.logger.Errorw(msg, "err", err.Error(), "serviceCode", code)
+		return errors.DatabaseError(msg, &err)
+	}
+	serviceDAO, err := r.findServiceDAOByCode(tx, code)
+	if err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+	columns := r.patchOwnership(serviceDAO, patch.Ownership)
+	if patch.Name != nil {
+		serviceDAO.Name = *patch.Name
+		columns = append(columns, "name")
+	}
+	if patch.Tier != nil {
+		serviceDAO.Tier = null.IntFrom(*patch.Tier)
+		columns = append(columns, "tier")
+	}
+	if patch.Lifecycle != nil {
+		serviceDAO.Lifecycle = lifecycleState(*patch.Lifecycle)
+		serviceDAO.SunsetDate = null.TimeFromPtr(patch.Lifecycle.SunsetDate)
+		columns = append(columns, "lifecycle", "sunset_date")
+	}
+	if patch.Version != nil {
+		serviceDAO.Version = null.StringFrom(*patch.Version)
+		columns = append(columns, "version")
+	}
+	if len(columns) > 0 {
+		_, err = serviceDAO.Update(context.Background(), tx, boil.Whitelist(columns...))
+		if err != nil {
+			msg := "Failed to update service"
+			r.logger.Errorw(msg, "err", err.Error(), "serviceCode", code)
+			_ = tx.Rollback()
+			return errors.DatabaseError(msg, &err)
 		}
-		err = r.upsertEndpoint(tx, &endpointDAO)
-		endpoint.ID = &endpointDAO.ID
-		endpointIDs[idx] = endpointDAO.ID
+	}
+	if patch.Labels != nil {
+		err = r.saveServiceLabels(tx, serviceDAO.ID, patch.Labels)
 		if err != nil {
 			_ = tx.Rollback()
 			return err
 		}
-		for depServiceCode, depEndpointCodes := range endpoint.Dependencies {
-			depService, err := r.FindByCode(depServiceCode)
+	}
+	_, err = r.saveEndpoints(tx, serviceDAO, patch.Endpoints)
+	if err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+	err = r.record(tx, code)
+	if err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+	err = tx.Commit()
+	if err != nil {
+		msg := "Failed to commit transaction when updating service"
+		r.logger.Errorw(msg, "err", err.Error(), "serviceCode", code)
+		return errors.DatabaseError(msg, &err)
+	}
+	return nil
+}
+
+// saveEndpoints upserts Endpoints of a Service, and sets their IDs. Every Endpoint is upserted before any dependencies
+// are saved, so that the Endpoints can depend on each other. Each Endpoint's dependencies are fully replaced, so any
+// that it no longer declares are deleted, and the changes to them are returned
+func (r *postgresRepository) saveEndpoints(
+	exec boil.ContextExecutor,
+	serviceDAO *pgdao.Service,
+	endpoints []Endpoint,
+) (DependencyDiff, error) {
+	err := r.upsertEndpoints(exec, serviceDAO, endpoints)
+	if err != nil {
+		return DependencyDiff{}, err
+	}
+	return r.saveEndpointDependencies(exec, serviceDAO, endpoints)
+}
+
+// upsertEndpoints upserts Endpoints of a Service, along with their labels, and sets their IDs. Endpoints that aren't
+// Confirmed are saved as placeholders
+func (r *postgresRepository) upsertEndpoints(
+	exec boil.ContextExecutor,
+	serviceDAO *pgdao.Service,
+	endpoints []Endpoint,
+) error {
+	for idx := range endpoints {
+		endpointDAO := pgdao.ServiceEndpoint{
+			ServiceID:  serviceDAO.ID,
+			Code:       endpoints[idx].Code,
+			Name:       endpoints[idx].Name,
+			Confirmed:  endpoints[idx].Confirmed,
+			Tier:       null.IntFromPtr(endpoints[idx].Tier),
+			Lifecycle:  lifecycleState(endpoints[idx].Lifecycle),
+			SunsetDate: null.TimeFromPtr(endpoints[idx].Lifecycle.SunsetDate),
+			Version:    null.StringFromPtr(endpoints[idx].Version),
+		}
+		err := r.upsertEndpoint(exec, &endpointDAO)
+		if err != nil {
+			return err
+		}
+		endpoints[idx].ID = &endpointDAO.ID
+		err = r.saveEndpointLabels(exec, endpointDAO.ID, endpoints[idx].Labels)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// saveEndpointDependencies fully replaces the dependencies of Endpoints of a Service, which must already be saved, and
+// returns the changes to them
+func (r *postgresRepository) saveEndpointDependencies(
+	exec boil.ContextExecutor,
+	serviceDAO *pgdao.Service,
+	endpoints []Endpoint,
+) (DependencyDiff, error) {
+	diff := DependencyDiff{Added: make([]DependencyEdge, 0), Removed: make([]DependencyEdge, 0)}
+	for idx := range endpoints {
+		endpointDiff, err := r.saveDependencies(exec, serviceDAO, &endpoints[idx])
+		if err != nil {
+			return diff, err
+		}
+		diff.Added = append(diff.Added, endpointDiff.Added...)
+		diff.Removed = append(diff.Removed, endpointDiff.Removed...)
+	}
+	return diff, nil
+}
+
+// saveDependencies fully replaces the dependencies of an Endpoint, which must already be saved, and returns the
+// changes to them
+func (r *postgresRepository) saveDependencies(
+	exec boil.ContextExecutor,
+	serviceDAO *pgdao.Service,
+	endpoint *Endpoint,
+) (DependencyDiff, error) {
+	diff := DependencyDiff{Added: make([]DependencyEdge, 0), Removed: make([]DependencyEdge, 0)}
+	from := EndpointRef{ServiceCode: serviceDAO.Code, EndpointCode: endpoint.Code}
+	previousDependencyDAOs, err := pgdao.ServiceEndpointDependencies(
+		qm.Load(qm.Rels(pgdao.ServiceEndpointDependencyRels.DependencyServiceEndpoint, pgdao.ServiceEndpointRels.Service)),
+		qm.Where("service_endpoint_id = ?", *endpoint.ID),
+	).All(context.Background(), exec)
+	if err != nil {
+		msg := "Failed to find dependencies by endpoint id"
+		r.logger.Errorw(msg, "err", err.Error(), "serviceEndpointID", *endpoint.ID)
+		return diff, errors.DatabaseError(msg, &err)
+	}
+	previousDependencies := make(map[int64]*pgdao.ServiceEndpointDependency)
+	for _, dependencyDAO := range previousDependencyDAOs {
+		previousDependencies[dependencyDAO.ID] = dependencyDAO
+	}
+	for depServiceCode, depEndpointCodes := range endpoint.Dependencies {
+		depServiceDAO, err := r.findServiceDAOByCode(exec, depServiceCode)
+		if err != nil {
+			return diff, err
+		}
+		for _, depEndpointCode := range depEndpointCodes {
+			depEndpoint, err := r.findEndpointByServiceIDAndCode(exec, depServiceDAO.ID, depEndpointCode)
 			if err != nil {
-				_ = tx.Rollback()
-				return err
+				return diff, err
 			}
-			for _, depEndpointCode := range depEndpointCodes {
-				depEndpoint, err := r.findEndpointByServiceIDAndCode(tx, *depService.ID, depEndpointCode)
-				if err != nil {
-					_ = tx.Rollback()
-					return err
-				}
-				dependencyDAO := pgdao.ServiceEndpointDependency{
-					ServiceEndpointID:           endpointDAO.ID,
-					DependencyServiceEndpointID: depEndpoint.ID,
-				}
-				err = r.upsertDependency(tx, &dependencyDAO)
-				if err != nil {
-					_ = tx.Rollback()
-					return err
-				}
+			dependencyDAO := pgdao.ServiceEndpointDependency{
+				ServiceEndpointID:           *endpoint.ID,
+				DependencyServiceEndpointID: depEndpoint.ID,
+				VersionConstraint:           null.StringFromPtr(endpoint.VersionConstraint(depServiceCode)),
+			}
+			err = r.upsertDependency(exec, &dependencyDAO)
+			if err != nil {
+				return diff, err
+			}
+			if _, ok := previousDependencies[dependencyDAO.ID]; ok {
+				delete(previousDependencies, dependencyDAO.ID)
+			} else {
+				diff.Added = append(diff.Added, DependencyEdge{
+					From: from,
+					To:   EndpointRef{ServiceCode: depServiceCode, EndpointCode: depEndpointCode},
+				})
 			}
 		}
 	}
-	// We want to fully replace the service, so remove any endpoints that no longer exist
-	_, err = pgdao.ServiceEndpoints(
-		qm.Where("service_id = ?", serviceDAO.ID),
-		qm.AndNotIn("id not in ?", endpointIDs...),
-	).DeleteAll(context.Background(), tx)
+	// Whatever is left over was not declared this time, so is no longer a dependency
+	staleDependencyDAOs := make(pgdao.ServiceEndpointDependencySlice, 0, len(previousDependencies))
+	for _, dependencyDAO := range previousDependencies {
+		depEndpointDAO := dependencyDAO.R.DependencyServiceEndpoint
+		staleDependencyDAOs = append(staleDependencyDAOs, dependencyDAO)
+		diff.Removed = append(diff.Removed, DependencyEdge{
+			From: from,
+			To:   EndpointRef{ServiceCode: depEndpointDAO.R.Service.Code, EndpointCode: depEndpointDAO.Code},
+		})
+	}
+	_, err = staleDependencyDAOs.DeleteAll(context.Background(), exec)
 	if err != nil {
-		msg := "Failed to delete endpoints by ids"
-		r.logger.Errorw(msg, "err", err.Error(), "ids", endpointIDs)
-		_ = tx.Rollback()
-		return errors.DatabaseError(msg, &err)
+		msg := "Failed to delete dependencies"
+		r.logger.Errorw(msg, "err", err.Error(), "serviceEndpointID", *endpoint.ID)
+		return diff, errors.DatabaseError(msg, &err)
 	}
-	_ = tx.Commit()
-	// TODO: remove any dangling dependencies (that should no longer exist)
-	return nil
+	return diff, nil
 }
 
 func (r *postgresRepository) FindByCode(code Code) (*Service, error) {
+	return r.findByCode(r.executor(), code)
+}
+
+// findByCode finds a Service by its Code, using exec, so that it can see the uncommitted changes of a transaction
+func (r *postgresRepository) findByCode(exec boil.ContextExecutor, code Code) (*Service, error) {
 	serviceDAO, err := pgdao.Services(
 		qm.Load(qm.Rels(
 			pgdao.ServiceRels.ServiceEndpoints,
 			pgdao.ServiceEndpointRels.ServiceEndpointDependencies,
 		)),
-		qm.Where("code = ?", code),
-	).One(context.Background(), r.db)
+		qm.Load(qm.Rels(pgdao.ServiceRels.ServiceEndpoints, pgdao.ServiceEndpointRels.ServiceEndpointLabels)),
+		qm.Load(pgdao.ServiceRels.ServiceLabels),
+		qm.Where("environment = ?", r.environment),
+		qm.And("code = ?", code),
+	).One(context.Background(), exec)
 	if err == sql.ErrNoRows {
 		return nil, nil
 	} else if err != nil {
@@ -102,36 +520,9 @@ func (r *postgresRepository) FindByCode(code Code) (*Service, error) {
 	}
 	endpoints := make([]Endpoint, len(serviceDAO.R.ServiceEndpoints))
 	for idx, endpointDAO := range serviceDAO.R.ServiceEndpoints {
-		dependencies := make(map[Code][]EndpointCode)
-		for _, dependencyDAO := range endpointDAO.R.ServiceEndpointDependencies {
-			depEndpointDAO, err := pgdao.ServiceEndpoints(
-				qm.Where("id = ?", dependencyDAO.DependencyServiceEndpointID),
-			).One(context.Background(), r.db)
-			if err != nil {
-				msg := "Failed to find service endpoint by id"
-				r.logger.Errorw(msg, "err", err.Error(), "id", dependencyDAO.DependencyServiceEndpointID)
-				return nil, errors.DatabaseError(msg, &err)
-			}
-			depServiceDAO, err := pgdao.Services(
-				qm.Where("id = ?", depEndpointDAO.ServiceID),
-			).One(context.Background(), r.db)
-			if err != nil {
-				msg := "Failed to find service by id"
-				r.logger.Errorw(msg, "err", err.Error(), "id", depEndpointDAO.ServiceID)
-				return nil, errors.DatabaseError(msg, &err)
-			}
-			depEndpoints, ok := dependencies[depServiceDAO.Code]
-			if ok {
-				depEndpoints = append(depEndpoints, depEndpointDAO.Code)
-			} else {
-				depEndpoints = []EndpointCode{depEndpointDAO.Code}
-			}
-			dependencies[depServiceDAO.Code] = depEndpoints
-		}
-		endpoints[idx] = Endpoint{
-			Code:         endpointDAO.Code,
-			Name:         endpointDAO.Name,
-			Dependencies: dependencies,
+		endpoints[idx], err = r.makeEndpoint(exec, endpointDAO)
+		if err != nil {
+			return nil, err
 		}
 	}
 	service := Service{
@@ -139,10 +530,420 @@ func (r *postgresRepository) FindByCode(code Code) (*Service, error) {
 		Code:      serviceDAO.Code,
 		Name:      serviceDAO.Name,
 		Endpoints: endpoints,
+		Confirmed: serviceDAO.Confirmed,
+		Ownership: r.makeOwnership(serviceDAO),
+		Labels:    r.makeServiceLabels(serviceDAO.R.ServiceLabels),
+		Tier:      serviceDAO.Tier.Ptr(),
+		Lifecycle: makeLifecycle(serviceDAO.Lifecycle, serviceDAO.SunsetDate),
+		Version:   serviceDAO.Version.Ptr(),
 	}
 	return &service, nil
 }
 
+func (r *postgresRepository) FindAll() ([]Service, error) {
+	serviceDAOs, err := pgdao.Services(
+		qm.Load(qm.Rels(
+			pgdao.ServiceRels.ServiceEndpoints,
+			pgdao.ServiceEndpointRels.ServiceEndpointDependencies,
+		)),
+		qm.Load(qm.Rels(pgdao.ServiceRels.ServiceEndpoints, pgdao.ServiceEndpointRels.ServiceEndpointLabels)),
+		qm.Load(pgdao.ServiceRels.ServiceLabels),
+		qm.Where("environment = ?", r.environment),
+		qm.OrderBy("code"),
+	).All(context.Background(), r.executor())
+	if err != nil {
+		msg := "Failed to find all services"
+		r.logger.Errorw(msg, "err", err.Error())
+		return nil, errors.DatabaseError(msg, &err)
+	}
+	// Every endpoint is loaded, so dependencies can be resolved without querying for each one
+	refs := make(map[int64]EndpointRef)
+	for _, serviceDAO := range serviceDAOs {
+		for _, endpointDAO := range serviceDAO.R.ServiceEndpoints {
+			refs[endpointDAO.ID] = EndpointRef{ServiceCode: serviceDAO.Code, EndpointCode: endpointDAO.Code}
+		}
+	}
+	services := make([]Service, len(serviceDAOs))
+	for idx, serviceDAO := range serviceDAOs {
+		endpoints := make([]Endpoint, len(serviceDAO.R.ServiceEndpoints))
+		for endpointIdx, endpointDAO := range serviceDAO.R.ServiceEndpoints {
+			dependencies := make(map[Code][]EndpointCode)
+			versionConstraints := make(map[Code]Version)
+			for _, dependencyDAO := range endpointDAO.R.ServiceEndpointDependencies {
+				ref := refs[dependencyDAO.DependencyServiceEndpointID]
+				dependencies[ref.ServiceCode] = append(dependencies[ref.ServiceCode], ref.EndpointCode)
+				if dependencyDAO.VersionConstraint.Valid {
+					versionConstraints[ref.ServiceCode] = dependencyDAO.VersionConstraint.String
+				}
+			}
+			endpoints[endpointIdx] = Endpoint{
+				ID:                 &endpointDAO.ID,
+				Code:               endpointDAO.Code,
+				Name:               endpointDAO.Name,
+				Dependencies:       dependencies,
+				Confirmed:          endpointDAO.Confirmed,
+				Labels:             r.makeEndpointLabels(endpointDAO.R.ServiceEndpointLabels),
+				Tier:               endpointDAO.Tier.Ptr(),
+				Lifecycle:          makeLifecycle(endpointDAO.Lifecycle, endpointDAO.SunsetDate),
+				Version:            endpointDAO.Version.Ptr(),
+				VersionConstraints: versionConstraints,
+			}
+		}
+		services[idx] = MakeService(
+			&serviceDAO.ID,
+			serviceDAO.Code,
+			serviceDAO.Name,
+			endpoints,
+			r.makeOwnership(serviceDAO),
+		)
+		services[idx].Confirmed = serviceDAO.Confirmed
+		services[idx].Labels = r.makeServiceLabels(serviceDAO.R.ServiceLabels)
+		services[idx].Tier = serviceDAO.Tier.Ptr()
+		services[idx].Lifecycle = makeLifecycle(serviceDAO.Lifecycle, serviceDAO.SunsetDate)
+		services[idx].Version = serviceDAO.Version.Ptr()
+	}
+	return services, nil
+}
+
+func (r *postgresRepository) FindEndpoint(serviceCode Code, endpointCode EndpointCode) (*Endpoint, error) {
+	endpointDAO, err := pgdao.ServiceEndpoints(
+		qm.Load(pgdao.ServiceEndpointRels.ServiceEndpointDependencies),
+		qm.Load(pgdao.ServiceEndpointRels.ServiceEndpointLabels),
+		qm.InnerJoin("service s on s.id = service_endpoint.service_id"),
+		qm.Where("s.environment = ?", r.environment),
+		qm.And("s.code = ?", serviceCode),
+		qm.And("service_endpoint.code = ?", endpointCode),
+	).One(context.Background(), r.executor())
+	if err == sql.ErrNoRows {
+		return nil, nil
+	} else if err != nil {
+		msg := "Failed to find endpoint by service code and code"
+		r.logger.Errorw(msg, "err", err.Error(), "serviceCode", serviceCode, "code", endpointCode)
+		return nil, errors.DatabaseError(msg, &err)
+	}
+	endpoint, err := r.makeEndpoint(r.executor(), endpointDAO)
+	if err != nil {
+		return nil, err
+	}
+	return &endpoint, nil
+}
+
+func (r *postgresRepository) DeleteEndpoint(serviceCode Code, endpointCode EndpointCode, force bool) (RemovedDependencies, error) {
+	tx, err := db.Begin(r.db, r.tx)
+	if err != nil {
+		msg := "Failed to begin transaction when deleting endpoint"
+		r.logger.Errorw(msg, "err", err.Error(), "serviceCode", serviceCode, "code", endpointCode)
+		return RemovedDependencies{}, errors.DatabaseError(msg, &err)
+	}
+	serviceDAO, err := r.findServiceDAOByCode(tx, serviceCode)
+	if err != nil {
+		_ = tx.Rollback()
+		return RemovedDependencies{}, err
+	}
+	endpointDAO, err := pgdao.ServiceEndpoints(
+		qm.Where("service_id = ?", serviceDAO.ID),
+		qm.And("code = ?", endpointCode),
+	).One(context.Background(), tx)
+	if err == sql.ErrNoRows {
+		_ = tx.Rollback()
+		return RemovedDependencies{}, errors.EndpointNotFound(
+			fmt.Sprintf("Endpoint with code %s not found on service %s", endpointCode, serviceCode),
+			nil,
+		)
+	} else if err != nil {
+		msg := "Failed to find endpoint by service id and code"
+		r.logger.Errorw(msg, "err", err.Error(), "serviceId", serviceDAO.ID, "code", endpointCode)
+		_ = tx.Rollback()
+		return RemovedDependencies{}, errors.DatabaseError(msg, &err)
+	}
+	removed, err := r.deleteIncomingDependencies(
+		tx,
+		[]int64{endpointDAO.ID},
+		force,
+		fmt.Sprintf("Endpoint %s of service %s", endpointCode, serviceCode),
+	)
+	if err != nil {
+		_ = tx.Rollback()
+		return RemovedDependencies{}, err
+	}
+	// The endpoint's own dependencies are deleted by cascade
+	_, err = endpointDAO.Delete(context.Background(), tx)
+	if err != nil {
+		msg := "Failed to delete endpoint"
+		r.logger.Errorw(msg, "err", err.Error(), "serviceCode", serviceCode, "code", endpointCode)
+		_ = tx.Rollback()
+		return RemovedDependencies{}, errors.DatabaseError(msg, &err)
+	}
+	err = r.record(tx, changedServiceCodes(serviceCode, nil, removed.Dependencies)...)
+	if err != nil {
+		_ = tx.Rollback()
+		return RemovedDependencies{}, err
+	}
+	err = tx.Commit()
+	if err != nil {
+		msg := "Failed to commit transaction when deleting endpoint"
+		r.logger.Errorw(msg, "err", err.Error(), "serviceCode", serviceCode, "code", endpointCode)
+		return RemovedDependencies{}, errors.DatabaseError(msg, &err)
+	}
+	return removed, nil
+}
+
+// makeEndpoint builds an Endpoint from an endpoint DAO, which must have its ServiceEndpointDependencies loaded, looking
+// up the Endpoints that it depends on with exec
+func (r *postgresRepository) makeEndpoint(
+	exec boil.ContextExecutor,
+	endpointDAO *pgdao.ServiceEndpoint,
+) (Endpoint, error) {
+	dependencies := make(map[Code][]EndpointCode)
+	versionConstraints := make(map[Code]Version)
+	for _, dependencyDAO := range endpointDAO.R.ServiceEndpointDependencies {
+		depEndpointDAO, err := pgdao.ServiceEndpoints(
+			qm.Where("id = ?", dependencyDAO.DependencyServiceEndpointID),
+		).One(context.Background(), exec)
+		if err != nil {
+			msg := "Failed to find service endpoint by id"
+			r.logger.Errorw(msg, "err", err.Error(), "id", dependencyDAO.DependencyServiceEndpointID)
+			return Endpoint{}, errors.DatabaseError(msg, &err)
+		}
+		depServiceDAO, err := pgdao.Services(
+			qm.Where("id = ?", depEndpointDAO.ServiceID),
+		).One(context.Background(), exec)
+		if err != nil {
+			msg := "Failed to find service by id"
+			r.logger.Errorw(msg, "err", err.Error(), "id", depEndpointDAO.ServiceID)
+			return Endpoint{}, errors.DatabaseError(msg, &err)
+		}
+		depEndpoints, ok := dependencies[depServiceDAO.Code]
+		if ok {
+			depEndpoints = append(depEndpoints, depEndpointDAO.Code)
+		} else {
+			depEndpoints = []EndpointCode{depEndpointDAO.Code}
+		}
+		dependencies[depServiceDAO.Code] = depEndpoints
+		if dependencyDAO.VersionConstraint.Valid {
+			versionConstraints[depServiceDAO.Code] = dependencyDAO.VersionConstraint.String
+		}
+	}
+	return Endpoint{
+		ID:                 &endpointDAO.ID,
+		Code:               endpointDAO.Code,
+		Name:               endpointDAO.Name,
+		Dependencies:       dependencies,
+		Confirmed:          endpointDAO.Confirmed,
+		Labels:             r.makeEndpointLabels(endpointDAO.R.ServiceEndpointLabels),
+		Tier:               endpointDAO.Tier.Ptr(),
+		Lifecycle:          makeLifecycle(endpointDAO.Lifecycle, endpointDAO.SunsetDate),
+		Version:            endpointDAO.Version.Ptr(),
+		VersionConstraints: versionConstraints,
+	}, nil
+}
+
+// record has the Recorder record the changes to the Services with the given Codes, within the transaction exec that
+// made them. All of the changes are recorded as made at the same moment
+func (r *postgresRepository) record(exec boil.ContextExecutor, codes ...Code) error {
+	changedAt := time.Now().UTC()
+	recorded := make(map[Code]bool)
+	for _, code := range codes {
+		if recorded[code] {
+			continue
+		}
+		recorded[code] = true
+		current, err := r.findByCode(exec, code)
+		if err != nil {
+			return err
+		}
+		err = r.recorder.Record(exec, r.environment, code, current, r.actor, changedAt)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// validateDependencies checks that every dependency of the Endpoints, which are about to be saved to the Service with
+// the given Code, resolves to an Endpoint, and that no new dependency is on a retired Endpoint. If replace is set, the
+// Endpoints replace all of the Service's existing Endpoints. Otherwise the Service must already exist. If
+// createPlaceholders is set, the Endpoints of other Services that need placeholders are returned
+func (r *postgresRepository) validateDependencies(
+	exec boil.ContextExecutor,
+	serviceCode Code,
+	endpoints []Endpoint,
+	replace bool,
+	createPlaceholders bool,
+) ([]EndpointRef, error) {
+	known, retired, err := r.findEndpointCodes(exec, append(dependencyServiceCodes(endpoints), serviceCode))
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := known[serviceCode]; !ok && !replace {
+		return nil, errors.ServiceNotFound(fmt.Sprintf("Service with code %s not found", serviceCode), nil)
+	}
+	placeholders, err := checkDependencies(serviceCode, endpoints, known, replace, createPlaceholders)
+	if err != nil {
+		return nil, err
+	}
+	if len(retired) == 0 {
+		return placeholders, nil
+	}
+	existing, err := r.findByCode(exec, serviceCode)
+	if err != nil {
+		return nil, err
+	}
+	return placeholders, checkRetiredDependencies(serviceCode, endpoints, existing, retired)
+}
+
+// validateServices checks that every dependency of Services that are being saved together resolves, either to an
+// existing Endpoint or to one of the Services' own, and returns the placeholders that are needed for those that don't,
+// if createPlaceholders is set. If checkRetired is set, it also checks that none of the Services newly depend on a
+// retired Endpoint
+func (r *postgresRepository) validateServices(
+	exec boil.ContextExecutor,
+	services []*Service,
+	createPlaceholders bool,
+	checkRetired bool,
+) ([]EndpointRef, error) {
+	serviceCodes := make([]Code, 0, len(services))
+	for _, s := range services {
+		serviceCodes = append(append(serviceCodes, s.Code), dependencyServiceCodes(s.Endpoints)...)
+	}
+	known, retired, err := r.findEndpointCodes(exec, serviceCodes)
+	if err != nil {
+		return nil, err
+	}
+	addEndpointCodes(known, services)
+	placeholders := make([]EndpointRef, 0)
+	seenPlaceholders := make(map[EndpointRef]bool)
+	for _, s := range services {
+		servicePlaceholders, err := checkDependencies(s.Code, s.Endpoints, known, true, createPlaceholders)
+		if err != nil {
+			return nil, err
+		}
+		for _, ref := range servicePlaceholders {
+			if !seenPlaceholders[ref] {
+				seenPlaceholders[ref] = true
+				placeholders = append(placeholders, ref)
+			}
+		}
+		if !checkRetired || len(retired) == 0 {
+			continue
+		}
+		existing, err := r.findByCode(exec, s.Code)
+		if err != nil {
+			return nil, err
+		}
+		err = checkRetiredDependencies(s.Code, s.Endpoints, existing, retired)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return placeholders, nil
+}
+
+// createPlaceholders creates unconfirmed placeholder Endpoints, along with unconfirmed placeholder Services for them if
+// their Services don't exist either. A placeholder's name is its code, until its owner saves it
+func (r *postgresRepository) createPlaceholders(exec boil.ContextExecutor, refs []EndpointRef) error {
+	serviceIDs := make(map[Code]int64)
+	for _, ref := range refs {
+		serviceID, ok := serviceIDs[ref.ServiceCode]
+		if !ok {
+			serviceDAO, err := pgdao.Services(
+				qm.Where("environment = ?", r.environment),
+				qm.And("code = ?", ref.ServiceCode),
+			).One(context.Background(), exec)
+			if err == sql.ErrNoRows {
+				serviceDAO = &pgdao.Service{
+					Code:        ref.ServiceCode,
+					Name:        ref.ServiceCode,
+					Confirmed:   false,
+					Environment: r.environment,
+				}
+				// Confirmed must be whitelisted, otherwise its zero value is skipped, and the column defaults to true
+				err = serviceDAO.Insert(
+					context.Background(),
+					exec,
+					boil.Whitelist("code", "name", "confirmed", "environment"),
+				)
+				if err != nil {
+					msg := "Failed to insert placeholder service"
+					r.logger.Errorw(msg, "err", err.Error(), "code", ref.ServiceCode)
+					return errors.DatabaseError(msg, &err)
+				}
+			} else if err != nil {
+				msg := "Failed to find service by code"
+				r.logger.Errorw(msg, "err", err.Error(), "code", ref.ServiceCode)
+				return errors.DatabaseError(msg, &err)
+			}
+			serviceID = serviceDAO.ID
+			serviceIDs[ref.ServiceCode] = serviceID
+		}
+		endpointDAO := pgdao.ServiceEndpoint{
+			ServiceID: serviceID,
+			Code:      ref.EndpointCode,
+			Name:      ref.EndpointCode,
+			Confirmed: false,
+		}
+		err := endpointDAO.Insert(context.Background(), exec, boil.Whitelist("service_id", "code", "name", "confirmed"))
+		if err != nil {
+			msg := "Failed to insert placeholder endpoint"
+			r.logger.Errorw(msg, "err", err.Error(), "serviceID", serviceID, "code", ref.EndpointCode)
+			return errors.DatabaseError(msg, &err)
+		}
+	}
+	return nil
+}
+
+// findEndpointCodes maps the Codes of the Services that exist, out of those given, to the Codes of their Endpoints. It
+// also finds which of their Endpoints are retired
+func (r *postgresRepository) findEndpointCodes(
+	exec boil.ContextExecutor,
+	serviceCodes []Code,
+) (map[Code]map[EndpointCode]bool, map[EndpointRef]bool, error) {
+	codes := make([]interface{}, len(serviceCodes))
+	for idx, serviceCode := range serviceCodes {
+		codes[idx] = serviceCode
+	}
+	serviceDAOs, err := pgdao.Services(
+		qm.Load(pgdao.ServiceRels.ServiceEndpoints),
+		qm.Where("environment = ?", r.environment),
+		qm.WhereIn("code in ?", codes...),
+	).All(context.Background(), exec)
+	if err != nil {
+		msg := "Failed to find services by codes"
+		r.logger.Errorw(msg, "err", err.Error(), "codes", serviceCodes)
+		return nil, nil, errors.DatabaseError(msg, &err)
+	}
+	known := make(map[Code]map[EndpointCode]bool)
+	retired := make(map[EndpointRef]bool)
+	for _, serviceDAO := range serviceDAOs {
+		known[serviceDAO.Code] = make(map[EndpointCode]bool)
+		svc := Service{Lifecycle: makeLifecycle(serviceDAO.Lifecycle, serviceDAO.SunsetDate)}
+		for _, endpointDAO := range serviceDAO.R.ServiceEndpoints {
+			known[serviceDAO.Code][endpointDAO.Code] = true
+			endpoint := Endpoint{Lifecycle: makeLifecycle(endpointDAO.Lifecycle, endpointDAO.SunsetDate)}
+			if svc.EndpointLifecycle(endpoint).State == Retired {
+				retired[EndpointRef{ServiceCode: serviceDAO.Code, EndpointCode: endpointDAO.Code}] = true
+			}
+		}
+	}
+	return known, retired, nil
+}
+
+// findServiceDAOByCode finds a service DAO by its code, returning a ServiceNotFound error if it doesn't exist
+func (r *postgresRepository) findServiceDAOByCode(exec boil.ContextExecutor, code Code) (*pgdao.Service, error) {
+	serviceDAO, err := pgdao.Services(
+		qm.Where("environment = ?", r.environment),
+		qm.And("code = ?", code),
+	).One(context.Background(), exec)
+	if err == sql.ErrNoRows {
+		return nil, errors.ServiceNotFound(fmt.Sprintf("Service with code %s not found", code), nil)
+	} else if err != nil {
+		msg := "Failed to find service by code"
+		r.logger.Errorw(msg, "err", err.Error(), "code", code)
+		return nil, errors.DatabaseError(msg, &err)
+	}
+	return serviceDAO, nil
+}
+
 func (r *postgresRepository) findEndpointByServiceIDAndCode(
 	exec boil.ContextExecutor,
 	serviceID int64,
@@ -169,8 +970,20 @@ func (r *postgresRepository) upsertService(exec boil.ContextExecutor, service *p
 		context.Background(),
 		exec,
 		true,
-		[]string{"code"},
-		boil.Whitelist("name"),
+		[]string{"environment", "code"},
+		boil.Whitelist(
+			"name",
+			"confirmed",
+			"team",
+			"on_call",
+			"slack_channel",
+			"email",
+			"repository_url",
+			"tier",
+			"lifecycle",
+			"sunset_date",
+			"version",
+		),
 		boil.Infer(),
 	)
 	if err != nil {
@@ -181,14 +994,119 @@ func (r *postgresRepository) upsertService(exec boil.ContextExecutor, service *p
 	return nil
 }
 
+// makeOwnership builds the Ownership of a Service from its DAO
+func (r *postgresRepository) makeOwnership(serviceDAO *pgdao.Service) Ownership {
+	return Ownership{
+		Team:          serviceDAO.Team.Ptr(),
+		OnCall:        serviceDAO.OnCall.Ptr(),
+		SlackChannel:  serviceDAO.SlackChannel.Ptr(),
+		Email:         serviceDAO.Email.Ptr(),
+		RepositoryURL: serviceDAO.RepositoryURL.Ptr(),
+	}
+}
+
+// setOwnership sets every ownership column of a service DAO, clearing those that the Ownership leaves unset
+func (r *postgresRepository) setOwnership(serviceDAO *pgdao.Service, ownership Ownership) {
+	serviceDAO.Team = null.StringFromPtr(ownership.Team)
+	serviceDAO.OnCall = null.StringFromPtr(ownership.OnCall)
+	serviceDAO.SlackChannel = null.StringFromPtr(ownership.SlackChannel)
+	serviceDAO.Email = null.StringFromPtr(ownership.Email)
+	serviceDAO.RepositoryURL = null.StringFromPtr(ownership.RepositoryURL)
+}
+
+// patchOwnership sets the ownership columns of a service DAO that the Ownership sets, and returns their names
+func (r *postgresRepository) patchOwnership(serviceDAO *pgdao.Service, ownership Ownership) []string {
+	columns := make([]string, 0)
+	if ownership.Team != nil {
+		serviceDAO.Team = null.StringFrom(*ownership.Team)
+		columns = append(columns, "team")
+	}
+	if ownership.OnCall != nil {
+		serviceDAO.OnCall = null.StringFrom(*ownership.OnCall)
+		columns = append(columns, "on_call")
+	}
+	if ownership.SlackChannel != nil {
+		serviceDAO.SlackChannel = null.StringFrom(*ownership.SlackChannel)
+		columns = append(columns, "slack_channel")
+	}
+	if ownership.Email != nil {
+		serviceDAO.Email = null.StringFrom(*ownership.Email)
+		columns = append(columns, "email")
+	}
+	if ownership.RepositoryURL != nil {
+		serviceDAO.RepositoryURL = null.StringFrom(*ownership.RepositoryURL)
+		columns = append(columns, "repository_url")
+	}
+	return columns
+}
+
+// saveServiceLabels fully replaces the Labels of a Service
+func (r *postgresRepository) saveServiceLabels(exec boil.ContextExecutor, serviceID int64, labels Labels) error {
+	_, err := pgdao.ServiceLabels(qm.Where("service_id = ?", serviceID)).DeleteAll(context.Background(), exec)
+	if err != nil {
+		msg := "Failed to delete service labels"
+		r.logger.Errorw(msg, "err", err.Error(), "serviceID", serviceID)
+		return errors.DatabaseError(msg, &err)
+	}
+	for _, key := range labelKeys(labels) {
+		labelDAO := pgdao.ServiceLabel{ServiceID: serviceID, LabelKey: key, LabelValue: labels[key]}
+		err = labelDAO.Insert(context.Background(), exec, boil.Infer())
+		if err != nil {
+			msg := "Failed to insert service label"
+			r.logger.Errorw(msg, "err", err.Error(), "serviceID", serviceID, "key", key)
+			return errors.DatabaseError(msg, &err)
+		}
+	}
+	return nil
+}
+
+// saveEndpointLabels fully replaces the Labels of an Endpoint
+func (r *postgresRepository) saveEndpointLabels(exec boil.ContextExecutor, endpointID int64, labels Labels) error {
+	_, err := pgdao.ServiceEndpointLabels(
+		qm.Where("service_endpoint_id = ?", endpointID),
+	).DeleteAll(context.Background(), exec)
+	if err != nil {
+		msg := "Failed to delete service endpoint labels"
+		r.logger.Errorw(msg, "err", err.Error(), "serviceEndpointID", endpointID)
+		return errors.DatabaseError(msg, &err)
+	}
+	for _, key := range labelKeys(labels) {
+		labelDAO := pgdao.ServiceEndpointLabel{ServiceEndpointID: endpointID, LabelKey: key, LabelValue: labels[key]}
+		err = labelDAO.Insert(context.Background(), exec, boil.Infer())
+		if err != nil {
+			msg := "Failed to insert service endpoint label"
+			r.logger.Errorw(msg, "err", err.Error(), "serviceEndpointID", endpointID, "key", key)
+			return errors.DatabaseError(msg, &err)
+		}
+	}
+	return nil
+}
+
+func (r *postgresRepository) makeServiceLabels(labelDAOs pgdao.ServiceLabelSlice) Labels {
+	labels := make(Labels)
+	for _, labelDAO := range labelDAOs {
+		labels[labelDAO.LabelKey] = labelDAO.LabelValue
+	}
+	return labels
+}
+
+func (r *postgresRepository) makeEndpointLabels(labelDAOs pgdao.ServiceEndpointLabelSlice) Labels {
+	labels := make(Labels)
+	for _, labelDAO := range labelDAOs {
+		labels[labelDAO.LabelKey] = labelDAO.LabelValue
+	}
+	return labels
+}
+
 func (r *postgresRepository) upsertEndpoint(exec boil.ContextExecutor, endpoint *pgdao.ServiceEndpoint) error {
+	// Confirmed must be whitelisted, otherwise its zero value is skipped, and the column defaults to true
 	err := endpoint.Upsert(
 		context.Background(),
 		exec,
 		true,
 		[]string{"service_id", "code"},
-		boil.Whitelist("name"),
-		boil.Infer(),
+		boil.Whitelist("name", "confirmed", "tier", "lifecycle", "sunset_date", "version"),
+		boil.Whitelist("service_id", "code", "name", "confirmed", "tier", "lifecycle", "sunset_date", "version"),
 	)
 	if err != nil {
 		msg := "Failed to upsert endpoint"
@@ -199,7 +1117,7 @@ func (r *postgresRepository) upsertEndpoint(exec boil.ContextExecutor, endpoint
 }
 
 func (r *postgresRepository) upsertDependency(exec boil.ContextExecutor, dependency *pgdao.ServiceEndpointDependency) error {
-	// We have nothing to update, so upsert won't work. Instead we do a get, and maybe insert
+	// Upsert would rewrite every dependency that is saved again unchanged. Instead we do a get, and maybe insert or update
 	previousDependency, err := pgdao.ServiceEndpointDependencies(
 		qm.Where("service_endpoint_id = ?", dependency.ServiceEndpointID),
 		qm.And("dependency_service_endpoint_id = ?", dependency.DependencyServiceEndpointID),
@@ -227,7 +1145,340 @@ func (r *postgresRepository) upsertDependency(exec boil.ContextExecutor, depende
 		)
 		return errors.DatabaseError(msg, &err)
 	}
-	// If found, nothing to do but update the passed in model
+	// If found, update the passed in model, and the version constraint if it changed
 	dependency.ID = previousDependency.ID
+	if previousDependency.VersionConstraint == dependency.VersionConstraint {
+		return nil
+	}
+	_, err = dependency.Update(context.Background(), exec, boil.Whitelist("version_constraint"))
+	if err != nil {
+		msg := "Failed to update service endpoint dependency"
+		r.logger.Errorw(msg,
+			"err", err.Error(),
+			"serviceEndpointID", dependency.ServiceEndpointID,
+			"dependencyServiceEndpointID", dependency.DependencyServiceEndpointID,
+		)
+		return errors.DatabaseError(msg, &err)
+	}
 	return nil
 }
+
+// postgresTraversal traverses the service_endpoint_edge view (dependencies, plus topic producers reaching topic
+// consumers) in a single recursive query, from the endpoints with ids in $1, from the %[1]s column to the %[2]s column.
+// It finds every edge crossed within $2 hops, or if $2 is 0, within as many hops as there are endpoints in the
+// environment $3, which no shortest path is longer than. Rows are distinct, so an edge is only crossed again at a
+// greater distance, through a cycle
+const postgresTraversal = `
+WITH RECURSIVE bound (depth) AS (
+    SELECT COALESCE(NULLIF($2, 0), (
+        SELECT count(*)
+        FROM service_endpoint e
+        INNER JOIN service s ON s.id = e.service_id
+        WHERE s.environment = $3
+    ))
+), crossed (from_id, to_id, distance) AS (
+    SELECT d.%[1]s, d.%[2]s, 1
+    FROM service_endpoint_edge d
+    WHERE d.%[1]s = ANY($1)
+    UNION
+    SELECT d.%[1]s, d.%[2]s, c.distance + 1
+    FROM crossed c
+    INNER JOIN service_endpoint_edge d ON d.%[1]s = c.to_id
+    CROSS JOIN bound b
+    WHERE c.distance < b.depth
+)
+SELECT from_id, to_id, distance FROM crossed`
+
+func (r *postgresRepository) FindDependencies(query DependencyQuery) ([]Dependency, error) {
+	return r.traverse(query, Downstream)
+}
+
+func (r *postgresRepository) FindDependents(query DependencyQuery) ([]Dependency, error) {
+	return r.traverse(query, Upstream)
+}
+
+// traverse walks the dependency graph in the given direction, from the starting point described by the query
+func (r *postgresRepository) traverse(query DependencyQuery, direction Direction) ([]Dependency, error) {
+	startIDs, err := r.findStartingEndpointIDs(query)
+	if err != nil {
+		return nil, err
+	}
+	if len(startIDs) == 0 {
+		return make([]Dependency, 0), nil
+	}
+	fromColumn, toColumn := traversalColumns(direction)
+	var edges []traversalEdge
+	err = queries.Raw(
+		fmt.Sprintf(postgresTraversal, fromColumn, toColumn),
+		pq.Array(startIDs),
+		query.MaxDepth,
+		r.environment,
+	).Bind(context.Background(), r.executor(), &edges)
+	if err != nil {
+		msg := "Failed to traverse endpoint dependencies"
+		r.logger.Errorw(msg, "err", err.Error(), "serviceCode", query.ServiceCode, "direction", direction)
+		return nil, errors.DatabaseError(msg, &err)
+	}
+	endpoints, err := r.findEndpointInfoByIDs(traversalEndpointIDs(startIDs, edges))
+	if err != nil {
+		return nil, err
+	}
+	return makeDependencies(startIDs, shortestPaths(startIDs, edges, endpoints), endpoints), nil
+}
+
+// findStartingEndpointIDs finds the ids of the Endpoints that a DependencyQuery starts from
+func (r *postgresRepository) findStartingEndpointIDs(query DependencyQuery) ([]int64, error) {
+	serviceDAO, err := pgdao.Services(
+		qm.Load(pgdao.ServiceRels.ServiceEndpoints),
+		qm.Where("environment = ?", r.environment),
+		qm.And("code = ?", query.ServiceCode),
+	).One(context.Background(), r.executor())
+	if err == sql.ErrNoRows {
+		return nil, errors.ServiceNotFound(fmt.Sprintf("Service with code %s not found", query.ServiceCode), nil)
+	} else if err != nil {
+		msg := "Failed to find service by code"
+		r.logger.Errorw(msg, "err", err.Error(), "code", query.ServiceCode)
+		return nil, errors.DatabaseError(msg, &err)
+	}
+	startIDs := make([]int64, 0)
+	for _, endpointDAO := range serviceDAO.R.ServiceEndpoints {
+		if query.EndpointCode == nil || *query.EndpointCode == endpointDAO.Code {
+			startIDs = append(startIDs, endpointDAO.ID)
+		}
+	}
+	if query.EndpointCode != nil && len(startIDs) == 0 {
+		return nil, errors.EndpointNotFound(
+			fmt.Sprintf("Endpoint with code %s not found on service %s", *query.EndpointCode, query.ServiceCode),
+			nil,
+		)
+	}
+	return startIDs, nil
+}
+
+func (r *postgresRepository) findEndpointInfoByIDs(ids []interface{}) (map[int64]endpointInfo, error) {
+	endpoints := make(map[int64]endpointInfo)
+	if len(ids) == 0 {
+		return endpoints, nil
+	}
+	endpointDAOs, err := pgdao.ServiceEndpoints(
+		qm.Load(pgdao.ServiceEndpointRels.Service),
+		qm.WhereIn("id in ?", ids...),
+	).All(context.Background(), r.executor())
+	if err != nil {
+		msg := "Failed to find endpoints by ids"
+		r.logger.Errorw(msg, "err", err.Error(), "ids", ids)
+		return nil, errors.DatabaseError(msg, &err)
+	}
+	for _, endpointDAO := range endpointDAOs {
+		endpoints[endpointDAO.ID] = endpointInfo{
+			Ref:         EndpointRef{ServiceCode: endpointDAO.R.Service.Code, EndpointCode: endpointDAO.Code},
+			Name:        endpointDAO.Name,
+			ServiceTeam: endpointDAO.R.Service.Team.Ptr(),
+		}
+	}
+	return endpoints, nil
+}
+
+func (r *postgresRepository) List(query ListQuery) (SummaryPage, error) {
+	serviceDAOs, err := pgdao.Services(
+		append(
+			listQueryMods(query, "ILIKE"),
+			qm.Where("environment = ?", r.environment),
+			qm.Load(pgdao.ServiceRels.ServiceLabels),
+		)...,
+	).All(context.Background(), r.executor())
+	if err != nil {
+		msg := "Failed to list services"
+		r.logger.Errorw(msg, "err", err.Error())
+		return SummaryPage{}, errors.DatabaseError(msg, &err)
+	}
+	summaries := make([]Summary, len(serviceDAOs))
+	if len(serviceDAOs) == 0 {
+		return makeSummaryPage(query, summaries), nil
+	}
+	serviceIDs := make([]interface{}, len(serviceDAOs))
+	for idx, serviceDAO := range serviceDAOs {
+		serviceIDs[idx] = serviceDAO.ID
+	}
+	var counts []endpointCount
+	err = pgdao.ServiceEndpoints(
+		qm.Select("service_id", "count(*) as endpoint_count"),
+		qm.WhereIn("service_id in ?", serviceIDs...),
+		qm.GroupBy("service_id"),
+	).Bind(context.Background(), r.executor(), &counts)
+	if err != nil {
+		msg := "Failed to count endpoints by service ids"
+		r.logger.Errorw(msg, "err", err.Error(), "serviceIds", serviceIDs)
+		return SummaryPage{}, errors.DatabaseError(msg, &err)
+	}
+	countsByServiceID := make(map[int64]int)
+	for _, count := range counts {
+		countsByServiceID[count.ServiceID] = count.Count
+	}
+	unconfirmedEndpointDAOs, err := pgdao.ServiceEndpoints(
+		qm.WhereIn("service_id in ?", serviceIDs...),
+		qm.And("confirmed = ?", false),
+		qm.OrderBy("code"),
+	).All(context.Background(), r.executor())
+	if err != nil {
+		msg := "Failed to find unconfirmed endpoints by service ids"
+		r.logger.Errorw(msg, "err", err.Error(), "serviceIds", serviceIDs)
+		return SummaryPage{}, errors.DatabaseError(msg, &err)
+	}
+	unconfirmedByServiceID := make(map[int64][]EndpointCode)
+	for _, endpointDAO := range unconfirmedEndpointDAOs {
+		serviceID := endpointDAO.ServiceID
+		unconfirmedByServiceID[serviceID] = append(unconfirmedByServiceID[serviceID], endpointDAO.Code)
+	}
+	for idx, serviceDAO := range serviceDAOs {
+		unconfirmedEndpoints, ok := unconfirmedByServiceID[serviceDAO.ID]
+		if !ok {
+			unconfirmedEndpoints = make([]EndpointCode, 0)
+		}
+		summaries[idx] = Summary{
+			ID:                   serviceDAO.ID,
+			Code:                 serviceDAO.Code,
+			Name:                 serviceDAO.Name,
+			EndpointCount:        countsByServiceID[serviceDAO.ID],
+			Confirmed:            serviceDAO.Confirmed,
+			UnconfirmedEndpoints: unconfirmedEndpoints,
+			Team:                 serviceDAO.Team.Ptr(),
+			Labels:               r.makeServiceLabels(serviceDAO.R.ServiceLabels),
+		}
+	}
+	return makeSummaryPage(query, summaries), nil
+}
+
+func (r *postgresRepository) Delete(code Code, force bool) (RemovedDependencies, error) {
+	tx, err := db.Begin(r.db, r.tx)
+	if err != nil {
+		msg := "Failed to begin transaction when deleting service"
+		r.logger.Errorw(msg, "err", err.Error(), "serviceCode", code)
+		return RemovedDependencies{}, errors.DatabaseError(msg, &err)
+	}
+	serviceDAO, err := pgdao.Services(
+		qm.Load(pgdao.ServiceRels.ServiceEndpoints),
+		qm.Where("environment = ?", r.environment),
+		qm.And("code = ?", code),
+	).One(context.Background(), tx)
+	if err == sql.ErrNoRows {
+		_ = tx.Rollback()
+		return RemovedDependencies{}, errors.ServiceNotFound(fmt.Sprintf("Service with code %s not found", code), nil)
+	} else if err != nil {
+		msg := "Failed to find service by code"
+		r.logger.Errorw(msg, "err", err.Error(), "code", code)
+		_ = tx.Rollback()
+		return RemovedDependencies{}, errors.DatabaseError(msg, &err)
+	}
+	endpointIDs := make([]int64, len(serviceDAO.R.ServiceEndpoints))
+	for idx, endpointDAO := range serviceDAO.R.ServiceEndpoints {
+		endpointIDs[idx] = endpointDAO.ID
+	}
+	removed, err := r.deleteIncomingDependencies(tx, endpointIDs, force, fmt.Sprintf("Service %s", code))
+	if err != nil {
+		_ = tx.Rollback()
+		return RemovedDependencies{}, err
+	}
+	// Endpoints, and their own dependencies, are deleted by cascade
+	_, err = serviceDAO.Delete(context.Background(), tx)
+	if err != nil {
+		msg := "Failed to delete service"
+		r.logger.Errorw(msg, "err", err.Error(), "code", code)
+		_ = tx.Rollback()
+		return RemovedDependencies{}, errors.DatabaseError(msg, &err)
+	}
+	err = r.record(tx, changedServiceCodes(code, nil, removed.Dependencies)...)
+	if err != nil {
+		_ = tx.Rollback()
+		return RemovedDependencies{}, err
+	}
+	err = tx.Commit()
+	if err != nil {
+		msg := "Failed to commit transaction when deleting service"
+		r.logger.Errorw(msg, "err", err.Error(), "code", code)
+		return RemovedDependencies{}, errors.DatabaseError(msg, &err)
+	}
+	return removed, nil
+}
+
+// deleteIncomingDependencies deletes every dependency on the Endpoints with the given ids, so that those Endpoints can
+// be deleted. Dependencies from other Endpoints (outside of the given ids) and from Clients are only deleted if force is
+// set, otherwise an error describing them is returned. The deleted dependencies from other Endpoints and Clients are
+// returned
+func (r *postgresRepository) deleteIncomingDependencies(
+	exec boil.ContextExecutor,
+	endpointIDs []int64,
+	force bool,
+	subject string,
+) (RemovedDependencies, error) {
+	removed := RemovedDependencies{
+		Dependencies:       make([]DependencyEdge, 0),
+		ClientDependencies: make([]ClientDependencyEdge, 0),
+	}
+	if len(endpointIDs) == 0 {
+		return removed, nil
+	}
+	deleted := make(map[int64]bool)
+	ids := make([]interface{}, len(endpointIDs))
+	for idx, id := range endpointIDs {
+		deleted[id] = true
+		ids[idx] = id
+	}
+	dependencyDAOs, err := pgdao.ServiceEndpointDependencies(
+		qm.Load(qm.Rels(pgdao.ServiceEndpointDependencyRels.ServiceEndpoint, pgdao.ServiceEndpointRels.Service)),
+		qm.Load(qm.Rels(pgdao.ServiceEndpointDependencyRels.DependencyServiceEndpoint, pgdao.ServiceEndpointRels.Service)),
+		qm.WhereIn("dependency_service_endpoint_id in ?", ids...),
+	).All(context.Background(), exec)
+	if err != nil {
+		msg := "Failed to find dependencies on endpoints"
+		r.logger.Errorw(msg, "err", err.Error(), "ids", endpointIDs)
+		return RemovedDependencies{}, errors.DatabaseError(msg, &err)
+	}
+	for _, dependencyDAO := range dependencyDAOs {
+		if deleted[dependencyDAO.ServiceEndpointID] {
+			continue
+		}
+		from, to := dependencyDAO.R.ServiceEndpoint, dependencyDAO.R.DependencyServiceEndpoint
+		removed.Dependencies = append(removed.Dependencies, DependencyEdge{
+			From: EndpointRef{ServiceCode: from.R.Service.Code, EndpointCode: from.Code},
+			To:   EndpointRef{ServiceCode: to.R.Service.Code, EndpointCode: to.Code},
+		})
+	}
+	clientDependencyDAOs, err := pgdao.ClientDependencies(
+		qm.Load(pgdao.ClientDependencyRels.Client),
+		qm.Load(qm.Rels(pgdao.ClientDependencyRels.DependencyServiceEndpoint, pgdao.ServiceEndpointRels.Service)),
+		qm.WhereIn("dependency_service_endpoint_id in ?", ids...),
+	).All(context.Background(), exec)
+	if err != nil {
+		msg := "Failed to find client dependencies on endpoints"
+		r.logger.Errorw(msg, "err", err.Error(), "ids", endpointIDs)
+		return RemovedDependencies{}, errors.DatabaseError(msg, &err)
+	}
+	for _, clientDependencyDAO := range clientDependencyDAOs {
+		to := clientDependencyDAO.R.DependencyServiceEndpoint
+		removed.ClientDependencies = append(removed.ClientDependencies, ClientDependencyEdge{
+			ClientCode: clientDependencyDAO.R.Client.Code,
+			To:         EndpointRef{ServiceCode: to.R.Service.Code, EndpointCode: to.Code},
+		})
+	}
+	if (len(removed.Dependencies) > 0 || len(removed.ClientDependencies) > 0) && !force {
+		return RemovedDependencies{}, dependentsError(subject, removed)
+	}
+	_, err = pgdao.ServiceEndpointDependencies(
+		qm.WhereIn("dependency_service_endpoint_id in ?", ids...),
+	).DeleteAll(context.Background(), exec)
+	if err != nil {
+		msg := "Failed to delete dependencies on endpoints"
+		r.logger.Errorw(msg, "err", err.Error(), "ids", endpointIDs)
+		return RemovedDependencies{}, errors.DatabaseError(msg, &err)
+	}
+	_, err = clientDependencyDAOs.DeleteAll(context.Background(), exec)
+	if err != nil {
+		msg := "Failed to delete client dependencies on endpoints"
+		r.logger.Errorw(msg, "err", err.Error(), "ids", endpointIDs)
+		return RemovedDependencies{}, errors.DatabaseError(msg, &err)
+	}
+	sortDependencyEdges(removed.Dependencies)
+	sortClientDependencyEdges(removed.ClientDependencies)
+	return removed, nil
+}
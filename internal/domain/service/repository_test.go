@@ -0,0 +1,166 @@
+package service
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+func TestShortestPaths(t *testing.T) {
+	// ladder is a graph of n rungs, where each rung's two endpoints both lead to both endpoints of the next rung, so
+	// there are 2^n paths from the first rung to the last
+	ladder := func(n int) map[int64][]int64 {
+		adjacent := make(map[int64][]int64)
+		for rung := int64(0); rung < int64(n); rung++ {
+			next := []int64{2*rung + 2, 2*rung + 3}
+			adjacent[2*rung], adjacent[2*rung+1] = next, next
+		}
+		return adjacent
+	}
+	tests := []struct {
+		name      string
+		adjacent  map[int64][]int64
+		endpoints map[int64]endpointInfo
+		startIDs  []int64
+		maxDepth  int
+		want      []traversalStep
+	}{
+		{
+			name:     "no edges",
+			adjacent: map[int64][]int64{},
+			startIDs: []int64{1},
+			want:     []traversalStep{},
+		},
+		{
+			name:     "keeps the smallest of the shortest paths",
+			adjacent: map[int64][]int64{1: {3, 2}, 2: {4}, 3: {4}, 4: {5}},
+			startIDs: []int64{1},
+			want: []traversalStep{
+				{EndpointID: 2, Distance: 1, Path: []int64{1, 2}},
+				{EndpointID: 3, Distance: 1, Path: []int64{1, 3}},
+				{EndpointID: 4, Distance: 2, Path: []int64{1, 2, 4}},
+				{EndpointID: 5, Distance: 3, Path: []int64{1, 2, 4, 5}},
+			},
+		},
+		{
+			name:     "compares paths by the codes of their endpoints, not their ids",
+			adjacent: map[int64][]int64{1: {2, 3}, 2: {4}, 3: {4}},
+			endpoints: map[int64]endpointInfo{
+				1: {Ref: EndpointRef{ServiceCode: "a", EndpointCode: "start"}},
+				2: {Ref: EndpointRef{ServiceCode: "c", EndpointCode: "via"}},
+				3: {Ref: EndpointRef{ServiceCode: "b", EndpointCode: "via"}},
+				4: {Ref: EndpointRef{ServiceCode: "d", EndpointCode: "end"}},
+			},
+			startIDs: []int64{1},
+			want: []traversalStep{
+				{EndpointID: 3, Distance: 1, Path: []int64{1, 3}},
+				{EndpointID: 2, Distance: 1, Path: []int64{1, 2}},
+				{EndpointID: 4, Distance: 2, Path: []int64{1, 3, 4}},
+			},
+		},
+		{
+			name:     "compares endpoint codes when service codes are the same",
+			adjacent: map[int64][]int64{1: {2, 3}, 2: {4}, 3: {4}},
+			endpoints: map[int64]endpointInfo{
+				1: {Ref: EndpointRef{ServiceCode: "a", EndpointCode: "start"}},
+				2: {Ref: EndpointRef{ServiceCode: "b", EndpointCode: "y"}},
+				3: {Ref: EndpointRef{ServiceCode: "b", EndpointCode: "x"}},
+				4: {Ref: EndpointRef{ServiceCode: "c", EndpointCode: "end"}},
+			},
+			startIDs: []int64{1},
+			want: []traversalStep{
+				{EndpointID: 3, Distance: 1, Path: []int64{1, 3}},
+				{EndpointID: 2, Distance: 1, Path: []int64{1, 2}},
+				{EndpointID: 4, Distance: 2, Path: []int64{1, 3, 4}},
+			},
+		},
+		{
+			name:     "doesn't follow cycles, or revisit starting endpoints",
+			adjacent: map[int64][]int64{1: {2}, 2: {3}, 3: {1, 2}},
+			startIDs: []int64{1},
+			want: []traversalStep{
+				{EndpointID: 2, Distance: 1, Path: []int64{1, 2}},
+				{EndpointID: 3, Distance: 2, Path: []int64{1, 2, 3}},
+			},
+		},
+		{
+			name:     "starts from every starting endpoint at once",
+			adjacent: map[int64][]int64{1: {3}, 2: {3, 4}, 4: {1}},
+			startIDs: []int64{2, 1},
+			want: []traversalStep{
+				{EndpointID: 3, Distance: 1, Path: []int64{1, 3}},
+				{EndpointID: 4, Distance: 1, Path: []int64{2, 4}},
+			},
+		},
+		{
+			name:     "stops at the max depth",
+			adjacent: map[int64][]int64{1: {2}, 2: {3}, 3: {4}},
+			startIDs: []int64{1},
+			maxDepth: 2,
+			want: []traversalStep{
+				{EndpointID: 2, Distance: 1, Path: []int64{1, 2}},
+				{EndpointID: 3, Distance: 2, Path: []int64{1, 2, 3}},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			endpoints := tt.endpoints
+			if endpoints == nil {
+				endpoints = endpointsOf(tt.adjacent)
+			}
+			got := shortestPaths(tt.startIDs, crossedEdges(tt.adjacent, tt.startIDs, tt.maxDepth), endpoints)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("shortestPaths() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	t.Run("finds each endpoint once, however many paths there are", func(t *testing.T) {
+		adjacent := ladder(40)
+		steps := shortestPaths([]int64{0}, crossedEdges(adjacent, []int64{0}, 0), endpointsOf(adjacent))
+		if len(steps) != 80 {
+			t.Errorf("shortestPaths() found %d endpoints, want 80", len(steps))
+		}
+	})
+}
+
+// crossedEdges finds the edges that the recursive traversal query would cross, from an adjacency list. Like the query,
+// if maxDepth is 0 it goes as many hops as there are endpoints
+func crossedEdges(adjacent map[int64][]int64, startIDs []int64, maxDepth int) []traversalEdge {
+	if maxDepth == 0 {
+		maxDepth = len(endpointsOf(adjacent))
+	}
+	edges := make([]traversalEdge, 0)
+	frontier := make(map[int64]bool)
+	for _, id := range startIDs {
+		frontier[id] = true
+	}
+	for distance := 1; distance <= maxDepth && len(frontier) > 0; distance++ {
+		next := make(map[int64]bool)
+		for from := range frontier {
+			for _, to := range adjacent[from] {
+				edges = append(edges, traversalEdge{FromID: from, ToID: to, Distance: distance})
+				next[to] = true
+			}
+		}
+		frontier = next
+	}
+	return edges
+}
+
+// endpointsOf makes up an endpoint for every id in an adjacency list, with codes that order them like their ids
+func endpointsOf(adjacent map[int64][]int64) map[int64]endpointInfo {
+	endpoints := make(map[int64]endpointInfo)
+	add := func(id int64) {
+		code := fmt.Sprintf("%04d", id)
+		endpoints[id] = endpointInfo{Ref: EndpointRef{ServiceCode: "service-" + code, EndpointCode: "endpoint-" + code}}
+	}
+	for from, tos := range adjacent {
+		add(from)
+		for _, to := range tos {
+			add(to)
+		}
+	}
+	return endpoints
+}
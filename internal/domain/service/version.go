@@ -0,0 +1,227 @@
+package service
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/yashap/crius/internal/errors"
+)
+
+// Version is a semantic version, like "2.3.1", or a range of them, like "^2", "~1.4", ">=1.4 <3" or "1.x || 2.x". A
+// Service or Endpoint has a Version to say which version(s) of its API it serves, and a dependency has one to pin the
+// versions that it can call. Pre-release and build metadata aren't supported
+type Version = string
+
+// VersionRange is a parsed Version. It is the set of semantic versions that the Version covers, as a union of
+// intervals
+type VersionRange []versionInterval
+
+// semanticVersion is a MAJOR.MINOR.PATCH version
+type semanticVersion [3]int
+
+// versionInterval is the half-open interval of semantic versions [lower, upper). Versions are integers, so every
+// comparator can be expressed as one, e.g. "<=1.2.3" is "<1.2.4". If unbounded is set, it has no upper bound
+type versionInterval struct {
+	lower     semanticVersion
+	upper     semanticVersion
+	unbounded bool
+}
+
+var (
+	versionComparator = regexp.MustCompile(
+		`^(\^|~|>=|>|<=|<|=)?v?(\d+|[xX*])(?:\.(\d+|[xX*]))?(?:\.(\d+|[xX*]))?$`,
+	)
+	versionOperatorSpacing = regexp.MustCompile(`(\^|~|>=|>|<=|<|=)\s+`)
+)
+
+// ParseVersion parses a Version, returning an InvalidInput error if it isn't a valid semantic version or range
+func ParseVersion(raw Version) (VersionRange, error) {
+	versionRange := make(VersionRange, 0)
+	for _, alternative := range strings.Split(raw, "||") {
+		interval := versionInterval{unbounded: true}
+		// Comparators are separated by whitespace or commas, and may have whitespace after their operators
+		alternative = versionOperatorSpacing.ReplaceAllString(strings.ReplaceAll(alternative, ",", " "), "$1")
+		comparators := strings.Fields(alternative)
+		if len(comparators) == 0 {
+			return nil, invalidVersion(raw)
+		}
+		for _, comparator := range comparators {
+			parsed, ok := parseVersionComparator(comparator)
+			if !ok {
+				return nil, invalidVersion(raw)
+			}
+			interval = interval.intersect(parsed)
+		}
+		if !interval.empty() {
+			versionRange = append(versionRange, interval)
+		}
+	}
+	if len(versionRange) == 0 {
+		return nil, errors.InvalidInput(fmt.Sprintf("version %s doesn't match any versions", raw), nil)
+	}
+	return versionRange, nil
+}
+
+// ValidateVersion checks that a Version is a valid semantic version or range, returning an InvalidInput error if not
+func ValidateVersion(raw Version) error {
+	_, err := ParseVersion(raw)
+	return err
+}
+
+// Overlaps returns whether some semantic version is in both VersionRanges
+func (r VersionRange) Overlaps(other VersionRange) bool {
+	for _, interval := range r {
+		for _, otherInterval := range other {
+			if !interval.intersect(otherInterval).empty() {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// EndpointVersion is the Version of one of the Service's Endpoints, which is the Endpoint's own Version if set, and
+// otherwise the Service's Version. It is nil if neither is set
+func (s Service) EndpointVersion(endpoint Endpoint) *Version {
+	if endpoint.Version != nil {
+		return endpoint.Version
+	}
+	return s.Version
+}
+
+// VersionConstraint is the Version that the Endpoint's dependencies on the Service with the given Code are pinned to.
+// It is nil if they aren't pinned
+func (e Endpoint) VersionConstraint(code Code) *Version {
+	constraint, ok := e.VersionConstraints[code]
+	if !ok {
+		return nil
+	}
+	return &constraint
+}
+
+// SplitVersionConstraint splits a dependency key like "payments@^2" into the Code of the Service depended on, and the
+// Version that the dependency is pinned to. Keys without an "@", like "payments", aren't pinned, so have a nil Version
+func SplitVersionConstraint(key string) (Code, *Version) {
+	idx := strings.Index(key, "@")
+	if idx < 0 {
+		return key, nil
+	}
+	constraint := key[idx+1:]
+	return key[:idx], &constraint
+}
+
+// JoinVersionConstraint is the inverse of SplitVersionConstraint
+func JoinVersionConstraint(code Code, constraint *Version) string {
+	if constraint == nil {
+		return code
+	}
+	return code + "@" + *constraint
+}
+
+// parseVersionComparator parses a single comparator, like "^2.1" or "<3", into the interval of versions it covers
+func parseVersionComparator(comparator string) (versionInterval, bool) {
+	match := versionComparator.FindStringSubmatch(comparator)
+	if match == nil {
+		return versionInterval{}, false
+	}
+	operator, parts := match[1], match[2:]
+	// precision is how many parts of the version are set, before the first wildcard or missing part
+	var version semanticVersion
+	precision := 0
+	for precision < len(parts) && isVersionNumber(parts[precision]) {
+		number, err := strconv.Atoi(parts[precision])
+		if err != nil {
+			return versionInterval{}, false
+		}
+		version[precision] = number
+		precision++
+	}
+	for _, part := range parts[precision:] {
+		if isVersionNumber(part) {
+			// A number after a wildcard, like "1.x.3"
+			return versionInterval{}, false
+		}
+	}
+	if precision == 0 {
+		// A wildcard, like "*" or ">=x", matches every version, unless it is exclusive
+		if operator == ">" || operator == "<" {
+			return versionInterval{}, true
+		}
+		return versionInterval{unbounded: true}, true
+	}
+	// next is the first version after every version that matches the set parts, like 3.0.0 for "2" or 2.2.0 for "2.1"
+	next := version.bump(precision - 1)
+	switch operator {
+	case "", "=":
+		return versionInterval{lower: version, upper: next}, true
+	case "^":
+		// Changes that don't modify the leftmost non-zero part are allowed
+		idx := 0
+		for idx < precision-1 && version[idx] == 0 {
+			idx++
+		}
+		return versionInterval{lower: version, upper: version.bump(idx)}, true
+	case "~":
+		// Patch changes are allowed, or minor changes if only the major version is set
+		idx := 1
+		if precision == 1 {
+			idx = 0
+		}
+		return versionInterval{lower: version, upper: version.bump(idx)}, true
+	case ">=":
+		return versionInterval{lower: version, unbounded: true}, true
+	case ">":
+		return versionInterval{lower: next, unbounded: true}, true
+	case "<":
+		return versionInterval{upper: version}, true
+	default: // "<="
+		return versionInterval{upper: next}, true
+	}
+}
+
+// bump returns the version with the part at idx incremented, and all parts after it zeroed
+func (v semanticVersion) bump(idx int) semanticVersion {
+	var bumped semanticVersion
+	copy(bumped[:idx], v[:idx])
+	bumped[idx] = v[idx] + 1
+	return bumped
+}
+
+func (v semanticVersion) less(other semanticVersion) bool {
+	for idx := range v {
+		if v[idx] != other[idx] {
+			return v[idx] < other[idx]
+		}
+	}
+	return false
+}
+
+func (i versionInterval) intersect(other versionInterval) versionInterval {
+	intersection := i
+	if intersection.lower.less(other.lower) {
+		intersection.lower = other.lower
+	}
+	if intersection.unbounded || (!other.unbounded && other.upper.less(intersection.upper)) {
+		intersection.upper = other.upper
+		intersection.unbounded = other.unbounded
+	}
+	return intersection
+}
+
+func (i versionInterval) empty() bool {
+	return !i.unbounded && !i.lower.less(i.upper)
+}
+
+// isVersionNumber returns whether a part of a version is a number, rather than a wildcard or missing
+func isVersionNumber(part string) bool {
+	return part != "" && part != "x" && part != "X" && part != "*"
+}
+
+func invalidVersion(raw Version) error {
+	return errors.InvalidInput(
+		fmt.Sprintf("version %s must be a semantic version like 2.3.1, or a range like ^2, ~1.4 or >=1.4 <3", raw),
+		nil,
+	)
+}
@@ -0,0 +1,157 @@
+package service
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/yashap/crius/internal/errors"
+)
+
+func TestParseVersion(t *testing.T) {
+	v := func(major, minor, patch int) semanticVersion {
+		return semanticVersion{major, minor, patch}
+	}
+	between := func(lower, upper semanticVersion) versionInterval {
+		return versionInterval{lower: lower, upper: upper}
+	}
+	atLeast := func(lower semanticVersion) versionInterval {
+		return versionInterval{lower: lower, unbounded: true}
+	}
+	tests := []struct {
+		name string
+		raw  Version
+		want VersionRange
+	}{
+		{name: "an exact version", raw: "2.3.1", want: VersionRange{between(v(2, 3, 1), v(2, 3, 2))}},
+		{name: "a v prefix", raw: "v2.3", want: VersionRange{between(v(2, 3, 0), v(2, 4, 0))}},
+		{name: "a major version", raw: "2", want: VersionRange{between(v(2, 0, 0), v(3, 0, 0))}},
+		{name: "a wildcard minor", raw: "2.x", want: VersionRange{between(v(2, 0, 0), v(3, 0, 0))}},
+		{name: "any version", raw: "*", want: VersionRange{atLeast(v(0, 0, 0))}},
+		{name: "a caret", raw: "^2.1", want: VersionRange{between(v(2, 1, 0), v(3, 0, 0))}},
+		{name: "a caret below 1", raw: "^0.2.3", want: VersionRange{between(v(0, 2, 3), v(0, 3, 0))}},
+		{name: "a caret below 0.1", raw: "^0.0.3", want: VersionRange{between(v(0, 0, 3), v(0, 0, 4))}},
+		{name: "a tilde", raw: "~1.4", want: VersionRange{between(v(1, 4, 0), v(1, 5, 0))}},
+		{name: "a tilde on a major version", raw: "~1", want: VersionRange{between(v(1, 0, 0), v(2, 0, 0))}},
+		{name: "a greater than", raw: ">1.2", want: VersionRange{atLeast(v(1, 3, 0))}},
+		{name: "a less than or equal", raw: "<=1.2.3", want: VersionRange{between(v(0, 0, 0), v(1, 2, 4))}},
+		{name: "a range", raw: ">=1.4 <3", want: VersionRange{between(v(1, 4, 0), v(3, 0, 0))}},
+		{
+			name: "a range with commas and spaced operators",
+			raw:  ">= 1.4, < 3",
+			want: VersionRange{between(v(1, 4, 0), v(3, 0, 0))},
+		},
+		{
+			name: "alternatives",
+			raw:  "1.x || 2.x",
+			want: VersionRange{
+				between(v(1, 0, 0), v(2, 0, 0)),
+				between(v(2, 0, 0), v(3, 0, 0)),
+			},
+		},
+		{
+			name: "alternatives, one of which matches nothing",
+			raw:  "<1 || >=2 <1",
+			want: VersionRange{between(v(0, 0, 0), v(1, 0, 0))},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseVersion(tt.raw)
+			if err != nil {
+				t.Fatalf("ParseVersion(%q) error = %v", tt.raw, err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseVersion(%q) = %v, want %v", tt.raw, got, tt.want)
+			}
+			if err := ValidateVersion(tt.raw); err != nil {
+				t.Errorf("ValidateVersion(%q) = %v, want nil", tt.raw, err)
+			}
+		})
+	}
+
+	invalid := []struct {
+		name string
+		raw  Version
+	}{
+		{name: "an empty version", raw: ""},
+		{name: "a name", raw: "latest"},
+		{name: "a number after a wildcard", raw: "1.x.3"},
+		{name: "a pre-release", raw: "1.2.3-beta"},
+		{name: "too many parts", raw: "1.2.3.4"},
+		{name: "an empty alternative", raw: "1 ||"},
+		{name: "a range that matches nothing", raw: ">=3 <2"},
+		{name: "an exclusive wildcard", raw: ">*"},
+	}
+	for _, tt := range invalid {
+		t.Run("rejects "+tt.name, func(t *testing.T) {
+			_, err := ParseVersion(tt.raw)
+			if e, ok := err.(*errors.Error); !ok || e.StatusCode != 400 {
+				t.Errorf("ParseVersion(%q) error = %v, want an InvalidInput error", tt.raw, err)
+			}
+			if ValidateVersion(tt.raw) == nil {
+				t.Errorf("ValidateVersion(%q) = nil, want an error", tt.raw)
+			}
+		})
+	}
+}
+
+func TestVersionRangeOverlaps(t *testing.T) {
+	tests := []struct {
+		a    Version
+		b    Version
+		want bool
+	}{
+		{a: "^2", b: "2.3.1", want: true},
+		{a: "^2", b: "3.0.0", want: false},
+		{a: "<2", b: ">=2", want: false},
+		{a: "<=2", b: ">=2", want: true},
+		{a: "1.x || 3.x", b: "~3.1", want: true},
+		{a: "1.x || 3.x", b: "2", want: false},
+		{a: "~1.4", b: "1.5", want: false},
+		{a: "*", b: "0.0.1", want: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.a+" and "+tt.b, func(t *testing.T) {
+			a, err := ParseVersion(tt.a)
+			if err != nil {
+				t.Fatalf("ParseVersion(%q) error = %v", tt.a, err)
+			}
+			b, err := ParseVersion(tt.b)
+			if err != nil {
+				t.Fatalf("ParseVersion(%q) error = %v", tt.b, err)
+			}
+			if got := a.Overlaps(b); got != tt.want {
+				t.Errorf("%q.Overlaps(%q) = %v, want %v", tt.a, tt.b, got, tt.want)
+			}
+			if got := b.Overlaps(a); got != tt.want {
+				t.Errorf("%q.Overlaps(%q) = %v, want %v", tt.b, tt.a, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSplitVersionConstraint(t *testing.T) {
+	pinned := func(constraint Version) *Version { return &constraint }
+	tests := []struct {
+		key            string
+		wantCode       Code
+		wantConstraint *Version
+	}{
+		{key: "payments", wantCode: "payments", wantConstraint: nil},
+		{key: "payments@^2", wantCode: "payments", wantConstraint: pinned("^2")},
+		{key: "payments@>=1.4 <3", wantCode: "payments", wantConstraint: pinned(">=1.4 <3")},
+		{key: "payments@", wantCode: "payments", wantConstraint: pinned("")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.key, func(t *testing.T) {
+			code, constraint := SplitVersionConstraint(tt.key)
+			if code != tt.wantCode || !reflect.DeepEqual(constraint, tt.wantConstraint) {
+				t.Errorf("SplitVersionConstraint(%q) = %q, %v, want %q, %v",
+					tt.key, code, constraint, tt.wantCode, tt.wantConstraint)
+			}
+			if joined := JoinVersionConstraint(code, constraint); joined != tt.key {
+				t.Errorf("JoinVersionConstraint(%q, %v) = %q, want %q", code, constraint, joined, tt.key)
+			}
+		})
+	}
+}
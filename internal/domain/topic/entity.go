@@ -0,0 +1,41 @@
+package topic
+
+import "github.com/yashap/crius/internal/domain/service"
+
+// Code is a code that uniquely identifies a Topic
+type Code = string
+
+// Name is the human-readable/friendly name of a Topic
+type Name = string
+
+// Topic represents a message topic (or event stream). Service Endpoints produce messages to it, and consume messages
+// from it, so an Endpoint that produces to a Topic reaches the Topic's consumers when walking the dependency graph
+type Topic struct {
+	// ID uniquely identifies this topic
+	ID *int64
+	// Code is a unique code for the topic. For example, "location_updated" for location update events
+	Code Code
+	// Name is a friendly name for the topic. For example, "Location Updated" for location update events
+	Name Name
+	// Producers are the Endpoints that produce messages to the Topic
+	Producers []service.EndpointRef
+	// Consumers are the Endpoints that consume messages from the Topic
+	Consumers []service.EndpointRef
+}
+
+// MakeTopic constructs a Topic
+func MakeTopic(
+	id *int64,
+	code Code,
+	name Name,
+	producers []service.EndpointRef,
+	consumers []service.EndpointRef,
+) Topic {
+	return Topic{
+		ID:        id,
+		Code:      code,
+		Name:      name,
+		Producers: producers,
+		Consumers: consumers,
+	}
+}
@@ -0,0 +1,139 @@
+package topic
+
+import (
+	"database/sql"
+	"fmt"
+	"github.com/jmoiron/sqlx"
+	"github.com/xo/dburl"
+	"github.com/yashap/crius/internal/domain/service"
+	"github.com/yashap/crius/internal/errors"
+	"go.uber.org/zap"
+	"log"
+	"sort"
+)
+
+// Repository is a Topic repository. Like service.Repository, the mental model is that it represents a collection of
+// Topic instances, and every change that it makes to Topics is recorded by its Recorder, in the same transaction
+type Repository interface {
+	// InEnvironment returns a Repository of the Topics in the given Environment. A new Repository holds the Topics in
+	// the service.DefaultEnvironment
+	InEnvironment(env service.Environment) Repository
+	// InTransaction returns a Repository that makes its changes in the transaction tx, which is already open, so that
+	// they are committed or rolled back along with the rest of it
+	InTransaction(tx *sql.Tx) Repository
+	// AsActor returns a Repository that records its changes to Topics as made by the given Actor. A new Repository
+	// records them as made by the service.AnonymousActor
+	AsActor(actor service.Actor) Repository
+	// Save saves a Topic, fully replacing any previous version of it, including its producers and consumers
+	Save(t *Topic) error
+	// FindByCode finds a Topic by its Code
+	FindByCode(code Code) (*Topic, error)
+	// FindAll finds every Topic, sorted by Code
+	FindAll() ([]Topic, error)
+	// Delete deletes a Topic by its Code, along with its links to its producers and consumers
+	Delete(code Code) error
+	// FindEnvironments finds every Environment that has at least one Topic, sorted
+	FindEnvironments() ([]service.Environment, error)
+}
+
+func NewRepository(
+	dbURL *dburl.URL,
+	db *sqlx.DB,
+	logger *zap.SugaredLogger,
+	recorder Recorder,
+) Repository {
+	if dbURL.Driver == "postgres" {
+		return &postgresRepository{
+			db:          db,
+			logger:      logger,
+			environment: service.DefaultEnvironment,
+			recorder:    recorder,
+			actor:       service.AnonymousActor,
+		}
+	} else if dbURL.Driver == "mysql" {
+		return &mysqlRepository{
+			db:          db,
+			logger:      logger,
+			environment: service.DefaultEnvironment,
+			recorder:    recorder,
+			actor:       service.AnonymousActor,
+		}
+	}
+	log.Fatalf("Unsupported database: %s", dbURL.Driver)
+	return nil
+}
+
+// endpointServiceCodes returns the Codes of every Service that the Endpoints belong to
+func endpointServiceCodes(refs []service.EndpointRef) []interface{} {
+	seen := make(map[service.Code]bool)
+	codes := make([]interface{}, 0)
+	for _, ref := range refs {
+		if !seen[ref.ServiceCode] {
+			seen[ref.ServiceCode] = true
+			codes = append(codes, ref.ServiceCode)
+		}
+	}
+	return codes
+}
+
+// resolveEndpointIDs maps the producers and consumers of the Topic to the ids of their Endpoints. known maps the Codes
+// of existing Services to the Codes and ids of their Endpoints. Every producer and consumer that doesn't resolve is
+// listed in the returned error
+func resolveEndpointIDs(
+	t *Topic,
+	known map[service.Code]map[service.EndpointCode]int64,
+) ([]int64, []int64, error) {
+	details := make([]errors.Detail, 0)
+	resolve := func(role string, refs []service.EndpointRef) []int64 {
+		ids := make([]int64, 0, len(refs))
+		seen := make(map[int64]bool)
+		for _, ref := range refs {
+			endpointIDs, serviceExists := known[ref.ServiceCode]
+			id, endpointExists := endpointIDs[ref.EndpointCode]
+			if endpointExists {
+				if !seen[id] {
+					seen[id] = true
+					ids = append(ids, id)
+				}
+				continue
+			}
+			detail := errors.Detail{
+				"role":          role,
+				"service_code":  ref.ServiceCode,
+				"endpoint_code": ref.EndpointCode,
+			}
+			if !serviceExists {
+				detail["sub_code"] = errors.ServiceNotFoundSubCode.String()
+				detail["message"] = fmt.Sprintf("Service with code %s not found", ref.ServiceCode)
+			} else {
+				detail["sub_code"] = errors.EndpointNotFoundSubCode.String()
+				detail["message"] = fmt.Sprintf(
+					"Endpoint with code %s not found on service %s",
+					ref.EndpointCode,
+					ref.ServiceCode,
+				)
+			}
+			details = append(details, detail)
+		}
+		return ids
+	}
+	producerIDs := resolve("producer", t.Producers)
+	consumerIDs := resolve("consumer", t.Consumers)
+	if len(details) > 0 {
+		return nil, nil, errors.UnresolvedDependencies(
+			fmt.Sprintf("%d producers and consumers of topic %s don't exist", len(details), t.Code),
+			details,
+		)
+	}
+	return producerIDs, consumerIDs, nil
+}
+
+// sortEndpointRefs sorts refs by the Code of their Service, and then by their own Code
+func sortEndpointRefs(refs []service.EndpointRef) {
+	sort.Slice(refs, func(i, j int) bool {
+		if refs[i].ServiceCode != refs[j].ServiceCode {
+			return refs[i].ServiceCode < refs[j].ServiceCode
+		}
+		return refs[i].EndpointCode < refs[j].EndpointCode
+	})
+}
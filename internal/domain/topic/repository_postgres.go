@@ -0,0 +1,322 @@
+package topic
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"github.com/jmoiron/sqlx"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+	"github.com/yashap/crius/internal/db"
+	pgdao "github.com/yashap/crius/internal/db/postgresql/dao"
+	"github.com/yashap/crius/internal/domain/service"
+	"github.com/yashap/crius/internal/errors"
+	"go.uber.org/zap"
+	"time"
+)
+
+type postgresRepository struct {
+	db          *sqlx.DB
+	tx          *sql.Tx
+	logger      *zap.SugaredLogger
+	environment service.Environment
+	recorder    Recorder
+	actor       service.Actor
+}
+
+func (r *postgresRepository) InEnvironment(env service.Environment) Repository {
+	return &postgresRepository{
+		db:          r.db,
+		tx:          r.tx,
+		logger:      r.logger,
+		environment: env,
+		recorder:    r.recorder,
+		actor:       r.actor,
+	}
+}
+
+func (r *postgresRepository) InTransaction(tx *sql.Tx) Repository {
+	return &postgresRepository{
+		db:          r.db,
+		tx:          tx,
+		logger:      r.logger,
+		environment: r.environment,
+		recorder:    r.recorder,
+		actor:       r.actor,
+	}
+}
+
+func (r *postgresRepository) AsActor(actor service.Actor) Repository {
+	return &postgresRepository{
+		db:          r.db,
+		tx:          r.tx,
+		logger:      r.logger,
+		environment: r.environment,
+		recorder:    r.recorder,
+		actor:       actor,
+	}
+}
+
+// executor is what the Repository runs its queries with: the transaction that it joined, if it joined one, and
+// otherwise the database
+func (r *postgresRepository) executor() boil.ContextExecutor {
+	return db.Executor(r.db, r.tx)
+}
+
+func (r *postgresRepository) Save(t *Topic) error {
+	refs := append(append(make([]service.EndpointRef, 0), t.Producers...), t.Consumers...)
+	known, err := r.findEndpointIDs(refs)
+	if err != nil {
+		return err
+	}
+	producerIDs, consumerIDs, err := resolveEndpointIDs(t, known)
+	if err != nil {
+		return err
+	}
+	tx, err := db.Begin(r.db, r.tx)
+	if err != nil {
+		msg := "Failed to begin transaction when saving topic"
+		r.logger.Errorw(msg, "err", err.Error(), "topicCode", t.Code)
+		return errors.DatabaseError(msg, &err)
+	}
+	topicDAO := pgdao.Topic{Code: t.Code, Name: t.Name, Environment: r.environment}
+	err = r.upsertTopic(tx, &topicDAO)
+	if err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+	t.ID = &topicDAO.ID
+	// We want to fully replace the topic, so its producers and consumers are deleted, and then recreated
+	_, err = pgdao.TopicProducers(qm.Where("topic_id = ?", topicDAO.ID)).DeleteAll(context.Background(), tx)
+	if err != nil {
+		msg := "Failed to delete topic producers"
+		r.logger.Errorw(msg, "err", err.Error(), "topicID", topicDAO.ID)
+		_ = tx.Rollback()
+		return errors.DatabaseError(msg, &err)
+	}
+	_, err = pgdao.TopicConsumers(qm.Where("topic_id = ?", topicDAO.ID)).DeleteAll(context.Background(), tx)
+	if err != nil {
+		msg := "Failed to delete topic consumers"
+		r.logger.Errorw(msg, "err", err.Error(), "topicID", topicDAO.ID)
+		_ = tx.Rollback()
+		return errors.DatabaseError(msg, &err)
+	}
+	for _, endpointID := range producerIDs {
+		producerDAO := pgdao.TopicProducer{TopicID: topicDAO.ID, ServiceEndpointID: endpointID}
+		err = producerDAO.Insert(context.Background(), tx, boil.Infer())
+		if err != nil {
+			msg := "Failed to insert topic producer"
+			r.logger.Errorw(msg, "err", err.Error(), "topicID", topicDAO.ID, "serviceEndpointID", endpointID)
+			_ = tx.Rollback()
+			return errors.DatabaseError(msg, &err)
+		}
+	}
+	for _, endpointID := range consumerIDs {
+		consumerDAO := pgdao.TopicConsumer{TopicID: topicDAO.ID, ServiceEndpointID: endpointID}
+		err = consumerDAO.Insert(context.Background(), tx, boil.Infer())
+		if err != nil {
+			msg := "Failed to insert topic consumer"
+			r.logger.Errorw(msg, "err", err.Error(), "topicID", topicDAO.ID, "serviceEndpointID", endpointID)
+			_ = tx.Rollback()
+			return errors.DatabaseError(msg, &err)
+		}
+	}
+	err = r.record(tx, t.Code)
+	if err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+	err = tx.Commit()
+	if err != nil {
+		msg := "Failed to commit transaction when saving topic"
+		r.logger.Errorw(msg, "err", err.Error(), "topicCode", t.Code)
+		return errors.DatabaseError(msg, &err)
+	}
+	return nil
+}
+
+func (r *postgresRepository) FindByCode(code Code) (*Topic, error) {
+	return r.findByCode(r.executor(), code)
+}
+
+func (r *postgresRepository) FindAll() ([]Topic, error) {
+	topicDAOs, err := pgdao.Topics(
+		append(r.loadEndpointMods(), qm.Where("environment = ?", r.environment), qm.OrderBy("code"))...,
+	).All(context.Background(), r.executor())
+	if err != nil {
+		msg := "Failed to find all topics"
+		r.logger.Errorw(msg, "err", err.Error())
+		return nil, errors.DatabaseError(msg, &err)
+	}
+	topics := make([]Topic, len(topicDAOs))
+	for idx, topicDAO := range topicDAOs {
+		topics[idx] = r.makeTopic(topicDAO)
+	}
+	return topics, nil
+}
+
+func (r *postgresRepository) Delete(code Code) error {
+	tx, err := db.Begin(r.db, r.tx)
+	if err != nil {
+		msg := "Failed to begin transaction when deleting topic"
+		r.logger.Errorw(msg, "err", err.Error(), "code", code)
+		return errors.DatabaseError(msg, &err)
+	}
+	topicDAO, err := pgdao.Topics(
+		qm.Where("environment = ?", r.environment),
+		qm.And("code = ?", code),
+	).One(context.Background(), tx)
+	if err == sql.ErrNoRows {
+		_ = tx.Rollback()
+		return errors.TopicNotFound(fmt.Sprintf("Topic with code %s not found", code), nil)
+	} else if err != nil {
+		msg := "Failed to find topic by code"
+		r.logger.Errorw(msg, "err", err.Error(), "code", code)
+		_ = tx.Rollback()
+		return errors.DatabaseError(msg, &err)
+	}
+	// Producers and consumers are deleted by cascade
+	_, err = topicDAO.Delete(context.Background(), tx)
+	if err != nil {
+		msg := "Failed to delete topic"
+		r.logger.Errorw(msg, "err", err.Error(), "code", code)
+		_ = tx.Rollback()
+		return errors.DatabaseError(msg, &err)
+	}
+	err = r.record(tx, code)
+	if err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+	err = tx.Commit()
+	if err != nil {
+		msg := "Failed to commit transaction when deleting topic"
+		r.logger.Errorw(msg, "err", err.Error(), "code", code)
+		return errors.DatabaseError(msg, &err)
+	}
+	return nil
+}
+
+func (r *postgresRepository) FindEnvironments() ([]service.Environment, error) {
+	topicDAOs, err := pgdao.Topics(
+		qm.Select("distinct environment"),
+		qm.OrderBy("environment"),
+	).All(context.Background(), r.executor())
+	if err != nil {
+		msg := "Failed to find the environments of topics"
+		r.logger.Errorw(msg, "err", err.Error())
+		return nil, errors.DatabaseError(msg, &err)
+	}
+	envs := make([]service.Environment, len(topicDAOs))
+	for idx, topicDAO := range topicDAOs {
+		envs[idx] = topicDAO.Environment
+	}
+	return envs, nil
+}
+
+// findByCode finds a Topic by its Code with exec, or nil if there is none
+func (r *postgresRepository) findByCode(exec boil.ContextExecutor, code Code) (*Topic, error) {
+	topicDAO, err := pgdao.Topics(
+		append(r.loadEndpointMods(), qm.Where("environment = ?", r.environment), qm.And("code = ?", code))...,
+	).One(
+		context.Background(),
+		exec,
+	)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	} else if err != nil {
+		msg := "Failed to find topic by code"
+		r.logger.Errorw(msg, "err", err.Error(), "code", code)
+		return nil, errors.DatabaseError(msg, &err)
+	}
+	topic := r.makeTopic(topicDAO)
+	return &topic, nil
+}
+
+// record records the Topic with the Code, as it is in the transaction exec, with the Repository's Recorder
+func (r *postgresRepository) record(exec boil.ContextExecutor, code Code) error {
+	current, err := r.findByCode(exec, code)
+	if err != nil {
+		return err
+	}
+	return r.recorder.RecordTopic(exec, r.environment, code, current, r.actor, time.Now().UTC())
+}
+
+// loadEndpointMods loads a topic DAO's producers and consumers, along with their endpoints and services
+func (r *postgresRepository) loadEndpointMods() []qm.QueryMod {
+	return []qm.QueryMod{
+		qm.Load(qm.Rels(
+			pgdao.TopicRels.TopicProducers,
+			pgdao.TopicProducerRels.ServiceEndpoint,
+			pgdao.ServiceEndpointRels.Service,
+		)),
+		qm.Load(qm.Rels(
+			pgdao.TopicRels.TopicConsumers,
+			pgdao.TopicConsumerRels.ServiceEndpoint,
+			pgdao.ServiceEndpointRels.Service,
+		)),
+	}
+}
+
+// makeTopic builds a Topic from a topic DAO, which must have been loaded with loadEndpointMods
+func (r *postgresRepository) makeTopic(topicDAO *pgdao.Topic) Topic {
+	producers := make([]service.EndpointRef, len(topicDAO.R.TopicProducers))
+	for idx, producerDAO := range topicDAO.R.TopicProducers {
+		endpointDAO := producerDAO.R.ServiceEndpoint
+		producers[idx] = service.EndpointRef{ServiceCode: endpointDAO.R.Service.Code, EndpointCode: endpointDAO.Code}
+	}
+	consumers := make([]service.EndpointRef, len(topicDAO.R.TopicConsumers))
+	for idx, consumerDAO := range topicDAO.R.TopicConsumers {
+		endpointDAO := consumerDAO.R.ServiceEndpoint
+		consumers[idx] = service.EndpointRef{ServiceCode: endpointDAO.R.Service.Code, EndpointCode: endpointDAO.Code}
+	}
+	sortEndpointRefs(producers)
+	sortEndpointRefs(consumers)
+	return MakeTopic(&topicDAO.ID, topicDAO.Code, topicDAO.Name, producers, consumers)
+}
+
+// findEndpointIDs maps the Codes of the Services that exist, out of those that the Endpoints belong to, to the Codes
+// and ids of their Endpoints
+func (r *postgresRepository) findEndpointIDs(
+	refs []service.EndpointRef,
+) (map[service.Code]map[service.EndpointCode]int64, error) {
+	known := make(map[service.Code]map[service.EndpointCode]int64)
+	serviceCodes := endpointServiceCodes(refs)
+	if len(serviceCodes) == 0 {
+		return known, nil
+	}
+	serviceDAOs, err := pgdao.Services(
+		qm.Load(pgdao.ServiceRels.ServiceEndpoints),
+		qm.Where("environment = ?", r.environment),
+		qm.WhereIn("code in ?", serviceCodes...),
+	).All(context.Background(), r.executor())
+	if err != nil {
+		msg := "Failed to find services by codes"
+		r.logger.Errorw(msg, "err", err.Error(), "codes", serviceCodes)
+		return nil, errors.DatabaseError(msg, &err)
+	}
+	for _, serviceDAO := range serviceDAOs {
+		known[serviceDAO.Code] = make(map[service.EndpointCode]int64)
+		for _, endpointDAO := range serviceDAO.R.ServiceEndpoints {
+			known[serviceDAO.Code][endpointDAO.Code] = endpointDAO.ID
+		}
+	}
+	return known, nil
+}
+
+func (r *postgresRepository) upsertTopic(exec boil.ContextExecutor, topic *pgdao.Topic) error {
+	err := topic.Upsert(
+		context.Background(),
+		exec,
+		true,
+		[]string{"environment", "code"},
+		boil.Whitelist("name"),
+		boil.Infer(),
+	)
+	if err != nil {
+		msg := "Failed to upsert topic"
+		r.logger.Errorw(msg, "err", err.Error(), "topicCode", topic.Code)
+		return errors.DatabaseError(msg, &err)
+	}
+	return nil
+}
@@ -0,0 +1,121 @@
+package dto
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/yashap/crius/internal/domain/client"
+	"github.com/yashap/crius/internal/domain/service"
+	"github.com/yashap/crius/internal/errors"
+)
+
+// ClientCode is a code that uniquely identifies a Client
+type ClientCode = string
+
+// ClientName is the human-readable/friendly name of a Client
+type ClientName = string
+
+// Client represents a frontend or client application, like a web or mobile app, which calls service endpoints but has
+// none of its own
+type Client struct {
+	// Code is a unique code for the client. For example, "ios_v5" for version 5 of an iOS app
+	Code *ClientCode `json:"code"`
+	// Name is a friendly name for the client. For example, "iOS App v5" for version 5 of an iOS app
+	Name *ClientName `json:"name"`
+	// Dependencies is a map of the endpoints that the client calls. Keys are service codes, values are lists of endpoint
+	// codes
+	Dependencies *map[ServiceCode][]EndpointCode `json:"dependencies"`
+}
+
+// ClientDependent is a client that depends on the starting point of a search of the dependency graph
+type ClientDependent struct {
+	// ClientCode is the code of the dependent client
+	ClientCode ClientCode `json:"client_code"`
+	// ClientName is the friendly name of the dependent client
+	ClientName ClientName `json:"client_name"`
+	// Distance is the number of dependency hops from the client to the starting point. Clients that call a starting
+	// endpoint directly have a distance of 1
+	Distance int `json:"distance"`
+	// Path is the chain of endpoints from the starting endpoint to the one that the client calls
+	Path []EndpointRef `json:"path"`
+}
+
+// MakeClientFromRequest constructs a Client DTO from an HTTP request
+func MakeClientFromRequest(c *gin.Context) (Client, error) {
+	var cl Client
+	err := c.ShouldBindJSON(&cl)
+	if err != nil {
+		return cl, errors.InvalidInput("failed to unmarshall json to Client", &err)
+	}
+	err = cl.validate()
+	return cl, err
+}
+
+// ToEntity converts a Client DTO into a Client Entity
+func (cl *Client) ToEntity() client.Client {
+	dependencies := make(map[ServiceCode][]EndpointCode)
+	if cl.Dependencies != nil {
+		dependencies = *cl.Dependencies
+	}
+	return client.MakeClient(nil, *cl.Code, *cl.Name, dependencies)
+}
+
+// MakeClientFromEntity constructs a Client DTO from a Client Entity
+func MakeClientFromEntity(cl client.Client) Client {
+	return Client{
+		Code:         &cl.Code,
+		Name:         &cl.Name,
+		Dependencies: &cl.Dependencies,
+	}
+}
+
+// MakeClientsFromEntities constructs Client DTOs from Client Entities
+func MakeClientsFromEntities(clients []client.Client) []Client {
+	clientDTOs := make([]Client, len(clients))
+	for idx, cl := range clients {
+		clientDTOs[idx] = MakeClientFromEntity(cl)
+	}
+	return clientDTOs
+}
+
+// ClientDependencyEdge is a single dependency of a client on an endpoint
+type ClientDependencyEdge struct {
+	// ClientCode is the code of the client that has the dependency
+	ClientCode ClientCode `json:"client_code"`
+	// To is the endpoint that is depended on
+	To EndpointRef `json:"to"`
+}
+
+// MakeClientDependentsFromEntities constructs ClientDependent DTOs from Dependent Entities
+func MakeClientDependentsFromEntities(dependents []client.Dependent) []ClientDependent {
+	dependentDTOs := make([]ClientDependent, len(dependents))
+	for idx, dependent := range dependents {
+		dependentDTOs[idx] = ClientDependent{
+			ClientCode: dependent.ClientCode,
+			ClientName: dependent.ClientName,
+			Distance:   dependent.Distance,
+			Path:       makeEndpointRefsFromEntities(dependent.Path),
+		}
+	}
+	return dependentDTOs
+}
+
+// MakeClientDependencyEdgesFromEntities constructs ClientDependencyEdge DTOs from ClientDependencyEdge Entities
+func MakeClientDependencyEdgesFromEntities(edges []service.ClientDependencyEdge) []ClientDependencyEdge {
+	edgeDTOs := make([]ClientDependencyEdge, len(edges))
+	for idx, edge := range edges {
+		edgeDTOs[idx] = ClientDependencyEdge{
+			ClientCode: edge.ClientCode,
+			To:         MakeEndpointRefFromEntity(edge.To),
+		}
+	}
+	return edgeDTOs
+}
+
+func (cl Client) validate() error {
+	if cl.Code == nil {
+		return errors.InvalidInput("field 'code' on object Client is required", nil)
+	}
+	if cl.Name == nil {
+		return errors.InvalidInput("field 'name' on object Client is required", nil)
+	}
+	return nil
+}
@@ -0,0 +1,155 @@
+package dto
+
+import (
+	"sort"
+
+	"github.com/yashap/crius/internal/domain/client"
+	"github.com/yashap/crius/internal/domain/service"
+)
+
+// EndpointRef identifies an Endpoint by the code of its Service, and its own code
+type EndpointRef struct {
+	// ServiceCode is the code of the Service that the Endpoint belongs to
+	ServiceCode ServiceCode `json:"service_code"`
+	// EndpointCode is the code of the Endpoint
+	EndpointCode EndpointCode `json:"endpoint_code"`
+}
+
+// DependencyEdge is a single dependency of one Endpoint on another
+type DependencyEdge struct {
+	// From is the Endpoint that has the dependency
+	From EndpointRef `json:"from"`
+	// To is the Endpoint that is depended on
+	To EndpointRef `json:"to"`
+}
+
+// DependencyDiff describes how a set of dependencies changed
+type DependencyDiff struct {
+	// Added are the dependencies that are new
+	Added []DependencyEdge `json:"added"`
+	// Removed are the dependencies that no longer exist
+	Removed []DependencyEdge `json:"removed"`
+}
+
+// Dependency is an Endpoint that was reached while searching the dependency graph
+type Dependency struct {
+	// ServiceCode is the code of the Service that the reached Endpoint belongs to
+	ServiceCode ServiceCode `json:"service_code"`
+	// EndpointCode is the code of the reached Endpoint
+	EndpointCode EndpointCode `json:"endpoint_code"`
+	// EndpointName is the friendly name of the reached Endpoint
+	EndpointName EndpointName `json:"endpoint_name"`
+	// Distance is the number of dependency hops it took to reach the Endpoint. Direct dependencies have a distance of 1
+	Distance int `json:"distance"`
+	// Path is the chain of Endpoints that reached this Endpoint, from the starting Endpoint to this one
+	Path []EndpointRef `json:"path"`
+}
+
+// ServiceDependents is every Endpoint of a single Service that depends on the starting point of a search
+type ServiceDependents struct {
+	// ServiceCode is the code of the dependent Service
+	ServiceCode ServiceCode `json:"service_code"`
+	// Team is the team that owns the dependent Service, if known, so that they can be notified of changes
+	Team *Team `json:"team"`
+	// Endpoints are the dependent Endpoints of the Service
+	Endpoints []Dependency `json:"endpoints"`
+}
+
+// DependentsSummary summarizes the "blast radius" of a change to the starting point of a search
+type DependentsSummary struct {
+	// AffectedServices is the number of distinct Services with at least one dependent Endpoint
+	AffectedServices int `json:"affected_services"`
+	// AffectedEndpoints is the number of dependent Endpoints
+	AffectedEndpoints int `json:"affected_endpoints"`
+	// AffectedClients is the number of dependent Clients
+	AffectedClients int `json:"affected_clients"`
+}
+
+// Dependents is every Endpoint that depends on the starting point of a search, grouped by Service, along with every
+// Client that depends on it
+type Dependents struct {
+	// Dependents are the dependent Endpoints, grouped by Service, and ordered by Service code
+	Dependents []ServiceDependents `json:"dependents"`
+	// Clients are the dependent Clients, ordered by distance, and then by Client code
+	Clients []ClientDependent `json:"clients"`
+	// Summary summarizes the dependents
+	Summary DependentsSummary `json:"summary"`
+}
+
+// MakeEndpointRefFromEntity constructs an EndpointRef DTO from an EndpointRef Entity
+func MakeEndpointRefFromEntity(ref service.EndpointRef) EndpointRef {
+	return EndpointRef{
+		ServiceCode:  ref.ServiceCode,
+		EndpointCode: ref.EndpointCode,
+	}
+}
+
+// MakeDependencyEdgesFromEntities constructs DependencyEdge DTOs from DependencyEdge Entities
+func MakeDependencyEdgesFromEntities(edges []service.DependencyEdge) []DependencyEdge {
+	edgeDTOs := make([]DependencyEdge, len(edges))
+	for idx, edge := range edges {
+		edgeDTOs[idx] = DependencyEdge{
+			From: MakeEndpointRefFromEntity(edge.From),
+			To:   MakeEndpointRefFromEntity(edge.To),
+		}
+	}
+	return edgeDTOs
+}
+
+// MakeDependencyDiffFromEntity constructs a DependencyDiff DTO from a DependencyDiff Entity
+func MakeDependencyDiffFromEntity(diff service.DependencyDiff) DependencyDiff {
+	return DependencyDiff{
+		Added:   MakeDependencyEdgesFromEntities(diff.Added),
+		Removed: MakeDependencyEdgesFromEntities(diff.Removed),
+	}
+}
+
+// MakeDependenciesFromEntities constructs Dependency DTOs from Dependency Entities
+func MakeDependenciesFromEntities(dependencies []service.Dependency) []Dependency {
+	dependencyDTOs := make([]Dependency, len(dependencies))
+	for idx, dependency := range dependencies {
+		path := make([]EndpointRef, len(dependency.Path))
+		for pathIdx, ref := range dependency.Path {
+			path[pathIdx] = MakeEndpointRefFromEntity(ref)
+		}
+		dependencyDTOs[idx] = Dependency{
+			ServiceCode:  dependency.Endpoint.ServiceCode,
+			EndpointCode: dependency.Endpoint.EndpointCode,
+			EndpointName: dependency.EndpointName,
+			Distance:     dependency.Distance,
+			Path:         path,
+		}
+	}
+	return dependencyDTOs
+}
+
+// MakeDependentsFromEntities constructs a Dependents DTO from Dependency Entities found by walking the graph upstream,
+// and the Client Dependents found along the way
+func MakeDependentsFromEntities(dependents []service.Dependency, clientDependents []client.Dependent) Dependents {
+	byService := make(map[ServiceCode][]Dependency)
+	teams := make(map[ServiceCode]*Team)
+	for idx, dependent := range MakeDependenciesFromEntities(dependents) {
+		byService[dependent.ServiceCode] = append(byService[dependent.ServiceCode], dependent)
+		teams[dependent.ServiceCode] = dependents[idx].ServiceTeam
+	}
+	serviceDependents := make([]ServiceDependents, 0, len(byService))
+	for serviceCode, endpoints := range byService {
+		serviceDependents = append(serviceDependents, ServiceDependents{
+			ServiceCode: serviceCode,
+			Team:        teams[serviceCode],
+			Endpoints:   endpoints,
+		})
+	}
+	sort.Slice(serviceDependents, func(i, j int) bool {
+		return serviceDependents[i].ServiceCode < serviceDependents[j].ServiceCode
+	})
+	return Dependents{
+		Dependents: serviceDependents,
+		Clients:    MakeClientDependentsFromEntities(clientDependents),
+		Summary: DependentsSummary{
+			AffectedServices:  len(serviceDependents),
+			AffectedEndpoints: len(dependents),
+			AffectedClients:   len(clientDependents),
+		},
+	}
+}
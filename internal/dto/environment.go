@@ -0,0 +1,19 @@
+package dto
+
+// Promotion describes how promoting the declared graph of one environment into another changed the target environment
+type Promotion struct {
+	// Services are the codes of the services that were saved to, and deleted from, the target environment
+	Services PromotedCodes `json:"services"`
+	// Topics are the codes of the topics that were saved to, and deleted from, the target environment
+	Topics PromotedCodes `json:"topics"`
+	// Clients are the codes of the clients that were saved to, and deleted from, the target environment
+	Clients PromotedCodes `json:"clients"`
+}
+
+// PromotedCodes are the codes of the things that a promotion saved and deleted
+type PromotedCodes struct {
+	// Saved are the codes of the things that were copied from the source environment
+	Saved []string `json:"saved"`
+	// Deleted are the codes of the things that were deleted because they aren't in the source environment
+	Deleted []string `json:"deleted"`
+}
@@ -0,0 +1,203 @@
+package dto
+
+import (
+	"github.com/yashap/crius/internal/domain/client"
+	"github.com/yashap/crius/internal/domain/graph"
+	"github.com/yashap/crius/internal/domain/service"
+	"github.com/yashap/crius/internal/errors"
+)
+
+// Cycles are the dependency cycles in the dependency graph. Each cycle is an ordered path that starts and ends with the
+// same node, where each node depends on the next one
+type Cycles struct {
+	// EndpointCycles are cycles between Endpoints
+	EndpointCycles [][]EndpointRef `json:"endpoint_cycles"`
+	// ServiceCycles are cycles between Services, ignoring dependencies between Endpoints of the same Service
+	ServiceCycles [][]ServiceCode `json:"service_cycles"`
+}
+
+// MakeCyclesFromEntity constructs a Cycles DTO from a Cycles Entity
+func MakeCyclesFromEntity(cycles graph.Cycles) Cycles {
+	endpointCycles := make([][]EndpointRef, len(cycles.Endpoints))
+	for idx, cycle := range cycles.Endpoints {
+		endpointCycles[idx] = make([]EndpointRef, len(cycle))
+		for refIdx, ref := range cycle {
+			endpointCycles[idx][refIdx] = MakeEndpointRefFromEntity(ref)
+		}
+	}
+	return Cycles{
+		EndpointCycles: endpointCycles,
+		ServiceCycles:  cycles.Services,
+	}
+}
+
+// MakeCycleDetails describes each of the cycles as an errors.Detail
+func MakeCycleDetails(cycles graph.Cycles) []errors.Detail {
+	cyclesDTO := MakeCyclesFromEntity(cycles)
+	details := make([]errors.Detail, 0, len(cyclesDTO.ServiceCycles)+len(cyclesDTO.EndpointCycles))
+	for _, cycle := range cyclesDTO.ServiceCycles {
+		details = append(details, errors.Detail{"granularity": "service", "path": cycle})
+	}
+	for _, cycle := range cyclesDTO.EndpointCycles {
+		details = append(details, errors.Detail{"granularity": "endpoint", "path": cycle})
+	}
+	return details
+}
+
+// Violations are the dependencies of more critical endpoints on less critical ones, direct or transitive
+type Violations struct {
+	// Violations are sorted by the endpoint they are from, and then by the endpoint they are to
+	Violations []Violation `json:"violations"`
+}
+
+// Violation is a dependency of a more critical endpoint on a less critical one, i.e. one with a higher tier
+type Violation struct {
+	// From is the more critical endpoint, which has the dependency
+	From EndpointRef `json:"from"`
+	// FromTier is the tier of From
+	FromTier Tier `json:"from_tier"`
+	// To is the less critical endpoint, which is depended on
+	To EndpointRef `json:"to"`
+	// ToTier is the tier of To
+	ToTier Tier `json:"to_tier"`
+	// Direct is whether From depends on To directly, rather than through other endpoints
+	Direct bool `json:"direct"`
+	// Path is the shortest path of dependencies from From to To, starting with From and ending with To
+	Path []EndpointRef `json:"path"`
+}
+
+// MakeViolationsFromEntities constructs a Violations DTO from Violation Entities
+func MakeViolationsFromEntities(violations []graph.Violation) Violations {
+	violationDTOs := make([]Violation, len(violations))
+	for idx, violation := range violations {
+		path := make([]EndpointRef, len(violation.Path))
+		for refIdx, ref := range violation.Path {
+			path[refIdx] = MakeEndpointRefFromEntity(ref)
+		}
+		violationDTOs[idx] = Violation{
+			From:     MakeEndpointRefFromEntity(violation.From),
+			FromTier: violation.FromTier,
+			To:       MakeEndpointRefFromEntity(violation.To),
+			ToTier:   violation.ToTier,
+			Direct:   violation.Direct(),
+			Path:     path,
+		}
+	}
+	return Violations{Violations: violationDTOs}
+}
+
+// StalePins are the dependencies that are pinned to versions of the endpoints they depend on that no longer exist
+type StalePins struct {
+	// StalePins are sorted by the endpoint they are from, and then by the endpoint they are to
+	StalePins []StalePin `json:"stale_pins"`
+}
+
+// StalePin is a dependency that is pinned to versions of the endpoint it depends on that no longer exist
+type StalePin struct {
+	// From is the endpoint that has the dependency
+	From EndpointRef `json:"from"`
+	// To is the endpoint that is depended on
+	To EndpointRef `json:"to"`
+	// Constraint is the version, or range of versions, that From's dependencies on To's service are pinned to
+	Constraint Version `json:"constraint"`
+	// Version is To's version, which may be inherited from its service, and doesn't match Constraint
+	Version Version `json:"version"`
+}
+
+// MakeStalePinsFromEntities constructs a StalePins DTO from StalePin Entities
+func MakeStalePinsFromEntities(stalePins []graph.StalePin) StalePins {
+	stalePinDTOs := make([]StalePin, len(stalePins))
+	for idx, stalePin := range stalePins {
+		stalePinDTOs[idx] = StalePin{
+			From:       MakeEndpointRefFromEntity(stalePin.From),
+			To:         MakeEndpointRefFromEntity(stalePin.To),
+			Constraint: stalePin.Constraint,
+			Version:    stalePin.Version,
+		}
+	}
+	return StalePins{StalePins: stalePinDTOs}
+}
+
+// Deprecations are the deprecated and retired endpoints that are still called
+type Deprecations struct {
+	// Deprecations are sorted by sunset date, soonest first, with those that have no sunset date last
+	Deprecations []Deprecation `json:"deprecations"`
+}
+
+// Deprecation is a deprecated or retired endpoint, along with the endpoints and clients that still call it
+type Deprecation struct {
+	// EndpointRef is the deprecated or retired endpoint. Its fields are inlined into the deprecation's JSON
+	EndpointRef
+	// Lifecycle is the endpoint's lifecycle, which may be inherited from its service. Its fields are inlined into the
+	// deprecation's JSON
+	Lifecycle
+	// Callers are the endpoints that still depend on the endpoint
+	Callers []EndpointRef `json:"callers"`
+	// Clients are the codes of the clients that still call the endpoint
+	Clients []ClientCode `json:"clients"`
+}
+
+// MakeDeprecationsFromEntities constructs a Deprecations DTO from Deprecation Entities, and the codes of the Clients
+// that call each deprecated Endpoint
+func MakeDeprecationsFromEntities(
+	deprecations []graph.Deprecation,
+	clients map[service.EndpointRef][]client.Code,
+) Deprecations {
+	deprecationDTOs := make([]Deprecation, len(deprecations))
+	for idx, deprecation := range deprecations {
+		callers := make([]EndpointRef, len(deprecation.Callers))
+		for refIdx, ref := range deprecation.Callers {
+			callers[refIdx] = MakeEndpointRefFromEntity(ref)
+		}
+		clientCodes := clients[deprecation.Endpoint]
+		if clientCodes == nil {
+			clientCodes = make([]ClientCode, 0)
+		}
+		deprecationDTOs[idx] = Deprecation{
+			EndpointRef: MakeEndpointRefFromEntity(deprecation.Endpoint),
+			Lifecycle:   makeLifecycleFromEntity(deprecation.Lifecycle),
+			Callers:     callers,
+			Clients:     clientCodes,
+		}
+	}
+	return Deprecations{Deprecations: deprecationDTOs}
+}
+
+// GraphDiff is the difference between two versions of the dependency graph. Services are matched up by code, and
+// endpoints by the code of their service and their own code
+type GraphDiff struct {
+	// Services are the codes of the services that were added and removed
+	Services ServiceCodeDiff `json:"services"`
+	// Endpoints are the endpoints that were added and removed, including those of added and removed services
+	Endpoints EndpointRefDiff `json:"endpoints"`
+	// Dependencies are the dependencies between endpoints that were added and removed
+	Dependencies DependencyDiff `json:"dependencies"`
+}
+
+// ServiceCodeDiff describes how a set of services changed
+type ServiceCodeDiff struct {
+	// Added are the codes of the services that are new
+	Added []ServiceCode `json:"added"`
+	// Removed are the codes of the services that no longer exist
+	Removed []ServiceCode `json:"removed"`
+}
+
+// EndpointRefDiff describes how a set of endpoints changed
+type EndpointRefDiff struct {
+	// Added are the endpoints that are new
+	Added []EndpointRef `json:"added"`
+	// Removed are the endpoints that no longer exist
+	Removed []EndpointRef `json:"removed"`
+}
+
+// MakeGraphDiffFromEntity constructs a GraphDiff DTO from a Diff Entity
+func MakeGraphDiffFromEntity(diff graph.Diff) GraphDiff {
+	return GraphDiff{
+		Services: ServiceCodeDiff{Added: diff.AddedServices, Removed: diff.RemovedServices},
+		Endpoints: EndpointRefDiff{
+			Added:   makeEndpointRefsFromEntities(diff.AddedEndpoints),
+			Removed: makeEndpointRefsFromEntities(diff.RemovedEndpoints),
+		},
+		Dependencies: MakeDependencyDiffFromEntity(diff.Dependencies),
+	}
+}
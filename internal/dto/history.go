@@ -0,0 +1,116 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/yashap/crius/internal/domain/history"
+)
+
+// History is the history of a service: every change that was made to it, oldest first
+type History struct {
+	// Changes are sorted by version
+	Changes []Change `json:"changes"`
+}
+
+// Change is a single change to a service, described as the difference from the version before it
+type Change struct {
+	// ID uniquely identifies the change. It can be used as a snapshot id, standing for the whole dependency graph right
+	// after the change was made
+	ID *int64 `json:"id"`
+	// Version numbers the changes to a service, starting at 1
+	Version int `json:"version"`
+	// ChangeType is created, updated or deleted
+	ChangeType string `json:"change_type"`
+	// Actor is whoever made the change, as sent in the X-Crius-Actor header
+	Actor string `json:"actor"`
+	// ChangedAt is when the change was made
+	ChangedAt time.Time `json:"changed_at"`
+	// Diff is the difference between the service before and after the change
+	Diff Diff `json:"diff"`
+}
+
+// Diff is the difference between two versions of a service
+type Diff struct {
+	// Fields are the fields of the service itself that changed
+	Fields []FieldChange `json:"fields"`
+	// AddedEndpoints are the codes of the endpoints that are new
+	AddedEndpoints []EndpointCode `json:"added_endpoints"`
+	// RemovedEndpoints are the codes of the endpoints that no longer exist
+	RemovedEndpoints []EndpointCode `json:"removed_endpoints"`
+	// ChangedEndpoints are the endpoints whose fields changed, including added and removed ones
+	ChangedEndpoints []EndpointDiff `json:"changed_endpoints"`
+	// Dependencies are the dependencies of the service's endpoints that were added and removed
+	Dependencies DependencyDiff `json:"dependencies"`
+}
+
+// EndpointDiff is the difference between two versions of an endpoint, not counting its dependencies
+type EndpointDiff struct {
+	// Code is the code of the endpoint
+	Code EndpointCode `json:"code"`
+	// Fields are the fields of the endpoint that changed
+	Fields []FieldChange `json:"fields"`
+}
+
+// FieldChange is a change to a single field of a service or endpoint
+type FieldChange struct {
+	// Field is the name of the field. For example, "name" or "sunset_date"
+	Field string `json:"field"`
+	// Before is the value of the field before the change, or null if it wasn't set
+	Before interface{} `json:"before"`
+	// After is the value of the field after the change, or null if it isn't set
+	After interface{} `json:"after"`
+}
+
+// MakeHistoryFromEntities constructs a History DTO from history Entry Entities
+func MakeHistoryFromEntities(entries []history.Entry) History {
+	changeDTOs := make([]Change, len(entries))
+	for idx, entry := range entries {
+		changeDTOs[idx] = Change{
+			ID:         entry.Change.ID,
+			Version:    entry.Change.Version,
+			ChangeType: entry.Change.Type,
+			Actor:      entry.Change.Actor,
+			ChangedAt:  entry.Change.ChangedAt,
+			Diff:       makeDiffFromEntity(entry.Diff),
+		}
+	}
+	return History{Changes: changeDTOs}
+}
+
+func makeDiffFromEntity(diff history.Diff) Diff {
+	changedEndpoints := make([]EndpointDiff, len(diff.ChangedEndpoints))
+	for idx, endpointDiff := range diff.ChangedEndpoints {
+		changedEndpoints[idx] = EndpointDiff{
+			Code:   endpointDiff.Code,
+			Fields: makeFieldChangesFromEntities(endpointDiff.Fields),
+		}
+	}
+	return Diff{
+		Fields:           makeFieldChangesFromEntities(diff.Fields),
+		AddedEndpoints:   diff.AddedEndpoints,
+		RemovedEndpoints: diff.RemovedEndpoints,
+		ChangedEndpoints: changedEndpoints,
+		Dependencies:     MakeDependencyDiffFromEntity(diff.Dependencies),
+	}
+}
+
+func makeFieldChangesFromEntities(changes []history.FieldChange) []FieldChange {
+	changeDTOs := make([]FieldChange, len(changes))
+	for idx, change := range changes {
+		changeDTOs[idx] = FieldChange{
+			Field:  change.Field,
+			Before: makeFieldValue(change.Before),
+			After:  makeFieldValue(change.After),
+		}
+	}
+	return changeDTOs
+}
+
+// makeFieldValue formats the value of a field the way the API formats that field. The only field values that need it
+// are dates
+func makeFieldValue(value interface{}) interface{} {
+	if date, ok := value.(time.Time); ok {
+		return date.Format(dateLayout)
+	}
+	return value
+}
@@ -0,0 +1,173 @@
+package dto
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/yashap/crius/internal/domain/service"
+	"github.com/yashap/crius/internal/errors"
+)
+
+const (
+	defaultListLimit = 50
+	maxListLimit     = 500
+)
+
+// ServiceSummary is a lightweight summary of a Service, without its Endpoints
+type ServiceSummary struct {
+	// ID uniquely identifies the service
+	ID int64 `json:"id"`
+	// Code is the unique code of the service
+	Code ServiceCode `json:"code"`
+	// Name is the friendly name of the service
+	Name ServiceName `json:"name"`
+	// EndpointCount is the number of Endpoints that the Service has
+	EndpointCount int `json:"endpoint_count"`
+	// Confirmed is false for placeholder services, which nobody has registered yet
+	Confirmed bool `json:"confirmed"`
+	// UnconfirmedEndpoints are the codes of the service's placeholder endpoints, which nobody has registered yet
+	UnconfirmedEndpoints []EndpointCode `json:"unconfirmed_endpoints"`
+	// Team is the team that owns the service, if known
+	Team *Team `json:"team"`
+	// Labels are the service's labels
+	Labels Labels `json:"labels"`
+}
+
+// ServiceSummaryPage is a single page of ServiceSummaries
+type ServiceSummaryPage struct {
+	// Services are the ServiceSummaries on this page
+	Services []ServiceSummary `json:"services"`
+	// NextCursor, if set, is an opaque cursor that fetches the next page when passed as the cursor query param
+	NextCursor *string `json:"next_cursor"`
+}
+
+// cursor is the content of an opaque cursor. It records the sort order it was made for, so that it can't be used to
+// paginate through a differently sorted list
+type cursor struct {
+	SortBy     service.SortField `json:"sort_by"`
+	Descending bool              `json:"descending"`
+	SortValue  string            `json:"sort_value"`
+	ID         int64             `json:"id"`
+}
+
+// MakeListQueryFromRequest constructs a service.ListQuery from the query params of an HTTP request
+func MakeListQueryFromRequest(c *gin.Context) (service.ListQuery, error) {
+	query := service.ListQuery{
+		SortBy: service.SortByCode,
+		Limit:  defaultListLimit,
+	}
+	if codePrefix, ok := c.GetQuery("codePrefix"); ok {
+		query.CodePrefix = &codePrefix
+	}
+	if nameContains, ok := c.GetQuery("nameContains"); ok {
+		query.NameContains = &nameContains
+	}
+	if hasEndpoint, ok := c.GetQuery("hasEndpoint"); ok {
+		query.EndpointContains = &hasEndpoint
+	}
+	if team, ok := c.GetQuery("team"); ok {
+		query.Team = &team
+	}
+	if rawSelector, ok := c.GetQuery("selector"); ok {
+		selector, err := service.ParseSelector(rawSelector)
+		if err != nil {
+			return query, err
+		}
+		query.Selector = selector
+	}
+	if rawUnconfirmed, ok := c.GetQuery("unconfirmed"); ok {
+		unconfirmed, err := strconv.ParseBool(rawUnconfirmed)
+		if err != nil {
+			return query, errors.InvalidInput("query param 'unconfirmed' must be true or false", &err)
+		}
+		query.Unconfirmed = unconfirmed
+	}
+	sort := c.DefaultQuery("sort", service.SortByCode)
+	if strings.HasPrefix(sort, "-") {
+		query.Descending = true
+		sort = strings.TrimPrefix(sort, "-")
+	}
+	if sort != service.SortByCode && sort != service.SortByName {
+		return query, errors.InvalidInput("query param 'sort' must be one of code, -code, name or -name", nil)
+	}
+	query.SortBy = sort
+	if rawLimit, ok := c.GetQuery("limit"); ok {
+		limit, err := strconv.Atoi(rawLimit)
+		if err != nil {
+			return query, errors.InvalidInput("query param 'limit' must be an integer", &err)
+		}
+		if limit < 1 || limit > maxListLimit {
+			return query, errors.InvalidInput(
+				fmt.Sprintf("query param 'limit' must be between 1 and %d", maxListLimit),
+				nil,
+			)
+		}
+		query.Limit = limit
+	}
+	if rawCursor, ok := c.GetQuery("cursor"); ok {
+		after, err := decodeCursor(rawCursor, query)
+		if err != nil {
+			return query, err
+		}
+		query.After = after
+	}
+	return query, nil
+}
+
+// MakeServiceSummaryPageFromEntity constructs a ServiceSummaryPage DTO from a SummaryPage Entity, fetched with the
+// given query
+func MakeServiceSummaryPageFromEntity(page service.SummaryPage, query service.ListQuery) ServiceSummaryPage {
+	summaryDTOs := make([]ServiceSummary, len(page.Summaries))
+	for idx, summary := range page.Summaries {
+		summaryDTOs[idx] = ServiceSummary{
+			ID:                   summary.ID,
+			Code:                 summary.Code,
+			Name:                 summary.Name,
+			EndpointCount:        summary.EndpointCount,
+			Confirmed:            summary.Confirmed,
+			UnconfirmedEndpoints: summary.UnconfirmedEndpoints,
+			Team:                 summary.Team,
+			Labels:               summary.Labels,
+		}
+	}
+	var nextCursor *string
+	if page.Next != nil {
+		encoded := encodeCursor(*page.Next, query)
+		nextCursor = &encoded
+	}
+	return ServiceSummaryPage{
+		Services:   summaryDTOs,
+		NextCursor: nextCursor,
+	}
+}
+
+func encodeCursor(next service.Cursor, query service.ListQuery) string {
+	// Marshalling a struct of strings, ints and bools cannot fail
+	rawCursor, _ := json.Marshal(cursor{
+		SortBy:     query.SortBy,
+		Descending: query.Descending,
+		SortValue:  next.SortValue,
+		ID:         next.ID,
+	})
+	return base64.RawURLEncoding.EncodeToString(rawCursor)
+}
+
+func decodeCursor(encoded string, query service.ListQuery) (*service.Cursor, error) {
+	rawCursor, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, errors.InvalidInput("query param 'cursor' is not a valid cursor", &err)
+	}
+	var decoded cursor
+	err = json.Unmarshal(rawCursor, &decoded)
+	if err != nil {
+		return nil, errors.InvalidInput("query param 'cursor' is not a valid cursor", &err)
+	}
+	if decoded.SortBy != query.SortBy || decoded.Descending != query.Descending {
+		return nil, errors.InvalidInput("query param 'cursor' was created for a different sort order", nil)
+	}
+	return &service.Cursor{SortValue: decoded.SortValue, ID: decoded.ID}, nil
+}
@@ -0,0 +1,279 @@
+package dto
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/yashap/crius/internal/domain/service"
+	"github.com/yashap/crius/internal/errors"
+	"gopkg.in/yaml.v2"
+)
+
+// OpenAPI is an OpenAPI 3 document, in JSON or YAML. Only the parts that describe a service's endpoints are read
+type OpenAPI struct {
+	// OpenAPI is the version of the OpenAPI specification that the document follows, like "3.0.3"
+	OpenAPI *string `json:"openapi" yaml:"openapi"`
+	// Info describes the API
+	Info OpenAPIInfo `json:"info" yaml:"info"`
+	// Paths are the API's paths, like "/teams/{id}", along with the operations on them
+	Paths map[string]OpenAPIPathItem `json:"paths" yaml:"paths"`
+}
+
+// OpenAPIInfo describes the API of an OpenAPI document
+type OpenAPIInfo struct {
+	// Title is the name of the API, which is used to name services that don't exist yet
+	Title *string `json:"title" yaml:"title"`
+	// Version is the version of the API, which becomes the service's version. It must be a semantic version, or a
+	// range of them
+	Version *string `json:"version" yaml:"version"`
+}
+
+// OpenAPIPathItem is the operations on a single path of an OpenAPI document, one for each HTTP method
+type OpenAPIPathItem struct {
+	Get     *OpenAPIOperation `json:"get" yaml:"get"`
+	Put     *OpenAPIOperation `json:"put" yaml:"put"`
+	Post    *OpenAPIOperation `json:"post" yaml:"post"`
+	Delete  *OpenAPIOperation `json:"delete" yaml:"delete"`
+	Options *OpenAPIOperation `json:"options" yaml:"options"`
+	Head    *OpenAPIOperation `json:"head" yaml:"head"`
+	Patch   *OpenAPIOperation `json:"patch" yaml:"patch"`
+	Trace   *OpenAPIOperation `json:"trace" yaml:"trace"`
+}
+
+// OpenAPIOperation is a single operation, i.e. an HTTP method on a path, of an OpenAPI document
+type OpenAPIOperation struct {
+	// OperationID uniquely identifies the operation, like "getTeam"
+	OperationID *string `json:"operationId" yaml:"operationId"`
+	// Summary is a short summary of what the operation does, like "Get a team"
+	Summary *string `json:"summary" yaml:"summary"`
+	// Dependencies is the x-crius-dependencies extension, which declares the dependencies of the operation's endpoint.
+	// It has the same shape as Endpoint.Dependencies, but is decoded generically, so that a malformed extension is
+	// reported against its own operation
+	Dependencies interface{} `json:"x-crius-dependencies" yaml:"x-crius-dependencies"`
+}
+
+// MakeOpenAPIFromRequest constructs an OpenAPI DTO from an HTTP request, whose body is a JSON or YAML document
+func MakeOpenAPIFromRequest(c *gin.Context) (OpenAPI, error) {
+	var o OpenAPI
+	data, err := c.GetRawData()
+	if err != nil {
+		return o, errors.InvalidInput("failed to read OpenAPI document", &err)
+	}
+	// YAML is a superset of JSON, but JSON documents are often indented with tabs, which YAML doesn't allow
+	if bytes.HasPrefix(bytes.TrimSpace(data), []byte("{")) {
+		err = json.Unmarshal(data, &o)
+		if err != nil {
+			return o, errors.InvalidInput("failed to unmarshall json to OpenAPI", &err)
+		}
+	} else {
+		err = yaml.Unmarshal(data, &o)
+		if err != nil {
+			return o, errors.InvalidInput("failed to unmarshall yaml to OpenAPI", &err)
+		}
+	}
+	err = o.validate()
+	return o, err
+}
+
+// ToEntity converts an OpenAPI DTO into the Service with the given Code, which has an Endpoint for each operation in
+// the document. Endpoint codes are the operation's method and path, like "GET /teams/{id}", and Endpoint names are
+// the operation's summary, or failing that its operationId. Operations with an x-crius-dependencies extension declare
+// their Endpoint's Dependencies. The existing Service, if there is one, keeps everything but its Endpoints, and the
+// Endpoints that it still has keep everything but their names, including the Dependencies of those whose operations
+// don't declare any. Its unconfirmed Endpoints, which other services depend on but it hasn't declared yet, are kept
+// even if the document doesn't list them. A Service that doesn't exist yet is named after the document's title
+func (o *OpenAPI) ToEntity(code service.Code, existing *service.Service) service.Service {
+	var svc service.Service
+	if existing == nil {
+		name := code
+		if o.Info.Title != nil {
+			name = *o.Info.Title
+		}
+		svc = service.MakeService(nil, code, name, nil, service.Ownership{})
+		svc.Labels = make(service.Labels)
+		svc.Lifecycle = service.Lifecycle{State: service.Active}
+	} else {
+		svc = *existing
+	}
+	if o.Info.Version != nil {
+		svc.Version = o.Info.Version
+	}
+	existingEndpoints := make(map[service.EndpointCode]service.Endpoint)
+	for _, endpoint := range svc.Endpoints {
+		existingEndpoints[endpoint.Code] = endpoint
+	}
+	svc.Endpoints = make([]service.Endpoint, 0)
+	for _, path := range o.sortedPaths() {
+		for _, method := range o.Paths[path].methods() {
+			endpointCode := method.method + " " + path
+			endpoint, ok := existingEndpoints[endpointCode]
+			if !ok {
+				endpoint = service.Endpoint{
+					Code:         endpointCode,
+					Dependencies: make(map[service.Code][]service.EndpointCode),
+					Labels:       make(service.Labels),
+					Lifecycle:    service.Lifecycle{State: service.Active},
+				}
+			}
+			// The operation was validated when the document was made, so it converts cleanly
+			declared, _ := method.operation.toEndpoint(endpointCode)
+			endpoint.Name = *declared.Name
+			endpoint.Confirmed = true
+			if declared.Dependencies != nil {
+				declaredEntity := declared.ToEntity()
+				endpoint.Dependencies = declaredEntity.Dependencies
+				endpoint.VersionConstraints = declaredEntity.VersionConstraints
+			}
+			svc.Endpoints = append(svc.Endpoints, endpoint)
+			delete(existingEndpoints, endpointCode)
+		}
+	}
+	unlisted := make([]service.Endpoint, 0)
+	for _, endpoint := range existingEndpoints {
+		if !endpoint.Confirmed {
+			unlisted = append(unlisted, endpoint)
+		}
+	}
+	sort.Slice(unlisted, func(i, j int) bool { return unlisted[i].Code < unlisted[j].Code })
+	svc.Endpoints = append(svc.Endpoints, unlisted...)
+	return svc
+}
+
+// openAPIMethod is an HTTP method, like "GET", along with its operation on a path
+type openAPIMethod struct {
+	method    string
+	operation *OpenAPIOperation
+}
+
+// methods lists the HTTP methods that the path has operations for, in the order that the OpenAPI specification lists
+// them
+func (p OpenAPIPathItem) methods() []openAPIMethod {
+	methods := make([]openAPIMethod, 0)
+	for _, method := range []openAPIMethod{
+		{"GET", p.Get},
+		{"PUT", p.Put},
+		{"POST", p.Post},
+		{"DELETE", p.Delete},
+		{"OPTIONS", p.Options},
+		{"HEAD", p.Head},
+		{"PATCH", p.Patch},
+		{"TRACE", p.Trace},
+	} {
+		if method.operation != nil {
+			methods = append(methods, method)
+		}
+	}
+	return methods
+}
+
+// toEndpoint converts the operation into an Endpoint DTO with the given code, whose Dependencies are nil if the
+// operation doesn't declare any, and validates it
+func (op OpenAPIOperation) toEndpoint(endpointCode EndpointCode) (Endpoint, error) {
+	name := op.name(endpointCode)
+	endpoint := Endpoint{Code: &endpointCode, Name: &name}
+	if op.Dependencies != nil {
+		dependencies, err := decodeOpenAPIDependencies(op.Dependencies)
+		if err != nil {
+			return endpoint, err
+		}
+		endpoint.Dependencies = &dependencies
+	}
+	return endpoint, endpoint.validate()
+}
+
+// decodeOpenAPIDependencies decodes an x-crius-dependencies extension, which maps service codes (optionally pinned to
+// versions, like "payments@^2") to lists of endpoint codes. YAML decodes maps with interface{} keys, and JSON with
+// string keys, so both are accepted
+func decodeOpenAPIDependencies(raw interface{}) (map[ServiceCode][]EndpointCode, error) {
+	malformed := errors.InvalidInput(
+		"extension 'x-crius-dependencies' must map service codes to lists of endpoint codes",
+		nil,
+	)
+	var entries map[interface{}]interface{}
+	switch typed := raw.(type) {
+	case map[interface{}]interface{}:
+		entries = typed
+	case map[string]interface{}:
+		entries = make(map[interface{}]interface{}, len(typed))
+		for key, value := range typed {
+			entries[key] = value
+		}
+	default:
+		return nil, malformed
+	}
+	dependencies := make(map[ServiceCode][]EndpointCode)
+	for key, value := range entries {
+		depServiceCode, ok := key.(string)
+		if !ok {
+			return nil, malformed
+		}
+		rawEndpointCodes, ok := value.([]interface{})
+		if !ok {
+			return nil, malformed
+		}
+		depEndpointCodes := make([]EndpointCode, len(rawEndpointCodes))
+		for idx, rawEndpointCode := range rawEndpointCodes {
+			depEndpointCode, ok := rawEndpointCode.(string)
+			if !ok {
+				return nil, malformed
+			}
+			depEndpointCodes[idx] = depEndpointCode
+		}
+		dependencies[depServiceCode] = depEndpointCodes
+	}
+	return dependencies, nil
+}
+
+// name is the name of the Endpoint for the operation, which is its summary, or failing that its operationId, or failing
+// that the Endpoint's code
+func (op OpenAPIOperation) name(endpointCode EndpointCode) EndpointName {
+	if op.Summary != nil && *op.Summary != "" {
+		return *op.Summary
+	}
+	if op.OperationID != nil && *op.OperationID != "" {
+		return *op.OperationID
+	}
+	return endpointCode
+}
+
+func (o *OpenAPI) sortedPaths() []string {
+	paths := make([]string, 0, len(o.Paths))
+	for path := range o.Paths {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	return paths
+}
+
+func (o OpenAPI) validate() error {
+	if o.OpenAPI == nil || !strings.HasPrefix(*o.OpenAPI, "3.") {
+		return errors.InvalidInput("field 'openapi' on object OpenAPI must be an OpenAPI 3 version, like 3.0.3", nil)
+	}
+	details := make([]errors.Detail, 0)
+	if err := validateVersion(o.Info.Version); err != nil {
+		details = append(details, errors.Detail{"field": "info.version", "message": errors.Message(err)})
+	}
+	for _, path := range o.sortedPaths() {
+		if !strings.HasPrefix(path, "/") {
+			return errors.InvalidInput(
+				fmt.Sprintf("path %s on object OpenAPI must start with a /, like /teams/{id}", path),
+				nil,
+			)
+		}
+		for _, method := range o.Paths[path].methods() {
+			endpointCode := method.method + " " + path
+			_, err := method.operation.toEndpoint(endpointCode)
+			if err != nil {
+				details = append(details, errors.Detail{"operation": endpointCode, "message": errors.Message(err)})
+			}
+		}
+	}
+	if len(details) > 0 {
+		return errors.InvalidOperations("some fields or operations on object OpenAPI are invalid, see details", details)
+	}
+	return nil
+}
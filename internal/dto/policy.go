@@ -0,0 +1,229 @@
+package dto
+
+import (
+	"fmt"
+
+	"github.com/gin-gonic/gin"
+	"github.com/yashap/crius/internal/domain/policy"
+	"github.com/yashap/crius/internal/domain/service"
+	"github.com/yashap/crius/internal/errors"
+	"gopkg.in/yaml.v2"
+)
+
+// PolicyRules are a set of policy rules. It is also the format of the policy file
+type PolicyRules struct {
+	// Rules are the policy rules
+	Rules []PolicyRule `json:"rules" yaml:"rules"`
+}
+
+// PolicyRule is a declarative rule about which endpoints may depend on which other endpoints
+type PolicyRule struct {
+	// Code is a unique code for the rule. For example, "ledger_only_called_by_payments"
+	Code *string `json:"code" yaml:"code"`
+	// Description describes the rule, and why it exists. It is reported along with any violations of the rule
+	Description *string `json:"description" yaml:"description"`
+	// Effect is deny, to forbid endpoints that match from from depending on endpoints that match to, or allow, to only
+	// let endpoints that match from depend on endpoints that match to
+	Effect *string `json:"effect" yaml:"effect"`
+	// Enforcement is reject (the default), to reject saves that break the rule, or warn, to only warn about them
+	Enforcement *string `json:"enforcement" yaml:"enforcement"`
+	// From matches the endpoints that have dependencies. If unset, it matches every endpoint
+	From *PolicyMatcher `json:"from" yaml:"from"`
+	// To matches the endpoints that are depended on. If unset, it matches every endpoint
+	To *PolicyMatcher `json:"to" yaml:"to"`
+	// Source is api for rules saved through the API, or file for rules loaded from the policy file. It is ignored in
+	// requests, and in the policy file
+	Source *string `json:"source,omitempty" yaml:"-"`
+}
+
+// PolicyMatcher matches endpoints. An endpoint must meet every condition that is set to match
+type PolicyMatcher struct {
+	// Services, if set, only matches endpoints of services with one of these codes
+	Services *[]ServiceCode `json:"services" yaml:"services"`
+	// Endpoints, if set, only matches endpoints with one of these codes
+	Endpoints *[]EndpointCode `json:"endpoints" yaml:"endpoints"`
+	// Selector, if set, only matches endpoints whose labels (merged over their service's labels) match this label
+	// selector. For example, "domain!=payments"
+	Selector *string `json:"selector" yaml:"selector"`
+}
+
+// PolicyViolation is a dependency that breaks a policy rule
+type PolicyViolation struct {
+	// RuleCode is the code of the rule that is broken
+	RuleCode string `json:"rule_code"`
+	// Description is the description of the rule that is broken
+	Description string `json:"description"`
+	// Enforcement is whether the rule rejects saves that break it, or only warns about them
+	Enforcement string `json:"enforcement"`
+	// From is the endpoint that has the dependency
+	From EndpointRef `json:"from"`
+	// To is the endpoint that is depended on
+	To EndpointRef `json:"to"`
+}
+
+// MakePolicyRuleFromRequest constructs a PolicyRule DTO from an HTTP request
+func MakePolicyRuleFromRequest(c *gin.Context) (PolicyRule, error) {
+	var r PolicyRule
+	err := c.ShouldBindJSON(&r)
+	if err != nil {
+		return r, errors.InvalidInput("failed to unmarshall json to PolicyRule", &err)
+	}
+	err = r.validate()
+	return r, err
+}
+
+// MakePolicyRulesFromYAML constructs a PolicyRules DTO from the contents of a policy file. Unknown fields are rejected,
+// so that typos don't silently loosen a rule
+func MakePolicyRulesFromYAML(data []byte) (PolicyRules, error) {
+	var rules PolicyRules
+	err := yaml.UnmarshalStrict(data, &rules)
+	if err != nil {
+		return rules, errors.InvalidInput("failed to unmarshall yaml to PolicyRules", &err)
+	}
+	seen := make(map[string]bool)
+	for _, rule := range rules.Rules {
+		err = rule.validate()
+		if err != nil {
+			return rules, err
+		}
+		if seen[*rule.Code] {
+			return rules, errors.InvalidInput(fmt.Sprintf("policy rule code %s is not unique", *rule.Code), nil)
+		}
+		seen[*rule.Code] = true
+	}
+	return rules, nil
+}
+
+// ToEntity converts a PolicyRule DTO into a Rule Entity, returning an InvalidInput error if it isn't a valid Rule
+func (r *PolicyRule) ToEntity() (policy.Rule, error) {
+	description := ""
+	if r.Description != nil {
+		description = *r.Description
+	}
+	enforcement := policy.Reject
+	if r.Enforcement != nil {
+		enforcement = *r.Enforcement
+	}
+	from, err := r.From.toEntity()
+	if err != nil {
+		return policy.Rule{}, err
+	}
+	to, err := r.To.toEntity()
+	if err != nil {
+		return policy.Rule{}, err
+	}
+	rule := policy.MakeRule(nil, *r.Code, description, *r.Effect, enforcement, from, to, policy.APISource)
+	return rule, rule.Validate()
+}
+
+// ToEntities converts a PolicyRules DTO into Rule Entities, returning an InvalidInput error if any isn't a valid Rule
+func (rs *PolicyRules) ToEntities() ([]policy.Rule, error) {
+	rules := make([]policy.Rule, len(rs.Rules))
+	for idx := range rs.Rules {
+		rule, err := rs.Rules[idx].ToEntity()
+		if err != nil {
+			return nil, err
+		}
+		rules[idx] = rule
+	}
+	return rules, nil
+}
+
+// MakePolicyRuleFromEntity constructs a PolicyRule DTO from a Rule Entity
+func MakePolicyRuleFromEntity(r policy.Rule) PolicyRule {
+	return PolicyRule{
+		Code:        &r.Code,
+		Description: &r.Description,
+		Effect:      &r.Effect,
+		Enforcement: &r.Enforcement,
+		From:        makePolicyMatcherFromEntity(r.From),
+		To:          makePolicyMatcherFromEntity(r.To),
+		Source:      &r.Source,
+	}
+}
+
+// MakePolicyRulesFromEntities constructs a PolicyRules DTO from Rule Entities
+func MakePolicyRulesFromEntities(rules []policy.Rule) PolicyRules {
+	ruleDTOs := make([]PolicyRule, len(rules))
+	for idx, rule := range rules {
+		ruleDTOs[idx] = MakePolicyRuleFromEntity(rule)
+	}
+	return PolicyRules{Rules: ruleDTOs}
+}
+
+// MakePolicyViolationsFromEntities constructs PolicyViolation DTOs from Violation Entities
+func MakePolicyViolationsFromEntities(violations []policy.Violation) []PolicyViolation {
+	violationDTOs := make([]PolicyViolation, len(violations))
+	for idx, violation := range violations {
+		violationDTOs[idx] = PolicyViolation{
+			RuleCode:    violation.Rule.Code,
+			Description: violation.Rule.Description,
+			Enforcement: violation.Rule.Enforcement,
+			From:        MakeEndpointRefFromEntity(violation.Dependency.From),
+			To:          MakeEndpointRefFromEntity(violation.Dependency.To),
+		}
+	}
+	return violationDTOs
+}
+
+// MakePolicyViolationDetails describes each of the violations as an errors.Detail
+func MakePolicyViolationDetails(violations []policy.Violation) []errors.Detail {
+	details := make([]errors.Detail, len(violations))
+	for idx, violation := range MakePolicyViolationsFromEntities(violations) {
+		details[idx] = errors.Detail{
+			"rule_code":   violation.RuleCode,
+			"description": violation.Description,
+			"enforcement": violation.Enforcement,
+			"from":        violation.From,
+			"to":          violation.To,
+		}
+	}
+	return details
+}
+
+func (m *PolicyMatcher) toEntity() (policy.Matcher, error) {
+	var matcher policy.Matcher
+	if m == nil {
+		return matcher, nil
+	}
+	if m.Services != nil {
+		matcher.ServiceCodes = *m.Services
+	}
+	if m.Endpoints != nil {
+		matcher.EndpointCodes = *m.Endpoints
+	}
+	if m.Selector != nil {
+		selector, err := service.ParseSelector(*m.Selector)
+		if err != nil {
+			return matcher, err
+		}
+		matcher.Selector = selector
+	}
+	return matcher, nil
+}
+
+func makePolicyMatcherFromEntity(m policy.Matcher) *PolicyMatcher {
+	services, endpoints := m.ServiceCodes, m.EndpointCodes
+	if services == nil {
+		services = make([]ServiceCode, 0)
+	}
+	if endpoints == nil {
+		endpoints = make([]EndpointCode, 0)
+	}
+	matcher := PolicyMatcher{Services: &services, Endpoints: &endpoints}
+	if m.Selector != nil {
+		selector := m.Selector.String()
+		matcher.Selector = &selector
+	}
+	return &matcher
+}
+
+func (r PolicyRule) validate() error {
+	if r.Code == nil {
+		return errors.InvalidInput("field 'code' on object PolicyRule is required", nil)
+	}
+	if r.Effect == nil {
+		return errors.InvalidInput("field 'effect' on object PolicyRule is required", nil)
+	}
+	return nil
+}
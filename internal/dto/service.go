@@ -1,6 +1,9 @@
 package dto
 
 import (
+	"fmt"
+	"time"
+
 	"github.com/gin-gonic/gin"
 	"github.com/yashap/crius/internal/domain/service"
 	"github.com/yashap/crius/internal/errors"
@@ -18,6 +21,21 @@ type EndpointCode = string
 // EndpointName is the human-readable/friedly name of an Endpoint
 type EndpointName = string
 
+// Team is the name of a team that owns Services
+type Team = string
+
+// Labels are free-form key/value pairs that describe a Service or an Endpoint, with Kubernetes label syntax
+type Labels = map[string]string
+
+// Tier is how critical a Service or Endpoint is. Lower Tiers are more critical, with 0 being the most critical
+type Tier = int
+
+// Version is a semantic version, like "2.3.1", or a range of them, like "^2" or ">=1.4 <3"
+type Version = string
+
+// dateLayout is the layout of dates, like sunset dates, in the API
+const dateLayout = "2006-01-02"
+
 // Service represents a service
 type Service struct {
 	// Code is a unique code for the service. For example, "location_tracking" for a location tracking service
@@ -26,6 +44,50 @@ type Service struct {
 	Name *ServiceName `json:"name"`
 	// Endpoints is a list of Endpoints that the Service has
 	Endpoints *[]Endpoint `json:"endpoints"`
+	// Confirmed is false for placeholder services, which nobody has registered yet. It is ignored in requests
+	Confirmed *bool `json:"confirmed,omitempty"`
+	// Ownership describes who owns the service. Its fields are inlined into the service's JSON
+	Ownership
+	// Labels are free-form key/value pairs that describe the service. For example, "tier": "critical"
+	Labels *Labels `json:"labels"`
+	// Tier is how critical the service is, with 0 being the most critical. It is the default tier of its endpoints
+	Tier *Tier `json:"tier"`
+	// Lifecycle is how far the service is through its lifecycle. Its fields are inlined into the service's JSON
+	Lifecycle
+	// Version is the semantic version, or range of versions, of the service's API. It is the default version of its
+	// endpoints
+	Version *Version `json:"version"`
+}
+
+// UpdatedService is a Service that was just partially updated, along with the violations of policy rules that only
+// warn, which the update's new dependencies break. The Service's fields are inlined into its JSON
+type UpdatedService struct {
+	Service
+	// PolicyWarnings are the violations of policy rules that only warn
+	PolicyWarnings []PolicyViolation `json:"policy_warnings"`
+}
+
+// Lifecycle is how far a Service or Endpoint is through its lifecycle
+type Lifecycle struct {
+	// State is active (the default), deprecated or retired
+	State *string `json:"lifecycle"`
+	// SunsetDate is the date, like "2021-06-30", that a deprecated service or endpoint will be retired on, or that a
+	// retired one was retired on. Deprecated ones must have one, and active ones must not
+	SunsetDate *string `json:"sunset_date"`
+}
+
+// Ownership describes who owns a Service, and how to reach them. Every field is optional
+type Ownership struct {
+	// Team is the team that owns the service. For example, "logistics"
+	Team *Team `json:"team"`
+	// OnCall is how to reach whoever is on call for the service. For example, a pager rotation or a phone number
+	OnCall *string `json:"on_call"`
+	// SlackChannel is the Slack channel where the owning team can be reached. For example, "#logistics"
+	SlackChannel *string `json:"slack_channel"`
+	// Email is the email address (usually a mailing list) where the owning team can be reached
+	Email *string `json:"email"`
+	// RepositoryURL is the URL of the service's source code repository
+	RepositoryURL *string `json:"repository_url"`
 }
 
 // Endpoint represents an Endpoint of a Service
@@ -35,8 +97,38 @@ type Endpoint struct {
 	Code *EndpointCode `json:"code"`
 	// Name is a friendly name for the Endpoint. For example, "Create location" or "Get location by id"
 	Name *EndpointName `json:"name"`
-	// Dependencies is a map of Dependencies for a given Endpoint. Keys are service codes, values are lists of endpoint codes
+	// Dependencies is a map of Dependencies for a given Endpoint. Keys are service codes, values are lists of endpoint codes.
+	// A key can pin the versions of the service that the Endpoint can call, like "payments@^2"
 	Dependencies *map[ServiceCode][]EndpointCode `json:"dependencies"`
+	// Confirmed is false for placeholder endpoints, which nobody has registered yet. It is ignored in requests
+	Confirmed *bool `json:"confirmed,omitempty"`
+	// Labels are free-form key/value pairs that describe the endpoint. For example, "pci": "true"
+	Labels *Labels `json:"labels"`
+	// Tier is how critical the endpoint is, if it differs from its service's tier
+	Tier *Tier `json:"tier"`
+	// Lifecycle is how far the endpoint is through its lifecycle. Its fields are inlined into the endpoint's JSON
+	Lifecycle
+	// Version is the semantic version, or range of versions, of the endpoint, if it differs from its service's version
+	Version *Version `json:"version"`
+}
+
+// ServicePatch is a partial update to a Service
+type ServicePatch struct {
+	// Name, if set, replaces the Service's name
+	Name *ServiceName `json:"name"`
+	// Endpoints, if set, are saved one at a time, replacing any existing Endpoints with the same codes. The Service's
+	// other Endpoints are left alone
+	Endpoints *[]Endpoint `json:"endpoints"`
+	// Ownership's fields, where set, replace those of the Service's ownership. Unset fields are left alone
+	Ownership
+	// Labels, if set, replace all of the Service's labels
+	Labels *Labels `json:"labels"`
+	// Tier, if set, replaces the Service's tier
+	Tier *Tier `json:"tier"`
+	// Lifecycle, if its state is set, replaces the Service's lifecycle, including its sunset date
+	Lifecycle
+	// Version, if set, replaces the Service's version
+	Version *Version `json:"version"`
 }
 
 // ToEntity converts a Service DTO into a Service Entity
@@ -47,12 +139,18 @@ func (s *Service) ToEntity() service.Service {
 	} else {
 		endpoints = endpointsToEntities(*s.Endpoints)
 	}
-	return service.MakeService(
+	svc := service.MakeService(
 		nil,
 		*s.Code,
 		*s.Name,
 		endpoints,
+		s.Ownership.toEntity(),
 	)
+	svc.Labels = labelsToEntity(s.Labels)
+	svc.Tier = s.Tier
+	svc.Lifecycle = s.Lifecycle.toEntity()
+	svc.Version = s.Version
+	return svc
 }
 
 // MakeServiceFromRequest constructs a Service DTO from an HTTP request
@@ -66,6 +164,106 @@ func MakeServiceFromRequest(c *gin.Context) (Service, error) {
 	return s, err
 }
 
+// MakeServicePatchFromRequest constructs a ServicePatch DTO from an HTTP request
+func MakeServicePatchFromRequest(c *gin.Context) (ServicePatch, error) {
+	var p ServicePatch
+	err := c.ShouldBindJSON(&p)
+	if err != nil {
+		return p, errors.InvalidInput("failed to unmarshall json to ServicePatch", &err)
+	}
+	err = p.validate()
+	return p, err
+}
+
+// ToEntity converts a ServicePatch DTO into a Patch Entity
+func (p *ServicePatch) ToEntity() service.Patch {
+	endpoints := make([]service.Endpoint, 0)
+	if p.Endpoints != nil {
+		endpoints = endpointsToEntities(*p.Endpoints)
+	}
+	var labels service.Labels
+	if p.Labels != nil {
+		labels = labelsToEntity(p.Labels)
+	}
+	var lifecycle *service.Lifecycle
+	if p.Lifecycle.State != nil {
+		l := p.Lifecycle.toEntity()
+		lifecycle = &l
+	}
+	return service.Patch{
+		Name:      p.Name,
+		Endpoints: endpoints,
+		Ownership: p.Ownership.toEntity(),
+		Labels:    labels,
+		Tier:      p.Tier,
+		Lifecycle: lifecycle,
+		Version:   p.Version,
+	}
+}
+
+// MakeEndpointFromRequest constructs an Endpoint DTO from an HTTP request. The Endpoint's code is taken from the
+// request path, so it can be left out of the body, but if it is in the body, it must match
+func MakeEndpointFromRequest(c *gin.Context, code EndpointCode) (Endpoint, error) {
+	var e Endpoint
+	err := c.ShouldBindJSON(&e)
+	if err != nil {
+		return e, errors.InvalidInput("failed to unmarshall json to Endpoint", &err)
+	}
+	if e.Code == nil {
+		e.Code = &code
+	} else if *e.Code != code {
+		return e, errors.InvalidInput("field 'code' on object Endpoint must match the endpoint code in the path", nil)
+	}
+	err = e.validate()
+	return e, err
+}
+
+// ToEntity converts an Endpoint DTO into an Endpoint Entity. Endpoints in requests are registered by their owners, so
+// they are confirmed
+func (e *Endpoint) ToEntity() service.Endpoint {
+	dependencies := make(map[ServiceCode][]EndpointCode)
+	versionConstraints := make(map[ServiceCode]Version)
+	if e.Dependencies != nil {
+		for key, depEndpointCodes := range *e.Dependencies {
+			depServiceCode, constraint := service.SplitVersionConstraint(key)
+			dependencies[depServiceCode] = depEndpointCodes
+			if constraint != nil {
+				versionConstraints[depServiceCode] = *constraint
+			}
+		}
+	}
+	return service.Endpoint{
+		Code:               *e.Code,
+		Name:               *e.Name,
+		Dependencies:       dependencies,
+		Confirmed:          true,
+		Labels:             labelsToEntity(e.Labels),
+		Tier:               e.Tier,
+		Lifecycle:          e.Lifecycle.toEntity(),
+		Version:            e.Version,
+		VersionConstraints: versionConstraints,
+	}
+}
+
+// MakeEndpointFromEntity constructs an Endpoint DTO from an Endpoint Entity
+func MakeEndpointFromEntity(e service.Endpoint) Endpoint {
+	dependencies := make(map[ServiceCode][]EndpointCode)
+	for depServiceCode, depEndpointCodes := range e.Dependencies {
+		key := service.JoinVersionConstraint(depServiceCode, e.VersionConstraint(depServiceCode))
+		dependencies[key] = depEndpointCodes
+	}
+	return Endpoint{
+		Code:         &e.Code,
+		Name:         &e.Name,
+		Dependencies: &dependencies,
+		Confirmed:    &e.Confirmed,
+		Labels:       &e.Labels,
+		Tier:         e.Tier,
+		Lifecycle:    makeLifecycleFromEntity(e.Lifecycle),
+		Version:      e.Version,
+	}
+}
+
 // MakeServiceFromEntity constructs a Service DTO from a Service Entity
 func MakeServiceFromEntity(s service.Service) Service {
 	endpointDTOs := makeEndpointsFromEntities(s.Endpoints)
@@ -73,23 +271,70 @@ func MakeServiceFromEntity(s service.Service) Service {
 		Code:      &s.Code,
 		Name:      &s.Name,
 		Endpoints: &endpointDTOs,
+		Confirmed: &s.Confirmed,
+		Ownership: makeOwnershipFromEntity(s.Ownership),
+		Labels:    &s.Labels,
+		Tier:      s.Tier,
+		Lifecycle: makeLifecycleFromEntity(s.Lifecycle),
+		Version:   s.Version,
+	}
+}
+
+func (o Ownership) toEntity() service.Ownership {
+	return service.Ownership{
+		Team:          o.Team,
+		OnCall:        o.OnCall,
+		SlackChannel:  o.SlackChannel,
+		Email:         o.Email,
+		RepositoryURL: o.RepositoryURL,
+	}
+}
+
+// toEntity converts a Lifecycle DTO, which must be valid, into a Lifecycle Entity
+func (l Lifecycle) toEntity() service.Lifecycle {
+	lifecycle := service.Lifecycle{State: service.Active}
+	if l.State != nil {
+		lifecycle.State = *l.State
+	}
+	if l.SunsetDate != nil {
+		sunsetDate, err := time.Parse(dateLayout, *l.SunsetDate)
+		if err == nil {
+			lifecycle.SunsetDate = &sunsetDate
+		}
+	}
+	return lifecycle
+}
+
+func makeLifecycleFromEntity(l service.Lifecycle) Lifecycle {
+	lifecycle := Lifecycle{State: &l.State}
+	if l.SunsetDate != nil {
+		sunsetDate := l.SunsetDate.Format(dateLayout)
+		lifecycle.SunsetDate = &sunsetDate
+	}
+	return lifecycle
+}
+
+func labelsToEntity(labels *Labels) service.Labels {
+	if labels == nil {
+		return make(service.Labels)
+	}
+	return *labels
+}
+
+func makeOwnershipFromEntity(o service.Ownership) Ownership {
+	return Ownership{
+		Team:          o.Team,
+		OnCall:        o.OnCall,
+		SlackChannel:  o.SlackChannel,
+		Email:         o.Email,
+		RepositoryURL: o.RepositoryURL,
 	}
 }
 
 func endpointsToEntities(endpoints []Endpoint) []service.Endpoint {
 	endpointEntities := make([]service.Endpoint, len(endpoints))
-	for idx, endpoint := range endpoints {
-		var dependencies map[ServiceCode][]EndpointCode
-		if endpoint.Dependencies == nil {
-			dependencies = make(map[ServiceCode][]EndpointCode)
-		} else {
-			dependencies = *endpoint.Dependencies
-		}
-		endpointEntities[idx] = service.Endpoint{
-			Code:         *endpoint.Code,
-			Name:         *endpoint.Name,
-			Dependencies: dependencies,
-		}
+	for idx := range endpoints {
+		endpointEntities[idx] = endpoints[idx].ToEntity()
 	}
 	return endpointEntities
 }
@@ -97,13 +342,7 @@ func endpointsToEntities(endpoints []Endpoint) []service.Endpoint {
 func makeEndpointsFromEntities(endpoints []service.Endpoint) []Endpoint {
 	endpointDTOs := make([]Endpoint, len(endpoints))
 	for idx := range endpoints {
-		endpoint := endpoints[idx]
-		endpointDTO := Endpoint{
-			Code:         &endpoint.Code,
-			Name:         &endpoint.Name,
-			Dependencies: &endpoint.Dependencies,
-		}
-		endpointDTOs[idx] = endpointDTO
+		endpointDTOs[idx] = MakeEndpointFromEntity(endpoints[idx])
 	}
 	return endpointDTOs
 }
@@ -115,7 +354,59 @@ func (s Service) validate() error {
 	if s.Name == nil {
 		return errors.InvalidInput("field 'name' on object Service is required", nil)
 	}
-	for _, endpoint := range *s.Endpoints {
+	if s.Labels != nil {
+		err := service.ValidateLabels(*s.Labels)
+		if err != nil {
+			return err
+		}
+	}
+	err := validateTier(s.Tier, "Service")
+	if err != nil {
+		return err
+	}
+	err = s.Lifecycle.validate()
+	if err != nil {
+		return err
+	}
+	err = validateVersion(s.Version)
+	if err != nil {
+		return err
+	}
+	return validateEndpoints(s.Endpoints)
+}
+
+func (p ServicePatch) validate() error {
+	if p.Labels != nil {
+		err := service.ValidateLabels(*p.Labels)
+		if err != nil {
+			return err
+		}
+	}
+	err := validateTier(p.Tier, "ServicePatch")
+	if err != nil {
+		return err
+	}
+	if p.Lifecycle.State == nil && p.Lifecycle.SunsetDate != nil {
+		return errors.InvalidInput("field 'sunset_date' on object ServicePatch requires field 'lifecycle'", nil)
+	}
+	if p.Lifecycle.State != nil {
+		err = p.Lifecycle.validate()
+		if err != nil {
+			return err
+		}
+	}
+	err = validateVersion(p.Version)
+	if err != nil {
+		return err
+	}
+	return validateEndpoints(p.Endpoints)
+}
+
+func validateEndpoints(endpoints *[]Endpoint) error {
+	if endpoints == nil {
+		return nil
+	}
+	for _, endpoint := range *endpoints {
 		err := endpoint.validate()
 		if err != nil {
 			return err
@@ -131,5 +422,73 @@ func (e Endpoint) validate() error {
 	if e.Name == nil {
 		return errors.InvalidInput("field 'name' on object Endpoint is required", nil)
 	}
+	if e.Labels != nil {
+		err := service.ValidateLabels(*e.Labels)
+		if err != nil {
+			return err
+		}
+	}
+	err := validateTier(e.Tier, "Endpoint")
+	if err != nil {
+		return err
+	}
+	err = e.Lifecycle.validate()
+	if err != nil {
+		return err
+	}
+	err = validateVersion(e.Version)
+	if err != nil {
+		return err
+	}
+	return e.validateVersionConstraints()
+}
+
+// validateVersionConstraints checks the versions that dependency keys like "payments@^2" are pinned to, and that each
+// service is only depended on once, whether pinned or not
+func (e Endpoint) validateVersionConstraints() error {
+	if e.Dependencies == nil {
+		return nil
+	}
+	keys := make(map[ServiceCode]string)
+	for key := range *e.Dependencies {
+		depServiceCode, constraint := service.SplitVersionConstraint(key)
+		if other, ok := keys[depServiceCode]; ok {
+			return errors.InvalidInput(
+				fmt.Sprintf("dependencies %s and %s on object Endpoint are on the same service", other, key),
+				nil,
+			)
+		}
+		keys[depServiceCode] = key
+		if constraint != nil {
+			err := service.ValidateVersion(*constraint)
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (l Lifecycle) validate() error {
+	if l.SunsetDate != nil {
+		_, err := time.Parse(dateLayout, *l.SunsetDate)
+		if err != nil {
+			return errors.InvalidInput("field 'sunset_date' must be a date, like 2021-06-30", &err)
+		}
+	}
+	return l.toEntity().Validate()
+}
+
+func validateVersion(version *Version) error {
+	if version == nil {
+		return nil
+	}
+	return service.ValidateVersion(*version)
+}
+
+func validateTier(tier *Tier, object string) error {
+	if tier != nil && *tier < 0 {
+		return errors.InvalidInput(fmt.Sprintf("field 'tier' on object %s must not be negative", object), nil)
+	}
 	return nil
 }
@@ -0,0 +1,105 @@
+package dto
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/yashap/crius/internal/domain/service"
+	"github.com/yashap/crius/internal/domain/topic"
+	"github.com/yashap/crius/internal/errors"
+)
+
+// TopicCode is a code that uniquely identifies a Topic
+type TopicCode = string
+
+// TopicName is the human-readable/friendly name of a Topic
+type TopicName = string
+
+// Topic represents a message topic, which endpoints produce messages to, and consume messages from
+type Topic struct {
+	// Code is a unique code for the topic. For example, "location_updated" for location update events
+	Code *TopicCode `json:"code"`
+	// Name is a friendly name for the topic. For example, "Location Updated" for location update events
+	Name *TopicName `json:"name"`
+	// Producers are the endpoints that produce messages to the topic
+	Producers *[]EndpointRef `json:"producers"`
+	// Consumers are the endpoints that consume messages from the topic
+	Consumers *[]EndpointRef `json:"consumers"`
+}
+
+// MakeTopicFromRequest constructs a Topic DTO from an HTTP request
+func MakeTopicFromRequest(c *gin.Context) (Topic, error) {
+	var t Topic
+	err := c.ShouldBindJSON(&t)
+	if err != nil {
+		return t, errors.InvalidInput("failed to unmarshall json to Topic", &err)
+	}
+	err = t.validate()
+	return t, err
+}
+
+// ToEntity converts a Topic DTO into a Topic Entity
+func (t *Topic) ToEntity() topic.Topic {
+	return topic.MakeTopic(nil, *t.Code, *t.Name, endpointRefsToEntities(t.Producers), endpointRefsToEntities(t.Consumers))
+}
+
+// MakeTopicFromEntity constructs a Topic DTO from a Topic Entity
+func MakeTopicFromEntity(t topic.Topic) Topic {
+	producers := makeEndpointRefsFromEntities(t.Producers)
+	consumers := makeEndpointRefsFromEntities(t.Consumers)
+	return Topic{
+		Code:      &t.Code,
+		Name:      &t.Name,
+		Producers: &producers,
+		Consumers: &consumers,
+	}
+}
+
+// MakeTopicsFromEntities constructs Topic DTOs from Topic Entities
+func MakeTopicsFromEntities(topics []topic.Topic) []Topic {
+	topicDTOs := make([]Topic, len(topics))
+	for idx, t := range topics {
+		topicDTOs[idx] = MakeTopicFromEntity(t)
+	}
+	return topicDTOs
+}
+
+func endpointRefsToEntities(refs *[]EndpointRef) []service.EndpointRef {
+	if refs == nil {
+		return make([]service.EndpointRef, 0)
+	}
+	entities := make([]service.EndpointRef, len(*refs))
+	for idx, ref := range *refs {
+		entities[idx] = service.EndpointRef{ServiceCode: ref.ServiceCode, EndpointCode: ref.EndpointCode}
+	}
+	return entities
+}
+
+func makeEndpointRefsFromEntities(refs []service.EndpointRef) []EndpointRef {
+	refDTOs := make([]EndpointRef, len(refs))
+	for idx, ref := range refs {
+		refDTOs[idx] = MakeEndpointRefFromEntity(ref)
+	}
+	return refDTOs
+}
+
+func (t Topic) validate() error {
+	if t.Code == nil {
+		return errors.InvalidInput("field 'code' on object Topic is required", nil)
+	}
+	if t.Name == nil {
+		return errors.InvalidInput("field 'name' on object Topic is required", nil)
+	}
+	for _, refs := range []*[]EndpointRef{t.Producers, t.Consumers} {
+		if refs == nil {
+			continue
+		}
+		for _, ref := range *refs {
+			if ref.ServiceCode == "" || ref.EndpointCode == "" {
+				return errors.InvalidInput(
+					"fields 'service_code' and 'endpoint_code' on producers and consumers of object Topic are required",
+					nil,
+				)
+			}
+		}
+	}
+	return nil
+}
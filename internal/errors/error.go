@@ -13,9 +13,21 @@ type Error struct {
 	Message    string
 	StatusCode int
 	SubCode    uuid.UUID
+	Details    []Detail
 	cause      *error // TODO: read about interfaces, should this be a pointer? Or is an interface automatically nil-able?
 }
 
+// Detail is a structured description of one of the specific problems behind an Error. It is returned to clients as a
+// JSON object, so keys should be snake_case, like the rest of the API
+type Detail = map[string]interface{}
+
+var (
+	// ServiceNotFoundSubCode is the sub code of ServiceNotFound errors, and of Details about Services that don't exist
+	ServiceNotFoundSubCode = uuid.MustParse("4b281f39-2eaf-4e09-8b6f-ffb277ea0cbb")
+	// EndpointNotFoundSubCode is the sub code of EndpointNotFound errors, and of Details about Endpoints that don't exist
+	EndpointNotFoundSubCode = uuid.MustParse("0e86e5ad-e332-4962-b138-34dddade1dd1")
+)
+
 var sentinel error = errors.New("error did not have a cause")
 
 func (e *Error) Error() string {
@@ -32,6 +44,15 @@ func (e *Error) Unwrap() error {
 	return sentinel
 }
 
+// Message is the message of an Error, without its status code, sub code and cause, or the text of any other error
+func Message(err error) string {
+	var e *Error
+	if errors.As(err, &e) {
+		return e.Message
+	}
+	return err.Error()
+}
+
 func SetResponse(err error, c *gin.Context) {
 	var e *Error
 	if errors.As(err, &e) {
@@ -40,14 +61,15 @@ func SetResponse(err error, c *gin.Context) {
 			causeMsg := e.Unwrap().Error()
 			cause = &causeMsg
 		}
-		c.JSON(
-			e.StatusCode,
-			gin.H{
-				"message":  e.Message,
-				"sub_code": e.SubCode.String(),
-				"cause":    cause,
-			},
-		)
+		body := gin.H{
+			"message":  e.Message,
+			"sub_code": e.SubCode.String(),
+			"cause":    cause,
+		}
+		if len(e.Details) > 0 {
+			body["details"] = e.Details
+		}
+		c.JSON(e.StatusCode, body)
 	} else {
 		SetResponse(UnclassifiedError("unclassified error", &err), c)
 	}
@@ -66,7 +88,7 @@ func ServiceNotFound(message string, cause *error) error {
 	return &Error{
 		Message:    message,
 		StatusCode: http.StatusNotFound,
-		SubCode:    uuid.MustParse("4b281f39-2eaf-4e09-8b6f-ffb277ea0cbb"),
+		SubCode:    ServiceNotFoundSubCode,
 		cause:      cause,
 	}
 }
@@ -75,11 +97,92 @@ func EndpointNotFound(message string, cause *error) error {
 	return &Error{
 		Message:    message,
 		StatusCode: http.StatusNotFound,
-		SubCode:    uuid.MustParse("0e86e5ad-e332-4962-b138-34dddade1dd1"),
+		SubCode:    EndpointNotFoundSubCode,
+		cause:      cause,
+	}
+}
+
+func TopicNotFound(message string, cause *error) error {
+	return &Error{
+		Message:    message,
+		StatusCode: http.StatusNotFound,
+		SubCode:    uuid.MustParse("aeb8028a-c077-49e8-8a2a-7994f360292c"),
+		cause:      cause,
+	}
+}
+
+func ClientNotFound(message string, cause *error) error {
+	return &Error{
+		Message:    message,
+		StatusCode: http.StatusNotFound,
+		SubCode:    uuid.MustParse("7a795513-175a-431a-9b10-f271da83b78a"),
+		cause:      cause,
+	}
+}
+
+func PolicyRuleNotFound(message string, cause *error) error {
+	return &Error{
+		Message:    message,
+		StatusCode: http.StatusNotFound,
+		SubCode:    uuid.MustParse("77e5e935-246d-4f53-a36f-f9e4e7fbdc81"),
 		cause:      cause,
 	}
 }
 
+func UnresolvedDependencies(message string, details []Detail) error {
+	return &Error{
+		Message:    message,
+		StatusCode: http.StatusUnprocessableEntity,
+		SubCode:    uuid.MustParse("d50a89d7-5242-4d88-b140-9db2cde7d352"),
+		Details:    details,
+	}
+}
+
+func RetiredDependencies(message string, details []Detail) error {
+	return &Error{
+		Message:    message,
+		StatusCode: http.StatusUnprocessableEntity,
+		SubCode:    uuid.MustParse("19a4350f-e5f1-4fd1-ac15-5ff0d0b0760d"),
+		Details:    details,
+	}
+}
+
+func HasDependents(message string, details []Detail) error {
+	return &Error{
+		Message:    message,
+		StatusCode: http.StatusConflict,
+		SubCode:    uuid.MustParse("d1a7aaf4-822f-48e0-96f1-c61d3086fa69"),
+		Details:    details,
+	}
+}
+
+func IntroducesCycle(message string, details []Detail) error {
+	return &Error{
+		Message:    message,
+		StatusCode: http.StatusConflict,
+		SubCode:    uuid.MustParse("bef15c8e-d4ec-4e59-8f52-036b1c416f4f"),
+		Details:    details,
+	}
+}
+
+func PolicyViolations(message string, details []Detail) error {
+	return &Error{
+		Message:    message,
+		StatusCode: http.StatusUnprocessableEntity,
+		SubCode:    uuid.MustParse("22ee2fa0-83f1-4800-9d69-8e59bc0b0bb4"),
+		Details:    details,
+	}
+}
+
+func InvalidOperations(message string, details []Detail) error {
+	return &Error{
+		Message:    message,
+		StatusCode: http.StatusBadRequest,
+		SubCode:    uuid.MustParse("c01e058d-e139-4e83-aad7-d98c8f7f190d"),
+		Details:    details,
+	}
+}
+
 func DatabaseError(message string, cause *error) error {
 	return &Error{
 		Message:    message,
@@ -0,0 +1,224 @@
+package export
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/yashap/crius/internal/domain/graph"
+	"github.com/yashap/crius/internal/domain/service"
+)
+
+// diffStatus is whether a node or edge of a graph diff was added, removed, or is in both versions of the graph
+type diffStatus int
+
+const (
+	unchanged diffStatus = iota
+	added
+	removed
+)
+
+const (
+	dotAddedColor           = "green4"
+	dotRemovedColor         = "red3"
+	mermaidAddedStyle       = "fill:#e6f4ea,stroke:#1e8e3e,color:#1e8e3e"
+	mermaidRemovedStyle     = "fill:#fce8e6,stroke:#d93025,color:#d93025,stroke-dasharray:5 5"
+	mermaidAddedLinkStyle   = "stroke:#1e8e3e"
+	mermaidRemovedLinkStyle = "stroke:#d93025,stroke-dasharray:5 5"
+)
+
+// diffStatuses knows the diffStatus of every node and edge in the union of two versions of a graph
+type diffStatuses struct {
+	services     map[service.Code]diffStatus
+	endpoints    map[service.EndpointRef]diffStatus
+	edges        map[service.DependencyEdge]diffStatus
+	serviceEdges map[[2]service.Code]diffStatus
+}
+
+// makeDiffStatuses finds the diffStatus of every node and edge in the union of two versions of a graph
+func makeDiffStatuses(before graph.Graph, after graph.Graph) diffStatuses {
+	diff := graph.Compare(before, after)
+	statuses := diffStatuses{
+		services:     make(map[service.Code]diffStatus),
+		endpoints:    make(map[service.EndpointRef]diffStatus),
+		edges:        make(map[service.DependencyEdge]diffStatus),
+		serviceEdges: make(map[[2]service.Code]diffStatus),
+	}
+	for _, code := range diff.AddedServices {
+		statuses.services[code] = added
+	}
+	for _, code := range diff.RemovedServices {
+		statuses.services[code] = removed
+	}
+	for _, ref := range diff.AddedEndpoints {
+		statuses.endpoints[ref] = added
+	}
+	for _, ref := range diff.RemovedEndpoints {
+		statuses.endpoints[ref] = removed
+	}
+	for _, edge := range diff.Dependencies.Added {
+		statuses.edges[edge] = added
+	}
+	for _, edge := range diff.Dependencies.Removed {
+		statuses.edges[edge] = removed
+	}
+	beforeServiceEdges := make(map[[2]service.Code]bool)
+	for _, edge := range before.ServiceEdges() {
+		beforeServiceEdges[[2]service.Code{edge.From, edge.To}] = true
+	}
+	afterServiceEdges := make(map[[2]service.Code]bool)
+	for _, edge := range after.ServiceEdges() {
+		afterServiceEdges[[2]service.Code{edge.From, edge.To}] = true
+		if !beforeServiceEdges[[2]service.Code{edge.From, edge.To}] {
+			statuses.serviceEdges[[2]service.Code{edge.From, edge.To}] = added
+		}
+	}
+	for pair := range beforeServiceEdges {
+		if !afterServiceEdges[pair] {
+			statuses.serviceEdges[pair] = removed
+		}
+	}
+	return statuses
+}
+
+// DiffDOT renders the difference between two versions of a dependency graph in the Graphviz DOT language. Everything in
+// either version is drawn, with what was added in green, and what was removed in dashed red
+func DiffDOT(before graph.Graph, after graph.Graph, granularity Granularity) string {
+	g := graph.Union(before, after)
+	statuses := makeDiffStatuses(before, after)
+	var b strings.Builder
+	b.WriteString("digraph crius {\n")
+	b.WriteString("  rankdir=LR;\n")
+	b.WriteString("  node [shape=box];\n")
+	if granularity == ServiceGranularity {
+		for _, svc := range g.Services {
+			attributes := append(
+				[]string{"label=" + dotID(serviceLabel(svc))},
+				dotDiffAttributes(statuses.services[svc.Code])...,
+			)
+			fmt.Fprintf(&b, "  %s%s;\n", dotID(svc.Code), dotAttributeList(attributes))
+		}
+		for _, edge := range g.ServiceEdges() {
+			attributes := append(
+				[]string{"label=" + dotID(fmt.Sprint(edge.Count))},
+				dotDiffAttributes(statuses.serviceEdges[[2]service.Code{edge.From, edge.To}])...,
+			)
+			fmt.Fprintf(&b, "  %s -> %s%s;\n", dotID(edge.From), dotID(edge.To), dotAttributeList(attributes))
+		}
+	} else {
+		for _, svc := range g.Services {
+			fmt.Fprintf(&b, "  subgraph %s {\n", dotID("cluster_"+svc.Code))
+			fmt.Fprintf(&b, "    label=%s;\n", dotID(serviceLabel(svc)))
+			for _, attribute := range dotDiffAttributes(statuses.services[svc.Code]) {
+				fmt.Fprintf(&b, "    %s;\n", attribute)
+			}
+			for _, endpoint := range svc.Endpoints {
+				ref := service.EndpointRef{ServiceCode: svc.Code, EndpointCode: endpoint.Code}
+				attributes := append([]string{"label=" + dotID(endpoint.Code)}, dotDiffAttributes(statuses.endpoints[ref])...)
+				fmt.Fprintf(&b, "    %s%s;\n", dotID(endpointID(ref)), dotAttributeList(attributes))
+			}
+			b.WriteString("  }\n")
+		}
+		for _, edge := range g.Edges() {
+			attributes := dotAttributeList(dotDiffAttributes(statuses.edges[edge]))
+			fmt.Fprintf(&b, "  %s -> %s%s;\n", dotID(endpointID(edge.From)), dotID(endpointID(edge.To)), attributes)
+		}
+	}
+	b.WriteString("}\n")
+	return b.String()
+}
+
+// dotDiffAttributes are the DOT attributes that color a node, edge or cluster by its diffStatus
+func dotDiffAttributes(status diffStatus) []string {
+	switch status {
+	case added:
+		return []string{"color=" + dotAddedColor, "fontcolor=" + dotAddedColor}
+	case removed:
+		return []string{"color=" + dotRemovedColor, "fontcolor=" + dotRemovedColor, "style=dashed"}
+	default:
+		return nil
+	}
+}
+
+// dotAttributeList formats DOT attributes as an attribute list, like ` [label="a", color=red3]`, or as nothing if there
+// are none
+func dotAttributeList(attributes []string) string {
+	if len(attributes) == 0 {
+		return ""
+	}
+	return " [" + strings.Join(attributes, ", ") + "]"
+}
+
+// DiffMermaid renders the difference between two versions of a dependency graph as a Mermaid flowchart. Everything in
+// either version is drawn, with what was added in green, and what was removed in dashed red
+func DiffMermaid(before graph.Graph, after graph.Graph, granularity Granularity) string {
+	g := graph.Union(before, after)
+	statuses := makeDiffStatuses(before, after)
+	ids := makeNodeIDs(g)
+	var b strings.Builder
+	// Styles must come after the nodes and edges that they style, so they are collected and written at the end
+	var styles strings.Builder
+	style := func(id string, status diffStatus) {
+		if style := mermaidDiffStyle(status); style != "" {
+			fmt.Fprintf(&styles, "  style %s %s\n", id, style)
+		}
+	}
+	edgeIdx := 0
+	linkStyle := func(status diffStatus) {
+		if status != unchanged {
+			fmt.Fprintf(&styles, "  linkStyle %d %s\n", edgeIdx, mermaidLinkStyle(status))
+		}
+		edgeIdx++
+	}
+	b.WriteString("flowchart LR\n")
+	if granularity == ServiceGranularity {
+		for _, svc := range g.Services {
+			fmt.Fprintf(&b, "  %s[%s]\n", ids.services[svc.Code], mermaidLabel(serviceLabel(svc)))
+			style(ids.services[svc.Code], statuses.services[svc.Code])
+		}
+		for _, edge := range g.ServiceEdges() {
+			fmt.Fprintf(&b, "  %s -->|%d| %s\n", ids.services[edge.From], edge.Count, ids.services[edge.To])
+			linkStyle(statuses.serviceEdges[[2]service.Code{edge.From, edge.To}])
+		}
+	} else {
+		for _, svc := range g.Services {
+			fmt.Fprintf(&b, "  subgraph %s[%s]\n", ids.services[svc.Code], mermaidLabel(serviceLabel(svc)))
+			style(ids.services[svc.Code], statuses.services[svc.Code])
+			for _, endpoint := range svc.Endpoints {
+				id := ids.endpoint(svc.Code, endpoint.Code)
+				fmt.Fprintf(&b, "    %s[%s]\n", id, mermaidLabel(endpoint.Code))
+				style(id, statuses.endpoints[service.EndpointRef{ServiceCode: svc.Code, EndpointCode: endpoint.Code}])
+			}
+			b.WriteString("  end\n")
+		}
+		for _, edge := range g.Edges() {
+			fmt.Fprintf(&b, "  %s --> %s\n", ids.endpoints[edge.From], ids.endpoints[edge.To])
+			linkStyle(statuses.edges[edge])
+		}
+	}
+	b.WriteString(styles.String())
+	return b.String()
+}
+
+// mermaidDiffStyle is the Mermaid style that colors a node by its diffStatus
+func mermaidDiffStyle(status diffStatus) string {
+	switch status {
+	case added:
+		return mermaidAddedStyle
+	case removed:
+		return mermaidRemovedStyle
+	default:
+		return ""
+	}
+}
+
+// mermaidLinkStyle is the Mermaid style that colors an edge by its diffStatus
+func mermaidLinkStyle(status diffStatus) string {
+	switch status {
+	case added:
+		return mermaidAddedLinkStyle
+	case removed:
+		return mermaidRemovedLinkStyle
+	default:
+		return ""
+	}
+}
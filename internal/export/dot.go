@@ -0,0 +1,51 @@
+package export
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/yashap/crius/internal/domain/graph"
+	"github.com/yashap/crius/internal/domain/service"
+)
+
+// DOT renders a dependency graph in the Graphviz DOT language
+func DOT(g graph.Graph, granularity Granularity) string {
+	var b strings.Builder
+	b.WriteString("digraph crius {\n")
+	b.WriteString("  rankdir=LR;\n")
+	b.WriteString("  node [shape=box];\n")
+	if granularity == ServiceGranularity {
+		for _, svc := range g.Services {
+			fmt.Fprintf(&b, "  %s [label=%s];\n", dotID(svc.Code), dotID(serviceLabel(svc)))
+		}
+		for _, edge := range g.ServiceEdges() {
+			fmt.Fprintf(&b, "  %s -> %s [label=%s];\n", dotID(edge.From), dotID(edge.To), dotID(fmt.Sprint(edge.Count)))
+		}
+	} else {
+		for _, svc := range g.Services {
+			fmt.Fprintf(&b, "  subgraph %s {\n", dotID("cluster_"+svc.Code))
+			fmt.Fprintf(&b, "    label=%s;\n", dotID(serviceLabel(svc)))
+			for _, endpoint := range svc.Endpoints {
+				ref := service.EndpointRef{ServiceCode: svc.Code, EndpointCode: endpoint.Code}
+				fmt.Fprintf(&b, "    %s [label=%s];\n", dotID(endpointID(ref)), dotID(endpoint.Code))
+			}
+			b.WriteString("  }\n")
+		}
+		for _, edge := range g.Edges() {
+			fmt.Fprintf(&b, "  %s -> %s;\n", dotID(endpointID(edge.From)), dotID(endpointID(edge.To)))
+		}
+	}
+	b.WriteString("}\n")
+	return b.String()
+}
+
+// endpointID is the id of an Endpoint node. Endpoint codes are only unique within a Service, so the Service code is
+// included
+func endpointID(ref service.EndpointRef) string {
+	return ref.ServiceCode + "/" + ref.EndpointCode
+}
+
+// dotID quotes a string as a DOT id, so that it may contain any characters
+func dotID(id string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(id) + `"`
+}
@@ -0,0 +1,52 @@
+// Package export renders the dependency graph in diagram languages, like Graphviz DOT, Mermaid and PlantUML
+package export
+
+import (
+	"fmt"
+
+	"github.com/yashap/crius/internal/domain/graph"
+	"github.com/yashap/crius/internal/domain/service"
+)
+
+// Granularity is the level of detail that a dependency graph is exported at
+type Granularity = string
+
+const (
+	// EndpointGranularity exports every Endpoint as a node, grouped by Service
+	EndpointGranularity Granularity = "endpoint"
+	// ServiceGranularity collapses each Service into a single node, with one edge per pair of dependent Services,
+	// labelled with the number of Endpoint dependencies between them
+	ServiceGranularity Granularity = "service"
+)
+
+// serviceLabel is the human readable label of a Service node or cluster
+func serviceLabel(svc service.Service) string {
+	return fmt.Sprintf("%s (%s)", svc.Name, svc.Code)
+}
+
+// nodeIDs are short, syntax-safe node ids for the Services and Endpoints of a graph, for diagram languages whose ids
+// can't be arbitrary quoted strings
+type nodeIDs struct {
+	services  map[service.Code]string
+	endpoints map[service.EndpointRef]string
+}
+
+// makeNodeIDs numbers the Services and Endpoints of a graph in order, so the same graph always gets the same ids
+func makeNodeIDs(g graph.Graph) nodeIDs {
+	ids := nodeIDs{
+		services:  make(map[service.Code]string),
+		endpoints: make(map[service.EndpointRef]string),
+	}
+	for serviceIdx, svc := range g.Services {
+		ids.services[svc.Code] = fmt.Sprintf("s%d", serviceIdx)
+		for endpointIdx, endpoint := range svc.Endpoints {
+			ref := service.EndpointRef{ServiceCode: svc.Code, EndpointCode: endpoint.Code}
+			ids.endpoints[ref] = fmt.Sprintf("s%d_e%d", serviceIdx, endpointIdx)
+		}
+	}
+	return ids
+}
+
+func (ids nodeIDs) endpoint(serviceCode service.Code, endpointCode service.EndpointCode) string {
+	return ids.endpoints[service.EndpointRef{ServiceCode: serviceCode, EndpointCode: endpointCode}]
+}
@@ -0,0 +1,40 @@
+package export
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/yashap/crius/internal/domain/graph"
+)
+
+// Mermaid renders a dependency graph as a Mermaid flowchart
+func Mermaid(g graph.Graph, granularity Granularity) string {
+	ids := makeNodeIDs(g)
+	var b strings.Builder
+	b.WriteString("flowchart LR\n")
+	if granularity == ServiceGranularity {
+		for _, svc := range g.Services {
+			fmt.Fprintf(&b, "  %s[%s]\n", ids.services[svc.Code], mermaidLabel(serviceLabel(svc)))
+		}
+		for _, edge := range g.ServiceEdges() {
+			fmt.Fprintf(&b, "  %s -->|%d| %s\n", ids.services[edge.From], edge.Count, ids.services[edge.To])
+		}
+	} else {
+		for _, svc := range g.Services {
+			fmt.Fprintf(&b, "  subgraph %s[%s]\n", ids.services[svc.Code], mermaidLabel(serviceLabel(svc)))
+			for _, endpoint := range svc.Endpoints {
+				fmt.Fprintf(&b, "    %s[%s]\n", ids.endpoint(svc.Code, endpoint.Code), mermaidLabel(endpoint.Code))
+			}
+			b.WriteString("  end\n")
+		}
+		for _, edge := range g.Edges() {
+			fmt.Fprintf(&b, "  %s --> %s\n", ids.endpoints[edge.From], ids.endpoints[edge.To])
+		}
+	}
+	return b.String()
+}
+
+// mermaidLabel quotes a Mermaid node label, so that it may contain characters like brackets and slashes
+func mermaidLabel(label string) string {
+	return `"` + strings.NewReplacer(`"`, "#quot;", "\n", " ").Replace(label) + `"`
+}
@@ -0,0 +1,41 @@
+package export
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/yashap/crius/internal/domain/graph"
+)
+
+// PlantUML renders a dependency graph as a PlantUML component diagram
+func PlantUML(g graph.Graph, granularity Granularity) string {
+	ids := makeNodeIDs(g)
+	var b strings.Builder
+	b.WriteString("@startuml\n")
+	if granularity == ServiceGranularity {
+		for _, svc := range g.Services {
+			fmt.Fprintf(&b, "component %s as %s\n", plantUMLLabel(serviceLabel(svc)), ids.services[svc.Code])
+		}
+		for _, edge := range g.ServiceEdges() {
+			fmt.Fprintf(&b, "%s --> %s : %d\n", ids.services[edge.From], ids.services[edge.To], edge.Count)
+		}
+	} else {
+		for _, svc := range g.Services {
+			fmt.Fprintf(&b, "package %s {\n", plantUMLLabel(serviceLabel(svc)))
+			for _, endpoint := range svc.Endpoints {
+				fmt.Fprintf(&b, "  component %s as %s\n", plantUMLLabel(endpoint.Code), ids.endpoint(svc.Code, endpoint.Code))
+			}
+			b.WriteString("}\n")
+		}
+		for _, edge := range g.Edges() {
+			fmt.Fprintf(&b, "%s --> %s\n", ids.endpoints[edge.From], ids.endpoints[edge.To])
+		}
+	}
+	b.WriteString("@enduml\n")
+	return b.String()
+}
+
+// plantUMLLabel quotes a PlantUML label. PlantUML has no way of escaping double quotes, so they become single quotes
+func plantUMLLabel(label string) string {
+	return `"` + strings.NewReplacer(`"`, "'", "\n", " ").Replace(label) + `"`
+}
@@ -0,0 +1,160 @@
+package integration_test
+
+import (
+	"net/url"
+	"path/filepath"
+	"testing"
+
+	"github.com/franela/goblin"
+	"github.com/gin-gonic/gin"
+	. "github.com/onsi/gomega"
+	"github.com/yashap/crius/internal/app"
+	"github.com/yashap/crius/internal/integration_test/util"
+)
+
+func TestClients(t *testing.T) {
+	g := goblin.Goblin(t)
+	RegisterFailHandler(func(m string, _ ...int) { g.Fail(m) })
+	relativeMigrationsDir := "../../script/postgresql/migrations"
+	migrationsDir, err := filepath.Abs(relativeMigrationsDir)
+	if err != nil {
+		t.Errorf("Could not convert to absolute path: %s ; Error: %s", relativeMigrationsDir, err.Error())
+	}
+	crius := app.NewCrius(testDB.URL).MigrateDB(migrationsDir)
+
+	g.Describe("POST /clients", func() {
+		g.It("Should set up services for clients to call", func() {
+			for _, postBody := range []gin.H{
+				{
+					"code": "rosters",
+					"name": "Rosters",
+					"endpoints": []gin.H{
+						{"code": "GET /rosters/{id}", "name": "Get roster"},
+					},
+				},
+				{
+					"code": "web_bff",
+					"name": "Web BFF",
+					"endpoints": []gin.H{
+						{
+							"code":         "GET /bff/rosters/{id}",
+							"name":         "Get roster page",
+							"dependencies": gin.H{"rosters": []string{"GET /rosters/{id}"}},
+						},
+					},
+				},
+			} {
+				Expect(util.HttpRequest(crius.Router(), "POST", "/services", postBody).Code).To(Equal(200))
+			}
+		})
+
+		g.It("Should create clients", func() {
+			for _, postBody := range []gin.H{
+				{
+					"code":         "ios_v5",
+					"name":         "iOS App v5",
+					"dependencies": gin.H{"rosters": []string{"GET /rosters/{id}"}},
+				},
+				{
+					"code":         "web",
+					"name":         "Web App",
+					"dependencies": gin.H{"web_bff": []string{"GET /bff/rosters/{id}"}},
+				},
+			} {
+				Expect(util.HttpRequest(crius.Router(), "POST", "/clients", postBody).Code).To(Equal(200))
+			}
+			response := util.HttpRequest(crius.Router(), "GET", "/clients/ios_v5", nil)
+			Expect(response.Code).To(Equal(200))
+			Expect(response.Body["name"]).To(Equal("iOS App v5"))
+			Expect(response.Body["dependencies"]).To(Equal(map[string]interface{}{
+				"rosters": []interface{}{"GET /rosters/{id}"},
+			}))
+			Expect(util.HttpRequest(crius.Router(), "GET", "/clients", nil).Body["clients"]).To(HaveLen(2))
+		})
+
+		g.It("Should reject dependencies that don't exist", func() {
+			postBody := gin.H{
+				"code":         "android",
+				"name":         "Android App",
+				"dependencies": gin.H{"rosters": []string{"GET /nope"}, "nope": []string{"GET /nope"}},
+			}
+			response := util.HttpRequest(crius.Router(), "POST", "/clients", postBody)
+			Expect(response.Code).To(Equal(422))
+			Expect(response.Body["details"]).To(HaveLen(2))
+			Expect(util.HttpRequest(crius.Router(), "GET", "/clients/android", nil).Code).To(Equal(404))
+		})
+	})
+
+	g.Describe("Dependents of services that clients call", func() {
+		g.It("Should report clients that call a service directly or transitively", func() {
+			response := util.HttpRequest(crius.Router(), "GET", "/services/rosters/dependents", nil)
+			Expect(response.Code).To(Equal(200))
+			clients := response.Body["clients"].([]interface{})
+			Expect(clients).To(HaveLen(2))
+			Expect(clients[0]).To(Equal(map[string]interface{}{
+				"client_code": "ios_v5",
+				"client_name": "iOS App v5",
+				"distance":    float64(1),
+				"path": []interface{}{
+					map[string]interface{}{"service_code": "rosters", "endpoint_code": "GET /rosters/{id}"},
+				},
+			}))
+			Expect(clients[1].(map[string]interface{})["client_code"]).To(Equal("web"))
+			Expect(clients[1].(map[string]interface{})["distance"]).To(Equal(float64(2)))
+			Expect(response.Body["summary"].(map[string]interface{})["affected_clients"]).To(Equal(float64(2)))
+		})
+
+		g.It("Should only report clients within the requested depth", func() {
+			path := "/services/rosters/endpoints/" + url.PathEscape("GET /rosters/{id}") + "/dependents?transitive=false"
+			response := util.HttpRequest(crius.Router(), "GET", path, nil)
+			Expect(response.Code).To(Equal(200))
+			clients := response.Body["clients"].([]interface{})
+			Expect(clients).To(HaveLen(1))
+			Expect(clients[0].(map[string]interface{})["client_code"]).To(Equal("ios_v5"))
+		})
+	})
+
+	g.Describe("DELETE /clients/:code", func() {
+		g.It("Should delete a client, so it no longer depends on anything", func() {
+			Expect(util.HttpRequest(crius.Router(), "DELETE", "/clients/ios_v5", nil).Code).To(Equal(200))
+			Expect(util.HttpRequest(crius.Router(), "GET", "/clients/ios_v5", nil).Code).To(Equal(404))
+			Expect(util.HttpRequest(crius.Router(), "DELETE", "/clients/ios_v5", nil).Code).To(Equal(404))
+			response := util.HttpRequest(crius.Router(), "GET", "/services/rosters/dependents", nil)
+			Expect(response.Body["clients"]).To(HaveLen(1))
+		})
+
+	})
+
+	g.Describe("DELETE /services/:code of services that clients call", func() {
+		g.It("Should refuse to delete services and endpoints that clients call", func() {
+			response := util.HttpRequest(crius.Router(), "DELETE", "/services/web_bff", nil)
+			Expect(response.Code).To(Equal(409))
+			Expect(response.Body["details"]).To(HaveLen(1))
+			detail := response.Body["details"].([]interface{})[0].(map[string]interface{})
+			Expect(detail["client_code"]).To(Equal("web"))
+			Expect(detail["dependency_endpoint_code"]).To(Equal("GET /bff/rosters/{id}"))
+
+			path := "/services/web_bff/endpoints/" + url.PathEscape("GET /bff/rosters/{id}")
+			Expect(util.HttpRequest(crius.Router(), "DELETE", path, nil).Code).To(Equal(409))
+		})
+
+		g.It("Should force delete a service, removing the client dependencies on it", func() {
+			response := util.HttpRequest(crius.Router(), "DELETE", "/services/web_bff?force=true", nil)
+			Expect(response.Code).To(Equal(200))
+			Expect(response.Body["removed_dependencies"]).To(HaveLen(0))
+			Expect(response.Body["removed_client_dependencies"]).To(Equal([]interface{}{
+				map[string]interface{}{
+					"client_code": "web",
+					"to":          map[string]interface{}{"service_code": "web_bff", "endpoint_code": "GET /bff/rosters/{id}"},
+				},
+			}))
+			response = util.HttpRequest(crius.Router(), "GET", "/clients/web", nil)
+			Expect(response.Body["dependencies"]).To(BeEmpty())
+		})
+
+		g.It("Should clean up", func() {
+			Expect(util.HttpRequest(crius.Router(), "DELETE", "/clients/web", nil).Code).To(Equal(200))
+			Expect(util.HttpRequest(crius.Router(), "DELETE", "/services/rosters", nil).Code).To(Equal(200))
+		})
+	})
+}
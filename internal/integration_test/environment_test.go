@@ -0,0 +1,326 @@
+package integration_test
+
+import (
+	"net/url"
+	"path/filepath"
+	"testing"
+
+	"github.com/franela/goblin"
+	"github.com/gin-gonic/gin"
+	. "github.com/onsi/gomega"
+	"github.com/yashap/crius/internal/app"
+	"github.com/yashap/crius/internal/integration_test/util"
+)
+
+func TestEnvironments(t *testing.T) {
+	g := goblin.Goblin(t)
+	RegisterFailHandler(func(m string, _ ...int) { g.Fail(m) })
+	relativeMigrationsDir := "../../script/postgresql/migrations"
+	migrationsDir, err := filepath.Abs(relativeMigrationsDir)
+	if err != nil {
+		t.Errorf("Could not convert to absolute path: %s ; Error: %s", relativeMigrationsDir, err.Error())
+	}
+	crius := app.NewCrius(testDB.URL).MigrateDB(migrationsDir)
+	endpointCodes := func(env string, code string) []interface{} {
+		response := util.HttpRequest(crius.Router(), "GET", "/envs/"+env+"/services/"+code, nil)
+		Expect(response.Code).To(Equal(200))
+		codes := make([]interface{}, 0)
+		for _, endpoint := range response.Body["endpoints"].([]interface{}) {
+			codes = append(codes, endpoint.(map[string]interface{})["code"])
+		}
+		return codes
+	}
+
+	g.Describe("/envs/:env", func() {
+		g.It("Should keep each environment's services apart", func() {
+			for _, postBody := range []gin.H{
+				{
+					"code":      "anchorage",
+					"name":      "Anchorage",
+					"endpoints": []gin.H{{"code": "GET /docks", "name": "Get docks"}},
+				},
+				{
+					"code": "quay",
+					"name": "Quay",
+					"endpoints": []gin.H{
+						{
+							"code":         "GET /berths",
+							"name":         "Get berths",
+							"dependencies": gin.H{"anchorage": []string{"GET /docks"}},
+						},
+					},
+				},
+			} {
+				Expect(util.HttpRequest(crius.Router(), "POST", "/envs/staging/services", postBody).Code).To(Equal(200))
+			}
+			postBody := gin.H{
+				"code":         "harbour_app",
+				"name":         "Harbour App",
+				"dependencies": gin.H{"quay": []string{"GET /berths"}},
+			}
+			Expect(util.HttpRequest(crius.Router(), "POST", "/envs/staging/clients", postBody).Code).To(Equal(200))
+
+			Expect(util.HttpRequest(crius.Router(), "GET", "/envs/staging/services/quay", nil).Code).To(Equal(200))
+			Expect(util.HttpRequest(crius.Router(), "GET", "/envs/production/services/quay", nil).Code).To(Equal(404))
+			Expect(util.HttpRequest(crius.Router(), "GET", "/services/quay", nil).Code).To(Equal(404))
+			response := util.HttpRequest(crius.Router(), "GET", "/envs/staging/services/quay/dependencies", nil)
+			Expect(response.Code).To(Equal(200))
+			Expect(response.Body["dependencies"]).To(HaveLen(1))
+		})
+
+		g.It("Should only resolve dependencies within an environment", func() {
+			postBody := gin.H{
+				"code": "quay",
+				"name": "Quay",
+				"endpoints": []gin.H{
+					{
+						"code":         "GET /berths",
+						"name":         "Get berths",
+						"dependencies": gin.H{"anchorage": []string{"GET /docks"}},
+					},
+				},
+			}
+			response := util.HttpRequest(crius.Router(), "POST", "/envs/production/services", postBody)
+			Expect(response.Code).To(Equal(422))
+		})
+
+		g.It("Should reject invalid environments", func() {
+			Expect(util.HttpRequest(crius.Router(), "GET", "/envs/Staging!/services", nil).Code).To(Equal(400))
+		})
+	})
+
+	g.Describe("POST /envs/:env/promote", func() {
+		g.It("Should set up an environment that has drifted", func() {
+			for _, postBody := range []gin.H{
+				{
+					"code": "anchorage",
+					"name": "Anchorage",
+					"endpoints": []gin.H{
+						{"code": "GET /docks", "name": "Get docks"},
+						{"code": "GET /cranes", "name": "Get cranes"},
+					},
+				},
+				{
+					"code": "quay",
+					"name": "Old Quay",
+					"endpoints": []gin.H{
+						{
+							"code":         "GET /berths",
+							"name":         "Get berths",
+							"dependencies": gin.H{"anchorage": []string{"GET /cranes"}},
+						},
+					},
+				},
+				{
+					"code":      "buoy_tender",
+					"name":      "Buoy Tender",
+					"endpoints": []gin.H{{"code": "GET /buoys", "name": "Get buoys"}},
+				},
+			} {
+				response := util.HttpRequest(crius.Router(), "POST", "/envs/production/services", postBody)
+				Expect(response.Code).To(Equal(200))
+			}
+		})
+
+		g.It("Should diff one environment's graph against another's", func() {
+			response := util.HttpRequest(crius.Router(), "GET", "/graph/diff?fromEnv=production&toEnv=staging", nil)
+			Expect(response.Code).To(Equal(200))
+			Expect(response.Body["services"]).To(Equal(map[string]interface{}{
+				"added":   []interface{}{},
+				"removed": []interface{}{"buoy_tender"},
+			}))
+			Expect(response.Body["dependencies"]).To(Equal(map[string]interface{}{
+				"added": []interface{}{
+					map[string]interface{}{
+						"from": map[string]interface{}{"service_code": "quay", "endpoint_code": "GET /berths"},
+						"to":   map[string]interface{}{"service_code": "anchorage", "endpoint_code": "GET /docks"},
+					},
+				},
+				"removed": []interface{}{
+					map[string]interface{}{
+						"from": map[string]interface{}{"service_code": "quay", "endpoint_code": "GET /berths"},
+						"to":   map[string]interface{}{"service_code": "anchorage", "endpoint_code": "GET /cranes"},
+					},
+				},
+			}))
+			// An environment compared against itself still needs from
+			response = util.HttpRequest(crius.Router(), "GET", "/envs/staging/graph/diff?toEnv=staging", nil)
+			Expect(response.Code).To(Equal(400))
+			response = util.HttpRequest(crius.Router(), "GET", "/graph/diff?fromEnv=Prod!&toEnv=staging", nil)
+			Expect(response.Code).To(Equal(400))
+		})
+
+		g.It("Should leave the target environment as it was when a promotion fails", func() {
+			postBody := gin.H{
+				"code": "lighthouse",
+				"name": "Lighthouse",
+				"endpoints": []gin.H{
+					{
+						"code":         "GET /beams",
+						"name":         "Get beams",
+						"dependencies": gin.H{"anchorage": []string{"GET /cranes"}},
+					},
+				},
+			}
+			response := util.HttpRequest(crius.Router(), "POST", "/envs/production/services", postBody)
+			Expect(response.Code).To(Equal(200))
+
+			// Staging's anchorage doesn't have the endpoint that production's lighthouse depends on
+			response = util.HttpRequest(crius.Router(), "POST", "/envs/staging/promote?to=production", nil)
+			Expect(response.Code).To(Equal(409))
+			Expect(endpointCodes("production", "anchorage")).To(ConsistOf("GET /docks", "GET /cranes"))
+			response = util.HttpRequest(crius.Router(), "GET", "/envs/production/services/quay", nil)
+			Expect(response.Body["name"]).To(Equal("Old Quay"))
+			response = util.HttpRequest(crius.Router(), "GET", "/envs/production/services/quay/history", nil)
+			Expect(response.Body["changes"]).To(HaveLen(1))
+			Expect(util.HttpRequest(crius.Router(), "GET", "/envs/production/clients/harbour_app", nil).Code).
+				To(Equal(404))
+
+			Expect(util.HttpRequest(crius.Router(), "DELETE", "/envs/production/services/lighthouse", nil).Code).
+				To(Equal(200))
+		})
+
+		g.It("Should copy one environment's graph into another", func() {
+			response := util.HttpRequest(crius.Router(), "POST", "/envs/staging/promote?to=production", nil)
+			Expect(response.Code).To(Equal(200))
+			Expect(response.Body["services"]).To(Equal(map[string]interface{}{
+				"saved":   []interface{}{"anchorage", "quay"},
+				"deleted": []interface{}{},
+			}))
+			Expect(response.Body["clients"]).To(Equal(map[string]interface{}{
+				"saved":   []interface{}{"harbour_app"},
+				"deleted": []interface{}{},
+			}))
+
+			// The endpoint that only production had is removed, even though production depended on it
+			Expect(endpointCodes("production", "anchorage")).To(Equal([]interface{}{"GET /docks"}))
+			response = util.HttpRequest(crius.Router(), "GET", "/envs/production/services/quay", nil)
+			Expect(response.Body["name"]).To(Equal("Quay"))
+			response = util.HttpRequest(crius.Router(), "GET", "/envs/production/services/quay/history", nil)
+			Expect(response.Body["changes"]).To(HaveLen(2))
+			Expect(util.HttpRequest(crius.Router(), "GET", "/envs/production/clients/harbour_app", nil).Code).
+				To(Equal(200))
+			Expect(util.HttpRequest(crius.Router(), "GET", "/envs/production/services/buoy_tender", nil).Code).
+				To(Equal(200))
+		})
+
+		g.It("Should delete what the source environment doesn't have when pruning", func() {
+			response := util.HttpRequest(crius.Router(), "POST", "/envs/staging/promote?to=production&prune=true", nil)
+			Expect(response.Code).To(Equal(200))
+			Expect(response.Body["services"].(map[string]interface{})["deleted"]).To(Equal([]interface{}{"buoy_tender"}))
+			Expect(util.HttpRequest(crius.Router(), "GET", "/envs/production/services/buoy_tender", nil).Code).
+				To(Equal(404))
+		})
+
+		g.It("Should keep placeholders as placeholders", func() {
+			postBody := gin.H{
+				"code": "pilot_boat",
+				"name": "Pilot Boat",
+				"endpoints": []gin.H{
+					{
+						"code": "GET /pilots",
+						"name": "Get pilots",
+						"dependencies": gin.H{
+							"anchorage":  []string{"GET /moorings"},
+							"coastguard": []string{"GET /alerts"},
+						},
+					},
+				},
+			}
+			response := util.HttpRequest(crius.Router(), "POST", "/envs/staging/services?placeholders=true", postBody)
+			Expect(response.Code).To(Equal(200))
+			response = util.HttpRequest(crius.Router(), "POST", "/envs/staging/promote?to=production", nil)
+			Expect(response.Code).To(Equal(200))
+
+			response = util.HttpRequest(crius.Router(), "GET", "/envs/production/services/anchorage", nil)
+			Expect(response.Body["confirmed"]).To(Equal(true))
+			confirmed := make(map[interface{}]interface{})
+			for _, endpoint := range response.Body["endpoints"].([]interface{}) {
+				confirmed[endpoint.(map[string]interface{})["code"]] = endpoint.(map[string]interface{})["confirmed"]
+			}
+			Expect(confirmed).To(Equal(map[interface{}]interface{}{"GET /docks": true, "GET /moorings": false}))
+			response = util.HttpRequest(crius.Router(), "GET", "/envs/production/services/coastguard", nil)
+			Expect(response.Body["confirmed"]).To(Equal(false))
+			Expect(response.Body["endpoints"].([]interface{})[0].(map[string]interface{})["confirmed"]).To(Equal(false))
+
+			for _, env := range []string{"staging", "production"} {
+				for _, path := range []string{
+					"/services/pilot_boat",
+					"/services/anchorage/endpoints/" + url.PathEscape("GET /moorings"),
+					"/services/coastguard",
+				} {
+					Expect(util.HttpRequest(crius.Router(), "DELETE", "/envs/"+env+path, nil).Code).To(Equal(200))
+				}
+			}
+		})
+
+		g.It("Should promote services that depend on each other whatever order their codes are in", func() {
+			postBody := gin.H{
+				"code":      "tugboat",
+				"name":      "Tugboat",
+				"endpoints": []gin.H{{"code": "GET /tows", "name": "Get tows", "lifecycle": "retired"}},
+			}
+			response := util.HttpRequest(crius.Router(), "POST", "/envs/production/services", postBody)
+			Expect(response.Code).To(Equal(200))
+			for _, postBody := range []gin.H{
+				{
+					"code": "tugboat",
+					"name": "Tugboat",
+					"endpoints": []gin.H{
+						{"code": "GET /tows", "name": "Get tows"},
+						{"code": "GET /escorts", "name": "Get escorts"},
+					},
+				},
+				{
+					"code": "water_taxi",
+					"name": "Water Taxi",
+					"endpoints": []gin.H{
+						{
+							"code":         "GET /rides",
+							"name":         "Get rides",
+							"dependencies": gin.H{"tugboat": []string{"GET /tows", "GET /escorts"}},
+						},
+					},
+				},
+			} {
+				response = util.HttpRequest(crius.Router(), "POST", "/envs/staging/services", postBody)
+				Expect(response.Code).To(Equal(200))
+			}
+
+			// water_taxi is saved before tugboat, whose endpoint is still retired in production until then
+			response = util.HttpRequest(crius.Router(), "POST", "/envs/staging/promote?to=production", nil)
+			Expect(response.Code).To(Equal(200))
+			response = util.HttpRequest(crius.Router(), "GET", "/envs/production/services/tugboat", nil)
+			for _, endpoint := range response.Body["endpoints"].([]interface{}) {
+				Expect(endpoint.(map[string]interface{})["lifecycle"]).To(Equal("active"))
+				Expect(endpoint.(map[string]interface{})["confirmed"]).To(Equal(true))
+			}
+			response = util.HttpRequest(crius.Router(), "GET", "/envs/production/services/water_taxi/dependencies", nil)
+			Expect(response.Body["dependencies"]).To(HaveLen(2))
+
+			for _, env := range []string{"staging", "production"} {
+				for _, code := range []string{"water_taxi", "tugboat"} {
+					Expect(util.HttpRequest(crius.Router(), "DELETE", "/envs/"+env+"/services/"+code, nil).Code).
+						To(Equal(200))
+				}
+			}
+		})
+
+		g.It("Should reject invalid targets", func() {
+			Expect(util.HttpRequest(crius.Router(), "POST", "/envs/staging/promote", nil).Code).To(Equal(400))
+			Expect(util.HttpRequest(crius.Router(), "POST", "/envs/staging/promote?to=staging", nil).Code).To(Equal(400))
+			Expect(util.HttpRequest(crius.Router(), "POST", "/envs/staging/promote?to=Prod!", nil).Code).To(Equal(400))
+		})
+
+		g.It("Should clean up", func() {
+			for _, env := range []string{"staging", "production"} {
+				Expect(util.HttpRequest(crius.Router(), "DELETE", "/envs/"+env+"/clients/harbour_app", nil).Code).
+					To(Equal(200))
+				for _, code := range []string{"quay", "anchorage"} {
+					Expect(util.HttpRequest(crius.Router(), "DELETE", "/envs/"+env+"/services/"+code, nil).Code).
+						To(Equal(200))
+				}
+			}
+		})
+	})
+}
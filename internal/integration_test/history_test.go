@@ -0,0 +1,429 @@
+package integration_test
+
+import (
+	"fmt"
+	"net/url"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/franela/goblin"
+	"github.com/gin-gonic/gin"
+	"github.com/jmoiron/sqlx"
+	. "github.com/onsi/gomega"
+	"github.com/yashap/crius/internal/app"
+	"github.com/yashap/crius/internal/integration_test/util"
+)
+
+func TestHistory(t *testing.T) {
+	g := goblin.Goblin(t)
+	RegisterFailHandler(func(m string, _ ...int) { g.Fail(m) })
+	relativeMigrationsDir := "../../script/postgresql/migrations"
+	migrationsDir, err := filepath.Abs(relativeMigrationsDir)
+	if err != nil {
+		t.Errorf("Could not convert to absolute path: %s ; Error: %s", relativeMigrationsDir, err.Error())
+	}
+	crius := app.NewCrius(testDB.URL).MigrateDB(migrationsDir)
+	as := func(actor string) map[string]string { return map[string]string{"X-Crius-Actor": actor} }
+	changes := func(code string) []interface{} {
+		response := util.HttpRequest(crius.Router(), "GET", "/services/"+code+"/history", nil)
+		Expect(response.Code).To(Equal(200))
+		return response.Body["changes"].([]interface{})
+	}
+
+	g.Describe("GET /services/:code/history", func() {
+		g.It("Should record services being created", func() {
+			for _, postBody := range []gin.H{
+				{
+					"code":      "stockroom",
+					"name":      "Stockroom",
+					"endpoints": []gin.H{{"code": "GET /stock", "name": "Get stock"}},
+				},
+				{
+					"code": "depot",
+					"name": "Depot",
+					"endpoints": []gin.H{
+						{
+							"code":         "GET /shelves",
+							"name":         "Get shelves",
+							"dependencies": gin.H{"stockroom": []string{"GET /stock"}},
+						},
+					},
+				},
+			} {
+				response := util.HttpRequestWithHeaders(crius.Router(), "POST", "/services", postBody, as("alice"))
+				Expect(response.Code).To(Equal(200))
+			}
+			history := changes("stockroom")
+			Expect(history).To(HaveLen(1))
+			created := history[0].(map[string]interface{})
+			Expect(created["version"]).To(Equal(float64(1)))
+			Expect(created["change_type"]).To(Equal("created"))
+			Expect(created["actor"]).To(Equal("alice"))
+			diff := created["diff"].(map[string]interface{})
+			Expect(diff["fields"]).To(ContainElement(map[string]interface{}{
+				"field":  "name",
+				"before": nil,
+				"after":  "Stockroom",
+			}))
+			Expect(diff["added_endpoints"]).To(Equal([]interface{}{"GET /stock"}))
+			Expect(diff["removed_endpoints"]).To(BeEmpty())
+		})
+
+		g.It("Should record nothing for changes that are rolled back", func() {
+			// The stockroom can't be deleted while the depot depends on it
+			Expect(util.HttpRequest(crius.Router(), "DELETE", "/services/stockroom", nil).Code).To(Equal(409))
+			Expect(changes("stockroom")).To(HaveLen(1))
+			Expect(changes("depot")).To(HaveLen(1))
+		})
+
+		g.It("Should record updates as diffs from the previous version", func() {
+			patchBody := gin.H{"name": "Stock Room", "tier": 1}
+			Expect(util.HttpRequest(crius.Router(), "PATCH", "/services/stockroom", patchBody).Code).To(Equal(200))
+			history := changes("stockroom")
+			Expect(history).To(HaveLen(2))
+			updated := history[1].(map[string]interface{})
+			Expect(updated["version"]).To(Equal(float64(2)))
+			Expect(updated["change_type"]).To(Equal("updated"))
+			Expect(updated["actor"]).To(Equal("anonymous"))
+			Expect(updated["diff"].(map[string]interface{})["fields"]).To(Equal([]interface{}{
+				map[string]interface{}{"field": "name", "before": "Stockroom", "after": "Stock Room"},
+				map[string]interface{}{"field": "tier", "before": nil, "after": float64(1)},
+			}))
+
+			// Saves that change nothing aren't recorded
+			Expect(util.HttpRequest(crius.Router(), "PATCH", "/services/stockroom", patchBody).Code).To(Equal(200))
+			Expect(changes("stockroom")).To(HaveLen(2))
+		})
+
+		g.It("Should record changes to endpoints and their dependencies", func() {
+			putBody := gin.H{"name": "List shelves"}
+			response := util.HttpRequestWithHeaders(
+				crius.Router(),
+				"PUT",
+				"/services/depot/endpoints/"+url.PathEscape("GET /shelves"),
+				putBody,
+				as("bob"),
+			)
+			Expect(response.Code).To(Equal(200))
+			history := changes("depot")
+			Expect(history).To(HaveLen(2))
+			updated := history[1].(map[string]interface{})
+			Expect(updated["actor"]).To(Equal("bob"))
+			diff := updated["diff"].(map[string]interface{})
+			Expect(diff["fields"]).To(BeEmpty())
+			Expect(diff["changed_endpoints"]).To(Equal([]interface{}{
+				map[string]interface{}{
+					"code": "GET /shelves",
+					"fields": []interface{}{
+						map[string]interface{}{"field": "name", "before": "Get shelves", "after": "List shelves"},
+					},
+				},
+			}))
+			Expect(diff["dependencies"]).To(Equal(map[string]interface{}{
+				"added": []interface{}{},
+				"removed": []interface{}{
+					map[string]interface{}{
+						"from": map[string]interface{}{"service_code": "depot", "endpoint_code": "GET /shelves"},
+						"to":   map[string]interface{}{"service_code": "stockroom", "endpoint_code": "GET /stock"},
+					},
+				},
+			}))
+		})
+
+		g.It("Should keep the history of deleted services", func() {
+			response := util.HttpRequestWithHeaders(crius.Router(), "DELETE", "/services/stockroom", nil, as("carol"))
+			Expect(response.Code).To(Equal(200))
+			history := changes("stockroom")
+			Expect(history).To(HaveLen(3))
+			deleted := history[2].(map[string]interface{})
+			Expect(deleted["change_type"]).To(Equal("deleted"))
+			Expect(deleted["actor"]).To(Equal("carol"))
+			Expect(deleted["diff"].(map[string]interface{})["removed_endpoints"]).To(Equal([]interface{}{"GET /stock"}))
+			Expect(util.HttpRequest(crius.Router(), "GET", "/services/warehouse_that_never_was/history", nil).Code).
+				To(Equal(404))
+		})
+
+		g.It("Should clean up", func() {
+			Expect(util.HttpRequest(crius.Router(), "DELETE", "/services/depot", nil).Code).To(Equal(200))
+		})
+	})
+
+	g.Describe("?asOf=", func() {
+		var before string
+		asOf := func(path string) util.HttpResponse {
+			return util.HttpRequest(crius.Router(), "GET", path+"asOf="+url.QueryEscape(before), nil)
+		}
+
+		g.It("Should set up a graph, and then change it", func() {
+			for _, postBody := range []gin.H{
+				{
+					"code":      "ferry",
+					"name":      "Ferry",
+					"endpoints": []gin.H{{"code": "GET /routes", "name": "Get routes"}},
+				},
+				{
+					"code": "harbour",
+					"name": "Harbour",
+					"endpoints": []gin.H{
+						{
+							"code":         "GET /berths",
+							"name":         "Get berths",
+							"dependencies": gin.H{"ferry": []string{"GET /routes"}},
+						},
+					},
+				},
+			} {
+				Expect(util.HttpRequest(crius.Router(), "POST", "/services", postBody).Code).To(Equal(200))
+			}
+			time.Sleep(10 * time.Millisecond)
+			before = time.Now().UTC().Format(time.RFC3339Nano)
+			time.Sleep(10 * time.Millisecond)
+			postBody := gin.H{
+				"code":      "harbour",
+				"name":      "Harbour",
+				"endpoints": []gin.H{{"code": "GET /berths", "name": "Get berths"}},
+			}
+			Expect(util.HttpRequest(crius.Router(), "POST", "/services", postBody).Code).To(Equal(200))
+			Expect(util.HttpRequest(crius.Router(), "DELETE", "/services/ferry", nil).Code).To(Equal(200))
+		})
+
+		g.It("Should get services as they were", func() {
+			response := asOf("/services/ferry?")
+			Expect(response.Code).To(Equal(200))
+			Expect(response.Body["name"]).To(Equal("Ferry"))
+			Expect(util.HttpRequest(crius.Router(), "GET", "/services/ferry", nil).Code).To(Equal(404))
+			response = util.HttpRequest(crius.Router(), "GET", "/services/harbour?asOf=2000-01-01T00:00:00Z", nil)
+			Expect(response.Code).To(Equal(404))
+		})
+
+		g.It("Should walk dependencies as they were", func() {
+			response := asOf("/services/harbour/dependencies?")
+			Expect(response.Code).To(Equal(200))
+			dependencies := response.Body["dependencies"].([]interface{})
+			Expect(dependencies).To(HaveLen(1))
+			Expect(dependencies[0].(map[string]interface{})["service_code"]).To(Equal("ferry"))
+			response = util.HttpRequest(crius.Router(), "GET", "/services/harbour/dependencies", nil)
+			Expect(response.Body["dependencies"]).To(BeEmpty())
+
+			response = asOf("/services/ferry/endpoints/" + url.PathEscape("GET /routes") + "/dependents?")
+			Expect(response.Code).To(Equal(200))
+			Expect(response.Body["dependents"]).To(HaveLen(1))
+		})
+
+		g.It("Should render and analyze the graph as it was", func() {
+			response := asOf("/graph.dot?")
+			Expect(response.Code).To(Equal(200))
+			Expect(response.Body["body"]).To(ContainSubstring("ferry"))
+			response = util.HttpRequest(crius.Router(), "GET", "/graph.dot", nil)
+			Expect(response.Body["body"]).NotTo(ContainSubstring("ferry"))
+			Expect(asOf("/graph/cycles?").Code).To(Equal(200))
+		})
+
+		g.It("Should reject timestamps that aren't RFC3339", func() {
+			response := util.HttpRequest(crius.Router(), "GET", "/services/harbour?asOf=last+tuesday", nil)
+			Expect(response.Code).To(Equal(400))
+			Expect(util.HttpRequest(crius.Router(), "DELETE", "/services/harbour", nil).Code).To(Equal(200))
+		})
+	})
+
+	g.Describe("?asOf= with topics and clients", func() {
+		var before, between string
+		asOf := func(path string, at string) util.HttpResponse {
+			return util.HttpRequest(crius.Router(), "GET", path+"asOf="+url.QueryEscape(at), nil)
+		}
+		lock := func(endpoints ...gin.H) gin.H {
+			return gin.H{"code": "lock", "name": "Lock", "endpoints": endpoints}
+		}
+		openEndpoint := gin.H{"code": "POST /locks/{id}/open", "name": "Open lock"}
+		levels := "/services/canal/endpoints/" + url.PathEscape("GET /levels") + "/dependents?"
+
+		g.It("Should set up topics and clients, and then change them", func() {
+			for _, postBody := range []gin.H{
+				lock(openEndpoint),
+				{
+					"code": "canal",
+					"name": "Canal",
+					"endpoints": []gin.H{
+						{"code": "CONSUME lock_opened", "name": "Consume lock opened"},
+						{"code": "GET /levels", "name": "Get water levels"},
+					},
+				},
+			} {
+				Expect(util.HttpRequest(crius.Router(), "POST", "/services", postBody).Code).To(Equal(200))
+			}
+			postBody := gin.H{
+				"code":      "lock_opened",
+				"name":      "Lock Opened",
+				"producers": []gin.H{{"service_code": "lock", "endpoint_code": "POST /locks/{id}/open"}},
+				"consumers": []gin.H{{"service_code": "canal", "endpoint_code": "CONSUME lock_opened"}},
+			}
+			Expect(util.HttpRequest(crius.Router(), "POST", "/topics", postBody).Code).To(Equal(200))
+			postBody = gin.H{
+				"code":         "barge_app",
+				"name":         "Barge App",
+				"dependencies": gin.H{"canal": []string{"GET /levels"}},
+			}
+			Expect(util.HttpRequest(crius.Router(), "POST", "/clients", postBody).Code).To(Equal(200))
+			time.Sleep(10 * time.Millisecond)
+			before = time.Now().UTC().Format(time.RFC3339Nano)
+			time.Sleep(10 * time.Millisecond)
+			// Removing the producer unlinks it from the topic, which adding it back doesn't undo
+			Expect(util.HttpRequest(crius.Router(), "POST", "/services", lock()).Code).To(Equal(200))
+			Expect(util.HttpRequest(crius.Router(), "POST", "/services", lock(openEndpoint)).Code).To(Equal(200))
+			time.Sleep(10 * time.Millisecond)
+			between = time.Now().UTC().Format(time.RFC3339Nano)
+			time.Sleep(10 * time.Millisecond)
+			Expect(util.HttpRequest(crius.Router(), "DELETE", "/topics/lock_opened", nil).Code).To(Equal(200))
+			Expect(util.HttpRequest(crius.Router(), "DELETE", "/clients/barge_app", nil).Code).To(Equal(200))
+		})
+
+		g.It("Should walk the edges through topics as they were", func() {
+			response := asOf("/services/lock/dependencies?", before)
+			Expect(response.Code).To(Equal(200))
+			dependencies := response.Body["dependencies"].([]interface{})
+			Expect(dependencies).To(HaveLen(1))
+			Expect(dependencies[0].(map[string]interface{})["service_code"]).To(Equal("canal"))
+			Expect(asOf("/services/lock/dependencies?", between).Body["dependencies"]).To(BeEmpty())
+			response = util.HttpRequest(crius.Router(), "GET", "/services/lock/dependencies", nil)
+			Expect(response.Body["dependencies"]).To(BeEmpty())
+		})
+
+		g.It("Should report the clients that called an endpoint as they were", func() {
+			response := asOf(levels, before)
+			Expect(response.Code).To(Equal(200))
+			clients := response.Body["clients"].([]interface{})
+			Expect(clients).To(HaveLen(1))
+			Expect(clients[0].(map[string]interface{})["client_code"]).To(Equal("barge_app"))
+			Expect(asOf(levels, between).Body["clients"]).To(HaveLen(1))
+			response = util.HttpRequest(crius.Router(), "GET", strings.TrimSuffix(levels, "?"), nil)
+			Expect(response.Body["clients"]).To(BeEmpty())
+		})
+
+		g.It("Should clean up", func() {
+			for _, code := range []string{"lock", "canal"} {
+				Expect(util.HttpRequest(crius.Router(), "DELETE", "/services/"+code, nil).Code).To(Equal(200))
+			}
+		})
+	})
+
+	g.Describe("GET /graph/diff", func() {
+		var snapshotID string
+		diff := func(query string) util.HttpResponse {
+			return util.HttpRequest(crius.Router(), "GET", "/graph/diff?from="+snapshotID+query, nil)
+		}
+
+		g.It("Should set up a graph, and then change it", func() {
+			postBody := gin.H{
+				"code":      "lighthouse",
+				"name":      "Lighthouse",
+				"endpoints": []gin.H{{"code": "GET /beams", "name": "Get beams"}},
+			}
+			Expect(util.HttpRequest(crius.Router(), "POST", "/services", postBody).Code).To(Equal(200))
+			history := changes("lighthouse")
+			snapshotID = fmt.Sprintf("%d", int64(history[0].(map[string]interface{})["id"].(float64)))
+			time.Sleep(10 * time.Millisecond)
+			postBody = gin.H{
+				"code": "buoy",
+				"name": "Buoy",
+				"endpoints": []gin.H{
+					{
+						"code":         "GET /signals",
+						"name":         "Get signals",
+						"dependencies": gin.H{"lighthouse": []string{"GET /beams"}},
+					},
+				},
+			}
+			Expect(util.HttpRequest(crius.Router(), "POST", "/services", postBody).Code).To(Equal(200))
+		})
+
+		g.It("Should report what was added and removed since a snapshot", func() {
+			response := diff("")
+			Expect(response.Code).To(Equal(200))
+			Expect(response.Body["services"]).To(Equal(map[string]interface{}{
+				"added":   []interface{}{"buoy"},
+				"removed": []interface{}{},
+			}))
+			Expect(response.Body["endpoints"]).To(Equal(map[string]interface{}{
+				"added": []interface{}{
+					map[string]interface{}{"service_code": "buoy", "endpoint_code": "GET /signals"},
+				},
+				"removed": []interface{}{},
+			}))
+			Expect(response.Body["dependencies"]).To(Equal(map[string]interface{}{
+				"added": []interface{}{
+					map[string]interface{}{
+						"from": map[string]interface{}{"service_code": "buoy", "endpoint_code": "GET /signals"},
+						"to":   map[string]interface{}{"service_code": "lighthouse", "endpoint_code": "GET /beams"},
+					},
+				},
+				"removed": []interface{}{},
+			}))
+
+			// Snapshot ids and timestamps can be mixed, and from can be later than to
+			response = util.HttpRequest(
+				crius.Router(),
+				"GET",
+				"/graph/diff?from="+url.QueryEscape(time.Now().UTC().Format(time.RFC3339Nano))+"&to="+snapshotID,
+				nil,
+			)
+			Expect(response.Code).To(Equal(200))
+			Expect(response.Body["services"].(map[string]interface{})["removed"]).To(Equal([]interface{}{"buoy"}))
+		})
+
+		g.It("Should render the diff as a colored graph", func() {
+			response := diff("&format=dot")
+			Expect(response.Code).To(Equal(200))
+			Expect(response.Body["body"]).To(ContainSubstring("green4"))
+			response = diff("&format=mermaid&granularity=service")
+			Expect(response.Code).To(Equal(200))
+			Expect(response.Body["body"]).To(ContainSubstring("buoy"))
+		})
+
+		g.It("Should reject versions that aren't timestamps or known snapshot ids", func() {
+			Expect(util.HttpRequest(crius.Router(), "GET", "/graph/diff", nil).Code).To(Equal(400))
+			Expect(util.HttpRequest(crius.Router(), "GET", "/graph/diff?from=yesterday", nil).Code).To(Equal(400))
+			Expect(util.HttpRequest(crius.Router(), "GET", "/graph/diff?from=999999999", nil).Code).To(Equal(400))
+			Expect(diff("&format=svg").Code).To(Equal(400))
+		})
+
+		g.It("Should clean up", func() {
+			Expect(util.HttpRequest(crius.Router(), "DELETE", "/services/buoy", nil).Code).To(Equal(200))
+			Expect(util.HttpRequest(crius.Router(), "DELETE", "/services/lighthouse", nil).Code).To(Equal(200))
+		})
+	})
+
+	g.Describe("Backfilling", func() {
+		g.It("Should record services that were last saved before history was recorded", func() {
+			postBody := gin.H{
+				"code":      "granary",
+				"name":      "Granary",
+				"endpoints": []gin.H{{"code": "GET /grain", "name": "Get grain"}},
+			}
+			Expect(util.HttpRequest(crius.Router(), "POST", "/services", postBody).Code).To(Equal(200))
+			database, err := sqlx.Connect(testDB.URL.Driver, testDB.URL.DSN)
+			Expect(err).To(BeNil())
+			defer database.Close()
+			_, err = database.Exec("delete from service_history where service_code = 'granary'")
+			Expect(err).To(BeNil())
+			Expect(util.HttpRequest(crius.Router(), "GET", "/services/granary/history", nil).Code).To(Equal(404))
+
+			app.NewCrius(testDB.URL).MigrateDB(migrationsDir)
+			history := changes("granary")
+			Expect(history).To(HaveLen(1))
+			created := history[0].(map[string]interface{})
+			Expect(created["change_type"]).To(Equal("created"))
+			Expect(created["actor"]).To(Equal("backfill"))
+			Expect(created["diff"].(map[string]interface{})["added_endpoints"]).To(Equal([]interface{}{"GET /grain"}))
+
+			// Backfilling again records nothing new
+			app.NewCrius(testDB.URL).MigrateDB(migrationsDir)
+			Expect(changes("granary")).To(HaveLen(1))
+		})
+
+		g.It("Should clean up", func() {
+			Expect(util.HttpRequest(crius.Router(), "DELETE", "/services/granary", nil).Code).To(Equal(200))
+		})
+	})
+}
@@ -0,0 +1,223 @@
+package integration_test
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/franela/goblin"
+	"github.com/gin-gonic/gin"
+	. "github.com/onsi/gomega"
+	"github.com/yashap/crius/internal/app"
+	"github.com/yashap/crius/internal/integration_test/util"
+)
+
+const rosterOpenAPI = `
+openapi: 3.0.3
+info:
+  title: Roster
+  version: 1.2.0
+paths:
+  /teams:
+    post:
+      operationId: createTeam
+      responses:
+        "201":
+          description: Created
+  /teams/{id}:
+    parameters:
+      - name: id
+        in: path
+        required: true
+    get:
+      operationId: getTeam
+      summary: Get a team
+      responses:
+        "200":
+          description: OK
+`
+
+const dockOpenAPI = `{
+	"openapi": "3.1.0",
+	"info": {"title": "Dock"},
+	"paths": {
+		"/berths": {"get": {"summary": "List berths"}}
+	}
+}`
+
+const rosterWithDependenciesOpenAPI = `
+openapi: 3.0.3
+info:
+  title: Roster
+paths:
+  /teams:
+    post:
+      operationId: createTeam
+      x-crius-dependencies:
+        crew:
+          - GET /sailors/{id}
+  /teams/{id}:
+    get:
+      summary: Get a team
+`
+
+const malformedDependenciesOpenAPI = `
+openapi: 3.0.3
+paths:
+  /teams:
+    post:
+      x-crius-dependencies:
+        - crew
+  /teams/{id}:
+    get:
+      x-crius-dependencies:
+        crew@^two:
+          - GET /sailors/{id}
+    delete:
+      x-crius-dependencies:
+        crew:
+          - GET /sailors/{id}
+`
+
+func TestOpenAPI(t *testing.T) {
+	g := goblin.Goblin(t)
+	RegisterFailHandler(func(m string, _ ...int) { g.Fail(m) })
+	relativeMigrationsDir := "../../script/postgresql/migrations"
+	migrationsDir, err := filepath.Abs(relativeMigrationsDir)
+	if err != nil {
+		t.Errorf("Could not convert to absolute path: %s ; Error: %s", relativeMigrationsDir, err.Error())
+	}
+	crius := app.NewCrius(testDB.URL).MigrateDB(migrationsDir)
+	endpoints := func(code string) map[string]map[string]interface{} {
+		response := util.HttpRequest(crius.Router(), "GET", "/services/"+code, nil)
+		Expect(response.Code).To(Equal(200))
+		byCode := make(map[string]map[string]interface{})
+		for _, endpoint := range response.Body["endpoints"].([]interface{}) {
+			byCode[endpoint.(map[string]interface{})["code"].(string)] = endpoint.(map[string]interface{})
+		}
+		return byCode
+	}
+	importOpenAPI := func(code string, document string) util.HttpResponse {
+		return util.HttpRequestWithRawBody(crius.Router(), "POST", "/services/"+code+"/import/openapi", document)
+	}
+
+	g.Describe("POST /services/:code/import/openapi", func() {
+		g.It("Should replace a service's endpoints, keeping the dependencies of those that still exist", func() {
+			for _, postBody := range []gin.H{
+				{
+					"code":      "crew",
+					"name":      "Crew",
+					"endpoints": []gin.H{{"code": "GET /sailors/{id}", "name": "Get sailor"}},
+				},
+				{
+					"code": "roster",
+					"name": "Team Roster",
+					"team": "rostering",
+					"endpoints": []gin.H{
+						{
+							"code":         "GET /teams/{id}",
+							"name":         "Get team",
+							"dependencies": gin.H{"crew": []string{"GET /sailors/{id}"}},
+						},
+						{"code": "GET /legacy", "name": "Legacy"},
+					},
+				},
+			} {
+				Expect(util.HttpRequest(crius.Router(), "POST", "/services", postBody).Code).To(Equal(200))
+			}
+			response := importOpenAPI("roster", rosterOpenAPI)
+			Expect(response.Code).To(Equal(200))
+
+			rosterEndpoints := endpoints("roster")
+			Expect(rosterEndpoints).To(HaveLen(2))
+			Expect(rosterEndpoints["GET /teams/{id}"]["name"]).To(Equal("Get a team"))
+			Expect(rosterEndpoints["GET /teams/{id}"]["dependencies"]).To(Equal(map[string]interface{}{
+				"crew": []interface{}{"GET /sailors/{id}"},
+			}))
+			Expect(rosterEndpoints["POST /teams"]["name"]).To(Equal("createTeam"))
+			// The rest of the service is left alone, but it takes the document's version
+			response = util.HttpRequest(crius.Router(), "GET", "/services/roster", nil)
+			Expect(response.Body["name"]).To(Equal("Team Roster"))
+			Expect(response.Body["team"]).To(Equal("rostering"))
+			Expect(response.Body["version"]).To(Equal("1.2.0"))
+		})
+
+		g.It("Should create a service from a JSON document", func() {
+			response := importOpenAPI("dock", dockOpenAPI)
+			Expect(response.Code).To(Equal(200))
+			response = util.HttpRequest(crius.Router(), "GET", "/services/dock", nil)
+			Expect(response.Body["name"]).To(Equal("Dock"))
+			Expect(response.Body["version"]).To(BeNil())
+			Expect(endpoints("dock")).To(HaveKey("GET /berths"))
+		})
+
+		g.It("Should reject documents that aren't OpenAPI 3", func() {
+			for _, body := range []string{
+				"swagger: \"2.0\"\npaths: {}\n",
+				"openapi: 3.0.3\npaths: [\n",
+				"openapi: 3.0.3\npaths:\n  teams:\n    get: {}\n",
+			} {
+				Expect(importOpenAPI("dock", body).Code).To(Equal(400))
+			}
+		})
+
+		g.It("Should declare dependencies with the x-crius-dependencies extension", func() {
+			response := importOpenAPI("roster", rosterWithDependenciesOpenAPI)
+			Expect(response.Code).To(Equal(200))
+			Expect(response.Body["dependencies"].(map[string]interface{})["added"]).To(HaveLen(1))
+			rosterEndpoints := endpoints("roster")
+			Expect(rosterEndpoints["POST /teams"]["dependencies"]).To(Equal(map[string]interface{}{
+				"crew": []interface{}{"GET /sailors/{id}"},
+			}))
+			// Operations without the extension keep their endpoint's dependencies
+			Expect(rosterEndpoints["GET /teams/{id}"]["dependencies"]).To(Equal(map[string]interface{}{
+				"crew": []interface{}{"GET /sailors/{id}"},
+			}))
+		})
+
+		g.It("Should report each operation with a malformed x-crius-dependencies extension", func() {
+			response := importOpenAPI("roster", malformedDependenciesOpenAPI)
+			Expect(response.Code).To(Equal(400))
+			operations := make([]interface{}, 0)
+			for _, detail := range response.Body["details"].([]interface{}) {
+				operations = append(operations, detail.(map[string]interface{})["operation"])
+			}
+			Expect(operations).To(Equal([]interface{}{"POST /teams", "GET /teams/{id}"}))
+			Expect(endpoints("roster")).To(HaveLen(2))
+		})
+
+		g.It("Should report an info.version that isn't a semantic version", func() {
+			document := strings.Replace(dockOpenAPI, `"title": "Dock"`, `"title": "Dock", "version": "2021-06"`, 1)
+			response := importOpenAPI("dock", document)
+			Expect(response.Code).To(Equal(400))
+			detail := response.Body["details"].([]interface{})[0].(map[string]interface{})
+			Expect(detail["field"]).To(Equal("info.version"))
+		})
+
+		g.It("Should keep unconfirmed endpoints that the document doesn't list", func() {
+			postBody := gin.H{
+				"code": "fleet",
+				"name": "Fleet",
+				"endpoints": []gin.H{
+					{
+						"code":         "GET /ships",
+						"name":         "List ships",
+						"dependencies": gin.H{"roster": []string{"GET /rotas"}},
+					},
+				},
+			}
+			response := util.HttpRequest(crius.Router(), "POST", "/services?placeholders=true", postBody)
+			Expect(response.Code).To(Equal(200))
+			Expect(importOpenAPI("roster", rosterOpenAPI).Code).To(Equal(200))
+			rosterEndpoints := endpoints("roster")
+			Expect(rosterEndpoints).To(HaveLen(3))
+			Expect(rosterEndpoints["GET /rotas"]["confirmed"]).To(Equal(false))
+		})
+
+		g.It("Should clean up", func() {
+			for _, code := range []string{"fleet", "roster", "dock", "crew"} {
+				Expect(util.HttpRequest(crius.Router(), "DELETE", "/services/"+code, nil).Code).To(Equal(200))
+			}
+		})
+	})
+}
@@ -0,0 +1,248 @@
+package integration_test
+
+import (
+	"io/ioutil"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/franela/goblin"
+	"github.com/gin-gonic/gin"
+	. "github.com/onsi/gomega"
+	"github.com/yashap/crius/internal/app"
+	"github.com/yashap/crius/internal/integration_test/util"
+)
+
+const policyFile = `
+rules:
+  - code: ledger_only_called_by_payments
+    description: Nothing outside the payments domain may call the ledger directly
+    effect: allow
+    from:
+      selector: domain=payments
+    to:
+      services: [ledger]
+`
+
+func TestPolicies(t *testing.T) {
+	g := goblin.Goblin(t)
+	RegisterFailHandler(func(m string, _ ...int) { g.Fail(m) })
+	relativeMigrationsDir := "../../script/postgresql/migrations"
+	migrationsDir, err := filepath.Abs(relativeMigrationsDir)
+	if err != nil {
+		t.Errorf("Could not convert to absolute path: %s ; Error: %s", relativeMigrationsDir, err.Error())
+	}
+	file, err := ioutil.TempFile("", "crius-policies-*.yaml")
+	if err != nil {
+		t.Errorf("Could not create policy file ; Error: %s", err.Error())
+	}
+	defer os.Remove(file.Name())
+	_, err = file.WriteString(policyFile)
+	if err != nil {
+		t.Errorf("Could not write policy file ; Error: %s", err.Error())
+	}
+	_ = file.Close()
+	crius := app.NewCrius(testDB.URL).MigrateDB(migrationsDir).LoadPolicies(file.Name())
+
+	g.Describe("/policies", func() {
+		g.It("Should save policy rules through the API", func() {
+			postBody := gin.H{
+				"code":        "no_new_deprecated_dependencies",
+				"description": "Nothing may start depending on deprecated services",
+				"effect":      "deny",
+				"enforcement": "warn",
+				"to":          gin.H{"selector": "deprecated=true"},
+			}
+			Expect(util.HttpRequest(crius.Router(), "POST", "/policies", postBody).Code).To(Equal(200))
+			response := util.HttpRequest(crius.Router(), "GET", "/policies/no_new_deprecated_dependencies", nil)
+			Expect(response.Code).To(Equal(200))
+			Expect(response.Body["source"]).To(Equal("api"))
+			Expect(response.Body["to"]).To(Equal(map[string]interface{}{
+				"services":  []interface{}{},
+				"endpoints": []interface{}{},
+				"selector":  "deprecated=true",
+			}))
+		})
+
+		g.It("Should list rules from the API and the policy file", func() {
+			response := util.HttpRequest(crius.Router(), "GET", "/policies", nil)
+			Expect(response.Code).To(Equal(200))
+			rules := response.Body["rules"].([]interface{})
+			Expect(rules).To(HaveLen(2))
+			Expect(rules[0].(map[string]interface{})["code"]).To(Equal("ledger_only_called_by_payments"))
+			Expect(rules[0].(map[string]interface{})["source"]).To(Equal("file"))
+			Expect(rules[0].(map[string]interface{})["enforcement"]).To(Equal("reject"))
+			Expect(rules[1].(map[string]interface{})["code"]).To(Equal("no_new_deprecated_dependencies"))
+		})
+
+		g.It("Should reject invalid rules, and changes to rules from the policy file", func() {
+			postBody := gin.H{"code": "bad", "effect": "maybe"}
+			Expect(util.HttpRequest(crius.Router(), "POST", "/policies", postBody).Code).To(Equal(400))
+			postBody = gin.H{"code": "bad", "effect": "deny", "to": gin.H{"selector": "a==="}}
+			Expect(util.HttpRequest(crius.Router(), "POST", "/policies", postBody).Code).To(Equal(400))
+			postBody = gin.H{"code": "ledger_only_called_by_payments", "effect": "deny"}
+			Expect(util.HttpRequest(crius.Router(), "POST", "/policies", postBody).Code).To(Equal(400))
+			response := util.HttpRequest(crius.Router(), "DELETE", "/policies/ledger_only_called_by_payments", nil)
+			Expect(response.Code).To(Equal(400))
+			Expect(util.HttpRequest(crius.Router(), "GET", "/policies/bad", nil).Code).To(Equal(404))
+		})
+	})
+
+	g.Describe("POST /services with policy rules", func() {
+		g.It("Should allow dependencies that break no rules", func() {
+			for _, postBody := range []gin.H{
+				{
+					"code":      "ledger",
+					"name":      "Ledger",
+					"labels":    gin.H{"domain": "payments"},
+					"endpoints": []gin.H{{"code": "POST /entries", "name": "Create entry"}},
+				},
+				{
+					"code":      "guestbook",
+					"name":      "Guestbook",
+					"labels":    gin.H{"deprecated": "true"},
+					"endpoints": []gin.H{{"code": "POST /signatures", "name": "Sign guestbook"}},
+				},
+				{
+					"code":   "payouts",
+					"name":   "Payouts",
+					"labels": gin.H{"domain": "payments"},
+					"endpoints": []gin.H{
+						{
+							"code":         "POST /payouts",
+							"name":         "Create payout",
+							"dependencies": gin.H{"ledger": []string{"POST /entries"}},
+						},
+					},
+				},
+			} {
+				response := util.HttpRequest(crius.Router(), "POST", "/services", postBody)
+				Expect(response.Code).To(Equal(200))
+				Expect(response.Body["policy_warnings"]).To(BeEmpty())
+			}
+		})
+
+		g.It("Should reject dependencies that break a rule that rejects", func() {
+			postBody := gin.H{
+				"code":   "newsletter",
+				"name":   "Newsletter",
+				"labels": gin.H{"domain": "content"},
+				"endpoints": []gin.H{
+					{
+						"code":         "POST /subscriptions",
+						"name":         "Subscribe",
+						"dependencies": gin.H{"ledger": []string{"POST /entries"}},
+					},
+				},
+			}
+			response := util.HttpRequest(crius.Router(), "POST", "/services", postBody)
+			Expect(response.Code).To(Equal(422))
+			Expect(response.Body["details"]).To(Equal([]interface{}{
+				map[string]interface{}{
+					"rule_code":   "ledger_only_called_by_payments",
+					"description": "Nothing outside the payments domain may call the ledger directly",
+					"enforcement": "reject",
+					"from":        map[string]interface{}{"service_code": "newsletter", "endpoint_code": "POST /subscriptions"},
+					"to":          map[string]interface{}{"service_code": "ledger", "endpoint_code": "POST /entries"},
+				},
+			}))
+			Expect(util.HttpRequest(crius.Router(), "GET", "/services/newsletter", nil).Code).To(Equal(404))
+		})
+
+		g.It("Should reject dependencies on placeholders that break a rule that rejects", func() {
+			postBody := gin.H{
+				"code":   "newsletter",
+				"name":   "Newsletter",
+				"labels": gin.H{"domain": "content"},
+				"endpoints": []gin.H{
+					{
+						"code":         "POST /subscriptions",
+						"name":         "Subscribe",
+						"dependencies": gin.H{"ledger": []string{"POST /reversals"}},
+					},
+				},
+			}
+			response := util.HttpRequest(crius.Router(), "POST", "/services?placeholders=true", postBody)
+			Expect(response.Code).To(Equal(422))
+			Expect(response.Body["details"]).To(HaveLen(1))
+			path := "/services/ledger/endpoints/" + url.PathEscape("POST /reversals")
+			Expect(util.HttpRequest(crius.Router(), "GET", path, nil).Code).To(Equal(404))
+		})
+
+		g.It("Should warn about new dependencies that break a rule that warns", func() {
+			postBody := gin.H{
+				"code":   "newsletter",
+				"name":   "Newsletter",
+				"labels": gin.H{"domain": "content"},
+				"endpoints": []gin.H{
+					{
+						"code":         "POST /subscriptions",
+						"name":         "Subscribe",
+						"dependencies": gin.H{"guestbook": []string{"POST /signatures"}},
+					},
+				},
+			}
+			response := util.HttpRequest(crius.Router(), "POST", "/services", postBody)
+			Expect(response.Code).To(Equal(200))
+			warnings := response.Body["policy_warnings"].([]interface{})
+			Expect(warnings).To(HaveLen(1))
+			Expect(warnings[0].(map[string]interface{})["rule_code"]).To(Equal("no_new_deprecated_dependencies"))
+
+			// The dependency isn't new the second time, so it isn't warned about again
+			response = util.HttpRequest(crius.Router(), "POST", "/services", postBody)
+			Expect(response.Code).To(Equal(200))
+			Expect(response.Body["policy_warnings"]).To(BeEmpty())
+		})
+	})
+
+	g.Describe("PATCH /services/:code and PUT /services/:code/endpoints/:endpointCode with policy rules", func() {
+		g.It("Should reject new dependencies that break a rule that rejects", func() {
+			refund := gin.H{
+				"code":         "POST /refunds",
+				"name":         "Refund",
+				"dependencies": gin.H{"ledger": []string{"POST /entries"}},
+			}
+			response := util.HttpRequest(crius.Router(), "PATCH", "/services/newsletter", gin.H{
+				"endpoints": []gin.H{refund},
+			})
+			Expect(response.Code).To(Equal(422))
+			Expect(response.Body["details"]).To(HaveLen(1))
+			path := "/services/newsletter/endpoints/" + url.PathEscape("POST /refunds")
+			response = util.HttpRequest(crius.Router(), "PUT", path, refund)
+			Expect(response.Code).To(Equal(422))
+			Expect(response.Body["details"]).To(HaveLen(1))
+			Expect(util.HttpRequest(crius.Router(), "GET", path, nil).Code).To(Equal(404))
+		})
+
+		g.It("Should warn about new dependencies that break a rule that warns", func() {
+			path := "/services/newsletter/endpoints/" + url.PathEscape("POST /guests")
+			response := util.HttpRequest(crius.Router(), "PUT", path, gin.H{
+				"name":         "Sign up guest",
+				"dependencies": gin.H{"guestbook": []string{"POST /signatures"}},
+			})
+			Expect(response.Code).To(Equal(200))
+			warnings := response.Body["policy_warnings"].([]interface{})
+			Expect(warnings).To(HaveLen(1))
+			Expect(warnings[0].(map[string]interface{})["rule_code"]).To(Equal("no_new_deprecated_dependencies"))
+
+			response = util.HttpRequest(crius.Router(), "PATCH", "/services/newsletter", gin.H{"name": "Weekly Newsletter"})
+			Expect(response.Code).To(Equal(200))
+			Expect(response.Body["name"]).To(Equal("Weekly Newsletter"))
+			Expect(response.Body["policy_warnings"]).To(BeEmpty())
+		})
+	})
+
+	g.Describe("Cleanup", func() {
+		g.It("Should delete the policy rules and services", func() {
+			response := util.HttpRequest(crius.Router(), "DELETE", "/policies/no_new_deprecated_dependencies", nil)
+			Expect(response.Code).To(Equal(200))
+			crius.LoadPolicies("")
+			response = util.HttpRequest(crius.Router(), "GET", "/policies", nil)
+			Expect(response.Body["rules"]).To(BeEmpty())
+			for _, code := range []string{"newsletter", "payouts", "guestbook", "ledger"} {
+				Expect(util.HttpRequest(crius.Router(), "DELETE", "/services/"+code, nil).Code).To(Equal(200))
+			}
+		})
+	})
+}
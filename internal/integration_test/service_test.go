@@ -1,6 +1,7 @@
 package integration_test
 
 import (
+	"net/url"
 	"os"
 	"path/filepath"
 	"testing"
@@ -73,4 +74,905 @@ func Test(t *testing.T) {
 			Expect(response.Body["id"]).To(Equal(float64(1)))
 		})
 	})
+
+	g.Describe("GET /services/:code/dependencies", func() {
+		g.Before(func() {
+			locations := gin.H{
+				"code": "locations",
+				"name": "Location Tracking Service",
+				"endpoints": []gin.H{
+					{
+						"code":         "GET /locations/{id}",
+						"name":         "Get location by id",
+						"dependencies": gin.H{"tops": []string{"GET /teams/{id}"}},
+					},
+				},
+			}
+			trips := gin.H{
+				"code": "trips",
+				"name": "Trips Service",
+				"endpoints": []gin.H{
+					{
+						"code":         "GET /trips/{id}",
+						"name":         "Get trip by id",
+						"dependencies": gin.H{"locations": []string{"GET /locations/{id}"}},
+					},
+				},
+			}
+			Expect(util.HttpRequest(crius.Router(), "POST", "/services", locations).Code).To(Equal(200))
+			Expect(util.HttpRequest(crius.Router(), "POST", "/services", trips).Code).To(Equal(200))
+		})
+
+		g.It("Should get direct dependencies", func() {
+			response := util.HttpRequest(crius.Router(), "GET", "/services/trips/dependencies", nil)
+			Expect(response.Code).To(Equal(200))
+			Expect(response.Body["dependencies"]).To(HaveLen(1))
+			dependency := response.Body["dependencies"].([]interface{})[0].(map[string]interface{})
+			Expect(dependency["service_code"]).To(Equal("locations"))
+			Expect(dependency["endpoint_code"]).To(Equal("GET /locations/{id}"))
+			Expect(dependency["distance"]).To(Equal(float64(1)))
+		})
+
+		g.It("Should get transitive dependencies, with the path that reached them", func() {
+			response := util.HttpRequest(crius.Router(), "GET", "/services/trips/dependencies?transitive=true", nil)
+			Expect(response.Code).To(Equal(200))
+			Expect(response.Body["dependencies"]).To(HaveLen(2))
+			dependency := response.Body["dependencies"].([]interface{})[1].(map[string]interface{})
+			Expect(dependency["service_code"]).To(Equal("tops"))
+			Expect(dependency["distance"]).To(Equal(float64(2)))
+			Expect(dependency["path"]).To(HaveLen(3))
+		})
+
+		g.It("Should limit transitive dependencies by depth", func() {
+			response := util.HttpRequest(crius.Router(), "GET", "/services/trips/dependencies?transitive=true&depth=1", nil)
+			Expect(response.Code).To(Equal(200))
+			Expect(response.Body["dependencies"]).To(HaveLen(1))
+		})
+
+		g.It("Should get the dependencies of a single endpoint", func() {
+			path := "/services/trips/endpoints/" + url.PathEscape("GET /trips/{id}") + "/dependencies?transitive=true"
+			response := util.HttpRequest(crius.Router(), "GET", path, nil)
+			Expect(response.Code).To(Equal(200))
+			Expect(response.Body["dependencies"]).To(HaveLen(2))
+		})
+
+		g.It("Should 404 for an unknown endpoint", func() {
+			path := "/services/trips/endpoints/" + url.PathEscape("GET /nope") + "/dependencies"
+			response := util.HttpRequest(crius.Router(), "GET", path, nil)
+			Expect(response.Code).To(Equal(404))
+		})
+	})
+
+	g.Describe("GET /services/:code/dependents", func() {
+		g.It("Should get direct and transitive dependents, grouped by service", func() {
+			response := util.HttpRequest(crius.Router(), "GET", "/services/tops/dependents", nil)
+			Expect(response.Code).To(Equal(200))
+			Expect(response.Body["dependents"]).To(HaveLen(2))
+			summary := response.Body["summary"].(map[string]interface{})
+			Expect(summary["affected_services"]).To(Equal(float64(2)))
+			Expect(summary["affected_endpoints"]).To(Equal(float64(2)))
+		})
+
+		g.It("Should get only direct dependents of a single endpoint", func() {
+			path := "/services/tops/endpoints/" + url.PathEscape("GET /teams/{id}") + "/dependents?transitive=false"
+			response := util.HttpRequest(crius.Router(), "GET", path, nil)
+			Expect(response.Code).To(Equal(200))
+			Expect(response.Body["dependents"]).To(HaveLen(1))
+			dependents := response.Body["dependents"].([]interface{})[0].(map[string]interface{})
+			Expect(dependents["service_code"]).To(Equal("locations"))
+		})
+	})
+
+	g.Describe("GET /services", func() {
+		g.It("Should list services, sorted by code", func() {
+			response := util.HttpRequest(crius.Router(), "GET", "/services", nil)
+			Expect(response.Code).To(Equal(200))
+			services := response.Body["services"].([]interface{})
+			Expect(services).To(HaveLen(3))
+			Expect(services[0].(map[string]interface{})["code"]).To(Equal("locations"))
+			Expect(services[1].(map[string]interface{})["code"]).To(Equal("tops"))
+			Expect(services[1].(map[string]interface{})["endpoint_count"]).To(Equal(float64(2)))
+			Expect(response.Body["next_cursor"]).To(BeNil())
+		})
+
+		g.It("Should paginate with a cursor", func() {
+			response := util.HttpRequest(crius.Router(), "GET", "/services?sort=-name&limit=2", nil)
+			Expect(response.Code).To(Equal(200))
+			Expect(response.Body["services"]).To(HaveLen(2))
+			cursor := response.Body["next_cursor"].(string)
+			response = util.HttpRequest(crius.Router(), "GET", "/services?sort=-name&limit=2&cursor="+cursor, nil)
+			Expect(response.Code).To(Equal(200))
+			services := response.Body["services"].([]interface{})
+			Expect(services).To(HaveLen(1))
+			Expect(services[0].(map[string]interface{})["code"]).To(Equal("locations"))
+			Expect(response.Body["next_cursor"]).To(BeNil())
+		})
+
+		g.It("Should filter by code prefix, name and endpoint", func() {
+			response := util.HttpRequest(crius.Router(), "GET", "/services?codePrefix=tr", nil)
+			Expect(response.Body["services"]).To(HaveLen(1))
+			response = util.HttpRequest(crius.Router(), "GET", "/services?nameContains=permissions", nil)
+			Expect(response.Body["services"]).To(HaveLen(1))
+			response = util.HttpRequest(crius.Router(), "GET", "/services?hasEndpoint="+url.QueryEscape("/teams/"), nil)
+			Expect(response.Body["services"]).To(HaveLen(1))
+		})
+
+		g.It("Should reject a cursor made for a different sort order", func() {
+			response := util.HttpRequest(crius.Router(), "GET", "/services?sort=name&limit=1", nil)
+			cursor := response.Body["next_cursor"].(string)
+			response = util.HttpRequest(crius.Router(), "GET", "/services?sort=code&cursor="+cursor, nil)
+			Expect(response.Code).To(Equal(400))
+		})
+	})
+
+	g.Describe("DELETE /services/:code", func() {
+		g.Before(func() {
+			billing := gin.H{
+				"code": "billing",
+				"name": "Billing Service",
+				"endpoints": []gin.H{
+					{
+						"code":         "POST /invoices",
+						"name":         "Create invoice",
+						"dependencies": gin.H{"trips": []string{"GET /trips/{id}"}},
+					},
+				},
+			}
+			Expect(util.HttpRequest(crius.Router(), "POST", "/services", billing).Code).To(Equal(200))
+		})
+
+		g.It("Should refuse to delete a service that others depend on", func() {
+			response := util.HttpRequest(crius.Router(), "DELETE", "/services/trips", nil)
+			Expect(response.Code).To(Equal(409))
+			Expect(response.Body["details"]).To(HaveLen(1))
+			detail := response.Body["details"].([]interface{})[0].(map[string]interface{})
+			Expect(detail["service_code"]).To(Equal("billing"))
+			Expect(detail["dependency_endpoint_code"]).To(Equal("GET /trips/{id}"))
+		})
+
+		g.It("Should force delete a service, removing the dependencies on it", func() {
+			response := util.HttpRequest(crius.Router(), "DELETE", "/services/trips?force=true", nil)
+			Expect(response.Code).To(Equal(200))
+			Expect(response.Body["removed_dependencies"]).To(HaveLen(1))
+			Expect(util.HttpRequest(crius.Router(), "GET", "/services/trips", nil).Code).To(Equal(404))
+		})
+
+		g.It("Should delete a service that nothing depends on", func() {
+			response := util.HttpRequest(crius.Router(), "DELETE", "/services/billing", nil)
+			Expect(response.Code).To(Equal(200))
+			Expect(response.Body["removed_dependencies"]).To(HaveLen(0))
+		})
+
+		g.It("Should 404 for an unknown service", func() {
+			response := util.HttpRequest(crius.Router(), "DELETE", "/services/nope", nil)
+			Expect(response.Code).To(Equal(404))
+			Expect(response.Body).NotTo(HaveKey("details"))
+		})
+	})
+
+	g.Describe("/services/:code/endpoints/:endpointCode", func() {
+		createTeam := "/services/tops/endpoints/" + url.PathEscape("POST /teams")
+
+		g.It("Should add a single endpoint to a service", func() {
+			response := util.HttpRequest(crius.Router(), "PUT", createTeam, gin.H{"name": "Create team"})
+			Expect(response.Code).To(Equal(200))
+			response = util.HttpRequest(crius.Router(), "GET", createTeam, nil)
+			Expect(response.Code).To(Equal(200))
+			Expect(response.Body["code"]).To(Equal("POST /teams"))
+			Expect(response.Body["name"]).To(Equal("Create team"))
+			response = util.HttpRequest(crius.Router(), "GET", "/services/tops", nil)
+			Expect(response.Body["endpoints"]).To(HaveLen(3))
+		})
+
+		g.It("Should 404 when adding an endpoint to an unknown service", func() {
+			path := "/services/nope/endpoints/" + url.PathEscape("POST /teams")
+			response := util.HttpRequest(crius.Router(), "PUT", path, gin.H{"name": "Create team"})
+			Expect(response.Code).To(Equal(404))
+		})
+
+		g.It("Should refuse to delete an endpoint that others depend on", func() {
+			path := "/services/tops/endpoints/" + url.PathEscape("GET /teams/{id}")
+			response := util.HttpRequest(crius.Router(), "DELETE", path, nil)
+			Expect(response.Code).To(Equal(409))
+		})
+
+		g.It("Should delete an endpoint", func() {
+			response := util.HttpRequest(crius.Router(), "DELETE", createTeam, nil)
+			Expect(response.Code).To(Equal(200))
+			Expect(util.HttpRequest(crius.Router(), "GET", createTeam, nil).Code).To(Equal(404))
+		})
+	})
+
+	g.Describe("PATCH /services/:code", func() {
+		g.It("Should rename a service, leaving its endpoints alone", func() {
+			response := util.HttpRequest(crius.Router(), "PATCH", "/services/locations", gin.H{"name": "Locations"})
+			Expect(response.Code).To(Equal(200))
+			Expect(response.Body["name"]).To(Equal("Locations"))
+			Expect(response.Body["endpoints"]).To(HaveLen(1))
+		})
+
+		g.It("Should 404 for an unknown service", func() {
+			response := util.HttpRequest(crius.Router(), "PATCH", "/services/nope", gin.H{"name": "Nope"})
+			Expect(response.Code).To(Equal(404))
+		})
+	})
+
+	g.Describe("POST /services dependency replacement", func() {
+		g.It("Should report added dependencies", func() {
+			postBody := gin.H{
+				"code": "locations",
+				"name": "Locations",
+				"endpoints": []gin.H{
+					{
+						"code":         "GET /locations/{id}",
+						"name":         "Get location by id",
+						"dependencies": gin.H{"tops": []string{"GET /teams/{id}", "DELETE /teams/{id}"}},
+					},
+				},
+			}
+			response := util.HttpRequest(crius.Router(), "POST", "/services", postBody)
+			Expect(response.Code).To(Equal(200))
+			diff := response.Body["dependencies"].(map[string]interface{})
+			Expect(diff["added"]).To(HaveLen(1))
+			Expect(diff["removed"]).To(HaveLen(0))
+		})
+
+		g.It("Should remove dependencies that are no longer declared", func() {
+			postBody := gin.H{
+				"code": "locations",
+				"name": "Locations",
+				"endpoints": []gin.H{
+					{
+						"code":         "GET /locations/{id}",
+						"name":         "Get location by id",
+						"dependencies": gin.H{"tops": []string{"GET /teams/{id}"}},
+					},
+				},
+			}
+			response := util.HttpRequest(crius.Router(), "POST", "/services", postBody)
+			Expect(response.Code).To(Equal(200))
+			diff := response.Body["dependencies"].(map[string]interface{})
+			Expect(diff["added"]).To(HaveLen(0))
+			Expect(diff["removed"]).To(HaveLen(1))
+			removed := diff["removed"].([]interface{})[0].(map[string]interface{})
+			Expect(removed["to"].(map[string]interface{})["endpoint_code"]).To(Equal("DELETE /teams/{id}"))
+			response = util.HttpRequest(crius.Router(), "GET", "/services/tops/dependents", nil)
+			Expect(response.Body["summary"].(map[string]interface{})["affected_endpoints"]).To(Equal(float64(1)))
+		})
+	})
+
+	g.Describe("GET /graph.dot", func() {
+		g.It("Should render services as clusters, and endpoints as nodes", func() {
+			response := util.HttpRequest(crius.Router(), "GET", "/graph.dot", nil)
+			Expect(response.Code).To(Equal(200))
+			dot := response.Body["body"].(string)
+			Expect(dot).To(HavePrefix("digraph crius {"))
+			Expect(dot).To(ContainSubstring(`subgraph "cluster_tops" {`))
+			Expect(dot).To(ContainSubstring(`"tops/DELETE /teams/{id}" [label="DELETE /teams/{id}"];`))
+			Expect(dot).To(ContainSubstring(`"locations/GET /locations/{id}" -> "tops/GET /teams/{id}";`))
+		})
+
+		g.It("Should only render what is reachable from the root", func() {
+			response := util.HttpRequest(crius.Router(), "GET", "/graph.dot?root=tops&direction=down", nil)
+			Expect(response.Code).To(Equal(200))
+			dot := response.Body["body"].(string)
+			Expect(dot).To(ContainSubstring(`subgraph "cluster_tops" {`))
+			Expect(dot).NotTo(ContainSubstring("locations"))
+
+			response = util.HttpRequest(crius.Router(), "GET", "/graph.dot?root=tops&direction=up&depth=1", nil)
+			Expect(response.Body["body"]).To(ContainSubstring(`"locations/GET /locations/{id}" -> "tops/GET /teams/{id}";`))
+		})
+
+		g.It("Should collapse services into single nodes", func() {
+			response := util.HttpRequest(crius.Router(), "GET", "/graph.dot?granularity=service", nil)
+			Expect(response.Code).To(Equal(200))
+			Expect(response.Body["body"]).To(ContainSubstring(`"locations" -> "tops" [label="1"];`))
+		})
+
+		g.It("Should reject bad query params", func() {
+			Expect(util.HttpRequest(crius.Router(), "GET", "/graph.dot?direction=sideways", nil).Code).To(Equal(400))
+			Expect(util.HttpRequest(crius.Router(), "GET", "/graph.dot?depth=2", nil).Code).To(Equal(400))
+			Expect(util.HttpRequest(crius.Router(), "GET", "/graph.dot?root=nope", nil).Code).To(Equal(404))
+		})
+	})
+
+	g.Describe("GET /services/:code/diagram", func() {
+		g.It("Should default to a Mermaid flowchart", func() {
+			response := util.HttpRequest(crius.Router(), "GET", "/services/locations/diagram", nil)
+			Expect(response.Code).To(Equal(200))
+			diagram := response.Body["body"].(string)
+			Expect(diagram).To(HavePrefix("flowchart LR\n"))
+			Expect(diagram).To(ContainSubstring(`subgraph s0["Locations (locations)"]`))
+			Expect(diagram).To(ContainSubstring(`s0_e0["GET /locations/{id}"]`))
+			Expect(diagram).To(ContainSubstring("s0_e0 --> s1_e0"))
+		})
+
+		g.It("Should render a PlantUML component diagram at service granularity", func() {
+			path := "/services/locations/diagram?format=plantuml&granularity=service"
+			response := util.HttpRequest(crius.Router(), "GET", path, nil)
+			Expect(response.Code).To(Equal(200))
+			diagram := response.Body["body"].(string)
+			Expect(diagram).To(HavePrefix("@startuml\n"))
+			Expect(diagram).To(ContainSubstring(`component "Locations (locations)" as s0`))
+			Expect(diagram).To(ContainSubstring("s0 --> s1 : 1"))
+		})
+
+		g.It("Should reject unknown formats and services", func() {
+			path := "/services/locations/diagram?format=visio"
+			Expect(util.HttpRequest(crius.Router(), "GET", path, nil).Code).To(Equal(400))
+			Expect(util.HttpRequest(crius.Router(), "GET", "/services/nope/diagram", nil).Code).To(Equal(404))
+		})
+	})
+
+	g.Describe("Dependency cycles", func() {
+		cyclicTops := func(deleteDependencies gin.H) gin.H {
+			return gin.H{
+				"code": "tops",
+				"name": "Teams, Organizations and Permissions Service",
+				"endpoints": []gin.H{
+					{
+						"code": "GET /teams/{id}",
+						"name": "Get team by id",
+					},
+					{
+						"code":         "DELETE /teams/{id}",
+						"name":         "Delete team by id",
+						"dependencies": deleteDependencies,
+					},
+				},
+			}
+		}
+
+		g.It("Should find no cycles in an acyclic graph", func() {
+			response := util.HttpRequest(crius.Router(), "GET", "/graph/cycles", nil)
+			Expect(response.Code).To(Equal(200))
+			Expect(response.Body["service_cycles"]).To(HaveLen(0))
+			Expect(response.Body["endpoint_cycles"]).To(HaveLen(0))
+		})
+
+		g.It("Should reject a save that introduces a cycle, when asked to", func() {
+			postBody := cyclicTops(gin.H{"locations": []string{"GET /locations/{id}"}})
+			response := util.HttpRequest(crius.Router(), "POST", "/services?rejectCycles=true", postBody)
+			Expect(response.Code).To(Equal(409))
+			Expect(response.Body["sub_code"]).To(Equal("bef15c8e-d4ec-4e59-8f52-036b1c416f4f"))
+			Expect(response.Body["details"]).To(HaveLen(1))
+			detail := response.Body["details"].([]interface{})[0].(map[string]interface{})
+			Expect(detail["granularity"]).To(Equal("service"))
+			Expect(detail["path"]).To(Equal([]interface{}{"tops", "locations", "tops"}))
+		})
+
+		g.It("Should reject partial updates that introduce a cycle, when asked to", func() {
+			deleteTeam := gin.H{
+				"code":         "DELETE /teams/{id}",
+				"name":         "Delete team by id",
+				"dependencies": gin.H{"locations": []string{"GET /locations/{id}"}},
+			}
+			patchBody := gin.H{"endpoints": []gin.H{deleteTeam}}
+			response := util.HttpRequest(crius.Router(), "PATCH", "/services/tops?rejectCycles=true", patchBody)
+			Expect(response.Code).To(Equal(409))
+			path := "/services/tops/endpoints/" + url.PathEscape("DELETE /teams/{id}") + "?rejectCycles=true"
+			response = util.HttpRequest(crius.Router(), "PUT", path, deleteTeam)
+			Expect(response.Code).To(Equal(409))
+			Expect(util.HttpRequest(crius.Router(), "GET", "/graph/cycles", nil).Body["service_cycles"]).To(HaveLen(0))
+		})
+
+		g.It("Should find service cycles", func() {
+			postBody := cyclicTops(gin.H{"locations": []string{"GET /locations/{id}"}})
+			response := util.HttpRequest(crius.Router(), "POST", "/services", postBody)
+			Expect(response.Code).To(Equal(200))
+			response = util.HttpRequest(crius.Router(), "GET", "/graph/cycles", nil)
+			Expect(response.Code).To(Equal(200))
+			Expect(response.Body["service_cycles"]).To(Equal([]interface{}{
+				[]interface{}{"locations", "tops", "locations"},
+			}))
+			Expect(response.Body["endpoint_cycles"]).To(HaveLen(0))
+			response = util.HttpRequest(crius.Router(), "POST", "/services", cyclicTops(gin.H{}))
+			Expect(response.Code).To(Equal(200))
+		})
+	})
+
+	g.Describe("POST /services dependency validation", func() {
+		g.It("Should list every dependency that doesn't exist", func() {
+			postBody := gin.H{
+				"code": "notifications",
+				"name": "Notifications",
+				"endpoints": []gin.H{
+					{
+						"code": "POST /notifications",
+						"name": "Send notification",
+						"dependencies": gin.H{
+							"nope": []string{"GET /nope"},
+							"tops": []string{"GET /teams/{id}", "GET /teams"},
+						},
+					},
+				},
+			}
+			response := util.HttpRequest(crius.Router(), "POST", "/services", postBody)
+			Expect(response.Code).To(Equal(422))
+			details := response.Body["details"].([]interface{})
+			Expect(details).To(HaveLen(2))
+			Expect(details[0].(map[string]interface{})["sub_code"]).To(Equal("4b281f39-2eaf-4e09-8b6f-ffb277ea0cbb"))
+			Expect(details[0].(map[string]interface{})["dependency_service_code"]).To(Equal("nope"))
+			Expect(details[1].(map[string]interface{})["sub_code"]).To(Equal("0e86e5ad-e332-4962-b138-34dddade1dd1"))
+			Expect(details[1].(map[string]interface{})["dependency_endpoint_code"]).To(Equal("GET /teams"))
+			Expect(util.HttpRequest(crius.Router(), "GET", "/services/notifications", nil).Code).To(Equal(404))
+		})
+
+		g.It("Should let a service's endpoints depend on each other", func() {
+			postBody := gin.H{
+				"code": "notifications",
+				"name": "Notifications",
+				"endpoints": []gin.H{
+					{
+						"code":         "POST /notifications",
+						"name":         "Send notification",
+						"dependencies": gin.H{"notifications": []string{"GET /templates/{id}"}},
+					},
+					{
+						"code": "GET /templates/{id}",
+						"name": "Get notification template by id",
+					},
+				},
+			}
+			response := util.HttpRequest(crius.Router(), "POST", "/services", postBody)
+			Expect(response.Code).To(Equal(200))
+			Expect(response.Body["dependencies"].(map[string]interface{})["added"]).To(HaveLen(1))
+			Expect(util.HttpRequest(crius.Router(), "DELETE", "/services/notifications", nil).Code).To(Equal(200))
+		})
+	})
+
+	g.Describe("POST /services placeholders", func() {
+		listUnconfirmed := func() []interface{} {
+			response := util.HttpRequest(crius.Router(), "GET", "/services?unconfirmed=true", nil)
+			Expect(response.Code).To(Equal(200))
+			return response.Body["services"].([]interface{})
+		}
+
+		g.It("Should create placeholders for dependencies that aren't registered yet", func() {
+			postBody := gin.H{
+				"code": "billing",
+				"name": "Billing",
+				"endpoints": []gin.H{
+					{
+						"code": "POST /invoices",
+						"name": "Create invoice",
+						"dependencies": gin.H{
+							"payments": []string{"POST /charges"},
+							"tops":     []string{"GET /teams"},
+						},
+					},
+				},
+			}
+			response := util.HttpRequest(crius.Router(), "POST", "/services?placeholders=true", postBody)
+			Expect(response.Code).To(Equal(200))
+			Expect(response.Body["dependencies"].(map[string]interface{})["added"]).To(HaveLen(2))
+
+			response = util.HttpRequest(crius.Router(), "GET", "/services/payments", nil)
+			Expect(response.Code).To(Equal(200))
+			Expect(response.Body["confirmed"]).To(Equal(false))
+			Expect(response.Body["name"]).To(Equal("payments"))
+			endpoint := response.Body["endpoints"].([]interface{})[0].(map[string]interface{})
+			Expect(endpoint["code"]).To(Equal("POST /charges"))
+			Expect(endpoint["confirmed"]).To(Equal(false))
+		})
+
+		g.It("Should list services and endpoints that nobody has registered", func() {
+			services := listUnconfirmed()
+			Expect(services).To(HaveLen(2))
+			payments := services[0].(map[string]interface{})
+			Expect(payments["code"]).To(Equal("payments"))
+			Expect(payments["confirmed"]).To(Equal(false))
+			tops := services[1].(map[string]interface{})
+			Expect(tops["code"]).To(Equal("tops"))
+			Expect(tops["confirmed"]).To(Equal(true))
+			Expect(tops["unconfirmed_endpoints"]).To(Equal([]interface{}{"GET /teams"}))
+		})
+
+		g.It("Should confirm placeholders when their owner registers them", func() {
+			postBody := gin.H{
+				"code": "payments",
+				"name": "Payments",
+				"endpoints": []gin.H{
+					{
+						"code": "POST /charges",
+						"name": "Create charge",
+					},
+				},
+			}
+			response := util.HttpRequest(crius.Router(), "POST", "/services", postBody)
+			Expect(response.Code).To(Equal(200))
+			response = util.HttpRequest(crius.Router(), "GET", "/services/payments", nil)
+			Expect(response.Body["confirmed"]).To(Equal(true))
+			Expect(response.Body["name"]).To(Equal("Payments"))
+			services := listUnconfirmed()
+			Expect(services).To(HaveLen(1))
+			Expect(services[0].(map[string]interface{})["code"]).To(Equal("tops"))
+
+			Expect(util.HttpRequest(crius.Router(), "DELETE", "/services/billing", nil).Code).To(Equal(200))
+			Expect(util.HttpRequest(crius.Router(), "DELETE", "/services/payments", nil).Code).To(Equal(200))
+			path := "/services/tops/endpoints/" + url.PathEscape("GET /teams")
+			Expect(util.HttpRequest(crius.Router(), "DELETE", path, nil).Code).To(Equal(200))
+			Expect(listUnconfirmed()).To(HaveLen(0))
+		})
+	})
+
+	g.Describe("Service ownership", func() {
+		g.It("Should save ownership with a service, and patch it", func() {
+			response := util.HttpRequest(crius.Router(), "GET", "/services/locations", nil)
+			Expect(response.Code).To(Equal(200))
+			postBody := response.Body
+			postBody["team"] = "logistics"
+			postBody["on_call"] = "logistics-primary"
+			postBody["slack_channel"] = "#logistics"
+			postBody["email"] = "logistics@example.com"
+			postBody["repository_url"] = "https://github.com/example/locations"
+			Expect(util.HttpRequest(crius.Router(), "POST", "/services", postBody).Code).To(Equal(200))
+			patchBody := gin.H{"team": "platform", "slack_channel": "#platform"}
+			Expect(util.HttpRequest(crius.Router(), "PATCH", "/services/tops", patchBody).Code).To(Equal(200))
+
+			response = util.HttpRequest(crius.Router(), "GET", "/services/locations", nil)
+			Expect(response.Body["team"]).To(Equal("logistics"))
+			Expect(response.Body["on_call"]).To(Equal("logistics-primary"))
+			Expect(response.Body["slack_channel"]).To(Equal("#logistics"))
+			Expect(response.Body["email"]).To(Equal("logistics@example.com"))
+			Expect(response.Body["repository_url"]).To(Equal("https://github.com/example/locations"))
+			response = util.HttpRequest(crius.Router(), "GET", "/services/tops", nil)
+			Expect(response.Body["team"]).To(Equal("platform"))
+			Expect(response.Body["slack_channel"]).To(Equal("#platform"))
+			Expect(response.Body["on_call"]).To(BeNil())
+		})
+
+		g.It("Should list the services owned by a team", func() {
+			response := util.HttpRequest(crius.Router(), "GET", "/teams/logistics/services", nil)
+			Expect(response.Code).To(Equal(200))
+			services := response.Body["services"].([]interface{})
+			Expect(services).To(HaveLen(1))
+			Expect(services[0].(map[string]interface{})["code"]).To(Equal("locations"))
+			Expect(services[0].(map[string]interface{})["team"]).To(Equal("logistics"))
+			response = util.HttpRequest(crius.Router(), "GET", "/teams/nobody/services", nil)
+			Expect(response.Code).To(Equal(200))
+			Expect(response.Body["services"]).To(HaveLen(0))
+		})
+
+		g.It("Should include the owning team of each dependent", func() {
+			response := util.HttpRequest(crius.Router(), "GET", "/services/tops/dependents", nil)
+			Expect(response.Code).To(Equal(200))
+			dependents := response.Body["dependents"].([]interface{})
+			Expect(dependents).To(HaveLen(1))
+			Expect(dependents[0].(map[string]interface{})["service_code"]).To(Equal("locations"))
+			Expect(dependents[0].(map[string]interface{})["team"]).To(Equal("logistics"))
+		})
+
+		g.It("Should clear ownership when a service is fully replaced without it", func() {
+			for _, code := range []string{"locations", "tops"} {
+				response := util.HttpRequest(crius.Router(), "GET", "/services/"+code, nil)
+				postBody := response.Body
+				for _, field := range []string{"team", "on_call", "slack_channel", "email", "repository_url"} {
+					delete(postBody, field)
+				}
+				Expect(util.HttpRequest(crius.Router(), "POST", "/services", postBody).Code).To(Equal(200))
+				response = util.HttpRequest(crius.Router(), "GET", "/services/"+code, nil)
+				Expect(response.Body["team"]).To(BeNil())
+				Expect(response.Body["slack_channel"]).To(BeNil())
+			}
+		})
+	})
+
+	g.Describe("Labels and label selectors", func() {
+		g.It("Should save labels on services and endpoints", func() {
+			for _, postBody := range []gin.H{
+				{
+					"code":   "ledger",
+					"name":   "Ledger",
+					"labels": gin.H{"tier": "critical", "lang": "go"},
+					"endpoints": []gin.H{
+						{"code": "POST /entries", "name": "Create entry"},
+					},
+				},
+				{
+					"code":   "cards",
+					"name":   "Cards",
+					"labels": gin.H{"tier": "critical", "lang": "java", "pci": "true"},
+					"endpoints": []gin.H{
+						{
+							"code":         "POST /cards",
+							"name":         "Create card",
+							"dependencies": gin.H{"ledger": []string{"POST /entries"}},
+						},
+					},
+				},
+				{
+					"code":   "blog",
+					"name":   "Blog",
+					"labels": gin.H{"tier": "low", "lang": "php"},
+					"endpoints": []gin.H{
+						{"code": "GET /posts", "name": "List posts"},
+						{"code": "POST /donations", "name": "Donate", "labels": gin.H{"pci": "true"}},
+					},
+				},
+			} {
+				Expect(util.HttpRequest(crius.Router(), "POST", "/services", postBody).Code).To(Equal(200))
+			}
+			response := util.HttpRequest(crius.Router(), "GET", "/services/blog", nil)
+			Expect(response.Body["labels"]).To(Equal(map[string]interface{}{"tier": "low", "lang": "php"}))
+			endpoints := response.Body["endpoints"].([]interface{})
+			Expect(endpoints[0].(map[string]interface{})["labels"]).To(Equal(map[string]interface{}{}))
+			Expect(endpoints[1].(map[string]interface{})["labels"]).To(Equal(map[string]interface{}{"pci": "true"}))
+		})
+
+		g.It("Should reject invalid labels", func() {
+			postBody := gin.H{"code": "bad", "name": "Bad", "labels": gin.H{"not a key": "value"}}
+			Expect(util.HttpRequest(crius.Router(), "POST", "/services", postBody).Code).To(Equal(400))
+			patchBody := gin.H{"labels": gin.H{"tier": "not a value"}}
+			Expect(util.HttpRequest(crius.Router(), "PATCH", "/services/blog", patchBody).Code).To(Equal(400))
+		})
+
+		g.It("Should list services that match a label selector", func() {
+			codes := func(selector string) []interface{} {
+				response := util.HttpRequest(crius.Router(), "GET", "/services?selector="+url.QueryEscape(selector), nil)
+				Expect(response.Code).To(Equal(200))
+				codes := make([]interface{}, 0)
+				for _, summary := range response.Body["services"].([]interface{}) {
+					codes = append(codes, summary.(map[string]interface{})["code"])
+				}
+				return codes
+			}
+			Expect(codes("tier=critical")).To(Equal([]interface{}{"cards", "ledger"}))
+			Expect(codes("tier=critical,lang!=java")).To(Equal([]interface{}{"ledger"}))
+			Expect(codes("lang in (go, php)")).To(Equal([]interface{}{"blog", "ledger"}))
+			Expect(codes("pci,tier notin (low)")).To(Equal([]interface{}{"cards"}))
+			Expect(codes("lang,!pci")).To(Equal([]interface{}{"blog", "ledger"}))
+			Expect(util.HttpRequest(crius.Router(), "GET", "/services?selector=tier%3D%3D%3D", nil).Code).To(Equal(400))
+		})
+
+		g.It("Should render what matches a label selector, and its dependencies", func() {
+			response := util.HttpRequest(crius.Router(), "GET", "/graph.dot?selector=pci%3Dtrue", nil)
+			Expect(response.Code).To(Equal(200))
+			dot := response.Body["body"].(string)
+			Expect(dot).To(ContainSubstring(`"cards/POST /cards" -> "ledger/POST /entries";`))
+			Expect(dot).To(ContainSubstring(`"blog/POST /donations"`))
+			Expect(dot).NotTo(ContainSubstring("GET /posts"))
+			Expect(dot).NotTo(ContainSubstring("tops"))
+
+			response = util.HttpRequest(crius.Router(), "GET", "/graph.dot?selector=pci%3Dtrue&depth=1&direction=up", nil)
+			Expect(response.Code).To(Equal(200))
+			Expect(response.Body["body"]).NotTo(ContainSubstring("ledger"))
+		})
+
+		g.It("Should replace labels with a patch", func() {
+			patchBody := gin.H{"labels": gin.H{"tier": "medium"}}
+			response := util.HttpRequest(crius.Router(), "PATCH", "/services/blog", patchBody)
+			Expect(response.Code).To(Equal(200))
+			Expect(response.Body["labels"]).To(Equal(map[string]interface{}{"tier": "medium"}))
+			for _, code := range []string{"blog", "cards", "ledger"} {
+				Expect(util.HttpRequest(crius.Router(), "DELETE", "/services/"+code, nil).Code).To(Equal(200))
+			}
+		})
+	})
+
+	g.Describe("GET /graph/violations", func() {
+		g.It("Should save tiers on services and endpoints", func() {
+			for _, postBody := range []gin.H{
+				{
+					"code":      "recommendations",
+					"name":      "Recommendations",
+					"tier":      2,
+					"endpoints": []gin.H{{"code": "GET /recommendations", "name": "Get recommendations"}},
+				},
+				{
+					"code": "promotions",
+					"name": "Promotions",
+					"tier": 1,
+					"endpoints": []gin.H{
+						{
+							"code":         "GET /promotions",
+							"name":         "Get promotions",
+							"dependencies": gin.H{"recommendations": []string{"GET /recommendations"}},
+						},
+					},
+				},
+				{
+					"code": "checkout",
+					"name": "Checkout",
+					"tier": 0,
+					"endpoints": []gin.H{
+						{
+							"code":         "POST /orders",
+							"name":         "Create order",
+							"dependencies": gin.H{"promotions": []string{"GET /promotions"}},
+						},
+						{
+							"code":         "GET /suggestions",
+							"name":         "Get suggestions",
+							"tier":         2,
+							"dependencies": gin.H{"recommendations": []string{"GET /recommendations"}},
+						},
+					},
+				},
+			} {
+				Expect(util.HttpRequest(crius.Router(), "POST", "/services", postBody).Code).To(Equal(200))
+			}
+			response := util.HttpRequest(crius.Router(), "GET", "/services/checkout", nil)
+			Expect(response.Body["tier"]).To(Equal(float64(0)))
+			tiers := make(map[interface{}]interface{})
+			for _, endpoint := range response.Body["endpoints"].([]interface{}) {
+				tiers[endpoint.(map[string]interface{})["code"]] = endpoint.(map[string]interface{})["tier"]
+			}
+			Expect(tiers).To(Equal(map[interface{}]interface{}{"POST /orders": nil, "GET /suggestions": float64(2)}))
+		})
+
+		g.It("Should reject negative tiers", func() {
+			postBody := gin.H{"code": "bad", "name": "Bad", "tier": -1}
+			Expect(util.HttpRequest(crius.Router(), "POST", "/services", postBody).Code).To(Equal(400))
+		})
+
+		g.It("Should report direct and transitive dependencies on less critical endpoints", func() {
+			ref := func(serviceCode string, endpointCode string) map[string]interface{} {
+				return map[string]interface{}{"service_code": serviceCode, "endpoint_code": endpointCode}
+			}
+			orders := ref("checkout", "POST /orders")
+			promotions := ref("promotions", "GET /promotions")
+			recommendations := ref("recommendations", "GET /recommendations")
+
+			response := util.HttpRequest(crius.Router(), "GET", "/graph/violations", nil)
+			Expect(response.Code).To(Equal(200))
+			Expect(response.Body["violations"]).To(Equal([]interface{}{
+				map[string]interface{}{
+					"from":      orders,
+					"from_tier": float64(0),
+					"to":        promotions,
+					"to_tier":   float64(1),
+					"direct":    true,
+					"path":      []interface{}{orders, promotions},
+				},
+				map[string]interface{}{
+					"from":      orders,
+					"from_tier": float64(0),
+					"to":        recommendations,
+					"to_tier":   float64(2),
+					"direct":    false,
+					"path":      []interface{}{orders, promotions, recommendations},
+				},
+				map[string]interface{}{
+					"from":      promotions,
+					"from_tier": float64(1),
+					"to":        recommendations,
+					"to_tier":   float64(2),
+					"direct":    true,
+					"path":      []interface{}{promotions, recommendations},
+				},
+			}))
+
+			response = util.HttpRequest(crius.Router(), "GET", "/graph/violations?transitive=false", nil)
+			Expect(response.Code).To(Equal(200))
+			Expect(response.Body["violations"]).To(HaveLen(2))
+		})
+
+		g.It("Should stop reporting violations once tiers are fixed", func() {
+			patchBody := gin.H{"tier": 0}
+			Expect(util.HttpRequest(crius.Router(), "PATCH", "/services/promotions", patchBody).Code).To(Equal(200))
+			Expect(util.HttpRequest(crius.Router(), "PATCH", "/services/recommendations", patchBody).Code).To(Equal(200))
+			response := util.HttpRequest(crius.Router(), "GET", "/graph/violations", nil)
+			Expect(response.Code).To(Equal(200))
+			Expect(response.Body["violations"]).To(BeEmpty())
+			for _, code := range []string{"checkout", "promotions", "recommendations"} {
+				Expect(util.HttpRequest(crius.Router(), "DELETE", "/services/"+code, nil).Code).To(Equal(200))
+			}
+		})
+	})
+
+	g.Describe("Deprecation lifecycle", func() {
+		g.It("Should save lifecycles on services and endpoints", func() {
+			for _, postBody := range []gin.H{
+				{
+					"code":        "legacy_search",
+					"name":        "Legacy Search",
+					"lifecycle":   "deprecated",
+					"sunset_date": "2030-01-31",
+					"endpoints":   []gin.H{{"code": "GET /search", "name": "Search"}},
+				},
+				{
+					"code": "maps",
+					"name": "Maps",
+					"endpoints": []gin.H{
+						{"code": "GET /geocode", "name": "Geocode"},
+						{"code": "GET /tiles", "name": "Get tiles", "lifecycle": "deprecated", "sunset_date": "2029-06-30"},
+					},
+				},
+				{
+					"code":      "fax",
+					"name":      "Fax",
+					"lifecycle": "retired",
+					"endpoints": []gin.H{{"code": "POST /faxes", "name": "Send fax"}},
+				},
+				{
+					"code": "storefront",
+					"name": "Storefront",
+					"endpoints": []gin.H{
+						{
+							"code": "GET /home",
+							"name": "Home page",
+							"dependencies": gin.H{
+								"legacy_search": []string{"GET /search"},
+								"maps":          []string{"GET /geocode", "GET /tiles"},
+							},
+						},
+					},
+				},
+			} {
+				Expect(util.HttpRequest(crius.Router(), "POST", "/services", postBody).Code).To(Equal(200))
+			}
+			response := util.HttpRequest(crius.Router(), "GET", "/services/legacy_search", nil)
+			Expect(response.Body["lifecycle"]).To(Equal("deprecated"))
+			Expect(response.Body["sunset_date"]).To(Equal("2030-01-31"))
+			response = util.HttpRequest(crius.Router(), "GET", "/services/storefront", nil)
+			Expect(response.Body["lifecycle"]).To(Equal("active"))
+			Expect(response.Body["sunset_date"]).To(BeNil())
+		})
+
+		g.It("Should reject invalid lifecycles", func() {
+			for _, postBody := range []gin.H{
+				{"code": "bad", "name": "Bad", "lifecycle": "sunsetting"},
+				{"code": "bad", "name": "Bad", "lifecycle": "deprecated"},
+				{"code": "bad", "name": "Bad", "sunset_date": "2030-01-31"},
+				{"code": "bad", "name": "Bad", "lifecycle": "deprecated", "sunset_date": "31/01/2030"},
+			} {
+				Expect(util.HttpRequest(crius.Router(), "POST", "/services", postBody).Code).To(Equal(400))
+			}
+			patchBody := gin.H{"sunset_date": "2030-01-31"}
+			Expect(util.HttpRequest(crius.Router(), "PATCH", "/services/storefront", patchBody).Code).To(Equal(400))
+		})
+
+		g.It("Should report callers of deprecated endpoints, ordered by sunset date", func() {
+			home := map[string]interface{}{"service_code": "storefront", "endpoint_code": "GET /home"}
+			response := util.HttpRequest(crius.Router(), "GET", "/graph/deprecations", nil)
+			Expect(response.Code).To(Equal(200))
+			Expect(response.Body["deprecations"]).To(Equal([]interface{}{
+				map[string]interface{}{
+					"service_code":  "maps",
+					"endpoint_code": "GET /tiles",
+					"lifecycle":     "deprecated",
+					"sunset_date":   "2029-06-30",
+					"callers":       []interface{}{home},
+					"clients":       []interface{}{},
+				},
+				map[string]interface{}{
+					"service_code":  "legacy_search",
+					"endpoint_code": "GET /search",
+					"lifecycle":     "deprecated",
+					"sunset_date":   "2030-01-31",
+					"callers":       []interface{}{home},
+					"clients":       []interface{}{},
+				},
+			}))
+		})
+
+		g.It("Should reject new dependencies on retired endpoints, but keep existing ones", func() {
+			postBody := gin.H{
+				"code": "reports",
+				"name": "Reports",
+				"endpoints": []gin.H{
+					{
+						"code":         "POST /reports",
+						"name":         "Send report",
+						"dependencies": gin.H{"fax": []string{"POST /faxes"}},
+					},
+				},
+			}
+			response := util.HttpRequest(crius.Router(), "POST", "/services", postBody)
+			Expect(response.Code).To(Equal(422))
+			Expect(response.Body["sub_code"]).To(Equal("19a4350f-e5f1-4fd1-ac15-5ff0d0b0760d"))
+			Expect(util.HttpRequest(crius.Router(), "GET", "/services/reports", nil).Code).To(Equal(404))
+
+			patchBody := gin.H{"lifecycle": "retired", "sunset_date": "2029-01-01"}
+			Expect(util.HttpRequest(crius.Router(), "PATCH", "/services/legacy_search", patchBody).Code).To(Equal(200))
+			response = util.HttpRequest(crius.Router(), "GET", "/services/storefront", nil)
+			Expect(response.Code).To(Equal(200))
+			storefront := response.Body
+			Expect(util.HttpRequest(crius.Router(), "POST", "/services", storefront).Code).To(Equal(200))
+			for _, code := range []string{"storefront", "fax", "maps", "legacy_search"} {
+				Expect(util.HttpRequest(crius.Router(), "DELETE", "/services/"+code, nil).Code).To(Equal(200))
+			}
+		})
+	})
 }
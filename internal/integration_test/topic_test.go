@@ -0,0 +1,118 @@
+package integration_test
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/franela/goblin"
+	"github.com/gin-gonic/gin"
+	. "github.com/onsi/gomega"
+	"github.com/yashap/crius/internal/app"
+	"github.com/yashap/crius/internal/integration_test/util"
+)
+
+func TestTopics(t *testing.T) {
+	g := goblin.Goblin(t)
+	RegisterFailHandler(func(m string, _ ...int) { g.Fail(m) })
+	relativeMigrationsDir := "../../script/postgresql/migrations"
+	migrationsDir, err := filepath.Abs(relativeMigrationsDir)
+	if err != nil {
+		t.Errorf("Could not convert to absolute path: %s ; Error: %s", relativeMigrationsDir, err.Error())
+	}
+	crius := app.NewCrius(testDB.URL).MigrateDB(migrationsDir)
+
+	g.Describe("POST /topics", func() {
+		g.It("Should set up services to produce and consume", func() {
+			for _, postBody := range []gin.H{
+				{
+					"code": "maps",
+					"name": "Maps",
+					"endpoints": []gin.H{
+						{"code": "GET /routes", "name": "Get route"},
+					},
+				},
+				{
+					"code": "dispatch",
+					"name": "Dispatch",
+					"endpoints": []gin.H{
+						{
+							"code":         "CONSUME driver_moved",
+							"name":         "Handle driver moved",
+							"dependencies": gin.H{"maps": []string{"GET /routes"}},
+						},
+					},
+				},
+				{
+					"code": "drivers",
+					"name": "Drivers",
+					"endpoints": []gin.H{
+						{"code": "PUT /drivers/{id}/location", "name": "Update driver location"},
+					},
+				},
+			} {
+				Expect(util.HttpRequest(crius.Router(), "POST", "/services", postBody).Code).To(Equal(200))
+			}
+		})
+
+		g.It("Should create a topic", func() {
+			postBody := gin.H{
+				"code":      "driver_moved",
+				"name":      "Driver Moved",
+				"producers": []gin.H{{"service_code": "drivers", "endpoint_code": "PUT /drivers/{id}/location"}},
+				"consumers": []gin.H{{"service_code": "dispatch", "endpoint_code": "CONSUME driver_moved"}},
+			}
+			response := util.HttpRequest(crius.Router(), "POST", "/topics", postBody)
+			Expect(response.Code).To(Equal(200))
+			response = util.HttpRequest(crius.Router(), "GET", "/topics/driver_moved", nil)
+			Expect(response.Code).To(Equal(200))
+			Expect(response.Body["producers"]).To(HaveLen(1))
+			Expect(response.Body["consumers"]).To(HaveLen(1))
+			Expect(util.HttpRequest(crius.Router(), "GET", "/topics", nil).Body["topics"]).To(HaveLen(1))
+		})
+
+		g.It("Should reject producers and consumers that don't exist", func() {
+			postBody := gin.H{
+				"code":      "driver_moved",
+				"name":      "Driver Moved",
+				"producers": []gin.H{{"service_code": "nope", "endpoint_code": "PUT /nope"}},
+				"consumers": []gin.H{{"service_code": "dispatch", "endpoint_code": "CONSUME nope"}},
+			}
+			response := util.HttpRequest(crius.Router(), "POST", "/topics", postBody)
+			Expect(response.Code).To(Equal(422))
+			Expect(response.Body["details"]).To(HaveLen(2))
+		})
+	})
+
+	g.Describe("Dependencies through topics", func() {
+		g.It("Should reach a topic's consumers, and their dependencies, from its producers", func() {
+			response := util.HttpRequest(crius.Router(), "GET", "/services/drivers/dependencies?transitive=true", nil)
+			Expect(response.Code).To(Equal(200))
+			dependencies := response.Body["dependencies"].([]interface{})
+			Expect(dependencies).To(HaveLen(2))
+			Expect(dependencies[0].(map[string]interface{})["service_code"]).To(Equal("dispatch"))
+			Expect(dependencies[1].(map[string]interface{})["service_code"]).To(Equal("maps"))
+			Expect(dependencies[1].(map[string]interface{})["distance"]).To(Equal(float64(2)))
+		})
+
+		g.It("Should reach a topic's producers from its consumers' dependencies", func() {
+			response := util.HttpRequest(crius.Router(), "GET", "/services/maps/dependents", nil)
+			Expect(response.Code).To(Equal(200))
+			Expect(response.Body["summary"].(map[string]interface{})["affected_services"]).To(Equal(float64(2)))
+		})
+
+		g.It("Should stop reaching consumers once the topic is deleted", func() {
+			Expect(util.HttpRequest(crius.Router(), "DELETE", "/topics/driver_moved", nil).Code).To(Equal(200))
+			Expect(util.HttpRequest(crius.Router(), "GET", "/topics/driver_moved", nil).Code).To(Equal(404))
+			response := util.HttpRequest(crius.Router(), "GET", "/services/drivers/dependencies?transitive=true", nil)
+			Expect(response.Body["dependencies"]).To(HaveLen(0))
+		})
+	})
+
+	g.Describe("Cleanup", func() {
+		g.It("Should delete the services that produced and consumed", func() {
+			for _, code := range []string{"drivers", "dispatch", "maps"} {
+				Expect(util.HttpRequest(crius.Router(), "DELETE", "/services/"+code, nil).Code).To(Equal(200))
+			}
+		})
+	})
+}
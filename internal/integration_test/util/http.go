@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 
 	"github.com/gin-gonic/gin"
 )
@@ -14,9 +15,36 @@ type HttpResponse struct {
 }
 
 func HttpRequest(router *gin.Engine, method string, url string, body map[string]interface{}) HttpResponse {
-	w := httptest.NewRecorder()
-	req, _ := http.NewRequest("POST", "/services", Json(body))
+	return HttpRequestWithHeaders(router, method, url, body, nil)
+}
 
+func HttpRequestWithHeaders(
+	router *gin.Engine,
+	method string,
+	url string,
+	body map[string]interface{},
+	headers map[string]string,
+) HttpResponse {
+	var req *http.Request
+	if body == nil {
+		req, _ = http.NewRequest(method, url, nil)
+	} else {
+		req, _ = http.NewRequest(method, url, Json(body))
+	}
+	for name, value := range headers {
+		req.Header.Set(name, value)
+	}
+	return serve(router, req)
+}
+
+// HttpRequestWithRawBody sends a request whose body isn't JSON, like a YAML document
+func HttpRequestWithRawBody(router *gin.Engine, method string, url string, body string) HttpResponse {
+	req, _ := http.NewRequest(method, url, strings.NewReader(body))
+	return serve(router, req)
+}
+
+func serve(router *gin.Engine, req *http.Request) HttpResponse {
+	w := httptest.NewRecorder()
 	router.ServeHTTP(w, req)
 	jsonMap := make(map[string]interface{})
 	bodyString := w.Body.String()
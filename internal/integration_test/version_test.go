@@ -0,0 +1,130 @@
+package integration_test
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/franela/goblin"
+	"github.com/gin-gonic/gin"
+	. "github.com/onsi/gomega"
+	"github.com/yashap/crius/internal/app"
+	"github.com/yashap/crius/internal/integration_test/util"
+)
+
+func TestVersions(t *testing.T) {
+	g := goblin.Goblin(t)
+	RegisterFailHandler(func(m string, _ ...int) { g.Fail(m) })
+	relativeMigrationsDir := "../../script/postgresql/migrations"
+	migrationsDir, err := filepath.Abs(relativeMigrationsDir)
+	if err != nil {
+		t.Errorf("Could not convert to absolute path: %s ; Error: %s", relativeMigrationsDir, err.Error())
+	}
+	crius := app.NewCrius(testDB.URL).MigrateDB(migrationsDir)
+
+	g.Describe("Versions", func() {
+		g.It("Should save versions and pinned dependencies", func() {
+			for _, postBody := range []gin.H{
+				{
+					"code":    "ledger",
+					"name":    "Ledger",
+					"version": "2.1.0",
+					"endpoints": []gin.H{
+						{"code": "POST /entries", "name": "Create entry"},
+						{"code": "GET /entries", "name": "Get entries", "version": "1.4.0"},
+					},
+				},
+				{
+					"code": "till",
+					"name": "Till",
+					"endpoints": []gin.H{
+						{
+							"code":         "POST /sales",
+							"name":         "Create sale",
+							"dependencies": gin.H{"ledger@^2": []string{"POST /entries"}},
+						},
+						{
+							"code":         "GET /sales",
+							"name":         "Get sales",
+							"dependencies": gin.H{"ledger@>=1.4 <2": []string{"GET /entries"}},
+						},
+					},
+				},
+			} {
+				Expect(util.HttpRequest(crius.Router(), "POST", "/services", postBody).Code).To(Equal(200))
+			}
+			response := util.HttpRequest(crius.Router(), "GET", "/services/till", nil)
+			Expect(response.Code).To(Equal(200))
+			dependencies := make([]interface{}, 0)
+			for _, endpoint := range response.Body["endpoints"].([]interface{}) {
+				dependencies = append(dependencies, endpoint.(map[string]interface{})["dependencies"])
+			}
+			Expect(dependencies).To(ConsistOf(
+				map[string]interface{}{"ledger@^2": []interface{}{"POST /entries"}},
+				map[string]interface{}{"ledger@>=1.4 <2": []interface{}{"GET /entries"}},
+			))
+			response = util.HttpRequest(crius.Router(), "GET", "/services/ledger", nil)
+			Expect(response.Body["version"]).To(Equal("2.1.0"))
+		})
+
+		g.It("Should find no stale pins while every pin matches", func() {
+			response := util.HttpRequest(crius.Router(), "GET", "/graph/stale-pins", nil)
+			Expect(response.Code).To(Equal(200))
+			Expect(response.Body["stale_pins"]).To(BeEmpty())
+		})
+
+		g.It("Should find callers pinned to versions that no longer exist", func() {
+			patchBody := gin.H{"version": "3.0.0"}
+			Expect(util.HttpRequest(crius.Router(), "PATCH", "/services/ledger", patchBody).Code).To(Equal(200))
+			response := util.HttpRequest(crius.Router(), "GET", "/graph/stale-pins", nil)
+			Expect(response.Code).To(Equal(200))
+			// GET /entries has its own version, so is still 1.4.0
+			Expect(response.Body["stale_pins"]).To(Equal([]interface{}{
+				map[string]interface{}{
+					"from":       map[string]interface{}{"service_code": "till", "endpoint_code": "POST /sales"},
+					"to":         map[string]interface{}{"service_code": "ledger", "endpoint_code": "POST /entries"},
+					"constraint": "^2",
+					"version":    "3.0.0",
+				},
+			}))
+		})
+
+		g.It("Should reject invalid versions and constraints", func() {
+			for _, postBody := range []gin.H{
+				{"code": "abacus", "name": "Abacus", "version": "two"},
+				{
+					"code": "abacus",
+					"name": "Abacus",
+					"endpoints": []gin.H{
+						{
+							"code":         "GET /sums",
+							"name":         "Get sums",
+							"dependencies": gin.H{"ledger@^x.2": []string{"GET /entries"}},
+						},
+					},
+				},
+				{
+					"code": "abacus",
+					"name": "Abacus",
+					"endpoints": []gin.H{
+						{
+							"code": "GET /sums",
+							"name": "Get sums",
+							"dependencies": gin.H{
+								"ledger@^2": []string{"POST /entries"},
+								"ledger@^3": []string{"GET /entries"},
+							},
+						},
+					},
+				},
+			} {
+				Expect(util.HttpRequest(crius.Router(), "POST", "/services", postBody).Code).To(Equal(400))
+			}
+		})
+
+		g.It("Should clean up", func() {
+			for _, code := range []string{"till", "ledger"} {
+				Expect(util.HttpRequest(crius.Router(), "DELETE", "/services/"+code, nil).Code).To(Equal(200))
+			}
+		})
+	})
+}
@@ -0,0 +1,3 @@
+ALTER TABLE service_endpoint DROP COLUMN confirmed;
+
+ALTER TABLE service DROP COLUMN confirmed;
@@ -0,0 +1,3 @@
+ALTER TABLE service ADD COLUMN confirmed BOOLEAN NOT NULL DEFAULT TRUE;
+
+ALTER TABLE service_endpoint ADD COLUMN confirmed BOOLEAN NOT NULL DEFAULT TRUE;
@@ -0,0 +1,7 @@
+DROP INDEX idx_service_team ON service;
+
+ALTER TABLE service DROP COLUMN repository_url;
+ALTER TABLE service DROP COLUMN email;
+ALTER TABLE service DROP COLUMN slack_channel;
+ALTER TABLE service DROP COLUMN on_call;
+ALTER TABLE service DROP COLUMN team;
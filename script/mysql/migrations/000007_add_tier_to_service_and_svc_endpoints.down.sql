@@ -0,0 +1,2 @@
+ALTER TABLE service_endpoint DROP COLUMN tier;
+ALTER TABLE service DROP COLUMN tier;
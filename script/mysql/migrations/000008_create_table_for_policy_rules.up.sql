@@ -0,0 +1,10 @@
+CREATE TABLE IF NOT EXISTS policy_rule (
+    id BIGINT NOT NULL AUTO_INCREMENT PRIMARY KEY,
+    code VARCHAR(511) UNIQUE NOT NULL,
+    description TEXT NOT NULL,
+    effect VARCHAR(31) NOT NULL,
+    enforcement VARCHAR(31) NOT NULL,
+    from_matcher TEXT NOT NULL,
+    to_matcher TEXT NOT NULL,
+    source VARCHAR(31) NOT NULL
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
@@ -0,0 +1,5 @@
+ALTER TABLE service_endpoint DROP COLUMN sunset_date;
+ALTER TABLE service_endpoint DROP COLUMN lifecycle;
+
+ALTER TABLE service DROP COLUMN sunset_date;
+ALTER TABLE service DROP COLUMN lifecycle;
@@ -0,0 +1,5 @@
+ALTER TABLE service ADD COLUMN lifecycle VARCHAR(31) NOT NULL DEFAULT 'active';
+ALTER TABLE service ADD COLUMN sunset_date DATE NULL;
+
+ALTER TABLE service_endpoint ADD COLUMN lifecycle VARCHAR(31) NOT NULL DEFAULT 'active';
+ALTER TABLE service_endpoint ADD COLUMN sunset_date DATE NULL;
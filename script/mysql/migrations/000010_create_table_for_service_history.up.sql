@@ -0,0 +1,10 @@
+CREATE TABLE IF NOT EXISTS service_history (
+    id BIGINT NOT NULL AUTO_INCREMENT PRIMARY KEY,
+    service_code VARCHAR(511) NOT NULL,
+    version INT NOT NULL,
+    change_type VARCHAR(31) NOT NULL,
+    actor VARCHAR(511) NOT NULL,
+    changed_at DATETIME(6) NOT NULL,
+    snapshot TEXT NULL,
+    UNIQUE (service_code, version)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
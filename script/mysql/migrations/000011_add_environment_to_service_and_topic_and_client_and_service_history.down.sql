@@ -0,0 +1,15 @@
+ALTER TABLE service_history DROP INDEX environment_service_code_version;
+ALTER TABLE service_history DROP COLUMN environment;
+ALTER TABLE service_history ADD UNIQUE INDEX service_code (service_code, version);
+
+ALTER TABLE client DROP INDEX environment_code;
+ALTER TABLE client DROP COLUMN environment;
+ALTER TABLE client ADD UNIQUE INDEX code (code);
+
+ALTER TABLE topic DROP INDEX environment_code;
+ALTER TABLE topic DROP COLUMN environment;
+ALTER TABLE topic ADD UNIQUE INDEX code (code);
+
+ALTER TABLE service DROP INDEX environment_code;
+ALTER TABLE service DROP COLUMN environment;
+ALTER TABLE service ADD UNIQUE INDEX code (code);
@@ -0,0 +1,15 @@
+ALTER TABLE service ADD COLUMN environment VARCHAR(127) NOT NULL DEFAULT 'default';
+ALTER TABLE service DROP INDEX code;
+ALTER TABLE service ADD UNIQUE INDEX environment_code (environment, code);
+
+ALTER TABLE topic ADD COLUMN environment VARCHAR(127) NOT NULL DEFAULT 'default';
+ALTER TABLE topic DROP INDEX code;
+ALTER TABLE topic ADD UNIQUE INDEX environment_code (environment, code);
+
+ALTER TABLE client ADD COLUMN environment VARCHAR(127) NOT NULL DEFAULT 'default';
+ALTER TABLE client DROP INDEX code;
+ALTER TABLE client ADD UNIQUE INDEX environment_code (environment, code);
+
+ALTER TABLE service_history ADD COLUMN environment VARCHAR(127) NOT NULL DEFAULT 'default';
+ALTER TABLE service_history DROP INDEX service_code;
+ALTER TABLE service_history ADD UNIQUE INDEX environment_service_code_version (environment, service_code, version);
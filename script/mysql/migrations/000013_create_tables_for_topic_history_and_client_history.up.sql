@@ -0,0 +1,23 @@
+CREATE TABLE IF NOT EXISTS topic_history (
+    id BIGINT NOT NULL AUTO_INCREMENT PRIMARY KEY,
+    environment VARCHAR(127) NOT NULL DEFAULT 'default',
+    topic_code VARCHAR(511) NOT NULL,
+    version INT NOT NULL,
+    change_type VARCHAR(31) NOT NULL,
+    actor VARCHAR(511) NOT NULL,
+    changed_at DATETIME(6) NOT NULL,
+    snapshot TEXT NULL,
+    UNIQUE INDEX environment_topic_code_version (environment, topic_code, version)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+CREATE TABLE IF NOT EXISTS client_history (
+    id BIGINT NOT NULL AUTO_INCREMENT PRIMARY KEY,
+    environment VARCHAR(127) NOT NULL DEFAULT 'default',
+    client_code VARCHAR(511) NOT NULL,
+    version INT NOT NULL,
+    change_type VARCHAR(31) NOT NULL,
+    actor VARCHAR(511) NOT NULL,
+    changed_at DATETIME(6) NOT NULL,
+    snapshot TEXT NULL,
+    UNIQUE INDEX environment_client_code_version (environment, client_code, version)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
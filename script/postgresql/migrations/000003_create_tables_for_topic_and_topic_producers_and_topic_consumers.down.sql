@@ -0,0 +1,4 @@
+DROP VIEW IF EXISTS service_endpoint_edge;
+DROP TABLE IF EXISTS topic_consumer;
+DROP TABLE IF EXISTS topic_producer;
+DROP TABLE IF EXISTS topic;
@@ -0,0 +1,32 @@
+CREATE TABLE IF NOT EXISTS topic (
+    id BIGSERIAL PRIMARY KEY,
+    code VARCHAR(511) UNIQUE NOT NULL,
+    name TEXT NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS topic_producer (
+    id BIGSERIAL PRIMARY KEY,
+    topic_id BIGINT NOT NULL,
+    service_endpoint_id BIGINT NOT NULL,
+    UNIQUE (topic_id, service_endpoint_id),
+    CONSTRAINT fk_topic_producer_topic FOREIGN KEY (topic_id) REFERENCES topic (id) ON DELETE CASCADE ON UPDATE RESTRICT,
+    CONSTRAINT fk_topic_producer_service_endpoint FOREIGN KEY (service_endpoint_id) REFERENCES service_endpoint (id) ON DELETE CASCADE ON UPDATE RESTRICT
+);
+
+CREATE TABLE IF NOT EXISTS topic_consumer (
+    id BIGSERIAL PRIMARY KEY,
+    topic_id BIGINT NOT NULL,
+    service_endpoint_id BIGINT NOT NULL,
+    UNIQUE (topic_id, service_endpoint_id),
+    CONSTRAINT fk_topic_consumer_topic FOREIGN KEY (topic_id) REFERENCES topic (id) ON DELETE CASCADE ON UPDATE RESTRICT,
+    CONSTRAINT fk_topic_consumer_service_endpoint FOREIGN KEY (service_endpoint_id) REFERENCES service_endpoint (id) ON DELETE CASCADE ON UPDATE RESTRICT
+);
+
+-- Every edge of the endpoint dependency graph. An endpoint that produces to a topic reaches the topic's consumers
+CREATE OR REPLACE VIEW service_endpoint_edge AS
+SELECT service_endpoint_id, dependency_service_endpoint_id
+FROM service_endpoint_dependency
+UNION
+SELECT p.service_endpoint_id, c.service_endpoint_id AS dependency_service_endpoint_id
+FROM topic_producer p
+INNER JOIN topic_consumer c ON c.topic_id = p.topic_id;
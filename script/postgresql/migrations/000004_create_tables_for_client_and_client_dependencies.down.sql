@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS client_dependency;
+DROP TABLE IF EXISTS client;
@@ -0,0 +1,14 @@
+CREATE TABLE IF NOT EXISTS client (
+    id BIGSERIAL PRIMARY KEY,
+    code VARCHAR(511) UNIQUE NOT NULL,
+    name TEXT NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS client_dependency (
+    id BIGSERIAL PRIMARY KEY,
+    client_id BIGINT NOT NULL,
+    dependency_service_endpoint_id BIGINT NOT NULL,
+    UNIQUE (client_id, dependency_service_endpoint_id),
+    CONSTRAINT fk_client_dependency_client FOREIGN KEY (client_id) REFERENCES client (id) ON DELETE CASCADE ON UPDATE RESTRICT,
+    CONSTRAINT fk_client_dependency_service_endpoint FOREIGN KEY (dependency_service_endpoint_id) REFERENCES service_endpoint (id) ON DELETE CASCADE ON UPDATE RESTRICT
+);
@@ -0,0 +1,7 @@
+ALTER TABLE service ADD COLUMN team VARCHAR(511) NULL;
+ALTER TABLE service ADD COLUMN on_call TEXT NULL;
+ALTER TABLE service ADD COLUMN slack_channel TEXT NULL;
+ALTER TABLE service ADD COLUMN email TEXT NULL;
+ALTER TABLE service ADD COLUMN repository_url TEXT NULL;
+
+CREATE INDEX idx_service_team ON service (team);
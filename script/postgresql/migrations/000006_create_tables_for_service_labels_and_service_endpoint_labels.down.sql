@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS service_endpoint_label;
+DROP TABLE IF EXISTS service_label;
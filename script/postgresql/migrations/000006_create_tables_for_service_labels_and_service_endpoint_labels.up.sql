@@ -0,0 +1,21 @@
+CREATE TABLE IF NOT EXISTS service_label (
+    id BIGSERIAL PRIMARY KEY,
+    service_id BIGINT NOT NULL,
+    label_key VARCHAR(317) NOT NULL,
+    label_value VARCHAR(63) NOT NULL,
+    UNIQUE (service_id, label_key),
+    CONSTRAINT fk_service_label_service FOREIGN KEY (service_id) REFERENCES service (id) ON DELETE CASCADE ON UPDATE RESTRICT
+);
+
+CREATE INDEX idx_service_label_key_value ON service_label (label_key, label_value);
+
+CREATE TABLE IF NOT EXISTS service_endpoint_label (
+    id BIGSERIAL PRIMARY KEY,
+    service_endpoint_id BIGINT NOT NULL,
+    label_key VARCHAR(317) NOT NULL,
+    label_value VARCHAR(63) NOT NULL,
+    UNIQUE (service_endpoint_id, label_key),
+    CONSTRAINT fk_service_endpoint_label_service_endpoint FOREIGN KEY (service_endpoint_id) REFERENCES service_endpoint (id) ON DELETE CASCADE ON UPDATE RESTRICT
+);
+
+CREATE INDEX idx_service_endpoint_label_key_value ON service_endpoint_label (label_key, label_value);
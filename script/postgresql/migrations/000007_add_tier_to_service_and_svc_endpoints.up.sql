@@ -0,0 +1,2 @@
+ALTER TABLE service ADD COLUMN tier INT NULL;
+ALTER TABLE service_endpoint ADD COLUMN tier INT NULL;
@@ -0,0 +1,10 @@
+CREATE TABLE IF NOT EXISTS service_history (
+    id BIGSERIAL PRIMARY KEY,
+    service_code VARCHAR(511) NOT NULL,
+    version INT NOT NULL,
+    change_type VARCHAR(31) NOT NULL,
+    actor VARCHAR(511) NOT NULL,
+    changed_at TIMESTAMP NOT NULL,
+    snapshot TEXT NULL,
+    UNIQUE (service_code, version)
+);
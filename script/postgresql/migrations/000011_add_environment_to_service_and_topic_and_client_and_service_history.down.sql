@@ -0,0 +1,15 @@
+ALTER TABLE service_history DROP CONSTRAINT service_history_environment_service_code_version_key;
+ALTER TABLE service_history DROP COLUMN environment;
+ALTER TABLE service_history ADD CONSTRAINT service_history_service_code_version_key UNIQUE (service_code, version);
+
+ALTER TABLE client DROP CONSTRAINT client_environment_code_key;
+ALTER TABLE client DROP COLUMN environment;
+ALTER TABLE client ADD CONSTRAINT client_code_key UNIQUE (code);
+
+ALTER TABLE topic DROP CONSTRAINT topic_environment_code_key;
+ALTER TABLE topic DROP COLUMN environment;
+ALTER TABLE topic ADD CONSTRAINT topic_code_key UNIQUE (code);
+
+ALTER TABLE service DROP CONSTRAINT service_environment_code_key;
+ALTER TABLE service DROP COLUMN environment;
+ALTER TABLE service ADD CONSTRAINT service_code_key UNIQUE (code);
@@ -0,0 +1,16 @@
+ALTER TABLE service ADD COLUMN environment VARCHAR(127) NOT NULL DEFAULT 'default';
+ALTER TABLE service DROP CONSTRAINT service_code_key;
+ALTER TABLE service ADD CONSTRAINT service_environment_code_key UNIQUE (environment, code);
+
+ALTER TABLE topic ADD COLUMN environment VARCHAR(127) NOT NULL DEFAULT 'default';
+ALTER TABLE topic DROP CONSTRAINT topic_code_key;
+ALTER TABLE topic ADD CONSTRAINT topic_environment_code_key UNIQUE (environment, code);
+
+ALTER TABLE client ADD COLUMN environment VARCHAR(127) NOT NULL DEFAULT 'default';
+ALTER TABLE client DROP CONSTRAINT client_code_key;
+ALTER TABLE client ADD CONSTRAINT client_environment_code_key UNIQUE (environment, code);
+
+ALTER TABLE service_history ADD COLUMN environment VARCHAR(127) NOT NULL DEFAULT 'default';
+ALTER TABLE service_history DROP CONSTRAINT service_history_service_code_version_key;
+ALTER TABLE service_history ADD CONSTRAINT service_history_environment_service_code_version_key
+    UNIQUE (environment, service_code, version);
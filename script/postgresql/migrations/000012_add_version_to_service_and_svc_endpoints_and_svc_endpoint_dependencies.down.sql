@@ -0,0 +1,5 @@
+ALTER TABLE service_endpoint_dependency DROP COLUMN version_constraint;
+
+ALTER TABLE service_endpoint DROP COLUMN version;
+
+ALTER TABLE service DROP COLUMN version;
@@ -0,0 +1,5 @@
+ALTER TABLE service ADD COLUMN version VARCHAR(255) NULL;
+
+ALTER TABLE service_endpoint ADD COLUMN version VARCHAR(255) NULL;
+
+ALTER TABLE service_endpoint_dependency ADD COLUMN version_constraint VARCHAR(255) NULL;
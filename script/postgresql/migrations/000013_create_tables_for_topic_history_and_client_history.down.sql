@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS client_history;
+DROP TABLE IF EXISTS topic_history;
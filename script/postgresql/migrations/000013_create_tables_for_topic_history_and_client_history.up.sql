@@ -0,0 +1,23 @@
+CREATE TABLE IF NOT EXISTS topic_history (
+    id BIGSERIAL PRIMARY KEY,
+    environment VARCHAR(127) NOT NULL DEFAULT 'default',
+    topic_code VARCHAR(511) NOT NULL,
+    version INT NOT NULL,
+    change_type VARCHAR(31) NOT NULL,
+    actor VARCHAR(511) NOT NULL,
+    changed_at TIMESTAMP NOT NULL,
+    snapshot TEXT NULL,
+    UNIQUE (environment, topic_code, version)
+);
+
+CREATE TABLE IF NOT EXISTS client_history (
+    id BIGSERIAL PRIMARY KEY,
+    environment VARCHAR(127) NOT NULL DEFAULT 'default',
+    client_code VARCHAR(511) NOT NULL,
+    version INT NOT NULL,
+    change_type VARCHAR(31) NOT NULL,
+    actor VARCHAR(511) NOT NULL,
+    changed_at TIMESTAMP NOT NULL,
+    snapshot TEXT NULL,
+    UNIQUE (environment, client_code, version)
+);